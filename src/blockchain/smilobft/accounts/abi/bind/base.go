@@ -30,6 +30,7 @@ import (
 	"go-smilo/src/blockchain/smilobft"
 	"go-smilo/src/blockchain/smilobft/accounts/abi"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // SignerFn is a signer function callback when a contract requires a method to
@@ -40,7 +41,8 @@ type SignerFn func(types.Signer, common.Address, *types.Transaction) (*types.Tra
 //
 // Additional arguments in order to support transaction privacy
 type PrivateTxArgs struct {
-	PrivateFor []string `json:"privateFor"`
+	PrivateFor  []string            `json:"privateFor"`
+	PrivacyFlag private.PrivacyFlag `json:"privacyFlag"`
 }
 
 // CallOpts is the collection of options to fine tune a contract call request.
@@ -67,6 +69,8 @@ type TransactOpts struct {
 	// Quorum
 	PrivateFrom string   // The public key of the Tessera/Constellation identity to send this tx from.
 	PrivateFor  []string // The public keys of the Tessera/Constellation identities this tx is intended for.
+
+	PrivacyFlag private.PrivacyFlag // Privacy mode of the private tx (0 = standard)
 }
 
 // FilterOpts is the collection of options to fine tune filtering for events
@@ -249,7 +253,11 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	// with the hash of the transaction from tessera/constellation/blackbox.
 	if opts.PrivateFor != nil {
 		var payload []byte
-		payload, err = c.transactor.PreparePrivateTransaction(rawTx.Data(), opts.PrivateFrom)
+		payload, err = private.WrapPayload(&private.PrivacyMetadata{Flag: opts.PrivacyFlag}, rawTx.Data())
+		if err != nil {
+			return nil, err
+		}
+		payload, err = c.transactor.PreparePrivateTransaction(payload, opts.PrivateFrom)
 		if err != nil {
 			return nil, err
 		}
@@ -265,7 +273,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		return nil, err
 	}
 
-	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx, PrivateTxArgs{PrivateFor: opts.PrivateFor}); err != nil {
+	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx, PrivateTxArgs{PrivateFor: opts.PrivateFor, PrivacyFlag: opts.PrivacyFlag}); err != nil {
 		return nil, err
 	}
 
@@ -175,11 +175,13 @@ Remove blockchain and state databases`,
 			utils.ExcludeCodeFlag,
 			utils.ExcludeStorageFlag,
 			utils.IncludeIncompletesFlag,
+			utils.DumpPrivateFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
 The arguments are interpreted as block numbers or hashes.
-Use "ethereum dump 0" to dump the genesis block.`,
+Use "ethereum dump 0" to dump the genesis block. With --private the private
+state of the block is dumped instead of the public state.`,
 	}
 	inspectCommand = cli.Command{
 		Action:    utils.MigrateFlags(inspect),
@@ -625,7 +627,11 @@ func dump(ctx *cli.Context) error {
 			fmt.Println("{}")
 			utils.Fatalf("block not found")
 		} else {
-			state, err := state.New(block.Root(), state.NewDatabase(chainDb))
+			root := block.Root()
+			if ctx.Bool(utils.DumpPrivateFlag.Name) {
+				root = core.GetPrivateStateRoot(chainDb, root)
+			}
+			state, err := state.New(root, state.NewDatabase(chainDb))
 			if err != nil {
 				utils.Fatalf("could not create new state: %v", err)
 			}
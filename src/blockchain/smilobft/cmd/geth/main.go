@@ -168,6 +168,17 @@ var (
 		utils.SportDAORequestTimeoutFlag,
 		utils.SportDAOBlockPeriodFlag,
 		utils.EnableNodePermissionFlag,
+		utils.ConsensusJournalFlag,
+		utils.VaultBackendFlag,
+		utils.VaultPathFlag,
+		utils.VaultURLFlag,
+		utils.VaultTLSCertFlag,
+		utils.VaultTLSKeyFlag,
+		utils.VaultTLSCAFlag,
+		utils.VaultRetriesFlag,
+		utils.VaultRequestTimeoutFlag,
+		utils.VaultHealthIntervalFlag,
+		utils.VaultPauseTimeoutFlag,
 		utils.PluginSettingsFlag,
 		utils.PluginSkipVerifyFlag,
 		utils.PluginLocalVerifyFlag,
@@ -232,6 +243,10 @@ func init() {
 		removedbCommand,
 		dumpCommand,
 		inspectCommand,
+		// See privatestatecmd.go:
+		privateStateCommand,
+		// See permissioncmd.go:
+		permissionCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -0,0 +1,155 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/urfave/cli.v1"
+
+	"go-smilo/src/blockchain/smilobft/cmd/utils"
+	"go-smilo/src/blockchain/smilobft/permission"
+	"go-smilo/src/blockchain/smilobft/permission/audit"
+)
+
+var (
+	auditFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "Format of the export (csv or json)",
+		Value: audit.FormatCSV,
+	}
+	auditOrgFlag = cli.StringFlag{
+		Name:  "org",
+		Usage: "Only export the changes of this org",
+	}
+	auditAccountFlag = cli.StringFlag{
+		Name:  "account",
+		Usage: "Only export the changes made by or to this account",
+	}
+	auditNodeFlag = cli.StringFlag{
+		Name:  "node",
+		Usage: "Only export the changes of this node (enode url or id)",
+	}
+
+	permissionCommand = cli.Command{
+		Name:     "permission",
+		Usage:    "Export the history of the permission changes",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The permission contracts only hold the current permissions of the network. The
+history of the changes, who made them and when, is rebuilt from the events of
+the contracts in the local chain and kept in the chain database. The contract
+addresses are read from the permission-config.json file of the data directory.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export the permission changes of a block range",
+				ArgsUsage: "<filename> [<blockNumFirst> [<blockNumLast>]]",
+				Action:    utils.MigrateFlags(exportPermissionAudit),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					utils.SyncModeFlag,
+					auditFormatFlag,
+					auditOrgFlag,
+					auditAccountFlag,
+					auditNodeFlag,
+				},
+				Description: `
+    geth permission export [--format csv|json] [--org <orgId>] [--account <address>] [--node <enode>] <filename> [<blockNumFirst> [<blockNumLast>]]
+
+Writes the org, node, role, account, voter and function rule changes of the
+blocks to the file, with the block, time and sending account of each change.
+The block range defaults to the whole chain, the changes can be narrowed down
+to an org, an account, matching the changes made by the account or to it, and
+a node.`,
+			},
+		},
+	}
+)
+
+func exportPermissionAudit(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires the file name as argument.")
+	}
+	format := ctx.String(auditFormatFlag.Name)
+	if format != audit.FormatCSV && format != audit.FormatJSON {
+		utils.Fatalf("Unknown export format %q, want %s or %s.", format, audit.FormatCSV, audit.FormatJSON)
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	config, err := permission.ParsePermissionConfig(stack.DataDir())
+	if err != nil {
+		utils.Fatalf("Invalid permission config: %v", err)
+	}
+	query := audit.Query{
+		OrgId: ctx.String(auditOrgFlag.Name),
+		Node:  ctx.String(auditNodeFlag.Name),
+	}
+	if ctx.IsSet(auditAccountFlag.Name) {
+		hex := ctx.String(auditAccountFlag.Name)
+		if !common.IsHexAddress(hex) {
+			utils.Fatalf("Invalid account %q", hex)
+		}
+		account := common.HexToAddress(hex)
+		query.Account = &account
+	}
+	if len(ctx.Args()) > 1 {
+		if query.FromBlock, err = strconv.ParseUint(ctx.Args().Get(1), 10, 64); err != nil {
+			utils.Fatalf("Invalid first block number: %v", err)
+		}
+	}
+	last := chain.CurrentBlock().NumberU64()
+	if len(ctx.Args()) > 2 {
+		if last, err = strconv.ParseUint(ctx.Args().Get(2), 10, 64); err != nil {
+			utils.Fatalf("Invalid last block number: %v", err)
+		}
+	}
+	query.ToBlock = last
+
+	start := time.Now()
+	auditLog, err := audit.New(&config)
+	if err != nil {
+		utils.Fatalf("Failed to create the audit log: %v", err)
+	}
+	if err := auditLog.Open(chainDb); err != nil {
+		utils.Fatalf("Failed to open the audit log: %v", err)
+	}
+	if err := auditLog.IndexChain(chain, last); err != nil {
+		utils.Fatalf("Failed to index the permission changes: %v", err)
+	}
+	entries := auditLog.Query(query)
+
+	f, err := os.Create(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Export error: %v", err)
+	}
+	defer f.Close()
+	if err := audit.Export(f, entries, format); err != nil {
+		utils.Fatalf("Export error: %v", err)
+	}
+	fmt.Printf("Exported %d permission changes of blocks %d-%d in %v\n", len(entries), query.FromBlock, last, time.Since(start))
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2015 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"go-smilo/src/blockchain/smilobft/cmd/utils"
+)
+
+var (
+	privateStateFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.CacheFlag,
+		utils.SyncModeFlag,
+		utils.VaultBackendFlag,
+		utils.VaultPathFlag,
+		utils.VaultURLFlag,
+		utils.VaultTLSCertFlag,
+		utils.VaultTLSKeyFlag,
+		utils.VaultTLSCAFlag,
+	}
+
+	privateStateCommand = cli.Command{
+		Name:     "privatestate",
+		Usage:    "Verify, repair, export and import the private state",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The private state roots are stored apart from the blocks and are not covered
+by consensus. These commands replay a block range, fetching the private
+payloads from the vault again, to detect and repair a corrupted private state
+without a full resync.
+
+The public state of the parent of every replayed block must be available, a
+node which is not running in archive mode can only replay its recent blocks.
+
+The private state of a block range can also be exported to a file and imported
+into another node holding the same chain, to migrate a member node or to hand
+a private state snapshot to an auditor.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "verify",
+				Usage:     "Report blocks whose stored private state root is wrong",
+				ArgsUsage: "<blockNumFirst> [<blockNumLast>]",
+				Action:    utils.MigrateFlags(verifyPrivateState),
+				Flags:     privateStateFlags,
+				Description: `
+    geth privatestate verify <blockNumFirst> [<blockNumLast>]
+
+Replays the blocks and prints every block whose recomputed private state root
+differs from the stored one. The last block defaults to the current head.`,
+			},
+			{
+				Name:      "rebuild",
+				Usage:     "Recompute and overwrite the private state of a block range",
+				ArgsUsage: "<blockNumFirst> [<blockNumLast>]",
+				Action:    utils.MigrateFlags(rebuildPrivateState),
+				Flags:     privateStateFlags,
+				Description: `
+    geth privatestate rebuild <blockNumFirst> [<blockNumLast>]
+
+Replays the blocks, writes the recomputed private states to the database and
+replaces every wrong private state root. The last block defaults to the
+current head.`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the private state and receipts of a block range",
+				ArgsUsage: "<filename> <blockNumFirst> [<blockNumLast>]",
+				Action:    utils.MigrateFlags(exportPrivateState),
+				Flags:     privateStateFlags,
+				Description: `
+    geth privatestate export <filename> <blockNumFirst> [<blockNumLast>]
+
+Writes the private state tries and the receipts of the blocks to the file. The
+last block defaults to the current head. If the file ends with .gz, the output
+will be gzipped.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Import a private state export",
+				ArgsUsage: "<filename>",
+				Action:    utils.MigrateFlags(importPrivateState),
+				Flags:     privateStateFlags,
+				Description: `
+    geth privatestate import <filename>
+
+Writes the private state tries, private state roots and receipts of an export
+to the database. The exported blocks must already be part of the local chain,
+import the blocks first if needed.`,
+			},
+		},
+	}
+)
+
+func verifyPrivateState(ctx *cli.Context) error {
+	return replayPrivateState(ctx, false)
+}
+
+func rebuildPrivateState(ctx *cli.Context) error {
+	return replayPrivateState(ctx, true)
+}
+
+func replayPrivateState(ctx *cli.Context, repair bool) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires the first block number as argument.")
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	first, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+	if err != nil {
+		utils.Fatalf("Invalid first block number: %v", err)
+	}
+	last := chain.CurrentBlock().NumberU64()
+	if len(ctx.Args()) > 1 {
+		if last, err = strconv.ParseUint(ctx.Args().Get(1), 10, 64); err != nil {
+			utils.Fatalf("Invalid last block number: %v", err)
+		}
+	}
+	start := time.Now()
+	mismatches, err := chain.ReplayPrivateState(first, last, repair)
+	for _, m := range mismatches {
+		fmt.Printf("block #%d [%x…]: stored private root %x, computed %x\n", m.Number, m.Hash[:4], m.Stored, m.Computed)
+	}
+	if err != nil {
+		utils.Fatalf("Private state replay failed: %v", err)
+	}
+	switch {
+	case len(mismatches) == 0:
+		fmt.Printf("Private state of blocks %d-%d is consistent, checked in %v\n", first, last, time.Since(start))
+	case repair:
+		fmt.Printf("Repaired %d private state roots of blocks %d-%d in %v\n", len(mismatches), first, last, time.Since(start))
+	default:
+		fmt.Printf("Found %d private state root mismatches in blocks %d-%d in %v\n", len(mismatches), first, last, time.Since(start))
+	}
+	return nil
+}
+
+func exportPrivateState(ctx *cli.Context) error {
+	if len(ctx.Args()) < 2 {
+		utils.Fatalf("This command requires the file name and the first block number as arguments.")
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	first, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	if err != nil {
+		utils.Fatalf("Invalid first block number: %v", err)
+	}
+	last := chain.CurrentBlock().NumberU64()
+	if len(ctx.Args()) > 2 {
+		if last, err = strconv.ParseUint(ctx.Args().Get(2), 10, 64); err != nil {
+			utils.Fatalf("Invalid last block number: %v", err)
+		}
+	}
+	start := time.Now()
+	if err := utils.ExportPrivateState(chain, ctx.Args().First(), first, last); err != nil {
+		utils.Fatalf("Export error: %v\n", err)
+	}
+	fmt.Printf("Export done in %v\n", time.Since(start))
+	return nil
+}
+
+func importPrivateState(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	start := time.Now()
+	if err := utils.ImportPrivateState(chain, ctx.Args().First()); err != nil {
+		utils.Fatalf("Import error: %v\n", err)
+	}
+	fmt.Printf("Import done in %v\n", time.Since(start))
+	return nil
+}
@@ -161,6 +161,7 @@ var AppHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			utils.MinBlocksEmptyMiningFlag,
 			utils.EnableNodePermissionFlag,
+			utils.ConsensusJournalFlag,
 			utils.PluginSettingsFlag,
 			utils.PluginSkipVerifyFlag,
 			utils.PluginLocalVerifyFlag,
@@ -287,6 +288,21 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.SportBlockPeriodFlag,
 		},
 	},
+	{
+		Name: "VAULT",
+		Flags: []cli.Flag{
+			utils.VaultBackendFlag,
+			utils.VaultPathFlag,
+			utils.VaultURLFlag,
+			utils.VaultTLSCertFlag,
+			utils.VaultTLSKeyFlag,
+			utils.VaultTLSCAFlag,
+			utils.VaultRetriesFlag,
+			utils.VaultRequestTimeoutFlag,
+			utils.VaultHealthIntervalFlag,
+			utils.VaultPauseTimeoutFlag,
+		},
+	},
 	{
 		Name: "CODE-QUALITY",
 		Flags: []cli.Flag{
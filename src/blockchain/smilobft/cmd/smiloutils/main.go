@@ -32,6 +32,7 @@ func main() {
 
 	app.Commands = []cli.Command{
 		src.TransactionCommand,
+		src.ConsensusCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
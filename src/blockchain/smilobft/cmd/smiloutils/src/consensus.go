@@ -0,0 +1,152 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-smilo library.
+//
+// The go-smilo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-smilo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-smilo library. If not, see <http://www.gnu.org/licenses/>.
+
+package src
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	tendermintConfig "go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	tendermintCore "go-smilo/src/blockchain/smilobft/consensus/tendermint/core"
+)
+
+var (
+	journalFlag = cli.StringFlag{
+		Name:  "journal",
+		Usage: "Consensus journal written by a node started with --consensus.journal",
+	}
+
+	engineFlag = cli.StringFlag{
+		Name:  "engine",
+		Usage: "Consensus engine that wrote the journal: sport, sportdao, istanbul or tendermint",
+		Value: "sport",
+	}
+
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the journal of the replayed core to",
+	}
+
+	ConsensusCommand = cli.Command{
+		Name:  "consensus",
+		Usage: "inspect consensus journals",
+		Subcommands: []cli.Command{
+			{
+				Action: ReplayConsensus,
+				Name:   "replay",
+				Usage:  "replay a consensus journal",
+				Flags: []cli.Flag{
+					journalFlag,
+					engineFlag,
+					outFlag,
+				},
+				Description: `Feed a consensus journal back into the consensus core, one entry at a time,
+and report the first step where the replayed core acts differently from the node that wrote it.`,
+			},
+		},
+	}
+
+	errReplayDiverged = errors.New("replay diverged from the journal")
+)
+
+// ReplayConsensus replays a consensus journal and compares the replay with it
+func ReplayConsensus(ctx *cli.Context) error {
+	path := ctx.String(journalFlag.Name)
+	if path == "" {
+		return errors.New("missing --journal")
+	}
+	entries, err := journal.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Unmined blocks reach the tendermint core on their own goroutine, where
+	// they land in the journal is not part of the consensus order
+	var skip string
+
+	var replayed bytes.Buffer
+	w := io.Writer(&replayed)
+	if path := ctx.String(outFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = io.MultiWriter(&replayed, f)
+	}
+	out := journal.New(w)
+	switch engine := ctx.String(engineFlag.Name); engine {
+	case "sport", "sportdao", "istanbul":
+		err = bftCore.Replay(entries, bft.DefaultConfig, out)
+	case "tendermint":
+		skip = journal.KindRequest
+		err = tendermintCore.Replay(entries, tendermintConfig.DefaultConfig(), out)
+	default:
+		return fmt.Errorf("unknown consensus engine %q", engine)
+	}
+	if err != nil {
+		return err
+	}
+	replayedEntries, err := journal.Read(&replayed)
+	if err != nil {
+		return err
+	}
+	return compareJournals(withoutKind(entries, skip), withoutKind(replayedEntries, skip))
+}
+
+// withoutKind returns the entries not of the given kind
+func withoutKind(entries []*journal.Entry, kind string) []*journal.Entry {
+	if kind == "" {
+		return entries
+	}
+	var result []*journal.Entry
+	for _, e := range entries {
+		if e.Kind != kind {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// compareJournals prints the first entry where the replay differs from the journal
+func compareJournals(entries, replayed []*journal.Entry) error {
+	for i := 0; i < len(entries) || i < len(replayed); i++ {
+		var have, want string
+		if i < len(entries) {
+			want = entries[i].String()
+		}
+		if i < len(replayed) {
+			have = replayed[i].String()
+		}
+		if have != want {
+			fmt.Printf("Replay diverges at entry %d\n", i)
+			fmt.Printf("  journal: %s\n", want)
+			fmt.Printf("  replay:  %s\n", have)
+			return errReplayDiverged
+		}
+	}
+	fmt.Printf("Replay matches the journal, %d entries\n", len(entries))
+	return nil
+}
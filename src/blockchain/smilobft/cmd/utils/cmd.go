@@ -240,6 +240,57 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 	return nil
 }
 
+// ExportPrivateState exports the private state and receipts of the blocks
+// first to last into the specified file, truncating any data already present
+// in the file.
+func ExportPrivateState(blockchain *core.BlockChain, fn string, first uint64, last uint64) error {
+	log.Info("Exporting private state", "file", fn)
+
+	// Open the file handle and potentially wrap with a gzip stream
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	if strings.HasSuffix(fn, ".gz") {
+		writer = gzip.NewWriter(writer)
+		defer writer.(*gzip.Writer).Close()
+	}
+	if err := blockchain.ExportPrivateState(writer, first, last); err != nil {
+		return err
+	}
+	log.Info("Exported private state", "file", fn)
+	return nil
+}
+
+// ImportPrivateState imports a private state export into the database. The
+// exported blocks must already be part of the local chain.
+func ImportPrivateState(blockchain *core.BlockChain, fn string) error {
+	log.Info("Importing private state", "file", fn)
+
+	// Open the file handle and potentially unwrap the gzip stream
+	fh, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var reader io.Reader = fh
+	if strings.HasSuffix(fn, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return err
+		}
+	}
+	count, err := blockchain.ImportPrivateState(reader)
+	if err != nil {
+		return err
+	}
+	log.Info("Imported private state", "file", fn, "blocks", count)
+	return nil
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 func ImportPreimages(db ethdb.Database, fn string) error {
 	log.Info("Importing preimages", "file", fn)
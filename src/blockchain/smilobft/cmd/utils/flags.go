@@ -70,6 +70,8 @@ import (
 	"go-smilo/src/blockchain/smilobft/p2p/nat"
 	"go-smilo/src/blockchain/smilobft/p2p/netutil"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
+	"go-smilo/src/blockchain/smilobft/private/privatetransactionmanager"
 	whisper "go-smilo/src/blockchain/smilobft/whisper/whisperv6"
 )
 
@@ -199,6 +201,10 @@ var (
 		Name:  "incompletes",
 		Usage: "Include accounts for which we don't have the address (missing preimage)",
 	}
+	DumpPrivateFlag = cli.BoolFlag{
+		Name:  "private",
+		Usage: "Dump the private state instead of the public state",
+	}
 	ExcludeCodeFlag = cli.BoolFlag{
 		Name:  "nocode",
 		Usage: "Exclude contract code (save db lookups)",
@@ -830,6 +836,56 @@ var (
 		Name:  "permissioned",
 		Usage: "If enabled, the node will allow only a defined list of nodes to connect",
 	}
+	ConsensusJournalFlag = cli.StringFlag{
+		Name:  "consensus.journal",
+		Usage: "Journal every consensus message sent and received to this file (replay it with smiloutils consensus replay)",
+	}
+
+	// Vault settings
+	VaultBackendFlag = cli.StringFlag{
+		Name:  "vault.backend",
+		Usage: "Blackbox vault backend (" + strings.Join(private.Backends(), ", ") + "), defaults to PRIVATE_CONFIG. The in-memory mock backend requires --dev",
+	}
+	VaultPathFlag = cli.StringFlag{
+		Name:  "vault.path",
+		Usage: "Blackbox unix socket or config file, used by the ipc vault backend",
+	}
+	VaultURLFlag = cli.StringFlag{
+		Name:  "vault.url",
+		Usage: "Blackbox url, used by the https vault backend",
+	}
+	VaultTLSCertFlag = cli.StringFlag{
+		Name:  "vault.tlscert",
+		Usage: "Client certificate presented to the Blackbox node by the https vault backend",
+	}
+	VaultTLSKeyFlag = cli.StringFlag{
+		Name:  "vault.tlskey",
+		Usage: "Private key of the vault client certificate",
+	}
+	VaultTLSCAFlag = cli.StringFlag{
+		Name:  "vault.tlsca",
+		Usage: "CA certificate used to verify the Blackbox node, defaults to the system roots",
+	}
+	VaultRetriesFlag = cli.IntFlag{
+		Name:  "vault.retries",
+		Usage: "Number of retries of a failed Blackbox request",
+		Value: privatetransactionmanager.DefaultOptions.Retries,
+	}
+	VaultRequestTimeoutFlag = cli.DurationFlag{
+		Name:  "vault.timeout",
+		Usage: "Timeout of a single Blackbox request",
+		Value: privatetransactionmanager.DefaultOptions.RequestTimeout,
+	}
+	VaultHealthIntervalFlag = cli.DurationFlag{
+		Name:  "vault.healthinterval",
+		Usage: "Interval between two Blackbox health checks",
+		Value: privatetransactionmanager.DefaultOptions.HealthInterval,
+	}
+	VaultPauseTimeoutFlag = cli.DurationFlag{
+		Name:  "vault.pausetimeout",
+		Usage: "Time block processing waits for an unreachable Blackbox before failing",
+		Value: privatetransactionmanager.DefaultOptions.PauseTimeout,
+	}
 )
 
 // MakeDataDir retrieves the currently requested data directory, terminating
@@ -1534,6 +1590,13 @@ func setBFT(ctx *cli.Context, cfg *eth.Config) {
 	if ctx.GlobalIsSet(EnableNodePermissionFlag.Name) {
 		cfg.EnableNodePermissionFlag = ctx.GlobalBool(EnableNodePermissionFlag.Name)
 	}
+	if ctx.GlobalIsSet(ConsensusJournalFlag.Name) {
+		journal := ctx.GlobalString(ConsensusJournalFlag.Name)
+		cfg.Sport.Journal = journal
+		cfg.SportDAO.Journal = journal
+		cfg.Istanbul.Journal = journal
+		cfg.Tendermint.Journal = journal
+	}
 }
 
 func setSport(ctx *cli.Context, cfg *eth.Config) {
@@ -1549,6 +1612,43 @@ func setSport(ctx *cli.Context, cfg *eth.Config) {
 	cfg.Sport.MinBlocksEmptyMining = GlobalBig(ctx, MinBlocksEmptyMiningFlag.Name)
 }
 
+func setVault(ctx *cli.Context, cfg *private.VaultConfig) {
+	if ctx.GlobalIsSet(VaultBackendFlag.Name) {
+		cfg.Backend = ctx.GlobalString(VaultBackendFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultPathFlag.Name) {
+		cfg.Path = ctx.GlobalString(VaultPathFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultURLFlag.Name) {
+		cfg.URL = ctx.GlobalString(VaultURLFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultTLSCertFlag.Name) {
+		cfg.TLSCert = ctx.GlobalString(VaultTLSCertFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultTLSKeyFlag.Name) {
+		cfg.TLSKey = ctx.GlobalString(VaultTLSKeyFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultTLSCAFlag.Name) {
+		cfg.TLSCA = ctx.GlobalString(VaultTLSCAFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultRetriesFlag.Name) {
+		cfg.Retries = ctx.GlobalInt(VaultRetriesFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultRequestTimeoutFlag.Name) {
+		cfg.RequestTimeout = ctx.GlobalDuration(VaultRequestTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultHealthIntervalFlag.Name) {
+		cfg.HealthInterval = ctx.GlobalDuration(VaultHealthIntervalFlag.Name)
+	}
+	if ctx.GlobalIsSet(VaultPauseTimeoutFlag.Name) {
+		cfg.PauseTimeout = ctx.GlobalDuration(VaultPauseTimeoutFlag.Name)
+	}
+	// The mock vault loses every private payload on restart
+	if strings.EqualFold(cfg.Backend, private.MockBackend) && !ctx.GlobalBool(DeveloperFlag.Name) {
+		Fatalf("The %s vault backend keeps private payloads in memory only, it requires --%s", private.MockBackend, DeveloperFlag.Name)
+	}
+}
+
 func setCodeQuality(ctx *cli.Context, cfg *eth.Config) {
 	if ctx.GlobalIsSet(SolcPathFlag.Name) {
 		cfg.SolcPath = ctx.GlobalString(SolcPathFlag.Name)
@@ -1637,6 +1737,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	setWhitelist(ctx, cfg)
 	setLes(ctx, cfg)
 	setCodeQuality(ctx, cfg)
+	setVault(ctx, &cfg.Vault)
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
@@ -1909,6 +2010,17 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 	return genesis
 }
 
+// MakeVault creates the Blackbox vault selected by the command line flags.
+func MakeVault(ctx *cli.Context) private.BlackboxVault {
+	cfg := new(private.VaultConfig)
+	setVault(ctx, cfg)
+	vault, err := private.NewVault(cfg)
+	if err != nil {
+		Fatalf("Could not create vault: %v", err)
+	}
+	return vault
+}
+
 // MakeChain creates a chain manager from set command line flags.
 func MakeChain(ctx *cli.Context, stack *node.Node) (chain *core.BlockChain, chainDb ethdb.Database) {
 	var err error
@@ -1949,7 +2061,10 @@ func MakeChain(ctx *cli.Context, stack *node.Node) (chain *core.BlockChain, chai
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheGCFlag.Name) {
 		cache.TrieDirtyLimit = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheGCFlag.Name) / 100
 	}
-	vmcfg := vm.Config{EnablePreimageRecording: ctx.GlobalBool(VMEnableDebugFlag.Name)}
+	vmcfg := vm.Config{
+		EnablePreimageRecording: ctx.GlobalBool(VMEnableDebugFlag.Name),
+		Vault:                   MakeVault(ctx),
+	}
 	chain, err = core.NewBlockChain(chainDb, cache, config, engine, vmcfg, nil)
 	if err != nil {
 		Fatalf("Can't create BlockChain: %v", err)
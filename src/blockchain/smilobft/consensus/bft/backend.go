@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package istanbul
+package bft
 
 import (
 	"math/big"
@@ -25,14 +25,20 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-// Backend provides application specific functions for Istanbul core
+// ValidatorSource provides the validators of a height, each engine backs it
+// with its own source (a static snapshot, a governance or Autonity contract)
+type ValidatorSource interface {
+	// Validators returns the validator set of the given block height
+	Validators(number uint64) ValidatorSet
+}
+
+// Backend provides application specific functions for the BFT core
 type Backend interface {
+	ValidatorSource
+
 	// Address returns the owner's address
 	Address() common.Address
 
-	// Validators returns the validator set
-	Validators(number uint64) ValidatorSet
-
 	// EventMux returns the event mux in backend
 	EventMux() *cmn.TypeMux
 
@@ -1,5 +1,5 @@
 // Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
+// Copyright 2016 The go-ethereum Authors
 // This file is part of the go-ethereum library.
 //
 // The go-ethereum library is free software: you can redistribute it and/or modify
@@ -15,13 +15,18 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package smilobftcore
+package bft
 
-import "github.com/ethereum/go-ethereum/common"
+// Config holds the round timeouts of the BFT core, each engine copies them
+// from its own configuration.
+type Config struct {
+	Engine         string // The engine running the core, names its metrics
+	RequestTimeout uint64 // The timeout for each round in milliseconds
+	MaxTimeout     uint64 // The max timeout for each round in seconds
+	Journal        string // The file the consensus messages are journaled to, empty disables it
+}
 
-func (c *core) handleFinalCommitted() error {
-	logger := c.logger.New("state", c.state)
-	logger.Debug("Received a final committed proposal")
-	c.startNewRound(common.Big0)
-	return nil
+var DefaultConfig = &Config{
+	RequestTimeout: 10000,
+	MaxTimeout:     60,
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 var (
@@ -37,14 +37,14 @@ var (
 // return errInvalidMessage if the message is invalid
 // return errFutureMessage if the message view is larger than current view
 // return errOldMessage if the message view is smaller than current view
-func (c *core) checkMessage(msgCode uint64, view *istanbul.View) error {
+func (c *core) checkMessage(msgCode uint64, view *bft.View) error {
 	if view == nil || view.Sequence == nil || view.Round == nil {
 		return errInvalidMessage
 	}
 
 	if msgCode == msgRoundChange {
 		if view.Sequence.Cmp(c.currentView().Sequence) > 0 {
-			log.Debug("istanbul, core/backlog.go, checkMessage, msgRoundChange, errFutureMessage", "view.Sequence", view.Sequence, "c.currentView().Sequence", c.currentView().Sequence)
+			log.Debug("bft, core/backlog.go, checkMessage, msgRoundChange, errFutureMessage", "view.Sequence", view.Sequence, "c.currentView().Sequence", c.currentView().Sequence)
 			return errFutureMessage
 		} else if view.Cmp(c.currentView()) < 0 {
 			return errOldMessage
@@ -53,7 +53,7 @@ func (c *core) checkMessage(msgCode uint64, view *istanbul.View) error {
 	}
 
 	if view.Cmp(c.currentView()) > 0 {
-		log.Debug("istanbul, core/backlog.go, checkMessage, errFutureMessage", "view", view, "c.currentView()", c.currentView())
+		log.Debug("bft, core/backlog.go, checkMessage, errFutureMessage", "view", view, "c.currentView()", c.currentView())
 		return errFutureMessage
 	}
 
@@ -62,7 +62,7 @@ func (c *core) checkMessage(msgCode uint64, view *istanbul.View) error {
 	}
 
 	if c.waitingForRoundChange {
-		log.Debug("istanbul, core/backlog.go, checkMessage, waitingForRoundChange, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
+		log.Debug("bft, core/backlog.go, checkMessage, waitingForRoundChange, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
 		return errFutureMessage
 	}
 
@@ -70,7 +70,7 @@ func (c *core) checkMessage(msgCode uint64, view *istanbul.View) error {
 	// other messages are future messages
 	if c.state == StateAcceptRequest {
 		if msgCode > msgPreprepare {
-			log.Debug("istanbul, core/backlog.go, checkMessage, StateAcceptRequest, msgPreprepare, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
+			log.Debug("bft, core/backlog.go, checkMessage, StateAcceptRequest, msgPreprepare, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
 			return errFutureMessage
 		}
 		return nil
@@ -81,7 +81,7 @@ func (c *core) checkMessage(msgCode uint64, view *istanbul.View) error {
 	return nil
 }
 
-func (c *core) storeBacklog(msg *message, src istanbul.Validator) {
+func (c *core) storeBacklog(msg *message, src bft.Validator) {
 	logger := c.logger.New("from", src, "state", c.state)
 
 	if src.Address() == c.Address() {
@@ -101,20 +101,21 @@ func (c *core) storeBacklog(msg *message, src istanbul.Validator) {
 	}
 	switch msg.Code {
 	case msgPreprepare:
-		var p *istanbul.Preprepare
+		var p *bft.Preprepare
 		err := msg.Decode(&p)
 		if err == nil {
 			backlog.Push(msg, toPriority(msg.Code, p.View))
 		}
 		// for msgRoundChange, msgPrepare and msgCommit cases
 	default:
-		var p *istanbul.Subject
+		var p *bft.Subject
 		err := msg.Decode(&p)
 		if err == nil {
 			backlog.Push(msg, toPriority(msg.Code, p.View))
 		}
 	}
 	c.backlogs[src] = backlog
+	c.trackBacklog()
 }
 
 func (c *core) processBacklog() {
@@ -144,17 +145,17 @@ func (c *core) processBacklog() {
 		for !(backlog.Empty() || isFuture) {
 			m, prio := backlog.Pop()
 			msg := m.(*message)
-			var view *istanbul.View
+			var view *bft.View
 			switch msg.Code {
 			case msgPreprepare:
-				var m *istanbul.Preprepare
+				var m *bft.Preprepare
 				err := msg.Decode(&m)
 				if err == nil {
 					view = m.View
 				}
 				// for msgRoundChange, msgPrepare and msgCommit cases
 			default:
-				var sub *istanbul.Subject
+				var sub *bft.Subject
 				err := msg.Decode(&sub)
 				if err == nil {
 					view = sub.View
@@ -168,7 +169,7 @@ func (c *core) processBacklog() {
 			err := c.checkMessage(msg.Code, view)
 			if err != nil {
 				if err == errFutureMessage {
-					log.Debug("istanbul, core/backlog.go, checkMessage, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
+					log.Debug("bft, core/backlog.go, checkMessage, errFutureMessage", "view", view, "c.currentView().Sequence", c.currentView().Sequence)
 					logger.Trace("Stop processing backlog", "msg", msg)
 					backlog.Push(msg, prio)
 					isFuture = true
@@ -185,9 +186,10 @@ func (c *core) processBacklog() {
 			})
 		}
 	}
+	c.trackBacklog()
 }
 
-func toPriority(msgCode uint64, view *istanbul.View) float32 {
+func toPriority(msgCode uint64, view *bft.View) float32 {
 	if msgCode == msgRoundChange {
 		// For msgRoundChange, set the message priority based on its sequence
 		return -float32(view.Sequence.Uint64() * 1000)
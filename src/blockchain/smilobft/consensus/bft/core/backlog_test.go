@@ -28,14 +28,14 @@ import (
 	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 )
 
 func TestCheckMessage(t *testing.T) {
 	c := &core{
 		state: StateAcceptRequest,
-		current: newRoundState(&istanbul.View{
+		current: newRoundState(&bft.View{
 			Sequence: big.NewInt(1),
 			Round:    big.NewInt(0),
 		}, newTestValidatorSet(4), common.Hash{}, nil, nil, nil),
@@ -51,7 +51,7 @@ func TestCheckMessage(t *testing.T) {
 	testCode := []uint64{msgPreprepare, msgPrepare, msgCommit, msgRoundChange}
 
 	// future sequence
-	v := &istanbul.View{
+	v := &bft.View{
 		Sequence: big.NewInt(2),
 		Round:    big.NewInt(0),
 	}
@@ -66,7 +66,7 @@ func TestCheckMessage(t *testing.T) {
 	}
 
 	// future round
-	v = &istanbul.View{
+	v = &bft.View{
 		Sequence: big.NewInt(1),
 		Round:    big.NewInt(1),
 	}
@@ -85,7 +85,7 @@ func TestCheckMessage(t *testing.T) {
 	}
 
 	// current view but waiting for round change
-	v = &istanbul.View{
+	v = &bft.View{
 		Sequence: big.NewInt(1),
 		Round:    big.NewInt(0),
 	}
@@ -169,16 +169,16 @@ func TestCheckMessage(t *testing.T) {
 func TestStoreBacklog(t *testing.T) {
 	c := &core{
 		logger:     log.New("backend", "test", "id", 0),
-		backlogs:   make(map[istanbul.Validator]*prque.Prque),
+		backlogs:   make(map[bft.Validator]*prque.Prque),
 		backlogsMu: new(sync.Mutex),
 	}
-	v := &istanbul.View{
+	v := &bft.View{
 		Round:    big.NewInt(10),
 		Sequence: big.NewInt(10),
 	}
 	p := validator.New(common.BytesToAddress([]byte("12345667890")))
 	// push preprepare msg
-	preprepare := &istanbul.Preprepare{
+	preprepare := &bft.Preprepare{
 		View:     v,
 		Proposal: makeBlock(1),
 	}
@@ -194,7 +194,7 @@ func TestStoreBacklog(t *testing.T) {
 	}
 
 	// push prepare msg
-	subject := &istanbul.Subject{
+	subject := &bft.Subject{
 		View:   v,
 		Digest: cmn.StringToHash("1234567890"),
 	}
@@ -239,10 +239,10 @@ func TestProcessFutureBacklog(t *testing.T) {
 	}
 	c := &core{
 		logger:     log.New("backend", "test", "id", 0),
-		backlogs:   make(map[istanbul.Validator]*prque.Prque),
+		backlogs:   make(map[bft.Validator]*prque.Prque),
 		backlogsMu: new(sync.Mutex),
 		backend:    backend,
-		current: newRoundState(&istanbul.View{
+		current: newRoundState(&bft.View{
 			Sequence: big.NewInt(1),
 			Round:    big.NewInt(0),
 		}, newTestValidatorSet(4), common.Hash{}, nil, nil, nil),
@@ -251,13 +251,13 @@ func TestProcessFutureBacklog(t *testing.T) {
 	c.subscribeEvents()
 	defer c.unsubscribeEvents()
 
-	v := &istanbul.View{
+	v := &bft.View{
 		Round:    big.NewInt(10),
 		Sequence: big.NewInt(10),
 	}
 	p := validator.New(common.BytesToAddress([]byte("12345667890")))
 	// push a future msg
-	subject := &istanbul.Subject{
+	subject := &bft.Subject{
 		View:   v,
 		Digest: cmn.StringToHash("1234567890"),
 	}
@@ -283,17 +283,17 @@ func TestProcessFutureBacklog(t *testing.T) {
 }
 
 func TestProcessBacklog(t *testing.T) {
-	v := &istanbul.View{
+	v := &bft.View{
 		Round:    big.NewInt(0),
 		Sequence: big.NewInt(1),
 	}
-	preprepare := &istanbul.Preprepare{
+	preprepare := &bft.Preprepare{
 		View:     v,
 		Proposal: makeBlock(1),
 	}
 	prepreparePayload, _ := Encode(preprepare)
 
-	subject := &istanbul.Subject{
+	subject := &bft.Subject{
 		View:   v,
 		Digest: cmn.StringToHash("1234567890"),
 	}
@@ -330,11 +330,11 @@ func testProcessBacklog(t *testing.T, msg *message) {
 	}
 	c := &core{
 		logger:     log.New("backend", "test", "id", 0),
-		backlogs:   make(map[istanbul.Validator]*prque.Prque),
+		backlogs:   make(map[bft.Validator]*prque.Prque),
 		backlogsMu: new(sync.Mutex),
 		backend:    backend,
 		state:      State(msg.Code),
-		current: newRoundState(&istanbul.View{
+		current: newRoundState(&bft.View{
 			Sequence: big.NewInt(1),
 			Round:    big.NewInt(0),
 		}, newTestValidatorSet(4), common.Hash{}, nil, nil, nil),
@@ -21,7 +21,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 func (c *core) sendCommit() {
@@ -29,15 +29,15 @@ func (c *core) sendCommit() {
 	c.broadcastCommit(sub)
 }
 
-func (c *core) sendCommitForOldBlock(view *istanbul.View, digest common.Hash) {
-	sub := &istanbul.Subject{
+func (c *core) sendCommitForOldBlock(view *bft.View, digest common.Hash) {
+	sub := &bft.Subject{
 		View:   view,
 		Digest: digest,
 	}
 	c.broadcastCommit(sub)
 }
 
-func (c *core) broadcastCommit(sub *istanbul.Subject) {
+func (c *core) broadcastCommit(sub *bft.Subject) {
 	logger := c.logger.New("state", c.state)
 
 	encodedSubject, err := Encode(sub)
@@ -51,9 +51,9 @@ func (c *core) broadcastCommit(sub *istanbul.Subject) {
 	})
 }
 
-func (c *core) handleCommit(msg *message, src istanbul.Validator) error {
+func (c *core) handleCommit(msg *message, src bft.Validator) error {
 	// Decode COMMIT message
-	var commit *istanbul.Subject
+	var commit *bft.Subject
 	err := msg.Decode(&commit)
 	if err != nil {
 		return errFailedDecodeCommit
@@ -73,7 +73,7 @@ func (c *core) handleCommit(msg *message, src istanbul.Validator) error {
 	//
 	// If we already have a proposal, we may have chance to speed up the consensus process
 	// by committing the proposal without PREPARE messages.
-	if c.current.Commits.Size() > 2*c.valSet.F() && c.state.Cmp(StateCommitted) < 0 {
+	if c.current.Commits.Size() >= c.valSet.Quorum() && c.state.Cmp(StateCommitted) < 0 {
 		// Still need to call LockHash here since state can skip Prepared state and jump directly to the Committed state.
 		c.current.LockHash()
 		c.commit()
@@ -83,7 +83,7 @@ func (c *core) handleCommit(msg *message, src istanbul.Validator) error {
 }
 
 // verifyCommit verifies if the received COMMIT message is equivalent to our subject
-func (c *core) verifyCommit(commit *istanbul.Subject, src istanbul.Validator) error {
+func (c *core) verifyCommit(commit *bft.Subject, src bft.Validator) error {
 	logger := c.logger.New("from", src, "state", c.state)
 
 	sub := c.current.Subject()
@@ -95,7 +95,7 @@ func (c *core) verifyCommit(commit *istanbul.Subject, src istanbul.Validator) er
 	return nil
 }
 
-func (c *core) acceptCommit(msg *message, src istanbul.Validator) error {
+func (c *core) acceptCommit(msg *message, src bft.Validator) error {
 	logger := c.logger.New("from", src, "state", c.state)
 
 	// Add the COMMIT message to current round state
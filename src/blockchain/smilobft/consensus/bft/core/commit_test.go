@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 )
@@ -34,8 +35,8 @@ func TestHandleCommit(t *testing.T) {
 	F := uint64(1)
 
 	proposal := newTestProposal()
-	expectedSubject := &istanbul.Subject{
-		View: &istanbul.View{
+	expectedSubject := &bft.Subject{
+		View: &bft.View{
 			Round:    big.NewInt(0),
 			Sequence: proposal.Number(),
 		},
@@ -55,7 +56,7 @@ func TestHandleCommit(t *testing.T) {
 					c := backend.engine.(*core)
 					c.valSet = backend.peers
 					c.current = newTestRoundState(
-						&istanbul.View{
+						&bft.View{
 							Round:    big.NewInt(0),
 							Sequence: big.NewInt(1),
 						},
@@ -88,7 +89,7 @@ func TestHandleCommit(t *testing.T) {
 						c.state = StatePreprepared
 					} else {
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(2),
 								Sequence: big.NewInt(3),
 							},
@@ -117,7 +118,7 @@ func TestHandleCommit(t *testing.T) {
 						c.state = StatePreprepared
 					} else {
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(0),
 								Sequence: big.NewInt(0),
 							},
@@ -138,7 +139,7 @@ func TestHandleCommit(t *testing.T) {
 					c := backend.engine.(*core)
 					c.valSet = backend.peers
 					c.current = newTestRoundState(
-						&istanbul.View{
+						&bft.View{
 							Round:    big.NewInt(0),
 							Sequence: proposal.Number(),
 						},
@@ -238,78 +239,78 @@ func TestVerifyCommit(t *testing.T) {
 
 	testCases := []struct {
 		expected   error
-		commit     *istanbul.Subject
+		commit     *bft.Subject
 		roundState *roundState
 	}{
 		{
 			// normal case
 			expected: nil,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
 		{
 			// old message
 			expected: errInconsistentSubject,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// different digest
 			expected: errInconsistentSubject,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: cmn.StringToHash("1234567890"),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// malicious package(lack of sequence)
 			expected: errInconsistentSubject,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: nil},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: nil},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// wrong prepare message with same sequence but different round
 			expected: errInconsistentSubject,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(0)},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(1), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
 		{
 			// wrong prepare message with same round but different sequence
 			expected: errInconsistentSubject,
-			commit: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
+			commit: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
@@ -30,12 +30,15 @@ import (
 	"github.com/ethereum/go-ethereum/metrics"
 	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
-// New creates an Istanbul consensus core
-func New(backend istanbul.Backend, config *istanbul.Config) Engine {
+// New creates a BFT consensus core
+func New(backend bft.Backend, config *bft.Config) Engine {
 	c := &core{
 		config:             config,
 		address:            backend.Address(),
@@ -43,14 +46,15 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 		handlerStopCh:      make(chan struct{}),
 		logger:             log.New("address", backend.Address()),
 		backend:            backend,
-		backlogs:           make(map[istanbul.Validator]*prque.Prque),
+		backlogs:           make(map[bft.Validator]*prque.Prque),
 		backlogsMu:         new(sync.Mutex),
 		pendingRequests:    prque.New(),
 		pendingRequestsMu:  new(sync.Mutex),
 		consensusTimestamp: time.Time{},
-		roundMeter:         metrics.NewRegisteredMeter("consensus/istanbul/core/round", nil),
-		sequenceMeter:      metrics.NewRegisteredMeter("consensus/istanbul/core/sequence", nil),
-		consensusTimer:     metrics.NewRegisteredTimer("consensus/istanbul/core/consensus", nil),
+		roundMeter:         metrics.NewRegisteredMeter("consensus/bft/core/round", nil),
+		sequenceMeter:      metrics.NewRegisteredMeter("consensus/bft/core/sequence", nil),
+		consensusTimer:     metrics.NewRegisteredTimer("consensus/bft/core/consensus", nil),
+		health:             health.NewTracker(engineName(config), backend.Address()),
 	}
 	c.validateFn = c.checkValidatorSignature
 	return c
@@ -59,22 +63,22 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 // ----------------------------------------------------------------------------
 
 type core struct {
-	config  *istanbul.Config
+	config  *bft.Config
 	address common.Address
 	state   State
 	logger  log.Logger
 
-	backend               istanbul.Backend
+	backend               bft.Backend
 	events                *cmn.TypeMuxSubscription
 	finalCommittedSub     *cmn.TypeMuxSubscription
 	timeoutSub            *cmn.TypeMuxSubscription
 	futurePreprepareTimer *time.Timer
 
-	valSet                istanbul.ValidatorSet
+	valSet                bft.ValidatorSet
 	waitingForRoundChange bool
 	validateFn            func([]byte, []byte) (common.Address, error)
 
-	backlogs   map[istanbul.Validator]*prque.Prque
+	backlogs   map[bft.Validator]*prque.Prque
 	backlogsMu *sync.Mutex
 
 	current       *roundState
@@ -96,6 +100,11 @@ type core struct {
 	consensusTimer metrics.Timer
 
 	sentPreprepare bool
+
+	// journal records the consensus messages and events, nil unless enabled
+	journal *journal.Journal
+	// health tracks the round state for the metrics and the status RPC
+	health *health.Tracker
 }
 
 func (c *core) finalizeMessage(msg *message) ([]byte, error) {
@@ -143,15 +152,18 @@ func (c *core) broadcast(msg *message) {
 	}
 
 	// Broadcast payload
-	log.Debug("$$$ Istanbul, core/core.go, Broadcast payload, ", "msg", msg)
-	if err = c.backend.Broadcast(c.valSet, payload); err != nil {
+	log.Debug("$$$ BFT, core/core.go, Broadcast payload, ", "msg", msg)
+	entry := c.journal.Message(journal.KindSent, enode.ID{}, payload)
+	err = c.backend.Broadcast(c.valSet, payload)
+	c.journal.Failed(entry, err)
+	if err != nil {
 		logger.Error("Failed to broadcast message", "msg", msg, "err", err)
 		return
 	}
 }
 
-func (c *core) currentView() *istanbul.View {
-	return &istanbul.View{
+func (c *core) currentView() *bft.View {
+	return &bft.View{
 		Sequence: new(big.Int).Set(c.current.Sequence()),
 		Round:    new(big.Int).Set(c.current.Round()),
 	}
@@ -199,7 +211,7 @@ func (c *core) startNewRound(round *big.Int) {
 
 	roundChange := false
 	// Try to get last proposal
-	lastProposal, lastProposer := c.backend.LastProposal()
+	lastProposal, lastProposer := c.lastProposal()
 	if c.current == nil {
 		logger.Trace("Start to the initial round")
 	} else if lastProposal.Number().Cmp(c.current.Sequence()) >= 0 {
@@ -225,18 +237,18 @@ func (c *core) startNewRound(round *big.Int) {
 		return
 	}
 
-	var newView *istanbul.View
+	var newView *bft.View
 	if roundChange {
-		newView = &istanbul.View{
+		newView = &bft.View{
 			Sequence: new(big.Int).Set(c.current.Sequence()),
 			Round:    new(big.Int).Set(round),
 		}
 	} else {
-		newView = &istanbul.View{
+		newView = &bft.View{
 			Sequence: new(big.Int).Add(lastProposal.Number(), common.Big1),
 			Round:    new(big.Int),
 		}
-		c.valSet = c.backend.Validators(lastProposal.Number().Uint64() + 1)
+		c.valSet = c.validators(lastProposal.Number().Uint64() + 1)
 	}
 
 	// Update logger
@@ -247,6 +259,8 @@ func (c *core) startNewRound(round *big.Int) {
 	c.updateRoundState(newView, c.valSet, roundChange)
 	// Calculate new proposer
 	c.valSet.CalcProposer(lastProposer, newView.Round.Uint64())
+	c.journalProposer()
+	c.trackRound()
 	c.waitingForRoundChange = false
 	c.sentPreprepare = false
 	c.setState(StateAcceptRequest)
@@ -254,7 +268,7 @@ func (c *core) startNewRound(round *big.Int) {
 		// If it is locked, propose the old proposal
 		// If we have pending request, propose pending request
 		if c.current.IsHashLocked() {
-			r := &istanbul.Request{
+			r := &bft.Request{
 				Proposal: c.current.Proposal(), //c.current.ProposalBlock would be the locked proposal by previous proposer, see updateRoundState
 			}
 			c.sendPreprepare(r)
@@ -267,7 +281,7 @@ func (c *core) startNewRound(round *big.Int) {
 	logger.Debug("New round", "new_round", newView.Round, "new_seq", newView.Sequence, "new_proposer", c.valSet.GetProposer(), "valSet", c.valSet.List(), "size", c.valSet.Size(), "isProposer", c.IsProposer())
 }
 
-func (c *core) catchUpRound(view *istanbul.View) {
+func (c *core) catchUpRound(view *bft.View) {
 	logger := c.logger.New("old_round", c.current.Round(), "old_seq", c.current.Sequence(), "old_proposer", c.valSet.GetProposer())
 
 	if view.Round.Cmp(c.current.Round()) > 0 {
@@ -277,6 +291,7 @@ func (c *core) catchUpRound(view *istanbul.View) {
 
 	// Need to keep block locked for round catching up
 	c.updateRoundState(view, c.valSet, true)
+	c.trackRound()
 	c.roundChangeSet.Clear(view.Round)
 	c.newRoundChangeTimer()
 
@@ -284,7 +299,7 @@ func (c *core) catchUpRound(view *istanbul.View) {
 }
 
 // updateRoundState updates round state by checking if locking block is necessary
-func (c *core) updateRoundState(view *istanbul.View, validatorSet istanbul.ValidatorSet, roundChange bool) {
+func (c *core) updateRoundState(view *bft.View, validatorSet bft.ValidatorSet, roundChange bool) {
 	// Lock only if both roundChange is true and it is locked
 	if roundChange && c.current != nil {
 		if c.current.IsHashLocked() {
@@ -301,6 +316,7 @@ func (c *core) setState(state State) {
 	if c.state != state {
 		c.state = state
 	}
+	c.health.Step(stateName(state))
 	if state == StateAcceptRequest {
 		c.processPendingRequests()
 	}
@@ -354,7 +370,7 @@ func (c *core) newRoundChangeTimer() {
 }
 
 func (c *core) checkValidatorSignature(data []byte, sig []byte) (common.Address, error) {
-	return istanbul.CheckValidatorSignature(c.valSet, data, sig)
+	return bft.CheckValidatorSignature(c.valSet, data, sig)
 }
 
 // PrepareCommittedSeal returns a committed seal for the given hash
@@ -24,7 +24,7 @@ import (
 
 	elog "github.com/ethereum/go-ethereum/log"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/core/types"
 )
 
@@ -40,7 +40,7 @@ func makeBlock(number int64) *types.Block {
 	return block.WithSeal(header)
 }
 
-func newTestProposal() istanbul.Proposal {
+func newTestProposal() bft.Proposal {
 	return makeBlock(1)
 }
 
@@ -77,3 +77,35 @@ func TestNewRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestStatus(t *testing.T) {
+	sys := NewTestSystemWithBackend(4, 1)
+
+	close := sys.Run(true)
+	defer close()
+
+	sys.backends[0].NewRequest(makeBlock(1))
+
+	<-time.After(1 * time.Second)
+
+	for i, backend := range sys.backends {
+		status := backend.engine.Status()
+		if status.Engine != "bft" || status.Height != 2 || status.Round != 0 {
+			t.Errorf("backend %d: expected bft at height 2 round 0, got %s at height %d round %d", i, status.Engine, status.Height, status.Round)
+		}
+		if status.Quorum != 3 || len(status.Validators) != 4 {
+			t.Errorf("backend %d: expected 4 validators with a quorum of 3, got %d and %d", i, len(status.Validators), status.Quorum)
+		}
+		if status.Step != stateName(StateAcceptRequest) {
+			t.Errorf("backend %d: expected step %s, got %s", i, stateName(StateAcceptRequest), status.Step)
+		}
+		for _, val := range status.Validators {
+			if val.LastHeight < 1 {
+				t.Errorf("backend %d: expected to have heard %v at height 1, got %d", i, val.Address.Hex(), val.LastHeight)
+			}
+		}
+		if len(status.Silent) != 0 {
+			t.Errorf("backend %d: expected no silent validator, got %v", i, status.Silent)
+		}
+	}
+}
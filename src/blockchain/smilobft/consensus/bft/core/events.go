@@ -17,11 +17,11 @@
 package core
 
 import (
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 type backlogEvent struct {
-	src istanbul.Validator
+	src bft.Validator
 	msg *message
 }
 
@@ -18,12 +18,19 @@ package core
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 // Start implements core.Engine.Start
 func (c *core) Start() error {
+	if c.journal == nil {
+		c.journal = journal.OpenCore(c.config.Journal, c.address, decodeMessage, c.logger)
+	}
+
 	// Start a new round from last sequence + 1
 	c.startNewRound(common.Big0)
 
@@ -42,6 +49,9 @@ func (c *core) Stop() error {
 
 	// Make sure the handler goroutine exits
 	c.handlerStopCh <- struct{}{}
+
+	c.journal.Stop(c.logger)
+	c.journal = nil
 	return nil
 }
 
@@ -51,8 +61,8 @@ func (c *core) Stop() error {
 func (c *core) subscribeEvents() {
 	c.events = c.backend.EventMux().Subscribe(
 		// external events
-		istanbul.RequestEvent{},
-		istanbul.MessageEvent{},
+		bft.RequestEvent{},
+		bft.MessageEvent{},
 		// internal events
 		backlogEvent{},
 	)
@@ -60,7 +70,7 @@ func (c *core) subscribeEvents() {
 		timeoutEvent{},
 	)
 	c.finalCommittedSub = c.backend.EventMux().Subscribe(
-		istanbul.FinalCommittedEvent{},
+		bft.FinalCommittedEvent{},
 	)
 }
 
@@ -85,56 +95,86 @@ func (c *core) handleEvents() {
 				return
 			}
 			// A real event arrived, process interesting content
-			switch ev := event.Data.(type) {
-			case istanbul.RequestEvent:
-				r := &istanbul.Request{
-					Proposal: ev.Proposal,
-				}
-				err := c.handleRequest(r)
-				if err == errFutureMessage {
-					c.logger.Debug("$$$ istanbul, handleEvents, RequestEvent arrived, errFutureMessage", "Proposal", ev.Proposal.Hash().Hex())
-					c.storeRequestMsg(r)
-				}
-			case istanbul.MessageEvent:
-				if err := c.handleMsg(ev.Payload); err == nil {
-					c.logger.Debug("$$$ istanbul, handleEvents, MessageEvent arrived, will send Gossip to fullnodeSet")
-					err = c.backend.Gossip(c.valSet, ev.Payload)
-					if err != nil {
-						c.logger.Error("$$$ istanbul, handleEvents, handleMsg, failed to backend.Gossip", "err", err)
-					}
-				} else {
-					c.logger.Error("$$$ istanbul, handleEvents, istanbul.MessageEvent", "err", err)
-				}
-			case backlogEvent:
-				// No need to check signature for internal messages
-				if err := c.handleCheckedMsg(ev.msg, ev.src); err == nil {
-					p, err := ev.msg.Payload()
-					if err != nil {
-						c.logger.Warn("handleEvents, Get message payload failed", "err", err)
-						continue
-					}
-					err = c.backend.Gossip(c.valSet, p)
-					if err != nil {
-						c.logger.Error("$$$ istanbul, handleEvents, handleCheckedMsg, backend.Gossip ", "err", err)
-					}
-				}
-			}
-		case _, ok := <-c.timeoutSub.Chan():
+			c.handleEvent(event.Data)
+		case event, ok := <-c.timeoutSub.Chan():
 			if !ok {
 				return
 			}
-			c.handleTimeoutMsg()
+			c.handleEvent(event.Data)
 		case event, ok := <-c.finalCommittedSub.Chan():
 			if !ok {
 				return
 			}
-			switch event.Data.(type) {
-			case istanbul.FinalCommittedEvent:
-				err := c.handleFinalCommitted()
-				if err != nil {
-					c.logger.Error("$$$ istanbul, handleEvents, FinalCommittedEvent, handleFinalCommitted", "err", err)
-				}
+			c.handleEvent(event.Data)
+		}
+	}
+}
+
+// handleEvent journals a single event before processing it, it is shared by
+// the event loop and the journal replay.
+func (c *core) handleEvent(event interface{}) {
+	switch ev := event.(type) {
+	case bft.RequestEvent:
+		var entry *journal.Entry
+		if c.journal != nil && ev.Proposal != nil {
+			payload, _ := rlp.EncodeToBytes(ev.Proposal)
+			entry = &journal.Entry{
+				Kind:    journal.KindRequest,
+				Height:  ev.Proposal.Number().Uint64(),
+				Payload: payload,
 			}
+			c.journal.Write(entry)
+		}
+		r := &bft.Request{
+			Proposal: ev.Proposal,
+		}
+		err := c.handleRequest(r)
+		c.journal.Failed(entry, err)
+		if err == errFutureMessage {
+			c.logger.Debug("$$$ bft, handleEvents, RequestEvent arrived, errFutureMessage", "Proposal", ev.Proposal.Hash().Hex())
+			c.storeRequestMsg(r)
+		}
+	case bft.MessageEvent:
+		entry := c.journal.Message(journal.KindReceived, ev.Peer, ev.Payload)
+		err := c.handleMsg(ev.Payload)
+		c.journal.Failed(entry, err)
+		if err == nil {
+			c.logger.Debug("$$$ bft, handleEvents, MessageEvent arrived, will send Gossip to fullnodeSet")
+			err = c.backend.Gossip(c.valSet, ev.Payload)
+			if err != nil {
+				c.logger.Error("$$$ bft, handleEvents, handleMsg, failed to backend.Gossip", "err", err)
+			}
+		} else {
+			c.logger.Error("$$$ bft, handleEvents, bft.MessageEvent", "err", err)
+		}
+	case backlogEvent:
+		// No need to check signature for internal messages
+		var entry *journal.Entry
+		p, perr := ev.msg.Payload()
+		if perr == nil {
+			entry = c.journal.Message(journal.KindBacklog, enode.ID{}, p)
+		}
+		err := c.handleCheckedMsg(ev.msg, ev.src)
+		if perr != nil {
+			c.logger.Warn("handleEvents, Get message payload failed", "err", perr)
+			return
+		}
+		c.journal.Failed(entry, err)
+		if err == nil {
+			err = c.backend.Gossip(c.valSet, p)
+			if err != nil {
+				c.logger.Error("$$$ bft, handleEvents, handleCheckedMsg, backend.Gossip ", "err", err)
+			}
+		}
+	case timeoutEvent:
+		c.journalEvent(journal.KindTimeout)
+		c.handleTimeoutMsg()
+	case bft.FinalCommittedEvent:
+		entry := c.journalEvent(journal.KindCommitted)
+		err := c.handleFinalCommitted()
+		c.journal.Failed(entry, err)
+		if err != nil {
+			c.logger.Error("$$$ bft, handleEvents, FinalCommittedEvent, handleFinalCommitted", "err", err)
 		}
 	}
 }
@@ -143,7 +183,7 @@ func (c *core) handleEvents() {
 func (c *core) sendEvent(ev interface{}) {
 	err := c.backend.EventMux().Post(ev)
 	if err != nil {
-		c.logger.Error("$$$ istanbul, sendEvent", "err", err)
+		c.logger.Error("$$$ bft, sendEvent", "err", err)
 	}
 }
 
@@ -161,13 +201,14 @@ func (c *core) handleMsg(payload []byte) error {
 	_, src := c.valSet.GetByAddress(msg.Address)
 	if src == nil {
 		logger.Error("Invalid address in message", "msg", msg)
-		return istanbul.ErrUnauthorizedAddress
+		return bft.ErrUnauthorizedAddress
 	}
+	c.health.Heard(src.Address())
 
 	return c.handleCheckedMsg(msg, src)
 }
 
-func (c *core) handleCheckedMsg(msg *message, src istanbul.Validator) error {
+func (c *core) handleCheckedMsg(msg *message, src bft.Validator) error {
 	logger := c.logger.New("address", c.address, "from", src)
 
 	// Store the message if it's a future message
@@ -196,6 +237,11 @@ func (c *core) handleCheckedMsg(msg *message, src istanbul.Validator) error {
 }
 
 func (c *core) handleTimeoutMsg() {
+	// The round timed out before a preprepare was accepted
+	if !c.waitingForRoundChange && c.state == StateAcceptRequest {
+		c.health.MissedProposal()
+	}
+
 	// If we're not waiting for round change yet, we can try to catch up
 	// the max round with F+1 round change message. We only need to catch up
 	// if the max round is larger than current round.
@@ -207,7 +253,7 @@ func (c *core) handleTimeoutMsg() {
 		}
 	}
 
-	lastProposal, _ := c.backend.LastProposal()
+	lastProposal, _ := c.lastProposal()
 	if lastProposal != nil && lastProposal.Number().Cmp(c.current.Sequence()) >= 0 {
 		c.logger.Trace("round change timeout, catch up latest sequence", "number", lastProposal.Number().Uint64())
 		c.startNewRound(common.Big0)
@@ -21,7 +21,7 @@ import (
 	"testing"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 // notice: the normal case have been tested in integration tests.
@@ -36,14 +36,14 @@ func TestHandleMsg(t *testing.T) {
 	v0 := sys.backends[0]
 	r0 := v0.engine.(*core)
 
-	m, _ := Encode(&istanbul.Subject{
-		View: &istanbul.View{
+	m, _ := Encode(&bft.Subject{
+		View: &bft.View{
 			Sequence: big.NewInt(0),
 			Round:    big.NewInt(0),
 		},
 		Digest: cmn.StringToHash("1234567890"),
 	})
-	// with a matched payload. msgPreprepare should match with *istanbul.Preprepare in normal case.
+	// with a matched payload. msgPreprepare should match with *bft.Preprepare in normal case.
 	msg := &message{
 		Code:          msgPreprepare,
 		Msg:           m,
@@ -57,14 +57,14 @@ func TestHandleMsg(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodePreprepare)
 	}
 
-	m, _ = Encode(&istanbul.Preprepare{
-		View: &istanbul.View{
+	m, _ = Encode(&bft.Preprepare{
+		View: &bft.View{
 			Sequence: big.NewInt(0),
 			Round:    big.NewInt(0),
 		},
 		Proposal: makeBlock(1),
 	})
-	// with a unmatched payload. msgPrepare should match with *istanbul.Subject in normal case.
+	// with a unmatched payload. msgPrepare should match with *bft.Subject in normal case.
 	msg = &message{
 		Code:          msgPrepare,
 		Msg:           m,
@@ -78,14 +78,14 @@ func TestHandleMsg(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodePreprepare)
 	}
 
-	m, _ = Encode(&istanbul.Preprepare{
-		View: &istanbul.View{
+	m, _ = Encode(&bft.Preprepare{
+		View: &bft.View{
 			Sequence: big.NewInt(0),
 			Round:    big.NewInt(0),
 		},
 		Proposal: makeBlock(2),
 	})
-	// with a unmatched payload. istanbul.MsgCommit should match with *istanbul.Subject in normal case.
+	// with a unmatched payload. msgCommit should match with *bft.Subject in normal case.
 	msg = &message{
 		Code:          msgCommit,
 		Msg:           m,
@@ -99,8 +99,8 @@ func TestHandleMsg(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodeCommit)
 	}
 
-	m, _ = Encode(&istanbul.Preprepare{
-		View: &istanbul.View{
+	m, _ = Encode(&bft.Preprepare{
+		View: &bft.View{
 			Sequence: big.NewInt(0),
 			Round:    big.NewInt(0),
 		},
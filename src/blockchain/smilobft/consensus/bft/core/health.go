@@ -0,0 +1,82 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+)
+
+// Status implements core.Engine.Status
+func (c *core) Status() *health.Status {
+	return c.health.Status()
+}
+
+// engineName returns the name the metrics of the core are registered under
+func engineName(config *bft.Config) string {
+	if config.Engine == "" {
+		return "bft"
+	}
+	return config.Engine
+}
+
+// stateName returns the metric name of a state
+func stateName(state State) string {
+	switch state {
+	case StateAcceptRequest:
+		return "acceptrequest"
+	case StatePreprepared:
+		return "preprepared"
+	case StatePrepared:
+		return "prepared"
+	case StateCommitted:
+		return "committed"
+	}
+	return "unknown"
+}
+
+// trackRound records the round that just started
+func (c *core) trackRound() {
+	if c.health == nil {
+		return
+	}
+	var proposer common.Address
+	if p := c.valSet.GetProposer(); p != nil {
+		proposer = p.Address()
+	}
+	validators := make([]common.Address, 0, c.valSet.Size())
+	for _, val := range c.valSet.List() {
+		validators = append(validators, val.Address())
+	}
+	c.health.NewRound(c.current.Sequence().Uint64(), c.current.Round().Uint64(), proposer, validators, c.valSet.Quorum())
+}
+
+// trackBacklog records the number of backlogged messages, backlogsMu must be held
+func (c *core) trackBacklog() {
+	if c.health == nil {
+		return
+	}
+	size := 0
+	for _, backlog := range c.backlogs {
+		if backlog != nil {
+			size += backlog.Size()
+		}
+	}
+	c.health.Backlog(size)
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+)
+
+// codeName returns the journal name of a message code
+func codeName(code uint64) string {
+	switch code {
+	case msgPreprepare:
+		return "preprepare"
+	case msgPrepare:
+		return "prepare"
+	case msgCommit:
+		return "commit"
+	case msgRoundChange:
+		return "roundchange"
+	}
+	return "unknown"
+}
+
+// decodeMessage fills the code, signer and view of a journaled message
+func decodeMessage(e *journal.Entry) {
+	msg := new(message)
+	if rlp.DecodeBytes(e.Payload, msg) != nil {
+		return
+	}
+	e.Code = codeName(msg.Code)
+	e.Address = msg.Address
+
+	var view *bft.View
+	if msg.Code == msgPreprepare {
+		var preprepare *bft.Preprepare
+		if msg.Decode(&preprepare) == nil {
+			view = preprepare.View
+		}
+	} else {
+		var subject *bft.Subject
+		if msg.Decode(&subject) == nil {
+			view = subject.View
+		}
+	}
+	if view != nil && view.Sequence != nil && view.Round != nil {
+		e.Height, e.Round = view.Sequence.Uint64(), view.Round.Uint64()
+	}
+}
+
+// journalEvent records a core input that isn't a message, at the current view
+func (c *core) journalEvent(kind string) *journal.Entry {
+	if c.journal == nil {
+		return nil
+	}
+	entry := &journal.Entry{Kind: kind}
+	if c.current != nil {
+		entry.Height, entry.Round = c.current.Sequence().Uint64(), c.current.Round().Uint64()
+	}
+	c.journal.Write(entry)
+	return entry
+}
+
+// lastProposal asks the backend for the last committed proposal and journals the answer
+func (c *core) lastProposal() (bft.Proposal, common.Address) {
+	proposal, proposer := c.backend.LastProposal()
+	if c.journal != nil && proposal != nil {
+		payload, _ := rlp.EncodeToBytes(proposal)
+		c.journal.Write(&journal.Entry{
+			Kind:    journal.KindHead,
+			Address: proposer,
+			Height:  proposal.Number().Uint64(),
+			Payload: payload,
+		})
+	}
+	return proposal, proposer
+}
+
+// validators asks the backend for the validators of a height and journals the answer
+func (c *core) validators(number uint64) bft.ValidatorSet {
+	valSet := c.backend.Validators(number)
+	if c.journal != nil {
+		entry := &journal.Entry{
+			Kind:   journal.KindValidators,
+			Height: number,
+			Quorum: valSet.Quorum(),
+			Faulty: valSet.F(),
+		}
+		for _, val := range valSet.List() {
+			entry.Validators = append(entry.Validators, val.Address())
+		}
+		c.journal.Write(entry)
+	}
+	return valSet
+}
+
+// verify asks the backend to verify a proposal and journals the answer
+func (c *core) verify(proposal bft.Proposal) (time.Duration, error) {
+	duration, err := c.backend.Verify(proposal)
+	if c.journal != nil {
+		c.journal.Write(&journal.Entry{
+			Kind:   journal.KindVerify,
+			Height: proposal.Number().Uint64(),
+			Error:  journal.ErrString(err),
+		})
+	}
+	return duration, err
+}
+
+// journalProposer records the proposer the validator set picked for the current round
+func (c *core) journalProposer() {
+	if c.journal == nil {
+		return
+	}
+	entry := &journal.Entry{
+		Kind:   journal.KindProposer,
+		Height: c.current.Sequence().Uint64(),
+		Round:  c.current.Round().Uint64(),
+	}
+	if proposer := c.valSet.GetProposer(); proposer != nil {
+		entry.Address = proposer.Address()
+	}
+	c.journal.Write(entry)
+}
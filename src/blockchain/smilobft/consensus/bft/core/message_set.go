@@ -24,13 +24,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 // Construct a new message set to accumulate messages for given sequence/view number.
-func newMessageSet(valSet istanbul.ValidatorSet) *messageSet {
+func newMessageSet(valSet bft.ValidatorSet) *messageSet {
 	return &messageSet{
-		view: &istanbul.View{
+		view: &bft.View{
 			Round:    new(big.Int),
 			Sequence: new(big.Int),
 		},
@@ -43,13 +43,13 @@ func newMessageSet(valSet istanbul.ValidatorSet) *messageSet {
 // ----------------------------------------------------------------------------
 
 type messageSet struct {
-	view       *istanbul.View
-	valSet     istanbul.ValidatorSet
+	view       *bft.View
+	valSet     bft.ValidatorSet
 	messagesMu *sync.Mutex
 	messages   map[common.Address]*message
 }
 
-func (ms *messageSet) View() *istanbul.View {
+func (ms *messageSet) View() *bft.View {
 	return ms.view
 }
 
@@ -92,7 +92,7 @@ func (ms *messageSet) Get(addr common.Address) *message {
 func (ms *messageSet) verify(msg *message) error {
 	// verify if the message comes from one of the validators
 	if _, v := ms.valSet.GetByAddress(msg.Address); v == nil {
-		return istanbul.ErrUnauthorizedAddress
+		return bft.ErrUnauthorizedAddress
 	}
 
 	// TODO: check view number and sequence number
@@ -23,7 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 func TestMessageSetWithPreprepare(t *testing.T) {
@@ -31,11 +31,11 @@ func TestMessageSetWithPreprepare(t *testing.T) {
 
 	ms := newMessageSet(valSet)
 
-	view := &istanbul.View{
+	view := &bft.View{
 		Round:    new(big.Int),
 		Sequence: new(big.Int),
 	}
-	pp := &istanbul.Preprepare{
+	pp := &bft.Preprepare{
 		View:     view,
 		Proposal: makeBlock(1),
 	}
@@ -70,12 +70,12 @@ func TestMessageSetWithSubject(t *testing.T) {
 
 	ms := newMessageSet(valSet)
 
-	view := &istanbul.View{
+	view := &bft.View{
 		Round:    new(big.Int),
 		Sequence: new(big.Int),
 	}
 
-	sub := &istanbul.Subject{
+	sub := &bft.Subject{
 		View:   view,
 		Digest: cmn.StringToHash("1234567890"),
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 )
@@ -34,8 +35,8 @@ func TestHandlePrepare(t *testing.T) {
 	F := uint64(1)
 
 	proposal := newTestProposal()
-	expectedSubject := &istanbul.Subject{
-		View: &istanbul.View{
+	expectedSubject := &bft.Subject{
+		View: &bft.View{
 			Round:    big.NewInt(0),
 			Sequence: proposal.Number(),
 		},
@@ -55,7 +56,7 @@ func TestHandlePrepare(t *testing.T) {
 					c := backend.engine.(*core)
 					c.valSet = backend.peers
 					c.current = newTestRoundState(
-						&istanbul.View{
+						&bft.View{
 							Round:    big.NewInt(0),
 							Sequence: big.NewInt(1),
 						},
@@ -88,7 +89,7 @@ func TestHandlePrepare(t *testing.T) {
 						c.state = StatePreprepared
 					} else {
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(2),
 								Sequence: big.NewInt(3),
 							},
@@ -117,7 +118,7 @@ func TestHandlePrepare(t *testing.T) {
 						c.state = StatePreprepared
 					} else {
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(0),
 								Sequence: big.NewInt(0),
 							},
@@ -146,7 +147,7 @@ func TestHandlePrepare(t *testing.T) {
 						c.state = StatePreprepared
 					} else {
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(0),
 								Sequence: big.NewInt(1)},
 							c.valSet,
@@ -246,7 +247,7 @@ OUTER:
 		if decodedMsg.Code != msgCommit {
 			t.Errorf("message code mismatch: have %v, want %v", decodedMsg.Code, msgCommit)
 		}
-		var m *istanbul.Subject
+		var m *bft.Subject
 		err = decodedMsg.Decode(&m)
 		if err != nil {
 			t.Errorf("error mismatch: have %v, want nil", err)
@@ -260,6 +261,48 @@ OUTER:
 	}
 }
 
+// quorumSet overrides the quorum of a validator set, as the SPoRT fullnode sets
+// do with MinApprovers
+type quorumSet struct {
+	bft.ValidatorSet
+	quorum int
+}
+
+func (s quorumSet) Quorum() int { return s.quorum }
+
+func TestHandlePrepareQuorum(t *testing.T) {
+	// 2F+1 is 3 for 6 validators, the validator set asks for 4
+	sys := NewTestSystemWithBackend(6, 1)
+	defer sys.Run(false)()
+
+	v0 := sys.backends[0]
+	r0 := v0.engine.(*core)
+	r0.valSet = quorumSet{v0.peers, 4}
+	r0.current = newTestRoundState(&bft.View{
+		Round:    big.NewInt(0),
+		Sequence: big.NewInt(1),
+	}, r0.valSet)
+	r0.state = StatePreprepared
+
+	m, _ := Encode(r0.current.Subject())
+	for i := 0; i < r0.valSet.Quorum(); i++ {
+		if r0.state != StatePreprepared {
+			t.Fatalf("state mismatch after %d PREPARE messages: have %v, want %v", i, r0.state, StatePreprepared)
+		}
+		validator := r0.valSet.GetByIndex(uint64(i))
+		if err := r0.handlePrepare(&message{
+			Code:    msgPrepare,
+			Msg:     m,
+			Address: validator.Address(),
+		}, validator); err != nil {
+			t.Fatalf("error mismatch: have %v, want nil", err)
+		}
+	}
+	if r0.state != StatePrepared {
+		t.Errorf("state mismatch: have %v, want %v", r0.state, StatePrepared)
+	}
+}
+
 // round is not checked for now
 func TestVerifyPrepare(t *testing.T) {
 	// for log purpose
@@ -272,78 +315,78 @@ func TestVerifyPrepare(t *testing.T) {
 	testCases := []struct {
 		expected error
 
-		prepare    *istanbul.Subject
+		prepare    *bft.Subject
 		roundState *roundState
 	}{
 		{
 			// normal case
 			expected: nil,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
 		{
 			// old message
 			expected: errInconsistentSubject,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// different digest
 			expected: errInconsistentSubject,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				Digest: cmn.StringToHash("1234567890"),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// malicious package(lack of sequence)
 			expected: errInconsistentSubject,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: nil},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: nil},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
+				&bft.View{Round: big.NewInt(1), Sequence: big.NewInt(1)},
 				valSet,
 			),
 		},
 		{
 			// wrong PREPARE message with same sequence but different round
 			expected: errInconsistentSubject,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(0)},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(1), Sequence: big.NewInt(0)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
 		{
 			// wrong PREPARE message with same round but different sequence
 			expected: errInconsistentSubject,
-			prepare: &istanbul.Subject{
-				View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
+			prepare: &bft.Subject{
+				View:   &bft.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
 				Digest: newTestProposal().Hash(),
 			},
 			roundState: newTestRoundState(
-				&istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
+				&bft.View{Round: big.NewInt(0), Sequence: big.NewInt(0)},
 				valSet,
 			),
 		},
@@ -22,17 +22,17 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
-func (c *core) sendPreprepare(request *istanbul.Request) {
+func (c *core) sendPreprepare(request *bft.Request) {
 	logger := c.logger.New("state", c.state)
 
 	// If I'm the proposer and I have the same sequence with the proposal
 	if c.current.Sequence().Cmp(request.Proposal.Number()) == 0 && c.IsProposer() && !c.sentPreprepare {
 		log.Debug("$$$ I'm the proposer and I have the same sequence with the proposal TRUE", "c.current.Sequence()", c.current.Sequence(), "c.isProposer()", c.IsProposer(), "request.Proposal.Number()", request.Proposal.Number(), "c.sentPreprepare", c.sentPreprepare)
 		curView := c.currentView()
-		preprepare, err := Encode(&istanbul.Preprepare{
+		preprepare, err := Encode(&bft.Preprepare{
 			View:     curView,
 			Proposal: request.Proposal,
 		})
@@ -49,10 +49,10 @@ func (c *core) sendPreprepare(request *istanbul.Request) {
 	}
 }
 
-func (c *core) handlePreprepare(msg *message, src istanbul.Validator) error {
+func (c *core) handlePreprepare(msg *message, src bft.Validator) error {
 	logger := c.logger.New("from", src, "state", c.state)
 	// Decode PRE-PREPARE
-	var preprepare *istanbul.Preprepare
+	var preprepare *bft.Preprepare
 	err := msg.Decode(&preprepare)
 	if err != nil {
 		return errFailedDecodePreprepare
@@ -85,7 +85,7 @@ func (c *core) handlePreprepare(msg *message, src istanbul.Validator) error {
 	}
 
 	// Verify the proposal we received
-	if duration, err := c.backend.Verify(preprepare.Proposal); err != nil {
+	if duration, err := c.verify(preprepare.Proposal); err != nil {
 		logger.Warn("Failed to verify proposal", "err", err, "duration", duration)
 		// if it's a future block, we will handle it again after the duration
 		// TIME FIELD OF HEADER CHECKED HERE - NOT HEIGHT
@@ -131,7 +131,7 @@ func (c *core) handlePreprepare(msg *message, src istanbul.Validator) error {
 	return nil
 }
 
-func (c *core) acceptPreprepare(preprepare *istanbul.Preprepare) {
+func (c *core) acceptPreprepare(preprepare *bft.Preprepare) {
 	c.consensusTimestamp = time.Now()
 	c.current.SetPreprepare(preprepare)
 }
@@ -21,11 +21,11 @@ import (
 	"reflect"
 	"testing"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
-func newTestPreprepare(v *istanbul.View) *istanbul.Preprepare {
-	return &istanbul.Preprepare{
+func newTestPreprepare(v *bft.View) *bft.Preprepare {
+	return &bft.Preprepare{
 		View:     v,
 		Proposal: newTestProposal(),
 	}
@@ -37,7 +37,7 @@ func TestHandlePreprepare(t *testing.T) {
 
 	testCases := []struct {
 		system          *testSystem
-		expectedRequest istanbul.Proposal
+		expectedRequest bft.Proposal
 		expectedErr     error
 		existingBlock   bool
 	}{
@@ -71,7 +71,7 @@ func TestHandlePreprepare(t *testing.T) {
 						c.state = StateAcceptRequest
 						// hack: force set subject that future message can be simulated
 						c.current = newTestRoundState(
-							&istanbul.View{
+							&bft.View{
 								Round:    big.NewInt(0),
 								Sequence: big.NewInt(0),
 							},
@@ -141,7 +141,7 @@ OUTER:
 
 		curView := r0.currentView()
 
-		preprepare := &istanbul.Preprepare{
+		preprepare := &bft.Preprepare{
 			View:     curView,
 			Proposal: test.expectedRequest,
 		}
@@ -191,7 +191,7 @@ OUTER:
 				t.Errorf("message code mismatch: have %v, want %v", decodedMsg.Code, expectedCode)
 			}
 
-			var subject *istanbul.Subject
+			var subject *bft.Subject
 			err = decodedMsg.Decode(&subject)
 			if err != nil {
 				t.Errorf("error mismatch: have %v, want nil", err)
@@ -225,8 +225,8 @@ func TestHandlePreprepareWithLock(t *testing.T) {
 
 	testCases := []struct {
 		system       *testSystem
-		proposal     istanbul.Proposal
-		lockProposal istanbul.Proposal
+		proposal     bft.Proposal
+		lockProposal bft.Proposal
 	}{
 		{
 			newSystem(),
@@ -245,11 +245,11 @@ func TestHandlePreprepareWithLock(t *testing.T) {
 		v0 := test.system.backends[0]
 		r0 := v0.engine.(*core)
 		curView := r0.currentView()
-		preprepare := &istanbul.Preprepare{
+		preprepare := &bft.Preprepare{
 			View:     curView,
 			Proposal: test.proposal,
 		}
-		lockPreprepare := &istanbul.Preprepare{
+		lockPreprepare := &bft.Preprepare{
 			View:     curView,
 			Proposal: test.lockProposal,
 		}
@@ -285,7 +285,7 @@ func TestHandlePreprepareWithLock(t *testing.T) {
 					t.Errorf("state mismatch: have %v, want %v", c.state, StateAcceptRequest)
 				}
 				// Should have triggered a round change
-				expectedView := &istanbul.View{
+				expectedView := &bft.View{
 					Sequence: curView.Sequence,
 					Round:    big.NewInt(1),
 				}
@@ -0,0 +1,336 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/cmn"
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// errNoJournalStart is returned when a journal doesn't begin with a start entry.
+var errNoJournalStart = errors.New("journal has no start entry")
+
+// Replay feeds a consensus journal back into a fresh core. The recorded inputs
+// are handled one by one in journal order, the chain answers are served back
+// as they were recorded and timers never reach the core, so the replay is
+// deterministic. Every step of the replayed core is journaled to out, to be
+// compared with the original journal. A journal appended over several runs is
+// replayed as one core per start entry.
+func Replay(entries []*journal.Entry, config *bft.Config, out *journal.Journal) error {
+	if len(entries) == 0 || entries[0].Kind != journal.KindStart {
+		return errNoJournalStart
+	}
+	backend := newReplayBackend(entries)
+
+	var c *core
+	for i, e := range entries {
+		if e.Kind == journal.KindStart {
+			if c != nil {
+				c.stopTimer()
+			}
+			backend.address = e.Address
+			c = New(backend, config).(*core)
+			c.journal = out
+			c.journal.Start(c.address, decodeMessage)
+			c.startNewRound(common.Big0)
+			continue
+		}
+		ev, err := c.replayEvent(e)
+		if err != nil {
+			c.stopTimer()
+			return fmt.Errorf("journal entry %d: %v", i, err)
+		}
+		if ev != nil {
+			c.handleEvent(ev)
+		}
+	}
+	c.stopTimer()
+	return nil
+}
+
+// replayEvent rebuilds the core event of a journaled input, nil for entries
+// that aren't inputs
+func (c *core) replayEvent(e *journal.Entry) (interface{}, error) {
+	switch e.Kind {
+	case journal.KindReceived:
+		return bft.MessageEvent{Payload: e.Payload, Peer: e.Peer}, nil
+	case journal.KindBacklog:
+		msg := new(message)
+		if err := rlp.DecodeBytes(e.Payload, msg); err != nil {
+			return nil, err
+		}
+		_, src := c.valSet.GetByAddress(msg.Address)
+		if src == nil {
+			src = replayValidator(msg.Address)
+		}
+		return backlogEvent{src: src, msg: msg}, nil
+	case journal.KindRequest:
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(e.Payload, block); err != nil {
+			return nil, err
+		}
+		return bft.RequestEvent{Proposal: block}, nil
+	case journal.KindTimeout:
+		return timeoutEvent{}, nil
+	case journal.KindCommitted:
+		return bft.FinalCommittedEvent{}, nil
+	}
+	return nil, nil
+}
+
+// replayBackend serves the chain answers of a journal. Timers and internal
+// events are posted to a mux nobody listens to, the journal holds the events
+// the original core acted upon.
+type replayBackend struct {
+	address    common.Address
+	events     *cmn.TypeMux
+	heads      []*journal.Entry
+	proposers  []*journal.Entry
+	verifies   []*journal.Entry
+	validators map[uint64]*journal.Entry
+
+	head *journal.Entry
+}
+
+func newReplayBackend(entries []*journal.Entry) *replayBackend {
+	b := &replayBackend{
+		events:     new(cmn.TypeMux),
+		validators: make(map[uint64]*journal.Entry),
+	}
+	for _, e := range entries {
+		switch e.Kind {
+		case journal.KindHead:
+			b.heads = append(b.heads, e)
+		case journal.KindProposer:
+			b.proposers = append(b.proposers, e)
+		case journal.KindVerify:
+			b.verifies = append(b.verifies, e)
+		case journal.KindValidators:
+			if _, ok := b.validators[e.Height]; !ok {
+				b.validators[e.Height] = e
+			}
+		}
+	}
+	return b
+}
+
+func (b *replayBackend) Address() common.Address {
+	return b.address
+}
+
+func (b *replayBackend) EventMux() *cmn.TypeMux {
+	return b.events
+}
+
+func (b *replayBackend) Validators(number uint64) bft.ValidatorSet {
+	set := &replaySet{backend: b}
+	if e, ok := b.validators[number]; ok {
+		for _, addr := range e.Validators {
+			set.validators = append(set.validators, replayValidator(addr))
+		}
+		set.quorum, set.f = e.Quorum, e.Faulty
+	}
+	return set
+}
+
+func (b *replayBackend) Broadcast(valSet bft.ValidatorSet, payload []byte) error {
+	return nil
+}
+
+func (b *replayBackend) Gossip(valSet bft.ValidatorSet, payload []byte) error {
+	return nil
+}
+
+func (b *replayBackend) Commit(proposal bft.Proposal, seals [][]byte) error {
+	return nil
+}
+
+// Verify returns the next recorded verification outcome
+func (b *replayBackend) Verify(proposal bft.Proposal) (time.Duration, error) {
+	if len(b.verifies) == 0 {
+		return 0, nil
+	}
+	e := b.verifies[0]
+	b.verifies = b.verifies[1:]
+	switch e.Error {
+	case "":
+		return 0, nil
+	case consensus.ErrFutureBlock.Error():
+		return 0, consensus.ErrFutureBlock
+	}
+	return 0, errors.New(e.Error)
+}
+
+// Sign returns an empty signature, the replayed messages are never delivered
+func (b *replayBackend) Sign(data []byte) ([]byte, error) {
+	return make([]byte, types.BFTExtraSeal), nil
+}
+
+func (b *replayBackend) CheckSignature(data []byte, addr common.Address, sig []byte) error {
+	return nil
+}
+
+// LastProposal returns the next recorded head, or the last one once the
+// journal runs out of heads
+func (b *replayBackend) LastProposal() (bft.Proposal, common.Address) {
+	if len(b.heads) > 0 {
+		b.head = b.heads[0]
+		b.heads = b.heads[1:]
+	}
+	if b.head == nil {
+		return types.NewBlockWithHeader(&types.Header{Number: new(big.Int)}), common.Address{}
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(b.head.Payload, block); err != nil {
+		return types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(b.head.Height)}), b.head.Address
+	}
+	return block, b.head.Address
+}
+
+func (b *replayBackend) HasPropsal(hash common.Hash, number *big.Int) bool {
+	return false
+}
+
+func (b *replayBackend) GetProposer(number uint64) common.Address {
+	return common.Address{}
+}
+
+func (b *replayBackend) HasBadProposal(hash common.Hash) bool {
+	return false
+}
+
+func (b *replayBackend) Close() error {
+	return nil
+}
+
+func (b *replayBackend) SetProposedBlockHash(hash common.Hash) {}
+
+// nextProposer returns the next recorded proposer
+func (b *replayBackend) nextProposer() (common.Address, bool) {
+	if len(b.proposers) == 0 {
+		return common.Address{}, false
+	}
+	e := b.proposers[0]
+	b.proposers = b.proposers[1:]
+	return e.Address, true
+}
+
+type replayValidator common.Address
+
+func (v replayValidator) Address() common.Address {
+	return common.Address(v)
+}
+
+func (v replayValidator) String() string {
+	return v.Address().String()
+}
+
+// replaySet is a validator set rebuilt from the journal. Each engine picks its
+// proposer with its own policy, so the set takes the recorded proposers instead.
+type replaySet struct {
+	backend    *replayBackend // nil for copies, which keep their proposer
+	validators []bft.Validator
+	proposer   bft.Validator
+	quorum     int
+	f          int
+}
+
+func (s *replaySet) CalcProposer(lastProposer common.Address, round uint64) {
+	if s.backend == nil {
+		return
+	}
+	if addr, ok := s.backend.nextProposer(); ok {
+		s.proposer = replayValidator(addr)
+	}
+}
+
+func (s *replaySet) Size() int {
+	return len(s.validators)
+}
+
+func (s *replaySet) List() []bft.Validator {
+	return s.validators
+}
+
+func (s *replaySet) GetByIndex(i uint64) bft.Validator {
+	if i >= uint64(len(s.validators)) {
+		return nil
+	}
+	return s.validators[i]
+}
+
+func (s *replaySet) GetByAddress(addr common.Address) (int, bft.Validator) {
+	for i, val := range s.validators {
+		if val.Address() == addr {
+			return i, val
+		}
+	}
+	return -1, nil
+}
+
+func (s *replaySet) GetProposer() bft.Validator {
+	return s.proposer
+}
+
+func (s *replaySet) IsProposer(address common.Address) bool {
+	return s.proposer != nil && s.proposer.Address() == address
+}
+
+func (s *replaySet) AddValidator(address common.Address) bool {
+	if _, val := s.GetByAddress(address); val != nil {
+		return false
+	}
+	s.validators = append(s.validators, replayValidator(address))
+	return true
+}
+
+func (s *replaySet) RemoveValidator(address common.Address) bool {
+	i, val := s.GetByAddress(address)
+	if val == nil {
+		return false
+	}
+	s.validators = append(s.validators[:i:i], s.validators[i+1:]...)
+	return true
+}
+
+func (s *replaySet) Copy() bft.ValidatorSet {
+	return &replaySet{
+		validators: append([]bft.Validator(nil), s.validators...),
+		proposer:   s.proposer,
+		quorum:     s.quorum,
+		f:          s.f,
+	}
+}
+
+func (s *replaySet) F() int {
+	return s.f
+}
+
+func (s *replaySet) Quorum() int {
+	return s.quorum
+}
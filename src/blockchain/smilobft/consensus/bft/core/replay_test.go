@@ -0,0 +1,140 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+)
+
+func signedPayload(t *testing.T, key *ecdsa.PrivateKey, code uint64, val interface{}) []byte {
+	encoded, err := Encode(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &message{Code: code, Msg: encoded, Address: crypto.PubkeyToAddress(key.PublicKey)}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := msg.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func replayEntries(t *testing.T, entries []*journal.Entry) []*journal.Entry {
+	var buf bytes.Buffer
+	if err := Replay(entries, bft.DefaultConfig, journal.New(&buf)); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	replayed, err := journal.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return replayed
+}
+
+func TestReplay(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	peer := enode.PubkeyToIDV4(&key.PublicKey)
+
+	genesis, block := makeBlock(0), makeBlock(1)
+	genesisRLP, _ := rlp.EncodeToBytes(genesis)
+	blockRLP, _ := rlp.EncodeToBytes(block)
+
+	view := &bft.View{Sequence: big.NewInt(1), Round: big.NewInt(0)}
+	subject := &bft.Subject{View: view, Digest: block.Hash()}
+
+	// The journal of a single validator committing block 1
+	entries := []*journal.Entry{
+		{Kind: journal.KindStart, Address: addr},
+		{Kind: journal.KindHead, Payload: genesisRLP},
+		{Kind: journal.KindValidators, Height: 1, Validators: []common.Address{addr}, Quorum: 1},
+		{Kind: journal.KindProposer, Height: 1, Address: addr},
+		{Kind: journal.KindRequest, Height: 1, Payload: blockRLP},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgPreprepare, &bft.Preprepare{View: view, Proposal: block})},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgPrepare, subject)},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgCommit, subject)},
+		{Kind: journal.KindCommitted},
+		{Kind: journal.KindHead, Height: 1, Payload: blockRLP},
+		{Kind: journal.KindValidators, Height: 2, Validators: []common.Address{addr}, Quorum: 1},
+		{Kind: journal.KindProposer, Height: 2, Address: addr},
+	}
+	replayed := replayEntries(t, entries)
+
+	var sent []string
+	for _, e := range replayed {
+		switch e.Kind {
+		case journal.KindSent:
+			if e.Height != 1 || e.Round != 0 || e.Address != addr {
+				t.Errorf("sent %s at %d/%d from %x, want 1/0 from %x", e.Code, e.Height, e.Round, e.Address, addr)
+			}
+			sent = append(sent, e.Code)
+		case journal.KindReceived:
+			if e.Peer != peer {
+				t.Errorf("received %s from peer %v, want %v", e.Code, e.Peer, peer)
+			}
+		case journal.KindFailed:
+			t.Errorf("replayed %s at %d/%d failed: %s", e.Code, e.Height, e.Round, e.Error)
+		}
+	}
+	if want := []string{"preprepare", "prepare", "commit"}; len(sent) != len(want) {
+		t.Fatalf("sent messages mismatch: have %v, want %v", sent, want)
+	} else {
+		for i := range want {
+			if sent[i] != want[i] {
+				t.Errorf("sent message %d mismatch: have %s, want %s", i, sent[i], want[i])
+			}
+		}
+	}
+	if last := replayed[len(replayed)-1]; last.Kind != journal.KindProposer || last.Height != 2 {
+		t.Errorf("replay did not move to the next sequence: last entry %v", last)
+	}
+
+	// Replaying the replay must take the same steps again
+	again := replayEntries(t, replayed)
+	if len(again) != len(replayed) {
+		t.Fatalf("entries mismatch: have %d, want %d", len(again), len(replayed))
+	}
+	for i := range again {
+		if again[i].String() != replayed[i].String() {
+			t.Errorf("entry %d mismatch: have %v, want %v", i, again[i], replayed[i])
+		}
+	}
+}
+
+func TestReplayWithoutStart(t *testing.T) {
+	if err := Replay([]*journal.Entry{{Kind: journal.KindTimeout}}, bft.DefaultConfig, nil); err != errNoJournalStart {
+		t.Errorf("error mismatch: have %v, want %v", err, errNoJournalStart)
+	}
+}
@@ -19,10 +19,10 @@ package core
 import (
 	"github.com/ethereum/go-ethereum/log"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
-func (c *core) handleRequest(request *istanbul.Request) error {
+func (c *core) handleRequest(request *bft.Request) error {
 	logger := c.logger.New("state", c.state, "seq", c.current.sequence)
 
 	if err := c.checkRequestMsg(request); err != nil {
@@ -47,7 +47,7 @@ func (c *core) handleRequest(request *istanbul.Request) error {
 // return errInvalidMessage if the message is invalid
 // return errFutureMessage if the sequence of proposal is larger than current sequence
 // return errOldMessage if the sequence of proposal is smaller than current sequence
-func (c *core) checkRequestMsg(request *istanbul.Request) error {
+func (c *core) checkRequestMsg(request *bft.Request) error {
 	if request == nil || request.Proposal == nil {
 		return errInvalidMessage
 	}
@@ -55,14 +55,14 @@ func (c *core) checkRequestMsg(request *istanbul.Request) error {
 	if current := c.current.sequence.Cmp(request.Proposal.Number()); current > 0 {
 		return errOldMessage
 	} else if current < 0 {
-		log.Debug("istanbul, core/request.go, checkRequestMsg, errFutureMessage", "request", request, "c.current.sequence", c.current.sequence)
+		log.Debug("bft, core/request.go, checkRequestMsg, errFutureMessage", "request", request, "c.current.sequence", c.current.sequence)
 		return errFutureMessage
 	} else {
 		return nil
 	}
 }
 
-func (c *core) storeRequestMsg(request *istanbul.Request) {
+func (c *core) storeRequestMsg(request *bft.Request) {
 	logger := c.logger.New("state", c.state)
 
 	logger.Trace("Store future request", "number", request.Proposal.Number(), "hash", request.Proposal.Hash())
@@ -79,7 +79,7 @@ func (c *core) processPendingRequests() {
 
 	for !(c.pendingRequests.Empty()) {
 		m, prio := c.pendingRequests.Pop()
-		r, ok := m.(*istanbul.Request)
+		r, ok := m.(*bft.Request)
 		if !ok {
 			c.logger.Warn("Malformed request, skip", "msg", m)
 			continue
@@ -97,7 +97,7 @@ func (c *core) processPendingRequests() {
 		}
 		c.logger.Trace("Post pending request", "number", r.Proposal.Number(), "hash", r.Proposal.Hash())
 
-		go c.sendEvent(istanbul.RequestEvent{
+		go c.sendEvent(bft.RequestEvent{
 			Proposal: r.Proposal,
 		})
 	}
@@ -29,13 +29,13 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 func TestCheckRequestMsg(t *testing.T) {
 	c := &core{
 		state: StateAcceptRequest,
-		current: newRoundState(&istanbul.View{
+		current: newRoundState(&bft.View{
 			Sequence: big.NewInt(1),
 			Round:    big.NewInt(0),
 		}, newTestValidatorSet(4), common.Hash{}, nil, nil, nil),
@@ -46,7 +46,7 @@ func TestCheckRequestMsg(t *testing.T) {
 	if err != errInvalidMessage {
 		t.Errorf("error mismatch: have %v, want %v", err, errInvalidMessage)
 	}
-	r := &istanbul.Request{
+	r := &bft.Request{
 		Proposal: nil,
 	}
 	err = c.checkRequestMsg(r)
@@ -55,7 +55,7 @@ func TestCheckRequestMsg(t *testing.T) {
 	}
 
 	// old request
-	r = &istanbul.Request{
+	r = &bft.Request{
 		Proposal: makeBlock(0),
 	}
 	err = c.checkRequestMsg(r)
@@ -64,7 +64,7 @@ func TestCheckRequestMsg(t *testing.T) {
 	}
 
 	// future request
-	r = &istanbul.Request{
+	r = &bft.Request{
 		Proposal: makeBlock(2),
 	}
 	err = c.checkRequestMsg(r)
@@ -73,7 +73,7 @@ func TestCheckRequestMsg(t *testing.T) {
 	}
 
 	// current request
-	r = &istanbul.Request{
+	r = &bft.Request{
 		Proposal: makeBlock(1),
 	}
 	err = c.checkRequestMsg(r)
@@ -90,14 +90,14 @@ func TestStoreRequestMsg(t *testing.T) {
 		logger:  log.New("backend", "test", "id", 0),
 		backend: backend,
 		state:   StateAcceptRequest,
-		current: newRoundState(&istanbul.View{
+		current: newRoundState(&bft.View{
 			Sequence: big.NewInt(0),
 			Round:    big.NewInt(0),
 		}, newTestValidatorSet(4), common.Hash{}, nil, nil, nil),
 		pendingRequests:   prque.New(),
 		pendingRequestsMu: new(sync.Mutex),
 	}
-	requests := []istanbul.Request{
+	requests := []bft.Request{
 		{
 			Proposal: makeBlock(1),
 		},
@@ -127,7 +127,7 @@ func TestStoreRequestMsg(t *testing.T) {
 	timeout := time.NewTimer(timeoutDura)
 	select {
 	case ev := <-c.events.Chan():
-		e, ok := ev.Data.(istanbul.RequestEvent)
+		e, ok := ev.Data.(bft.RequestEvent)
 		if !ok {
 			t.Errorf("unexpected event comes: %v", reflect.TypeOf(ev.Data))
 		}
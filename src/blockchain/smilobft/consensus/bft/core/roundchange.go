@@ -22,7 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 // sendNextRoundChange sends the ROUND CHANGE message with current round + 1
@@ -41,7 +41,7 @@ func (c *core) sendRoundChange(round *big.Int) {
 		return
 	}
 
-	c.catchUpRound(&istanbul.View{
+	c.catchUpRound(&bft.View{
 		// The round number we'd like to transfer to.
 		Round:    new(big.Int).Set(round),
 		Sequence: new(big.Int).Set(cv.Sequence),
@@ -49,7 +49,7 @@ func (c *core) sendRoundChange(round *big.Int) {
 
 	// Now we have the new round number and sequence number
 	cv = c.currentView()
-	rc := &istanbul.Subject{
+	rc := &bft.Subject{
 		View:   cv,
 		Digest: common.Hash{},
 	}
@@ -66,11 +66,11 @@ func (c *core) sendRoundChange(round *big.Int) {
 	})
 }
 
-func (c *core) handleRoundChange(msg *message, src istanbul.Validator) error {
+func (c *core) handleRoundChange(msg *message, src bft.Validator) error {
 	logger := c.logger.New("state", c.state, "from", src.Address().Hex())
 
 	// Decode ROUND CHANGE message
-	var rc *istanbul.Subject
+	var rc *bft.Subject
 	if err := msg.Decode(&rc); err != nil {
 		logger.Error("Failed to decode ROUND CHANGE", "err", err)
 		return errInvalidMessage
@@ -99,7 +99,7 @@ func (c *core) handleRoundChange(msg *message, src istanbul.Validator) error {
 			c.sendRoundChange(roundView.Round)
 		}
 		return nil
-	} else if num == 2*c.valSet.F()+1 && (c.waitingForRoundChange || cv.Round.Cmp(roundView.Round) < 0) {
+	} else if num == c.valSet.Quorum() && (c.waitingForRoundChange || cv.Round.Cmp(roundView.Round) < 0) {
 		// We've received 2f+1 ROUND CHANGE messages, start a new round immediately.
 		c.startNewRound(roundView.Round)
 		return nil
@@ -112,7 +112,7 @@ func (c *core) handleRoundChange(msg *message, src istanbul.Validator) error {
 
 // ----------------------------------------------------------------------------
 
-func newRoundChangeSet(valSet istanbul.ValidatorSet) *roundChangeSet {
+func newRoundChangeSet(valSet bft.ValidatorSet) *roundChangeSet {
 	return &roundChangeSet{
 		validatorSet: valSet,
 		roundChanges: make(map[uint64]*messageSet),
@@ -121,7 +121,7 @@ func newRoundChangeSet(valSet istanbul.ValidatorSet) *roundChangeSet {
 }
 
 type roundChangeSet struct {
-	validatorSet istanbul.ValidatorSet
+	validatorSet bft.ValidatorSet
 	roundChanges map[uint64]*messageSet
 	mu           *sync.Mutex
 }
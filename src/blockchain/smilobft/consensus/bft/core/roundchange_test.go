@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 )
@@ -30,11 +31,11 @@ func TestRoundChangeSet(t *testing.T) {
 	vset := validator.NewSet(generateValidators(4), istanbul.RoundRobin)
 	rc := newRoundChangeSet(vset)
 
-	view := &istanbul.View{
+	view := &bft.View{
 		Sequence: big.NewInt(1),
 		Round:    big.NewInt(1),
 	}
-	r := &istanbul.Subject{
+	r := &bft.Subject{
 		View:   view,
 		Digest: common.Hash{},
 	}
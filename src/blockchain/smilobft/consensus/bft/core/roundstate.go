@@ -24,13 +24,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 // newRoundState creates a new roundState instance with the given view and validatorSet
 // lockedHash and preprepare are for round change when lock exists,
 // we need to keep a reference of preprepare in order to propose locked proposal when there is a lock and itself is the proposer
-func newRoundState(view *istanbul.View, validatorSet istanbul.ValidatorSet, lockedHash common.Hash, preprepare *istanbul.Preprepare, pendingRequest *istanbul.Request, hasBadProposal func(hash common.Hash) bool) *roundState {
+func newRoundState(view *bft.View, validatorSet bft.ValidatorSet, lockedHash common.Hash, preprepare *bft.Preprepare, pendingRequest *bft.Request, hasBadProposal func(hash common.Hash) bool) *roundState {
 	return &roundState{
 		round:          view.Round,
 		sequence:       view.Sequence,
@@ -48,11 +48,11 @@ func newRoundState(view *istanbul.View, validatorSet istanbul.ValidatorSet, lock
 type roundState struct {
 	round          *big.Int
 	sequence       *big.Int
-	Preprepare     *istanbul.Preprepare
+	Preprepare     *bft.Preprepare
 	Prepares       *messageSet
 	Commits        *messageSet
 	lockedHash     common.Hash
-	pendingRequest *istanbul.Request
+	pendingRequest *bft.Request
 
 	mu             *sync.RWMutex
 	hasBadProposal func(hash common.Hash) bool
@@ -73,7 +73,7 @@ func (s *roundState) GetPrepareOrCommitSize() int {
 	return result
 }
 
-func (s *roundState) Subject() *istanbul.Subject {
+func (s *roundState) Subject() *bft.Subject {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -81,8 +81,8 @@ func (s *roundState) Subject() *istanbul.Subject {
 		return nil
 	}
 
-	return &istanbul.Subject{
-		View: &istanbul.View{
+	return &bft.Subject{
+		View: &bft.View{
 			Round:    new(big.Int).Set(s.round),
 			Sequence: new(big.Int).Set(s.sequence),
 		},
@@ -90,14 +90,14 @@ func (s *roundState) Subject() *istanbul.Subject {
 	}
 }
 
-func (s *roundState) SetPreprepare(preprepare *istanbul.Preprepare) {
+func (s *roundState) SetPreprepare(preprepare *bft.Preprepare) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.Preprepare = preprepare
 }
 
-func (s *roundState) Proposal() istanbul.Proposal {
+func (s *roundState) Proposal() bft.Proposal {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -176,11 +176,11 @@ func (s *roundState) DecodeRLP(stream *rlp.Stream) error {
 	var ss struct {
 		Round          *big.Int
 		Sequence       *big.Int
-		Preprepare     *istanbul.Preprepare
+		Preprepare     *bft.Preprepare
 		Prepares       *messageSet
 		Commits        *messageSet
 		lockedHash     common.Hash
-		pendingRequest *istanbul.Request
+		pendingRequest *bft.Request
 	}
 
 	if err := stream.Decode(&ss); err != nil {
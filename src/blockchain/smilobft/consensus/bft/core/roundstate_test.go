@@ -23,10 +23,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
-func newTestRoundState(view *istanbul.View, validatorSet istanbul.ValidatorSet) *roundState {
+func newTestRoundState(view *bft.View, validatorSet bft.ValidatorSet) *roundState {
 	return &roundState{
 		round:      view.Round,
 		sequence:   view.Sequence,
@@ -43,7 +43,7 @@ func newTestRoundState(view *istanbul.View, validatorSet istanbul.ValidatorSet)
 func TestLockHash(t *testing.T) {
 	sys := NewTestSystemWithBackend(1, 0)
 	rs := newTestRoundState(
-		&istanbul.View{
+		&bft.View{
 			Round:    big.NewInt(0),
 			Sequence: big.NewInt(0),
 		},
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	elog "github.com/ethereum/go-ethereum/log"
 
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 	"go-smilo/src/blockchain/smilobft/ethdb"
@@ -41,7 +42,7 @@ type testSystemBackend struct {
 	sys *testSystem
 
 	engine Engine
-	peers  istanbul.ValidatorSet
+	peers  bft.ValidatorSet
 	events *cmn.TypeMux
 
 	committedMsgs []testCommittedMsgs
@@ -53,20 +54,20 @@ type testSystemBackend struct {
 }
 
 type testCommittedMsgs struct {
-	commitProposal istanbul.Proposal
+	commitProposal bft.Proposal
 	committedSeals [][]byte
 }
 
 // ==============================================
 //
-// define the functions that needs to be provided for Istanbul.
+// define the functions that needs to be provided for the BFT core.
 
 func (b *testSystemBackend) Address() common.Address {
 	return b.address
 }
 
 // Peers returns all connected peers
-func (b *testSystemBackend) Validators(number uint64) istanbul.ValidatorSet {
+func (b *testSystemBackend) Validators(number uint64) bft.ValidatorSet {
 	return b.peers
 }
 
@@ -97,27 +98,27 @@ func (b *testSystemBackend) AddCommittedMsg(msg testCommittedMsgs) []testCommitt
 func (b *testSystemBackend) Send(message []byte, target common.Address) error {
 	testLogger.Info("enqueuing a message...", "address", b.Address())
 	b.sentMsgs = append(b.sentMsgs, message)
-	b.sys.queuedMessage <- istanbul.MessageEvent{
+	b.sys.queuedMessage <- bft.MessageEvent{
 		Payload: message,
 	}
 	return nil
 }
 
-func (b *testSystemBackend) Broadcast(valSet istanbul.ValidatorSet, message []byte) error {
+func (b *testSystemBackend) Broadcast(valSet bft.ValidatorSet, message []byte) error {
 	testLogger.Info("enqueuing a message...", "address", b.Address())
 	b.sentMsgs = append(b.sentMsgs, message)
-	b.sys.queuedMessage <- istanbul.MessageEvent{
+	b.sys.queuedMessage <- bft.MessageEvent{
 		Payload: message,
 	}
 	return nil
 }
 
-func (b *testSystemBackend) Gossip(valSet istanbul.ValidatorSet, message []byte) error {
+func (b *testSystemBackend) Gossip(valSet bft.ValidatorSet, message []byte) error {
 	testLogger.Warn("not sign any data")
 	return nil
 }
 
-func (b *testSystemBackend) Commit(proposal istanbul.Proposal, seals [][]byte) error {
+func (b *testSystemBackend) Commit(proposal bft.Proposal, seals [][]byte) error {
 	testLogger.Info("commit message", "address", b.Address())
 	b.AddCommittedMsg(testCommittedMsgs{
 		commitProposal: proposal,
@@ -126,12 +127,12 @@ func (b *testSystemBackend) Commit(proposal istanbul.Proposal, seals [][]byte) e
 
 	// fake new head events
 	go func() {
-		_ = b.events.Post(istanbul.FinalCommittedEvent{})
+		_ = b.events.Post(bft.FinalCommittedEvent{})
 	}()
 	return nil
 }
 
-func (b *testSystemBackend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
+func (b *testSystemBackend) Verify(proposal bft.Proposal) (time.Duration, error) {
 	return 0, nil
 }
 
@@ -152,9 +153,9 @@ func (b *testSystemBackend) Hash(_ interface{}) common.Hash {
 	return common.BytesToHash([]byte("Test"))
 }
 
-func (b *testSystemBackend) NewRequest(request istanbul.Proposal) {
+func (b *testSystemBackend) NewRequest(request bft.Proposal) {
 	go func() {
-		_ = b.events.Post(istanbul.RequestEvent{
+		_ = b.events.Post(bft.RequestEvent{
 			Proposal: request,
 		})
 	}()
@@ -164,7 +165,7 @@ func (b *testSystemBackend) HasBadProposal(hash common.Hash) bool {
 	return false
 }
 
-func (b *testSystemBackend) LastProposal() (istanbul.Proposal, common.Address) {
+func (b *testSystemBackend) LastProposal() (bft.Proposal, common.Address) {
 	l := b.LenCommittedMsgs()
 	if l > 0 {
 		return b.GetCommittedMsg(l - 1).commitProposal, common.Address{}
@@ -181,7 +182,7 @@ func (b *testSystemBackend) GetProposer(number uint64) common.Address {
 	return common.Address{}
 }
 
-func (b *testSystemBackend) ParentValidators(proposal istanbul.Proposal) istanbul.ValidatorSet {
+func (b *testSystemBackend) ParentValidators(proposal bft.Proposal) bft.ValidatorSet {
 	return b.peers
 }
 
@@ -200,7 +201,7 @@ func (b *testSystemBackend) Close() error {
 type testSystem struct {
 	backends []*testSystemBackend
 
-	queuedMessage chan istanbul.MessageEvent
+	queuedMessage chan bft.MessageEvent
 	quit          chan struct{}
 }
 
@@ -209,7 +210,7 @@ func newTestSystem(n uint64) *testSystem {
 	return &testSystem{
 		backends: make([]*testSystemBackend, n),
 
-		queuedMessage: make(chan istanbul.MessageEvent),
+		queuedMessage: make(chan bft.MessageEvent),
 		quit:          make(chan struct{}),
 	}
 }
@@ -223,7 +224,7 @@ func generateValidators(n int) []common.Address {
 	return vals
 }
 
-func newTestValidatorSet(n int) istanbul.ValidatorSet {
+func newTestValidatorSet(n int) bft.ValidatorSet {
 	return validator.NewSet(generateValidators(n), istanbul.RoundRobin)
 }
 
@@ -233,7 +234,7 @@ func NewTestSystemWithBackend(n, f uint64) *testSystem {
 
 	addrs := generateValidators(int(n))
 	sys := newTestSystem(n)
-	config := istanbul.DefaultConfig
+	config := bft.DefaultConfig
 
 	for i := uint64(0); i < n; i++ {
 		vset := validator.NewSet(addrs, istanbul.RoundRobin)
@@ -243,13 +244,14 @@ func NewTestSystemWithBackend(n, f uint64) *testSystem {
 
 		core := New(backend, config).(*core)
 		core.state = StateAcceptRequest
-		core.current = newRoundState(&istanbul.View{
+		core.current = newRoundState(&bft.View{
 			Round:    big.NewInt(0),
 			Sequence: big.NewInt(1),
 		}, vset, common.Hash{}, nil, nil, func(hash common.Hash) bool {
 			return false
 		})
 		core.valSet = vset
+		core.trackRound()
 		core.logger = testLogger
 		core.validateFn = backend.CheckValidatorSignature
 
@@ -281,7 +283,7 @@ func (t *testSystem) listen() {
 func (t *testSystem) Run(core bool) func() {
 	for _, b := range t.backends {
 		if core {
-			b.engine.Start() // start Istanbul core
+			b.engine.Start() // start BFT core
 		}
 	}
 
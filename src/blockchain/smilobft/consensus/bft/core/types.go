@@ -24,6 +24,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/health"
 )
 
 type Engine interface {
@@ -39,6 +41,9 @@ type Engine interface {
 	// pending request is populated right at the preprepare stage so this would give us the earliest verification
 	// to avoid any race condition of coming propagated blocks
 	IsCurrentProposal(blockHash common.Hash) bool
+
+	// Status returns the current round state and the validators not heard from lately
+	Status() *health.Status
 }
 
 type State uint64
@@ -128,7 +133,7 @@ func (m *message) FromPayload(b []byte, validateFn func([]byte, []byte) (common.
 	// Decode message
 	err := rlp.DecodeBytes(b, &m)
 	if err != nil {
-		log.Error("Istanbul, core/types.go, FromPayload, DecodeBytes, ", "err", err, "message", m)
+		log.Error("BFT, core/types.go, FromPayload, DecodeBytes, ", "err", err, "message", m)
 		return err
 	}
 
@@ -137,13 +142,13 @@ func (m *message) FromPayload(b []byte, validateFn func([]byte, []byte) (common.
 		var payload []byte
 		payload, err = m.PayloadNoSig()
 		if err != nil {
-			log.Error("Istanbul, core/types.go, FromPayload, PayloadNoSig, ", "err", err, "message", m)
+			log.Error("BFT, core/types.go, FromPayload, PayloadNoSig, ", "err", err, "message", m)
 			return err
 		}
 
 		_, err = validateFn(payload, m.Signature)
 		if err != nil {
-			log.Error("Istanbul, core/types.go, FromPayload, validateFn", "err", err, "message", m)
+			log.Error("BFT, core/types.go, FromPayload, validateFn", "err", err, "message", m)
 		}
 	}
 	// Still return the message even the err is not nil
@@ -24,12 +24,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
 func testPreprepare(t *testing.T) {
-	pp := &istanbul.Preprepare{
-		View: &istanbul.View{
+	pp := &bft.Preprepare{
+		View: &bft.View{
 			Round:    big.NewInt(1),
 			Sequence: big.NewInt(2),
 		},
@@ -54,13 +54,13 @@ func testPreprepare(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want nil", err)
 	}
 
-	var decodedPP *istanbul.Preprepare
+	var decodedPP *bft.Preprepare
 	err = decodedMsg.Decode(&decodedPP)
 	if err != nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
 	}
 
-	// if block is encoded/decoded by rlp, we cannot to compare interface data type using reflect.DeepEqual. (like istanbul.Proposal)
+	// if block is encoded/decoded by rlp, we cannot to compare interface data type using reflect.DeepEqual. (like bft.Proposal)
 	// so individual comparison here.
 	if !reflect.DeepEqual(pp.Proposal.Hash(), decodedPP.Proposal.Hash()) {
 		t.Errorf("proposal hash mismatch: have %v, want %v", decodedPP.Proposal.Hash(), pp.Proposal.Hash())
@@ -76,8 +76,8 @@ func testPreprepare(t *testing.T) {
 }
 
 func testSubject(t *testing.T) {
-	s := &istanbul.Subject{
-		View: &istanbul.View{
+	s := &bft.Subject{
+		View: &bft.View{
 			Round:    big.NewInt(1),
 			Sequence: big.NewInt(2),
 		},
@@ -103,7 +103,7 @@ func testSubject(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want nil", err)
 	}
 
-	var decodedSub *istanbul.Subject
+	var decodedSub *bft.Subject
 	err = decodedMsg.Decode(&decodedSub)
 	if err != nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
@@ -115,8 +115,8 @@ func testSubject(t *testing.T) {
 }
 
 func testSubjectWithSignature(t *testing.T) {
-	s := &istanbul.Subject{
-		View: &istanbul.View{
+	s := &bft.Subject{
+		View: &bft.View{
 			Round:    big.NewInt(1),
 			Sequence: big.NewInt(2),
 		},
@@ -167,10 +167,10 @@ func testSubjectWithSignature(t *testing.T) {
 	// 2.3 Test failed validate func
 	decodedMsg = new(message)
 	err = decodedMsg.FromPayload(msgPayload, func(data []byte, sig []byte) (common.Address, error) {
-		return common.Address{}, istanbul.ErrUnauthorizedAddress
+		return common.Address{}, bft.ErrUnauthorizedAddress
 	})
-	if err != istanbul.ErrUnauthorizedAddress {
-		t.Errorf("error mismatch: have %v, want %v", err, istanbul.ErrUnauthorizedAddress)
+	if err != bft.ErrUnauthorizedAddress {
+		t.Errorf("error mismatch: have %v, want %v", err, bft.ErrUnauthorizedAddress)
 	}
 }
 
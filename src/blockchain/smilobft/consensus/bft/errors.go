@@ -1,5 +1,5 @@
 // Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
+// Copyright 2016 The go-ethereum Authors
 // This file is part of the go-ethereum library.
 //
 // The go-ethereum library is free software: you can redistribute it and/or modify
@@ -15,8 +15,12 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package sportdao
+package bft
 
-type Request struct {
-	BlockProposal BlockProposal
-}
+import "errors"
+
+var (
+	// ErrUnauthorizedAddress is returned when given address cannot be found in
+	// current validator set.
+	ErrUnauthorizedAddress = errors.New("unauthorized address")
+)
@@ -14,16 +14,19 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package istanbul
+package bft
+
+import "go-smilo/src/blockchain/smilobft/p2p/enode"
 
 // RequestEvent is posted to propose a proposal
 type RequestEvent struct {
 	Proposal Proposal
 }
 
-// MessageEvent is posted for Istanbul engine communication
+// MessageEvent is posted for BFT engine communication
 type MessageEvent struct {
 	Payload []byte
+	Peer    enode.ID // p2p id of the peer the message was received from
 }
 
 // FinalCommittedEvent is posted when a proposal is committed
@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package istanbul
+package bft
 
 import (
 	"strings"
@@ -71,10 +71,6 @@ type ValidatorSet interface {
 	Copy() ValidatorSet
 	// Get the maximum number of faulty nodes
 	F() int
-	// Get proposer policy
-	Policy() ProposerPolicy
+	// Get the number of votes needed to prepare, commit or change round
+	Quorum() int
 }
-
-// ----------------------------------------------------------------------------
-
-type ProposalSelector func(ValidatorSet, common.Address, uint64) Validator
@@ -27,6 +27,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/rpc"
 
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 
 	"go-smilo/src/blockchain/smilobft/core/types"
 
@@ -125,8 +126,8 @@ type Handler interface {
 	// NewChainHead handles a new head block comes
 	NewChainHead() error
 
-	// HandleMsg handles a message from peer
-	HandleMsg(address common.Address, data p2p.Msg) (bool, error)
+	// HandleMsg handles a message from the peer with the given address and p2p id
+	HandleMsg(address common.Address, data p2p.Msg, peer enode.ID) (bool, error)
 
 	// SetBroadcaster sets the broadcaster to send message to peers
 	SetBroadcaster(Broadcaster)
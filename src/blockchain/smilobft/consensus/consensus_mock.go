@@ -5,6 +5,7 @@ import (
 	state "go-smilo/src/blockchain/smilobft/core/state"
 	types "go-smilo/src/blockchain/smilobft/core/types"
 	p2p "go-smilo/src/blockchain/smilobft/p2p"
+	enode "go-smilo/src/blockchain/smilobft/p2p/enode"
 	params "go-smilo/src/blockchain/smilobft/params"
 	rpc "go-smilo/src/blockchain/smilobft/rpc"
 	big "math/big"
@@ -384,18 +385,18 @@ func (mr *MockHandlerMockRecorder) NewChainHead() *gomock.Call {
 }
 
 // HandleMsg mocks base method
-func (m *MockHandler) HandleMsg(address common.Address, data p2p.Msg) (bool, error) {
+func (m *MockHandler) HandleMsg(address common.Address, data p2p.Msg, peer enode.ID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleMsg", address, data)
+	ret := m.ctrl.Call(m, "HandleMsg", address, data, peer)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleMsg indicates an expected call of HandleMsg
-func (mr *MockHandlerMockRecorder) HandleMsg(address, data interface{}) *gomock.Call {
+func (mr *MockHandlerMockRecorder) HandleMsg(address, data, peer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMsg", reflect.TypeOf((*MockHandler)(nil).HandleMsg), address, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMsg", reflect.TypeOf((*MockHandler)(nil).HandleMsg), address, data, peer)
 }
 
 // SetBroadcaster mocks base method
@@ -0,0 +1,252 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package health tracks the progress of a BFT consensus core, it feeds the
+// per-engine metrics and answers the status RPC of every BFT engine.
+package health
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Status is a snapshot of the round state of a consensus core
+type Status struct {
+	Engine          string            `json:"engine"`
+	Height          uint64            `json:"height"`
+	Round           uint64            `json:"round"`
+	Step            string            `json:"step"`
+	StepStarted     time.Time         `json:"stepStarted"`
+	Proposer        common.Address    `json:"proposer"`
+	IsProposer      bool              `json:"isProposer"`
+	Quorum          int               `json:"quorum"`
+	Validators      []ValidatorStatus `json:"validators"`
+	Silent          []common.Address  `json:"silent"` // validators not heard from in this height or the previous one
+	RoundChanges    uint64            `json:"roundChanges"`
+	MissedProposals uint64            `json:"missedProposals"`
+	Backlog         int               `json:"backlog"`
+}
+
+// ValidatorStatus is what the core knows of the participation of a validator
+type ValidatorStatus struct {
+	Address       common.Address `json:"address"`
+	LastHeight    uint64         `json:"lastHeight"` // last height a message of the validator was received at
+	LastSeen      time.Time      `json:"lastSeen"`
+	MissedHeights uint64         `json:"missedHeights"` // heights the validator was a member of and stayed silent
+}
+
+// Tracker records the progress of a consensus core. The metrics are registered
+// under consensus/<engine>. A nil tracker discards every update, so cores built
+// without one don't have to check.
+type Tracker struct {
+	mu     sync.Mutex
+	engine string
+	self   common.Address
+
+	height      uint64
+	round       uint64
+	step        string
+	stepStarted time.Time
+	proposer    common.Address
+	quorum      int
+	validators  []common.Address
+	seen        map[common.Address]*ValidatorStatus
+
+	roundChanges    uint64
+	missedProposals uint64
+	backlog         int
+
+	heightGauge           metrics.Gauge
+	roundGauge            metrics.Gauge
+	roundChangeMeter      metrics.Meter
+	missedProposalCounter metrics.Counter
+	backlogGauge          metrics.Gauge
+	stepTimers            map[string]metrics.Timer
+}
+
+// NewTracker creates a tracker for the core of the given engine run by self
+func NewTracker(engine string, self common.Address) *Tracker {
+	prefix := "consensus/" + engine + "/"
+	return &Tracker{
+		engine:                engine,
+		self:                  self,
+		seen:                  make(map[common.Address]*ValidatorStatus),
+		heightGauge:           metrics.GetOrRegisterGauge(prefix+"height", nil),
+		roundGauge:            metrics.GetOrRegisterGauge(prefix+"round", nil),
+		roundChangeMeter:      metrics.GetOrRegisterMeter(prefix+"round/change", nil),
+		missedProposalCounter: metrics.GetOrRegisterCounter(prefix+"proposal/missed", nil),
+		backlogGauge:          metrics.GetOrRegisterGauge(prefix+"backlog", nil),
+		stepTimers:            make(map[string]metrics.Timer),
+	}
+}
+
+// NewRound records the start of a round. The validators that stayed silent
+// during the height being left are counted as having missed it.
+func (t *Tracker) NewRound(height, round uint64, proposer common.Address, validators []common.Address, quorum int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if height != t.height {
+		if t.height != 0 {
+			for _, addr := range t.validators {
+				if v := t.validator(addr); v.LastHeight < t.height {
+					v.MissedHeights++
+				}
+			}
+		}
+	} else if round > t.round {
+		t.roundChanges += round - t.round
+		t.roundChangeMeter.Mark(int64(round - t.round))
+	}
+	t.height, t.round = height, round
+	t.proposer, t.validators, t.quorum = proposer, validators, quorum
+
+	t.heightGauge.Update(int64(height))
+	t.roundGauge.Update(int64(round))
+}
+
+// Step records the step the core moved to, the time spent in the previous
+// step is added to its consensus/<engine>/step/<name> timer
+func (t *Tracker) Step(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if name == t.step {
+		return
+	}
+	now := time.Now()
+	if t.step != "" {
+		t.stepTimer(t.step).Update(now.Sub(t.stepStarted))
+	}
+	t.step, t.stepStarted = name, now
+}
+
+// Heard records a message of a validator. The first message of a validator in
+// a height marks its consensus/<engine>/participation/<address> meter.
+func (t *Tracker) Heard(addr common.Address) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v := t.validator(addr)
+	if v.LastHeight != t.height {
+		v.LastHeight = t.height
+		metrics.GetOrRegisterMeter("consensus/"+t.engine+"/participation/"+addr.Hex(), nil).Mark(1)
+	}
+	v.LastSeen = time.Now()
+}
+
+// MissedProposal records a round that timed out without a proposal
+func (t *Tracker) MissedProposal() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.missedProposals++
+	t.missedProposalCounter.Inc(1)
+}
+
+// Backlog records the number of future messages waiting in the backlog
+func (t *Tracker) Backlog(size int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.backlog = size
+	t.backlogGauge.Update(int64(size))
+}
+
+// Status returns a snapshot of the tracked state
+func (t *Tracker) Status() *Status {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := &Status{
+		Engine:          t.engine,
+		Height:          t.height,
+		Round:           t.round,
+		Step:            t.step,
+		StepStarted:     t.stepStarted,
+		Proposer:        t.proposer,
+		IsProposer:      t.proposer == t.self,
+		Quorum:          t.quorum,
+		Validators:      make([]ValidatorStatus, 0, len(t.validators)),
+		Silent:          []common.Address{},
+		RoundChanges:    t.roundChanges,
+		MissedProposals: t.missedProposals,
+		Backlog:         t.backlog,
+	}
+	for _, addr := range t.validators {
+		v := t.validator(addr)
+		status.Validators = append(status.Validators, *v)
+		if addr != t.self && v.LastHeight+1 < t.height {
+			status.Silent = append(status.Silent, addr)
+		}
+	}
+	return status
+}
+
+func (t *Tracker) validator(addr common.Address) *ValidatorStatus {
+	v, ok := t.seen[addr]
+	if !ok {
+		v = &ValidatorStatus{Address: addr}
+		t.seen[addr] = v
+	}
+	return v
+}
+
+func (t *Tracker) stepTimer(name string) metrics.Timer {
+	timer, ok := t.stepTimers[name]
+	if !ok {
+		timer = metrics.GetOrRegisterTimer("consensus/"+t.engine+"/step/"+name, nil)
+		t.stepTimers[name] = timer
+	}
+	return timer
+}
+
+// API exposes the status of a consensus core over RPC, each engine registers
+// it in its own namespace, e.g. sport_status or tendermint_status
+type API struct {
+	status func() *Status
+}
+
+// NewAPI creates the status API of a core
+func NewAPI(status func() *Status) *API {
+	return &API{status: status}
+}
+
+// Status returns the live round state and the validators not heard from lately
+func (api *API) Status() *Status {
+	return api.status()
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTracker(t *testing.T) {
+	var (
+		self  = common.HexToAddress("0x01")
+		peer  = common.HexToAddress("0x02")
+		quiet = common.HexToAddress("0x03")
+		vals  = []common.Address{self, peer, quiet}
+	)
+	tracker := NewTracker("test", self)
+
+	tracker.NewRound(1, 0, self, vals, 2)
+	tracker.Step("propose")
+	tracker.Heard(self)
+	tracker.Heard(peer)
+	tracker.NewRound(1, 2, peer, vals, 2)
+	tracker.MissedProposal()
+	tracker.Backlog(5)
+
+	status := tracker.Status()
+	if status.Height != 1 || status.Round != 2 || status.RoundChanges != 2 {
+		t.Fatalf("Expected height 1 round 2 after 2 round changes, got height %d round %d after %d", status.Height, status.Round, status.RoundChanges)
+	}
+	if status.Proposer != peer || status.IsProposer {
+		t.Fatalf("Expected %v to be the proposer, got %v", peer, status.Proposer)
+	}
+	if status.Step != "propose" || status.MissedProposals != 1 || status.Backlog != 5 {
+		t.Fatalf("Expected step propose, 1 missed proposal and 5 backlogged messages, got %s, %d and %d", status.Step, status.MissedProposals, status.Backlog)
+	}
+	if len(status.Silent) != 0 {
+		t.Fatalf("Expected no silent validator in the first height, got %v", status.Silent)
+	}
+
+	// quiet stays silent for two heights, peer only for the current one
+	tracker.NewRound(2, 0, quiet, vals, 2)
+	tracker.Heard(self)
+	tracker.Heard(peer)
+	tracker.NewRound(3, 0, self, vals, 2)
+	tracker.Heard(self)
+
+	status = tracker.Status()
+	if len(status.Silent) != 1 || status.Silent[0] != quiet {
+		t.Fatalf("Expected only %v to be silent, got %v", quiet, status.Silent)
+	}
+	if !status.IsProposer {
+		t.Fatal("Expected to be the proposer")
+	}
+	for _, val := range status.Validators {
+		var missed uint64
+		if val.Address == quiet {
+			missed = 2
+		}
+		if val.MissedHeights != missed {
+			t.Fatalf("Expected %v to have missed %d heights, got %d", val.Address, missed, val.MissedHeights)
+		}
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+
+	tracker.NewRound(1, 0, common.Address{}, nil, 0)
+	tracker.Step("propose")
+	tracker.Heard(common.Address{})
+	tracker.MissedProposal()
+	tracker.Backlog(1)
+	if status := tracker.Status(); status != nil {
+		t.Fatalf("Expected no status, got %v", status)
+	}
+}
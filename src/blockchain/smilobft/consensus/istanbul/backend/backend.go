@@ -32,8 +32,9 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
-	istanbulCore "go-smilo/src/blockchain/smilobft/consensus/istanbul/core"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 	"go-smilo/src/blockchain/smilobft/core"
 	"go-smilo/src/blockchain/smilobft/core/types"
@@ -79,7 +80,12 @@ func New(config *istanbul.Config, privateKey *ecdsa.PrivateKey, db ethdb.Databas
 		knownMessages:    knownMessages,
 		vmConfig:         vmConfig,
 	}
-	backend.core = istanbulCore.New(backend, backend.config)
+	backend.core = bftCore.New(backend, &bft.Config{
+		Engine:         "istanbul",
+		RequestTimeout: config.RequestTimeout,
+		MaxTimeout:     config.MaxTimeout,
+		Journal:        config.Journal,
+	})
 	return backend
 }
 
@@ -90,7 +96,7 @@ type Backend struct {
 	istanbulEventMux *cmn.TypeMux
 	privateKey       *ecdsa.PrivateKey
 	address          common.Address
-	core             istanbulCore.Engine
+	core             bftCore.Engine
 	logger           log.Logger
 	db               ethdb.Database
 	blockchain       *core.BlockChain
@@ -299,7 +305,9 @@ func (sb *Backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 				}
 			}
 
-			validators, err = sb.blockchain.GetAutonityContract().ContractGetValidators(sb.blockchain, header, state)
+			// the contract is deployed on the first block of a consensus
+			// transition, as in Finalize
+			validators, err = sb.getValidators(header, sb.blockchain, state)
 			if err != nil {
 				return 0, err
 			}
@@ -34,8 +34,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
-	istanbulCore "go-smilo/src/blockchain/smilobft/consensus/istanbul/core"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul/validator"
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
@@ -281,7 +282,7 @@ func (sb *Backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 
 	// Check whether the committed seals are generated by parent's validators
 	validSeal := 0
-	proposalSeal := istanbulCore.PrepareCommittedSeal(header.Hash())
+	proposalSeal := bftCore.PrepareCommittedSeal(header.Hash())
 	// 1. Get committed seals from current header
 	for _, seal := range extra.CommittedSeal {
 		// 2. Get the original address by seal and parent block hash
@@ -460,7 +461,7 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainReader, state *state.StateDB) ([]common.Address, error) {
 	var validators []common.Address
 
-	if header.Number.Int64() == 1 {
+	if sb.blockchain.GetAutonityContract().NeedsDeployment(chain, header, state) {
 		log.Info("Autonity Contract Deployer", "Address", chain.Config().AutonityContractConfig.Deployer)
 
 		sb.blockchain.GetAutonityContract().SavedValidatorsRetriever = func(i uint64) (addresses []common.Address, e error) {
@@ -473,7 +474,7 @@ func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainRead
 			return nil, err
 		}
 		sb.autonityContractAddress = contractAddress
-		validators, err = sb.retrieveSavedValidators(1, chain)
+		validators, err = sb.retrieveSavedValidators(header.Number.Uint64(), chain)
 		if err != nil {
 			return nil, err
 		}
@@ -602,6 +603,11 @@ func (sb *Backend) APIs(chain consensus.ChainReader) []rpc.API {
 		Version:   "1.0",
 		Service:   &API{chain: chain, istanbul: sb},
 		Public:    true,
+	}, {
+		Namespace: "istanbul",
+		Version:   "1.0",
+		Service:   health.NewAPI(sb.core.Status),
+		Public:    true,
 	}}
 }
 
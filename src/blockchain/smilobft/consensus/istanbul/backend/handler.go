@@ -31,6 +31,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 const (
@@ -49,7 +50,7 @@ func (sb *Backend) Protocol() (protocolName string, extraMsgCodes uint64) {
 }
 
 // HandleMsg implements consensus.Handler.HandleMsg
-func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
+func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg, peer enode.ID) (bool, error) {
 	sb.coreMu.Lock()
 	defer sb.coreMu.Unlock()
 
@@ -84,6 +85,7 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 
 		go sb.istanbulEventMux.Post(istanbul.MessageEvent{
 			Payload: data,
+			Peer:    peer,
 		})
 
 		return true, nil
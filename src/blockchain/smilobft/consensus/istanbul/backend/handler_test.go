@@ -30,6 +30,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/cmn"
 	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 func TestIstanbulMessage(t *testing.T) {
@@ -56,7 +57,7 @@ func TestIstanbulMessage(t *testing.T) {
 	}
 
 	// 2. this message should be in cache after we handle it
-	_, err = backend.HandleMsg(addr, msg)
+	_, err = backend.HandleMsg(addr, msg, enode.ID{})
 	if err != nil {
 		t.Fatalf("handle message failed: %v", err)
 	}
@@ -87,7 +88,7 @@ func TestHandleNewBlockMessage_whenTypical(t *testing.T) {
 	arbitraryBlock, arbitraryP2PMessage := buildArbitraryP2PNewBlockMessage(t, false)
 	postAndWait(backend, arbitraryBlock, t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -111,7 +112,7 @@ func TestHandleNewBlockMessage_whenNotAProposedBlock(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -134,7 +135,7 @@ func TestHandleNewBlockMessage_whenFailToDecode(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
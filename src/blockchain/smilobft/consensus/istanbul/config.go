@@ -36,6 +36,7 @@ type Config struct {
 	Epoch                uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
 	CommunityAddress     string         `toml:",omitempty"` // The community address for miner donations
 	MinBlocksEmptyMining *big.Int       `toml:",omitempty"` // Min Blocks to mine before Stop Mining Empty Blocks
+	Journal              string         `toml:",omitempty"` // The file the consensus messages are journaled to, empty disables it
 
 	sync.RWMutex
 }
@@ -16,12 +16,16 @@
 
 package istanbul
 
-import "errors"
+import (
+	"errors"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+)
 
 var (
 	// ErrUnauthorizedAddress is returned when given address cannot be found in
 	// current validator set.
-	ErrUnauthorizedAddress = errors.New("unauthorized address")
+	ErrUnauthorizedAddress = bft.ErrUnauthorizedAddress
 	// ErrStoppedEngine is returned if the engine is stopped
 	ErrStoppedEngine = errors.New("stopped engine")
 	// ErrStartedEngine is returned if the engine is already started
@@ -1,4 +1,5 @@
-// Copyright 2017 The go-ethereum Authors
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
 // This file is part of the go-ethereum library.
 //
 // The go-ethereum library is free software: you can redistribute it and/or modify
@@ -17,132 +18,28 @@
 package istanbul
 
 import (
-	"fmt"
-	"io"
-	"math/big"
-
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rlp"
 
-	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
 )
 
-// Proposal supports retrieving height and serialized block to be used during Istanbul consensus.
-type Proposal interface {
-	// Number retrieves the sequence number of this proposal.
-	Number() *big.Int
-
-	// Hash retrieves the hash of this proposal.
-	Hash() common.Hash
-
-	EncodeRLP(w io.Writer) error
-
-	DecodeRLP(s *rlp.Stream) error
-
-	String() string
-}
-
-type Request struct {
-	Proposal Proposal
-}
-
-// View includes a round number and a sequence number.
-// Sequence is the block number we'd like to commit.
-// Each round has a number and is composed by 3 steps: preprepare, prepare and commit.
-//
-// If the given block is not accepted by validators, a round change will occur
-// and the validators start a new round with round+1.
-type View struct {
-	Round    *big.Int
-	Sequence *big.Int
-}
-
-// EncodeRLP serializes b into the Ethereum RLP format.
-func (v *View) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{v.Round, v.Sequence})
-}
-
-// DecodeRLP implements rlp.Decoder, and load the consensus fields from a RLP stream.
-func (v *View) DecodeRLP(s *rlp.Stream) error {
-	var view struct {
-		Round    *big.Int
-		Sequence *big.Int
-	}
-
-	if err := s.Decode(&view); err != nil {
-		return err
-	}
-	v.Round, v.Sequence = view.Round, view.Sequence
-	return nil
-}
-
-func (v *View) String() string {
-	return fmt.Sprintf("{Round: %d, Sequence: %d}", v.Round.Uint64(), v.Sequence.Uint64())
-}
-
-// Cmp compares v and y and returns:
-//   -1 if v <  y
-//    0 if v == y
-//   +1 if v >  y
-func (v *View) Cmp(y *View) int {
-	if v.Sequence.Cmp(y.Sequence) != 0 {
-		return v.Sequence.Cmp(y.Sequence)
-	}
-	if v.Round.Cmp(y.Round) != 0 {
-		return v.Round.Cmp(y.Round)
-	}
-	return 0
-}
-
-type Preprepare struct {
-	View     *View
-	Proposal Proposal
-}
-
-// EncodeRLP serializes b into the Ethereum RLP format.
-func (b *Preprepare) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{b.View, b.Proposal})
-}
-
-// DecodeRLP implements rlp.Decoder, and load the consensus fields from a RLP stream.
-func (b *Preprepare) DecodeRLP(s *rlp.Stream) error {
-	var preprepare struct {
-		View     *View
-		Proposal *types.Block
-	}
-
-	if err := s.Decode(&preprepare); err != nil {
-		return err
-	}
-	b.View, b.Proposal = preprepare.View, preprepare.Proposal
-
-	return nil
-}
-
-type Subject struct {
-	View   *View
-	Digest common.Hash
-}
-
-// EncodeRLP serializes b into the Ethereum RLP format.
-func (b *Subject) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{b.View, b.Digest})
-}
-
-// DecodeRLP implements rlp.Decoder, and load the consensus fields from a RLP stream.
-func (b *Subject) DecodeRLP(s *rlp.Stream) error {
-	var subject struct {
-		View   *View
-		Digest common.Hash
-	}
+// The Istanbul engine runs on the shared BFT core, these aliases keep the
+// names its backend and validator set are written against.
+type (
+	Backend             = bft.Backend
+	Proposal            = bft.Proposal
+	Request             = bft.Request
+	View                = bft.View
+	Preprepare          = bft.Preprepare
+	Subject             = bft.Subject
+	RequestEvent        = bft.RequestEvent
+	MessageEvent        = bft.MessageEvent
+	FinalCommittedEvent = bft.FinalCommittedEvent
+	Validator           = bft.Validator
+	Validators          = bft.Validators
+	ValidatorSet        = bft.ValidatorSet
+)
 
-	if err := s.Decode(&subject); err != nil {
-		return err
-	}
-	b.View, b.Digest = subject.View, subject.Digest
-	return nil
-}
+type ProposalSelector func(ValidatorSet, common.Address, uint64) Validator
 
-func (b *Subject) String() string {
-	return fmt.Sprintf("{View: %v, Digest: %v}", b.View, b.Digest.String())
-}
+var CheckValidatorSignature = bft.CheckValidatorSignature
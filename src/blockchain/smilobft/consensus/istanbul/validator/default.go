@@ -199,4 +199,6 @@ func (valSet *defaultSet) Copy() istanbul.ValidatorSet {
 
 func (valSet *defaultSet) F() int { return int(math.Ceil(float64(valSet.Size())/3)) - 1 }
 
+func (valSet *defaultSet) Quorum() int { return 2*valSet.F() + 1 }
+
 func (valSet *defaultSet) Policy() istanbul.ProposerPolicy { return valSet.policy }
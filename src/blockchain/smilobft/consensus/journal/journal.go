@@ -0,0 +1,273 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package journal implements an append-only log of consensus messages and of
+// the chain answers a consensus core acted upon, so that a stalled network can
+// be replayed offline. Entries are synced before the core acts on them, a
+// crashed node leaves the message it crashed on as the last input.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+)
+
+// Kinds of journal entries. The inputs of a core (received, backlog, request,
+// timeout and committed) are fed back on replay, the chain answers (head,
+// validators, proposer and verify) are served back in the order they were
+// recorded, and the sent messages are what a replay is compared against. A
+// failed entry records the error of the last input or sent message with the
+// same code and view.
+const (
+	KindStart      = "start"
+	KindSent       = "sent"
+	KindReceived   = "received"
+	KindBacklog    = "backlog"
+	KindRequest    = "request"
+	KindTimeout    = "timeout"
+	KindCommitted  = "committed"
+	KindHead       = "head"
+	KindValidators = "validators"
+	KindProposer   = "proposer"
+	KindVerify     = "verify"
+	KindFailed     = "failed"
+)
+
+// Entry is a single line of the journal
+type Entry struct {
+	Time       time.Time        `json:"time"`
+	Kind       string           `json:"kind"`
+	Code       string           `json:"code,omitempty"`    // message type, e.g. preprepare or prevote
+	Peer       enode.ID         `json:"peer"`              // p2p id of the peer a message was received from
+	Address    common.Address   `json:"address"`           // signer of the message, the proposer or the local validator
+	Height     uint64           `json:"height"`            // sequence or height of the message
+	Round      uint64           `json:"round"`             // round of the message
+	Payload    hexutil.Bytes    `json:"payload,omitempty"` // raw message or RLP encoded block
+	Validators []common.Address `json:"validators,omitempty"`
+	Quorum     int              `json:"quorum,omitempty"`
+	Faulty     int              `json:"faulty,omitempty"`
+	Error      string           `json:"error,omitempty"` // error of a failed entry or a verify answer
+}
+
+// String returns the entry without its time and payload, two entries with the
+// same string were handled the same way.
+func (e *Entry) String() string {
+	s := fmt.Sprintf("%s %s %d/%d %s", e.Kind, e.Code, e.Height, e.Round, e.Address.Hex())
+	if e.Peer != (enode.ID{}) {
+		s += " peer=" + e.Peer.TerminalString()
+	}
+	if e.Error != "" {
+		s += " err=" + e.Error
+	}
+	return s
+}
+
+// Decoder fills the code, signer and view of a message entry from its payload,
+// each consensus core decodes its own messages
+type Decoder func(e *Entry)
+
+// syncer is implemented by the writers that can flush their content to disk
+type syncer interface {
+	Sync() error
+}
+
+// Journal writes entries as JSON lines. A nil journal discards every entry, so
+// callers don't have to check whether journaling is enabled.
+type Journal struct {
+	mu     sync.Mutex
+	w      io.Writer
+	syncer syncer // syncs every entry if the writer is a file
+	closer io.Closer
+	enc    *json.Encoder
+	decode Decoder // decoder of the messages of the core writing the journal
+}
+
+// New creates a journal writing to w, entries are synced if w supports it
+func New(w io.Writer) *Journal {
+	j := &Journal{w: w, enc: json.NewEncoder(w)}
+	j.syncer, _ = w.(syncer)
+	return j
+}
+
+// Open creates a journal appending to the file at path
+func Open(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j := New(f)
+	j.closer = f
+	return j, nil
+}
+
+// OpenCore opens the journal of a consensus core at path and records the start
+// of the core. The journal is nil if path is empty or can't be opened, the
+// failure is logged as a core runs without a journal.
+func OpenCore(path string, address common.Address, decode Decoder, logger log.Logger) *Journal {
+	if path == "" {
+		return nil
+	}
+	j, err := Open(path)
+	if err != nil {
+		logger.Error("Failed to open the consensus journal", "path", path, "err", err)
+		return nil
+	}
+	j.Start(address, decode)
+	return j
+}
+
+// Start records the start of the core with the given address, the messages
+// journaled afterwards are decoded with decode
+func (j *Journal) Start(address common.Address, decode Decoder) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	j.decode = decode
+	j.mu.Unlock()
+	j.Write(&Entry{Kind: KindStart, Address: address})
+}
+
+// Message records a signed message payload before the core handles or sends
+// it, and returns the entry to pass to Failed. The peer is the one the message
+// was received from, zero for the messages the core sent or took from its
+// backlog.
+func (j *Journal) Message(kind string, peer enode.ID, payload []byte) *Entry {
+	if j == nil {
+		return nil
+	}
+	entry := &Entry{Kind: kind, Peer: peer, Payload: payload}
+	j.mu.Lock()
+	decode := j.decode
+	j.mu.Unlock()
+	if decode != nil {
+		decode(entry)
+	}
+	j.Write(entry)
+	return entry
+}
+
+// Failed records that handling or sending the message of e failed with err,
+// nothing is recorded if err is nil
+func (j *Journal) Failed(e *Entry, err error) {
+	if j == nil || e == nil || err == nil {
+		return
+	}
+	j.Write(&Entry{
+		Kind:    KindFailed,
+		Code:    e.Code,
+		Peer:    e.Peer,
+		Address: e.Address,
+		Height:  e.Height,
+		Round:   e.Round,
+		Error:   err.Error(),
+	})
+}
+
+// Write appends an entry and syncs it, stamping it with the current time if it
+// has none
+func (j *Journal) Write(e *Entry) {
+	if j == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.enc.Encode(e); err != nil {
+		log.Warn("Failed to write consensus journal entry", "kind", e.Kind, "err", err)
+		return
+	}
+	if j.syncer != nil {
+		if err := j.syncer.Sync(); err != nil {
+			log.Warn("Failed to sync consensus journal entry", "kind", e.Kind, "err", err)
+		}
+	}
+}
+
+// Close closes the underlying file, if any
+func (j *Journal) Close() error {
+	if j == nil || j.closer == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.closer.Close()
+}
+
+// Stop closes the journal of a core, logging a failure
+func (j *Journal) Stop(logger log.Logger) {
+	if err := j.Close(); err != nil {
+		logger.Error("Failed to close the consensus journal", "err", err)
+	}
+}
+
+// ErrString returns the journal form of an error, empty if there is none
+func ErrString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Read decodes all the entries of a journal
+func Read(r io.Reader) ([]*Entry, error) {
+	var (
+		entries []*Entry
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	// Entries carry whole blocks, allow lines well above the default 64KB
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e := new(Entry)
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			return nil, fmt.Errorf("journal line %d: %v", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadFile decodes all the entries of the journal file at path
+func ReadFile(path string) ([]*Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
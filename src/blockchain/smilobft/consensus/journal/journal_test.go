@@ -0,0 +1,141 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+)
+
+func TestJournalRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consensus-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.jsonl")
+
+	want := []*Entry{
+		{Kind: KindStart, Address: common.HexToAddress("0x01")},
+		{Kind: KindReceived, Code: "prevote", Peer: enode.HexID("0x3e93ac1c9c6bd5b9d7a2b3e6a4d81c5ea2c1b4b2a8bdc0f5d0e6b3a1f7c9e2d4"), Address: common.HexToAddress("0x02"), Height: 7, Round: 2, Payload: []byte{0xca, 0xfe}},
+		{Kind: KindValidators, Height: 8, Validators: []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}, Quorum: 2, Faulty: 0},
+		{Kind: KindVerify, Height: 8, Error: "invalid proposal"},
+	}
+	// Two runs append to the same file
+	for _, entries := range [][]*Entry{want[:2], want[2:]} {
+		j, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			j.Write(e)
+		}
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	have, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != len(want) {
+		t.Fatalf("entries mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].Time.IsZero() {
+			t.Errorf("entry %d has no time", i)
+		}
+		if have[i].String() != want[i].String() || !bytes.Equal(have[i].Payload, want[i].Payload) || len(have[i].Validators) != len(want[i].Validators) {
+			t.Errorf("entry %d mismatch: have %v, want %v", i, have[i], want[i])
+		}
+	}
+}
+
+func TestNilJournal(t *testing.T) {
+	var j *Journal
+	j.Write(&Entry{Kind: KindStart})
+	j.Failed(j.Message(KindReceived, enode.ID{}, nil), errors.New("discarded"))
+	if err := j.Close(); err != nil {
+		t.Errorf("close failed: %v", err)
+	}
+}
+
+func TestReadInvalidLine(t *testing.T) {
+	if _, err := Read(bytes.NewBufferString("{\"kind\":\"start\"}\nnot json\n")); err == nil {
+		t.Error("expected an error for an invalid line")
+	}
+}
+
+func TestCoreJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consensus-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.jsonl")
+
+	if j := OpenCore("", common.HexToAddress("0x01"), nil, log.Root()); j != nil {
+		t.Fatal("journal opened without a path")
+	}
+	decode := func(e *Entry) {
+		e.Code, e.Address, e.Height = "prevote", common.HexToAddress("0x02"), uint64(len(e.Payload))
+	}
+	j := OpenCore(path, common.HexToAddress("0x01"), decode, log.Root())
+	peer := enode.HexID("0x3e93ac1c9c6bd5b9d7a2b3e6a4d81c5ea2c1b4b2a8bdc0f5d0e6b3a1f7c9e2d4")
+	received := j.Message(KindReceived, peer, []byte{0xca, 0xfe})
+
+	// the message is on disk before it is handled
+	have, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 2 || have[1].Kind != KindReceived || !bytes.Equal(have[1].Payload, []byte{0xca, 0xfe}) {
+		t.Fatalf("received message not journaled before handling: have %v", have)
+	}
+	j.Failed(received, errors.New("future message"))
+	j.Failed(j.Message(KindSent, enode.ID{}, []byte{0xca}), nil)
+	j.Stop(log.Root())
+
+	if have, err = ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"start  0/0 0x0000000000000000000000000000000000000001",
+		"received prevote 2/0 0x0000000000000000000000000000000000000002 peer=3e93ac1c9c6bd5b9",
+		"failed prevote 2/0 0x0000000000000000000000000000000000000002 peer=3e93ac1c9c6bd5b9 err=future message",
+		"sent prevote 1/0 0x0000000000000000000000000000000000000002",
+	}
+	if len(have) != len(want) {
+		t.Fatalf("entries mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].String() != want[i] {
+			t.Errorf("entry %d mismatch: have %q, want %q", i, have[i], want[i])
+		}
+	}
+}
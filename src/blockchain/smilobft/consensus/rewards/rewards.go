@@ -0,0 +1,105 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-smilo library.
+//
+// The go-smilo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-smilo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-smilo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package rewards implements the block reward schedule shared by the SPoRT
+// engines.
+package rewards
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+// communityPercent is the share of the block reward minted to the community address
+// by the built-in schedule.
+const communityPercent = 25
+
+// Issuance is the projection of the rewards minted by a block.
+type Issuance struct {
+	Number   hexutil.Uint64                  `json:"number"`   // Block number of the projection
+	Coinbase *hexutil.Big                    `json:"coinbase"` // Reward of the block coinbase
+	Shares   map[common.Address]*hexutil.Big `json:"shares"`   // Shares minted to the beneficiaries
+	Total    *hexutil.Big                    `json:"total"`    // Issuance scheduled from genesis up to the block
+}
+
+// Schedule returns the reward schedule declared in the chain config, or the
+// built-in Smilo schedule of table if there is none.
+func Schedule(declared *params.RewardSchedule, table map[*big.Int]*big.Int, communityAddress string) *params.RewardSchedule {
+	if declared != nil {
+		return declared
+	}
+	return Legacy(table, communityAddress)
+}
+
+// Legacy converts a table of block rewards, keyed by the block they end at, into a
+// reward schedule, minting a quarter of every block reward to the community address
+// if one is configured.
+func Legacy(table map[*big.Int]*big.Int, communityAddress string) *params.RewardSchedule {
+	schedule := new(params.RewardSchedule)
+	for until, reward := range table {
+		schedule.Tranches = append(schedule.Tranches, params.RewardTranche{Until: until.Uint64(), Reward: reward})
+	}
+	sort.Slice(schedule.Tranches, func(i, j int) bool {
+		return schedule.Tranches[i].Until < schedule.Tranches[j].Until
+	})
+	if communityAddress != "" {
+		schedule.Shares = []params.RewardShare{{Address: common.HexToAddress(communityAddress), Percent: communityPercent}}
+	}
+	return schedule
+}
+
+// NewIssuance projects the rewards of a block under the given schedule.
+func NewIssuance(schedule *params.RewardSchedule, number uint64) *Issuance {
+	rewards := schedule.Rewards(number)
+	issuance := &Issuance{
+		Number:   hexutil.Uint64(number),
+		Coinbase: (*hexutil.Big)(rewards.Coinbase),
+		Shares:   make(map[common.Address]*hexutil.Big, len(rewards.Shares)),
+		Total:    (*hexutil.Big)(schedule.Issuance(number)),
+	}
+	for address, share := range rewards.Shares {
+		issuance.Shares[address] = (*hexutil.Big)(share)
+	}
+	return issuance
+}
+
+// Accumulate credits the coinbase of the given block with the mining reward.
+// The total reward consists of the block reward of the schedule and the shares of its beneficiaries.
+func Accumulate(schedule *params.RewardSchedule, state *state.StateDB, header *types.Header) {
+	emptryAddress := common.Address{}
+	if header.Coinbase == emptryAddress {
+		return
+	}
+	// add reward based on chain progression
+	rewards := schedule.Rewards(header.Number.Uint64())
+
+	log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, block: ", "blockNum", header.Number.Int64(), "BlockReward", rewards.Coinbase, "Coinbase", header.Coinbase.Hex())
+
+	// Accumulate the rewards to the beneficiaries
+	for address, share := range rewards.Shares {
+		state.AddBalance(address, share, header.Number)
+		log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, adding reward share ", "share", share, "address", address)
+	}
+	state.AddBalance(header.Coinbase, rewards.Coinbase, header.Number)
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-smilo library.
+//
+// The go-smilo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-smilo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-smilo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+var testTable = map[*big.Int]*big.Int{
+	big.NewInt(20): big.NewInt(40),
+	big.NewInt(10): big.NewInt(100),
+}
+
+func TestSchedule(t *testing.T) {
+	community := "0x00000000000000000000000000000000000c0de1"
+	schedule := Schedule(nil, testTable, community)
+	if err := schedule.Check(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedule.Tranches) != 2 || schedule.Tranches[0].Until != 10 || schedule.Tranches[1].Until != 20 {
+		t.Fatalf("tranches mismatch: have %v", schedule.Tranches)
+	}
+	if len(schedule.Shares) != 1 || schedule.Shares[0].Address != common.HexToAddress(community) || schedule.Shares[0].Percent != communityPercent {
+		t.Errorf("shares mismatch: have %v", schedule.Shares)
+	}
+	if shares := Schedule(nil, testTable, "").Shares; len(shares) != 0 {
+		t.Errorf("shares without a community address: have %v", shares)
+	}
+
+	// a schedule declared in the chain config takes precedence
+	declared := &params.RewardSchedule{InitialReward: big.NewInt(1)}
+	if schedule := Schedule(declared, testTable, community); schedule != declared {
+		t.Errorf("schedule mismatch: have %v, want %v", schedule, declared)
+	}
+}
+
+func TestNewIssuance(t *testing.T) {
+	community := "0x00000000000000000000000000000000000c0de1"
+	schedule := Legacy(testTable, community)
+
+	issuance := NewIssuance(schedule, 12)
+	if issuance.Number != 12 || issuance.Coinbase.ToInt().Int64() != 40 {
+		t.Errorf("reward mismatch: have %d at block %d, want 40 at block 12", issuance.Coinbase.ToInt(), issuance.Number)
+	}
+	if share := issuance.Shares[common.HexToAddress(community)]; share == nil || share.ToInt().Int64() != 10 {
+		t.Errorf("community share mismatch: have %v, want 10", share)
+	}
+	if total := issuance.Total.ToInt(); total.Cmp(schedule.Issuance(12)) != 0 {
+		t.Errorf("issuance mismatch: have %v, want %v", total, schedule.Issuance(12))
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	community := common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	coinbase := common.HexToAddress("0x0000000000000000000000000000000000000c0b")
+	schedule := Legacy(testTable, community.Hex())
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	Accumulate(schedule, statedb, &types.Header{Number: big.NewInt(5), Coinbase: coinbase})
+	if balance := statedb.GetBalance(coinbase); balance.Int64() != 100 {
+		t.Errorf("coinbase balance mismatch: have %v, want 100", balance)
+	}
+	if balance := statedb.GetBalance(community); balance.Int64() != 25 {
+		t.Errorf("community balance mismatch: have %v, want 25", balance)
+	}
+
+	// blocks without a coinbase are not rewarded
+	statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	Accumulate(schedule, statedb, &types.Header{Number: big.NewInt(5)})
+	if balance := statedb.GetBalance(community); balance.Sign() != 0 {
+		t.Errorf("community balance mismatch: have %v, want 0", balance)
+	}
+}
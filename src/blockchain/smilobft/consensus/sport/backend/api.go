@@ -26,8 +26,12 @@ import (
 	"math/big"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
+	"go-smilo/src/blockchain/smilobft/contracts/sportgovernance"
 	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
 )
 
 // API is a user facing RPC API to dump smilobft state
@@ -74,7 +78,10 @@ func (api *API) Proposals() map[common.Address]bool {
 }
 
 // Propose (clique override) injects a new authorization candidate that the fullnode will attempt to push through.
-func (api *API) Propose(address common.Address, auth bool) {
+func (api *API) Propose(address common.Address, auth bool) error {
+	if api.smilo.config.Governance {
+		return errGovernanceEnabled
+	}
 	api.smilo.candidatesLock.Lock()
 	defer api.smilo.candidatesLock.Unlock()
 
@@ -85,19 +92,92 @@ func (api *API) Propose(address common.Address, auth bool) {
 	statedb, _, err := api.chain.State()
 	if err != nil {
 		log.Error("Could not propose new candidate, got error with statedb", "error", err, "address", address, "auth", auth)
-		return
+		return err
 	} else if statedb.GetBalance(address).Cmp(requireSmilos) < 0 {
 		log.Error("Could not propose new candidate", "error", core.ErrInsufficientFunds.Error(), "address", address, "auth", auth, "MinFunds", api.smilo.config.MinFunds, "balance", statedb.GetBalance(address))
-		return
+		return core.ErrInsufficientFunds
 	}
 
 	api.smilo.candidates[address] = auth
+	return nil
 }
 
 // Discard (clique override) drops a currently running candidate, stopping the fullnode from casting further votes (either for or against).
-func (api *API) Discard(address common.Address) {
+func (api *API) Discard(address common.Address) error {
+	if api.smilo.config.Governance {
+		return errGovernanceEnabled
+	}
 	api.smilo.candidatesLock.Lock()
 	defer api.smilo.candidatesLock.Unlock()
 
 	delete(api.smilo.candidates, address)
+	return nil
+}
+
+// GovernanceFullnodes retrieves the fullnodes held by the governance contract at a given block.
+func (api *API) GovernanceFullnodes(number *rpc.BlockNumber) ([]common.Address, error) {
+	statedb, err := api.governanceState(number)
+	if err != nil {
+		return nil, err
+	}
+	return sportgovernance.Fullnodes(statedb), nil
+}
+
+// GovernanceProposals retrieves the proposals pending in the governance contract at a given block.
+func (api *API) GovernanceProposals(number *rpc.BlockNumber) ([]sportgovernance.Proposal, error) {
+	statedb, err := api.governanceState(number)
+	if err != nil {
+		return nil, err
+	}
+	return sportgovernance.Proposals(statedb), nil
+}
+
+// GovernanceHistory retrieves every vote and epoch outcome recorded by the governance
+// contract up to a given block.
+func (api *API) GovernanceHistory(number *rpc.BlockNumber) ([]sportgovernance.Record, error) {
+	statedb, err := api.governanceState(number)
+	if err != nil {
+		return nil, err
+	}
+	return sportgovernance.History(statedb), nil
+}
+
+// governanceState opens the state of a given block holding the governance contract.
+func (api *API) governanceState(number *rpc.BlockNumber) (*state.StateDB, error) {
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	chain, ok := api.chain.(stateReader)
+	if !ok {
+		return nil, errNoStakeState
+	}
+	statedb, _, err := chain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	if !sportgovernance.Deployed(statedb) {
+		return nil, sportgovernance.ErrNotDeployed
+	}
+	return statedb, nil
+}
+
+// GetRewardSchedule retrieves the block reward schedule of the chain.
+func (api *API) GetRewardSchedule() *params.RewardSchedule {
+	return api.smilo.rewardSchedule(api.chain.Config())
+}
+
+// GetIssuance projects the rewards minted by a given, possibly future, block and the
+// issuance scheduled from genesis up to it, assuming every block is rewarded.
+func (api *API) GetIssuance(number *rpc.BlockNumber) *rewards.Issuance {
+	schedule := api.smilo.rewardSchedule(api.chain.Config())
+	if number == nil || *number < 0 {
+		return rewards.NewIssuance(schedule, api.chain.CurrentHeader().Number.Uint64())
+	}
+	return rewards.NewIssuance(schedule, uint64(number.Int64()))
 }
@@ -28,8 +28,9 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
-	"go-smilo/src/blockchain/smilobft/consensus/sport/smilobftcore"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 )
@@ -43,6 +44,7 @@ const (
 func New(config *sport.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database) consensus.BFT {
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
+	weights, _ := lru.NewARC(inmemoryWeights)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
 	backend := &backend{
@@ -54,12 +56,18 @@ func New(config *sport.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database)
 		db:               db,
 		commitChBlock:    make(chan *types.Block, 1),
 		recents:          recents,
+		weights:          weights,
 		candidates:       make(map[common.Address]bool),
 		coreStarted:      false,
 		recentMessages:   recentMessages,
 		knownMessages:    knownMessages,
 	}
-	backend.core = smilobftcore.New(backend, backend.config)
+	backend.core = bftCore.New(bftBackend{backend}, &bft.Config{
+		Engine:         "sport",
+		RequestTimeout: config.RequestTimeout,
+		MaxTimeout:     config.MaxTimeout,
+		Journal:        config.Journal,
+	})
 	return backend
 }
 
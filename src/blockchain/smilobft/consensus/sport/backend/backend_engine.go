@@ -39,6 +39,8 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/consensus/sport/fullnode"
 	"go-smilo/src/blockchain/smilobft/core"
@@ -329,8 +331,8 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	}
 	sb.candidatesLock.RUnlock()
 
-	// pick one of the candidates randomly
-	if len(addresses) > 0 {
+	// pick one of the candidates randomly, unless fullnodes are voted on chain
+	if len(addresses) > 0 && !sb.config.Governance {
 		index := rand.Intn(len(addresses))
 		// add fullnode voting in coinbase
 		header.Coinbase = addresses[index]
@@ -341,8 +343,16 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 		}
 	}
 
-	// add fullnodes in snapshot to extraData's fullnodes section
-	extra, err := prepareExtra(header, snap.fullnodes())
+	// add fullnodes in snapshot to extraData's fullnodes section, checkpoints
+	// take the outcome of the governance contract when it is enabled
+	fullnodes := snap.fullnodes()
+	if sb.config.Governance && number%sb.config.Epoch == 0 {
+		if fullnodes, err = sb.governanceFullnodes(parent); err != nil {
+			log.Error("Could not read the fullnodes from the governance contract.", "err", err)
+			return err
+		}
+	}
+	extra, err := prepareExtra(header, fullnodes)
 	if err != nil {
 		log.Error("Could not add fullnodes in snapshot to extraData's fullnodes section.", "err", err)
 		return err
@@ -365,13 +375,20 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 func (sb *backend) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction,
 	uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
 
+	if sb.config.Governance {
+		if err := sb.applyGovernance(chain, header, state, txs, receipts); err != nil {
+			log.Error("Could not apply the governance contract.", "err", err)
+			return nil, err
+		}
+	}
+
 	// warn for empty blocks
 	number := header.Number.Int64()
 
 	//Will generate rewards for every block until block 40000000
 	//From this point on, ddd block rewards in Sport only if there is transactions in it
 	if header.Number.Cmp(big.NewInt(1)) > 0 && len(txs) > 0 || number < 40000000 {
-		AccumulateRewards(sb.config.CommunityAddress, state, header)
+		rewards.Accumulate(sb.rewardSchedule(chain.Config()), state, header)
 	}
 
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
@@ -439,7 +456,7 @@ func (sb *backend) Seal(chain consensus.ChainReader, block *types.Block, stop <-
 	// post block into Sport engine
 	go func() {
 		requestEvent := sport.RequestEvent{
-			BlockProposal: block,
+			Proposal: block,
 		}
 		err := sb.EventMux().Post(requestEvent)
 		if err != nil {
@@ -473,6 +490,11 @@ func (sb *backend) APIs(chain consensus.ChainReader) []rpc.API {
 		Version:   "1.0",
 		Service:   &API{chain: chain, smilo: sb},
 		Public:    true,
+	}, {
+		Namespace: "sport",
+		Version:   "1.0",
+		Service:   health.NewAPI(sb.core.Status),
+		Public:    true,
 	}}
 }
 
@@ -532,12 +554,26 @@ func (sb *backend) snapshot(chain consensus.ChainReader, number uint64, hash com
 			}
 		}
 		headers = append(headers, header)
+
+		// If we're at a checkpoint block without a known parent (e.g. a light client
+		// synced from a trusted CHT), trust the fullnodes declared in its extra-data.
+		// Votes are reset on checkpoints, so the tally before it is not needed.
+		if number%sb.config.Epoch == 0 && len(parents) == 0 && chain.GetHeader(header.ParentHash, number-1) == nil {
+			sportExtra, err := types.ExtractSportExtra(header)
+			if err != nil {
+				return nil, err
+			}
+			snap = newSnapshot(sb.config.Epoch, number-1, header.ParentHash, fullnode.NewFullnodeSet(sportExtra.Fullnodes, sb.config.SpeakerPolicy))
+			log.Info("Trusted checkpoint fullnodes", "number", number, "hash", hash, "fullnodes", snap.fullnodes())
+			break
+		}
 		number, hash = number-1, header.ParentHash
 	}
 	// Previous snapshot found, apply any pending headers on top of it
 	for i := 0; i < len(headers)/2; i++ {
 		headers[i], headers[len(headers)-1-i] = headers[len(headers)-1-i], headers[i]
 	}
+	snap.Governance = sb.config.Governance
 	snap, err := snap.apply(headers)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	"go-smilo/src/blockchain/smilobft/params"
 )
 
 func TestBlockRewards(t *testing.T) {
@@ -196,3 +201,25 @@ func TestBlockRewards(t *testing.T) {
 	}
 
 }
+
+func TestLegacyRewardSchedule(t *testing.T) {
+	community := "0x00000000000000000000000000000000000c0de1"
+	schedule := rewards.Legacy(smiloTokenMetricsTable, community)
+	require.NoError(t, schedule.Check())
+
+	for _, tranche := range schedule.Tranches {
+		for _, number := range []uint64{tranche.Until - 1, tranche.Until} {
+			blockRewards := schedule.Rewards(number)
+			expected := getSmiloBlockReward(new(big.Int).SetUint64(number))
+			require.Equal(t, expected.String(), blockRewards.Coinbase.String(), "Failed to get proper reward for block %d ", number)
+			share := blockRewards.Shares[common.HexToAddress(community)]
+			require.Equal(t, new(big.Int).Div(expected, big.NewInt(4)).String(), share.String(), "Failed to get proper community share for block %d ", number)
+		}
+	}
+
+	// A schedule declared in the chain config takes precedence
+	sb := &backend{config: sport.DefaultConfig}
+	declared := &params.RewardSchedule{InitialReward: big.NewInt(1)}
+	require.Equal(t, declared, sb.rewardSchedule(&params.ChainConfig{Sport: &params.SportConfig{Rewards: declared}}))
+	require.Equal(t, len(schedule.Tranches), len(sb.rewardSchedule(&params.ChainConfig{}).Tranches))
+}
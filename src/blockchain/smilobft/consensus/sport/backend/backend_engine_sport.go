@@ -23,14 +23,14 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
-	"go-smilo/src/blockchain/smilobft/consensus/sport/smilobftcore"
-	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
 )
 
 // verifySigner checks whether the signer is in parent's fullnode set
@@ -86,7 +86,7 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	fullnodes := snap.FullnodeSet.Copy()
 	// Check whether the committed seals are generated by parent's fullnodes
 	validSeal := 0
-	proposalSeal := smilobftcore.PrepareCommittedSeal(header.Hash())
+	proposalSeal := bftCore.PrepareCommittedSeal(header.Hash())
 	// 1. Get committed seals from current header
 	for _, seal := range extra.CommittedSeal {
 		// 2. Get the original address by seal and parent block hash
@@ -117,27 +117,14 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	return nil
 }
 
-// AccumulateRewards (override from ethash) credits the coinbase of the given block with the mining reward.
-// The total reward consists of the static block reward and rewards for  the community.
-func AccumulateRewards(communityAddress string, state *state.StateDB, header *types.Header) {
-	// add reward based on chain progression
-	blockReward := getSmiloBlockReward(header.Number)
-
-	// Accumulate the rewards
-	reward := new(big.Int).Set(blockReward)
-	emptryAddress := common.Address{}
-	if header.Coinbase != emptryAddress {
-
-		log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, block: ", "blockNum", header.Number.Int64(), "BlockReward", blockReward.Int64(), "Coinbase", header.Coinbase.Hex())
-
-		// Accumulate the rewards to community
-		if communityAddress != "" {
-			rewardForCommunity := new(big.Int).Div(blockReward, big.NewInt(4))
-			state.AddBalance(common.HexToAddress(communityAddress), rewardForCommunity, header.Number)
-			log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, adding reward to community ", "rewardForCommunity", rewardForCommunity, "communityAddress", communityAddress)
-		}
-		state.AddBalance(header.Coinbase, reward, header.Number)
+// rewardSchedule returns the reward schedule declared in the chain config, or the
+// built-in Smilo schedule if there is none.
+func (sb *backend) rewardSchedule(config *params.ChainConfig) *params.RewardSchedule {
+	var declared *params.RewardSchedule
+	if config.Sport != nil {
+		declared = config.Sport.Rewards
 	}
+	return rewards.Schedule(declared, smiloTokenMetricsTable, sb.config.CommunityAddress)
 }
 
 func getSmiloBlockReward(blockNum *big.Int) (blockReward *big.Int) {
@@ -32,10 +32,11 @@ import (
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 // HandleMsg implements consensus.Handler.HandleMsg
-func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
+func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg, peer enode.ID) (bool, error) {
 	sb.coreMu.Lock()
 	defer sb.coreMu.Unlock()
 
@@ -72,6 +73,7 @@ func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 		go func() {
 			err := sb.smilobftEventMux.Post(sport.MessageEvent{
 				Payload: data,
+				Peer:    peer,
 			})
 			if err != nil {
 				log.Error("Could not send sb.smilobftEventMux.Post, sport.MessageEvent", "err", err, "msg", msg.String())
@@ -82,7 +84,7 @@ func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 
 		return true, nil
 	}
-	if msg.Code == NewBlockMsg && sb.core.IsSpeaker() {
+	if msg.Code == NewBlockMsg && sb.core.IsProposer() {
 		// avoid race conditions
 		log.Debug("Speaker received NewBlockMsg", "size", msg.Size, "payload.type", reflect.TypeOf(msg.Payload), "sender", addr, "msg", msg.String())
 		if reader, ok := msg.Payload.(*bytes.Reader); ok {
@@ -101,7 +103,7 @@ func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 				return false, nil
 			}
 			newRequestedBlock := request.Block
-			if newRequestedBlock.Header().MixDigest == types.SportDigest && sb.core.IsCurrentBlockProposal(newRequestedBlock.Hash()) {
+			if newRequestedBlock.Header().MixDigest == types.SportDigest && sb.core.IsCurrentProposal(newRequestedBlock.Hash()) {
 				log.Debug("Speaker already proposed this block", "hash", newRequestedBlock.Hash(), "sender", addr, "msg", msg.String())
 				return true, nil
 			}
@@ -28,6 +28,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/cmn"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 func TestBackendHandler(t *testing.T) {
@@ -51,7 +52,7 @@ func TestBackendHandler(t *testing.T) {
 	}
 
 	// 2. this message should be in cache after we handle it
-	_, err := backend.HandleMsg(addr, msg)
+	_, err := backend.HandleMsg(addr, msg, enode.ID{})
 	if err != nil {
 		t.Fatalf("handle message failed: %v", err)
 	}
@@ -76,7 +77,7 @@ func TestHandleNewBlockMessage_whenTypical(t *testing.T) {
 	arbitraryBlock, arbitraryP2PMessage := buildArbitraryP2PNewBlockMessage(t, false)
 	postAndWait(backend, arbitraryBlock, t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -100,7 +101,7 @@ func TestHandleNewBlockMessage_whenNotAProposedBlock(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -123,7 +124,7 @@ func TestHandleNewBlockMessage_whenFailToDecode(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
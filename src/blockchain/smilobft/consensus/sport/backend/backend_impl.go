@@ -35,12 +35,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/core/types"
 )
 
-// Fullnodes implements sport.Backend.Fullnodes
-func (sb *backend) Fullnodes(proposal sport.BlockProposal) sport.FullnodeSet {
-	return sb.getFullnodes(proposal.Number().Uint64(), proposal.Hash())
-}
-
-// Broadcast implements sport.Backend.Broadcast
+// Broadcast sends a message to all fullnodes (include self)
 func (sb *backend) Broadcast(fullnodeSet sport.FullnodeSet, payload []byte) error {
 	// send to others
 	sb.Gossip(fullnodeSet, payload)
@@ -52,7 +47,7 @@ func (sb *backend) Broadcast(fullnodeSet sport.FullnodeSet, payload []byte) erro
 	return nil
 }
 
-// Gossip implements sport.Backend.Gossip
+// Gossip sends a message to all fullnodes (exclude self)
 func (sb *backend) Gossip(fullnodeSet sport.FullnodeSet, payload []byte) error {
 	hash := sport.RLPHash(payload)
 	sb.knownMessages.Add(hash, true)
@@ -97,7 +92,8 @@ func (sb *backend) Gossip(fullnodeSet sport.FullnodeSet, payload []byte) error {
 	return nil
 }
 
-// Commit implements sport.Backend.Commit
+// Commit delivers an approved proposal to backend.
+// The delivered proposal will be put into blockchain.
 func (sb *backend) Commit(proposal sport.BlockProposal, seals [][]byte) error {
 	// Check if the proposal is a valid block
 	block := &types.Block{}
@@ -140,12 +136,13 @@ func (sb *backend) Commit(proposal sport.BlockProposal, seals [][]byte) error {
 	return nil
 }
 
-// EventMux implements sport.Backend.EventMux
+// EventMux returns the event mux in backend
 func (sb *backend) EventMux() *cmn.TypeMux {
 	return sb.smilobftEventMux
 }
 
-// Verify implements sport.Backend.Verify
+// Verify verifies the proposal. If a consensus.ErrFutureBlock error is returned,
+// the time difference of the proposal and current time is also returned.
 func (sb *backend) Verify(proposal sport.BlockProposal) (time.Duration, error) {
 	// Check if the proposal is a valid block
 	block := &types.Block{}
@@ -185,13 +182,14 @@ func (sb *backend) Verify(proposal sport.BlockProposal) (time.Duration, error) {
 	return 0, err
 }
 
-// Sign implements sport.Backend.Sign
+// Sign signs input data with the backend's private key
 func (sb *backend) Sign(data []byte) ([]byte, error) {
 	hashData := crypto.Keccak256(data)
 	return crypto.Sign(hashData, sb.privateKey)
 }
 
-// CheckSignature implements sport.Backend.CheckSignature
+// CheckSignature verifies the signature by checking if it's signed by
+// the given fullnode
 func (sb *backend) CheckSignature(data []byte, address common.Address, sig []byte) error {
 	signer, err := sport.GetSignatureAddress(data, sig)
 	if err != nil {
@@ -205,12 +203,12 @@ func (sb *backend) CheckSignature(data []byte, address common.Address, sig []byt
 	return nil
 }
 
-// HasBlockProposal implements sport.Backend.HashBlock
+// HasBlockProposal checks if the combination of the given hash and height matches any existing blocks
 func (sb *backend) HasBlockProposal(hash common.Hash, number *big.Int) bool {
 	return sb.chain.GetHeader(hash, number.Uint64()) != nil
 }
 
-// GetSpeaker implements sport.Backend.GetSpeaker
+// GetSpeaker returns the speaker of the given block height
 func (sb *backend) GetSpeaker(number uint64) common.Address {
 	if h := sb.chain.GetHeaderByNumber(number); h != nil {
 		a, _ := sb.Author(h)
@@ -219,20 +217,23 @@ func (sb *backend) GetSpeaker(number uint64) common.Address {
 	return common.Address{}
 }
 
-// ParentFullnodes implements sport.Backend.GetParentFullnodes
-func (sb *backend) ParentFullnodes(proposal sport.BlockProposal) sport.FullnodeSet {
-	if block, ok := proposal.(*types.Block); ok {
-		return sb.getFullnodes(block.Number().Uint64()-1, block.ParentHash())
-	}
-	return fullnode.NewFullnodeSet(nil, sb.config.SpeakerPolicy)
-}
-
 func (sb *backend) getFullnodes(number uint64, hash common.Hash) sport.FullnodeSet {
 	snap, err := sb.snapshot(sb.chain, number, hash, nil)
 	if err != nil {
 		sb.logger.Error("Failed to getFullnodes from snapshot", "err", err)
 		return fullnode.NewFullnodeSet(nil, sb.config.SpeakerPolicy)
 	}
+	if sb.config.SpeakerPolicy == sport.StakeWeighted {
+		// weights are set on a new set so the seed of this block doesn't leak into the snapshot
+		weights, err := sb.stakeWeights(number)
+		if err != nil {
+			sb.logger.Error("Failed to getFullnodes stake weights", "number", number, "err", err)
+			return fullnode.NewFullnodeSet(nil, sb.config.SpeakerPolicy)
+		}
+		fullnodeSet := fullnode.NewFullnodeSet(snap.fullnodes(), sb.config.SpeakerPolicy)
+		fullnodeSet.SetWeights(weights, hash)
+		return fullnodeSet
+	}
 	return snap.FullnodeSet
 }
 
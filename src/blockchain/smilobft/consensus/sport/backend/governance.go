@@ -0,0 +1,121 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	"go-smilo/src/blockchain/smilobft/consensus/sport/fullnode"
+	"go-smilo/src/blockchain/smilobft/contracts/sportgovernance"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// errInvalidCheckpointFullnodes is returned if the fullnodes of a checkpoint
+// header don't match the outcome of the governance contract.
+var errInvalidCheckpointFullnodes = errors.New("checkpoint fullnodes don't match the governance contract")
+
+// errGovernanceEnabled is returned by the candidate API when the fullnodes are
+// voted through the governance contract.
+var errGovernanceEnabled = errors.New("fullnodes are voted through the governance contract")
+
+// governanceFullnodes returns the fullnodes the governance contract will leave in
+// place when closing the epoch on top of parent.
+func (sb *backend) governanceFullnodes(parent *types.Header) ([]common.Address, error) {
+	chain, ok := sb.chain.(stateReader)
+	if !ok {
+		return nil, errNoStakeState
+	}
+	statedb, _, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	if !sportgovernance.Deployed(statedb) {
+		return nil, sportgovernance.ErrNotDeployed
+	}
+	return sportgovernance.NextFullnodes(statedb), nil
+}
+
+// applyGovernance closes the epoch on checkpoint blocks and then records the votes
+// sent to the governance contract by the block transactions. Votes of a checkpoint
+// block count towards the next epoch, so the checkpoint fullnodes only depend on
+// the parent state and can be assembled in Prepare. Calls the contract rejects
+// fail their receipt, the receipts are only hashed once the block is finalized.
+func (sb *backend) applyGovernance(chain consensus.ChainReader, header *types.Header, statedb *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) error {
+	if !sportgovernance.Deployed(statedb) {
+		return sportgovernance.ErrNotDeployed
+	}
+	number := header.Number.Uint64()
+	if number%sb.config.Epoch == 0 {
+		sportExtra, err := types.ExtractSportExtra(header)
+		if err != nil {
+			return err
+		}
+		fullnodes := sportgovernance.Tally(statedb, number)
+		if !sameAddresses(fullnodes, sportExtra.Fullnodes) {
+			return errInvalidCheckpointFullnodes
+		}
+	}
+
+	signer := types.MakeSigner(chain.Config(), header.Number)
+	minBalance := new(big.Int).Mul(big.NewInt(sb.config.MinFunds), big.NewInt(1e18))
+	for i, tx := range txs {
+		if tx.To() == nil || *tx.To() != sportgovernance.Address {
+			continue
+		}
+		if i >= len(receipts) || receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		voter, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if err := sportgovernance.Apply(statedb, number, voter, tx.Data(), minBalance); err != nil {
+			sb.logger.Warn("Rejected governance call", "number", number, "tx", tx.Hash(), "voter", voter, "err", err)
+			receipts[i].Status = types.ReceiptStatusFailed
+		}
+	}
+	return nil
+}
+
+// checkpointFullnodes returns the fullnodes declared in a checkpoint header.
+func checkpointFullnodes(header *types.Header, policy sport.SpeakerPolicy) (sport.FullnodeSet, error) {
+	sportExtra, err := types.ExtractSportExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	return fullnode.NewFullnodeSet(sportExtra.Fullnodes, policy), nil
+}
+
+// sameAddresses reports whether both lists hold the same addresses in the same order.
+func sameAddresses(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,209 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	"go-smilo/src/blockchain/smilobft/contracts/sportgovernance"
+	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/rpc"
+)
+
+// newGovernanceChain creates a single fullnode chain voting through the governance
+// contract, with a funded candidate ready to be proposed.
+func newGovernanceChain(t *testing.T, epoch uint64) (*core.BlockChain, *backend, *ecdsa.PrivateKey, common.Address) {
+	genesis, nodeKeys := getGenesisAndKeys(1)
+	chainConfig := *genesis.Config
+	chainConfig.Sport = &params.SportConfig{Epoch: epoch, Governance: true}
+	genesis.Config = &chainConfig
+	genesis.GasLimit = params.GenesisGasLimit
+
+	candidate := common.HexToAddress("0x000000000000000000000000000000000000c0de")
+	genesis.Alloc = core.GenesisAlloc{
+		crypto.PubkeyToAddress(nodeKeys[0].PublicKey): {Balance: big.NewInt(1e18)},
+		candidate: {Balance: big.NewInt(1e18)},
+	}
+
+	config := *sport.DefaultConfig
+	config.Epoch = epoch
+	config.Governance = true
+
+	memDB := rawdb.NewMemoryDatabase()
+	b, _ := New(&config, nodeKeys[0], memDB).(*backend)
+	genesis.MustCommit(memDB)
+	blockchain, err := core.NewBlockChain(memDB, nil, genesis.Config, b, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start(context.Background(), blockchain, blockchain.CurrentBlock, blockchain.HasBadBlock)
+	return blockchain, b, nodeKeys[0], candidate
+}
+
+// makeGovernanceBlock assembles, seals and imports a block with the given transactions.
+func makeGovernanceBlock(t *testing.T, chain *core.BlockChain, engine *backend, parent *types.Block, txs []*types.Transaction) *types.Block {
+	header := makeHeader(parent, engine.config)
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatal(err)
+	}
+	statedb, _, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		gp       = new(core.GasPool).AddGas(header.GasLimit)
+		receipts []*types.Receipt
+	)
+	for i, tx := range txs {
+		statedb.Prepare(tx.Hash(), common.Hash{}, i)
+		receipt, _, _, err := core.ApplyTransaction(chain.Config(), chain, nil, gp, statedb, statedb, header, tx, &header.GasUsed, vm.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	block, err := engine.Finalize(chain, header, statedb, txs, nil, receipts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block, err = engine.Seal(chain, block, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestGovernanceVoting(t *testing.T) {
+	chain, engine, key, candidate := newGovernanceChain(t, 2)
+	api := &API{chain: chain, smilo: engine}
+	fullnode := crypto.PubkeyToAddress(key.PublicKey)
+
+	fullnodes, err := api.GovernanceFullnodes(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fullnodes, []common.Address{fullnode}) {
+		t.Errorf("genesis fullnodes mismatch: have %v", fullnodes)
+	}
+
+	// Vote for the candidate, it joins at the next checkpoint
+	signer := types.MakeSigner(chain.Config(), big.NewInt(1))
+	tx, err := types.SignTx(types.NewTransaction(0, sportgovernance.Address, common.Big0, 100000, common.Big0, sportgovernance.ProposeInput(candidate, true)), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := makeGovernanceBlock(t, chain, engine, chain.Genesis(), []*types.Transaction{tx})
+
+	proposals, err := api.GovernanceProposals(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sportgovernance.Proposal{{Address: candidate, Authorize: true, Voters: []common.Address{fullnode}}}
+	if !reflect.DeepEqual(proposals, want) {
+		t.Errorf("proposals mismatch: have %+v, want %+v", proposals, want)
+	}
+	if snap, _ := engine.snapshot(chain, 1, block.Hash(), nil); snap.FullnodeSet.Size() != 1 {
+		t.Errorf("fullnodes changed before the checkpoint: have %v", snap.fullnodes())
+	}
+
+	// A checkpoint that ignores the contract is rejected
+	header := makeHeader(block, engine.config)
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatal(err)
+	}
+	sportExtra, _ := types.ExtractSportExtra(header)
+	if len(sportExtra.Fullnodes) != 2 {
+		t.Errorf("checkpoint fullnodes mismatch: have %v", sportExtra.Fullnodes)
+	}
+	if header.Extra, err = prepareExtra(header, []common.Address{fullnode}); err != nil {
+		t.Fatal(err)
+	}
+	statedb, _, _ := chain.StateAt(block.Root())
+	if _, err := engine.Finalize(chain, header, statedb, nil, nil, nil); err != errInvalidCheckpointFullnodes {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidCheckpointFullnodes)
+	}
+
+	block = makeGovernanceBlock(t, chain, engine, block, nil)
+	snap, err := engine.snapshot(chain, 2, block.Hash(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, f := snap.FullnodeSet.GetByAddress(candidate); f == nil {
+		t.Errorf("candidate not authorized: have %v", snap.fullnodes())
+	}
+
+	latest := rpc.BlockNumber(2)
+	history, err := api.GovernanceHistory(&latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHistory := []sportgovernance.Record{
+		{Kind: sportgovernance.KindGenesis, Address: fullnode, Authorize: true},
+		{Block: 1, Kind: sportgovernance.KindVote, Voter: fullnode, Address: candidate, Authorize: true},
+		{Block: 2, Kind: sportgovernance.KindApplied, Address: candidate, Authorize: true},
+	}
+	if !reflect.DeepEqual(history, wantHistory) {
+		t.Errorf("history mismatch: have %+v, want %+v", history, wantHistory)
+	}
+}
+
+func TestGovernanceRejectedCall(t *testing.T) {
+	chain, engine, key, candidate := newGovernanceChain(t, 2)
+	api := &API{chain: chain, smilo: engine}
+
+	// The candidate API is replaced by the contract
+	if err := api.Propose(candidate, true); err != errGovernanceEnabled {
+		t.Errorf("propose error mismatch: have %v, want %v", err, errGovernanceEnabled)
+	}
+	if err := api.Discard(candidate); err != errGovernanceEnabled {
+		t.Errorf("discard error mismatch: have %v, want %v", err, errGovernanceEnabled)
+	}
+
+	// A call the contract rejects fails its receipt
+	signer := types.MakeSigner(chain.Config(), big.NewInt(1))
+	tx, err := types.SignTx(types.NewTransaction(0, sportgovernance.Address, common.Big0, 100000, common.Big0, []byte{0xde, 0xad, 0xbe, 0xef}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := makeGovernanceBlock(t, chain, engine, chain.Genesis(), []*types.Transaction{tx})
+	receipts := chain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != 1 || receipts[0].Status != types.ReceiptStatusFailed {
+		t.Errorf("rejected call receipt mismatch: have %+v", receipts)
+	}
+	proposals, err := api.GovernanceProposals(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 0 {
+		t.Errorf("rejected call recorded: have %+v", proposals)
+	}
+}
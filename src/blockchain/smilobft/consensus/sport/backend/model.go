@@ -29,9 +29,9 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/consensus/sport/fullnode"
-	"go-smilo/src/blockchain/smilobft/consensus/sport/smilobftcore"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 )
@@ -42,7 +42,7 @@ type backend struct {
 	smilobftEventMux *cmn.TypeMux
 	privateKey       *ecdsa.PrivateKey
 	address          common.Address
-	core             smilobftcore.Engine
+	core             bftCore.Engine
 	logger           log.Logger
 	db               ethdb.Database
 	chain            consensus.ChainReader
@@ -62,6 +62,8 @@ type backend struct {
 	candidatesLock sync.RWMutex
 	// Snapshots for recent block to speed up reorgs
 	recents *lru.ARCCache
+	// Stake weights of recent epochs for the StakeWeighted speaker policy
+	weights *lru.ARCCache
 
 	// event subscription for ChainHeadEvent event
 	broadcaster consensus.Broadcaster
@@ -101,6 +103,8 @@ type Snapshot struct {
 	Votes       []*Vote                  // List of votes cast in chronological order
 	Tally       map[common.Address]Tally // Current vote tally to avoid recalculating
 	FullnodeSet sport.FullnodeSet        // Set of authorized fullnodes at this moment
+
+	Governance bool // Whether fullnodes are taken from checkpoint headers instead of header votes
 }
 
 // ----------------------------------------------------------------------------
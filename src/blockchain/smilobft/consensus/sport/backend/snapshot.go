@@ -78,6 +78,7 @@ func (s *Snapshot) copy() *Snapshot {
 		FullnodeSet: s.FullnodeSet.Copy(),
 		Votes:       make([]*Vote, len(s.Votes)),
 		Tally:       make(map[common.Address]Tally),
+		Governance:  s.Governance,
 	}
 
 	for address, tally := range s.Tally {
@@ -164,6 +165,16 @@ func (s *Snapshot) apply(headers []*types.Header) (*Snapshot, error) {
 		if _, v := snap.FullnodeSet.GetByAddress(fullnode); v == nil {
 			return nil, errUnauthorized
 		}
+		// With the governance contract, votes are tallied on chain and checkpoints
+		// carry the resulting fullnodes, the coinbase is only the reward beneficiary
+		if snap.Governance {
+			if number%s.Epoch == 0 {
+				if snap.FullnodeSet, err = checkpointFullnodes(header, snap.FullnodeSet.Policy()); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
 
 		// Header authorized, discard any previous votes from the fullnode
 		for i, vote := range snap.Votes {
@@ -32,6 +32,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"go-smilo/src/blockchain/smilobft/consensus"
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/consensus/sport/fullnode"
 	"go-smilo/src/blockchain/smilobft/core"
@@ -464,3 +465,61 @@ func TestSaveAndLoad(t *testing.T) {
 		t.Errorf("fullnode set mismatch: have %v, want %v", snap1.FullnodeSet, snap.FullnodeSet)
 	}
 }
+
+// Tests that a snapshot can be created from a checkpoint header whose ancestors
+// are unknown, as happens on light clients synced from a trusted CHT.
+func TestSnapshotFromCheckpoint(t *testing.T) {
+	accounts := newTesterAccountPool()
+
+	genesis := &core.Genesis{
+		Difficulty: defaultDifficulty,
+		Mixhash:    types.SportDigest,
+	}
+	extra, _ := prepareExtra(genesis.ToBlock(nil).Header(), []common.Address{accounts.address("A")})
+	genesis.ExtraData = extra
+
+	db := rawdb.NewMemoryDatabase()
+	genesis.Commit(db)
+
+	config := *sport.DefaultConfig
+	config.Epoch = 3
+	engine := New(&config, accounts.accounts["A"], db).(*backend)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	// Assemble headers 1..5 declaring a fullnode set that differs from the genesis
+	fullnodes := []common.Address{accounts.address("A"), accounts.address("B")}
+	if bytes.Compare(fullnodes[0][:], fullnodes[1][:]) > 0 {
+		fullnodes[0], fullnodes[1] = fullnodes[1], fullnodes[0]
+	}
+	headers := make([]*types.Header, 5)
+	for j := range headers {
+		headers[j] = &types.Header{
+			Number:     big.NewInt(int64(j) + 1),
+			Time:       uint64(j) * config.BlockPeriod,
+			Difficulty: defaultDifficulty,
+			MixDigest:  types.SportDigest,
+		}
+		headers[j].Extra, _ = prepareExtra(headers[j], fullnodes)
+		if j > 0 {
+			headers[j].ParentHash = headers[j-1].Hash()
+		}
+		accounts.sign(headers[j], []string{"A", "B"}[j%2])
+	}
+	head := headers[len(headers)-1]
+
+	// Headers starting at the checkpoint block 3 are trusted from its extra-data
+	snap, err := engine.snapshot(chain, head.Number.Uint64(), head.Hash(), headers[2:])
+	if err != nil {
+		t.Fatalf("failed to create snapshot from checkpoint: %v", err)
+	}
+	if result := snap.fullnodes(); !reflect.DeepEqual(result, fullnodes) {
+		t.Errorf("fullnodes mismatch: have %x, want %x", result, fullnodes)
+	}
+	// Headers starting past a checkpoint block still need their ancestors
+	engine.recents.Purge()
+	if _, err := engine.snapshot(chain, head.Number.Uint64(), head.Hash(), headers[3:]); err != consensus.ErrUnknownAncestor {
+		t.Errorf("error mismatch: have %v, want %v", err, consensus.ErrUnknownAncestor)
+	}
+}
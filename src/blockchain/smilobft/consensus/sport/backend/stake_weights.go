@@ -0,0 +1,113 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/ethdb"
+)
+
+const (
+	dbKeyWeightsPrefix = "smilobft-weights"
+	inmemoryWeights    = 16 // Number of recent epoch weights to keep in memory
+)
+
+// errNoStakeState is returned when the chain can't provide the state of a past block.
+var errNoStakeState = errors.New("chain does not keep historical state")
+
+// stateReader is implemented by chains that can open the state of past blocks.
+type stateReader interface {
+	StateAt(root common.Hash) (*state.StateDB, *state.StateDB, error)
+}
+
+// stakeWeights returns the balance each fullnode held at the checkpoint that opened
+// the epoch of the given block. The balances are read from the checkpoint state once
+// and stored in the database, as the state itself may be pruned before the epoch ends.
+// The weights can't be guessed, a node without them would pick another speaker than
+// the rest of the network, so an error is returned if the checkpoint state is missing.
+func (sb *backend) stakeWeights(number uint64) (map[common.Address]*big.Int, error) {
+	checkpoint := sb.chain.GetHeaderByNumber(number - number%sb.config.Epoch)
+	if checkpoint == nil {
+		return nil, errUnknownBlock
+	}
+	hash := checkpoint.Hash()
+	if weights, ok := sb.weights.Get(hash); ok {
+		return weights.(map[common.Address]*big.Int), nil
+	}
+	weights, err := loadStakeWeights(sb.db, hash)
+	if err != nil {
+		if weights, err = sb.readStakeWeights(checkpoint); err != nil {
+			return nil, err
+		}
+		if err := storeStakeWeights(sb.db, hash, weights); err != nil {
+			sb.logger.Warn("Failed to store stake weights", "number", checkpoint.Number, "hash", hash, "err", err)
+		}
+	}
+	sb.weights.Add(hash, weights)
+	return weights, nil
+}
+
+// readStakeWeights reads the balance of the checkpoint fullnodes from its state.
+func (sb *backend) readStakeWeights(checkpoint *types.Header) (map[common.Address]*big.Int, error) {
+	chain, ok := sb.chain.(stateReader)
+	if !ok {
+		return nil, errNoStakeState
+	}
+	statedb, _, err := chain.StateAt(checkpoint.Root)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := sb.snapshot(sb.chain, checkpoint.Number.Uint64(), checkpoint.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[common.Address]*big.Int, snap.FullnodeSet.Size())
+	for _, address := range snap.fullnodes() {
+		weights[address] = statedb.GetBalance(address)
+	}
+	return weights, nil
+}
+
+// loadStakeWeights loads the weights of an epoch from the database.
+func loadStakeWeights(db ethdb.Database, hash common.Hash) (map[common.Address]*big.Int, error) {
+	blob, err := db.Get(append([]byte(dbKeyWeightsPrefix), hash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[common.Address]*big.Int)
+	if err := json.Unmarshal(blob, &weights); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// storeStakeWeights inserts the weights of an epoch into the database.
+func storeStakeWeights(db ethdb.Database, hash common.Hash, weights map[common.Address]*big.Int) error {
+	blob, err := json.Marshal(weights)
+	if err != nil {
+		return err
+	}
+	return db.Put(append([]byte(dbKeyWeightsPrefix), hash[:]...), blob)
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+)
+
+func TestStakeWeights(t *testing.T) {
+	genesis, nodeKeys := getGenesisAndKeys(3)
+	genesis.Alloc = core.GenesisAlloc{}
+	for i, key := range nodeKeys {
+		genesis.Alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: big.NewInt(int64(i * 100))}
+	}
+	memDB := rawdb.NewMemoryDatabase()
+	config := *sport.DefaultConfig
+	config.SpeakerPolicy = sport.StakeWeighted
+	b, _ := New(&config, nodeKeys[0], memDB).(*backend)
+	genesis.MustCommit(memDB)
+	chain, err := core.NewBlockChain(memDB, nil, genesis.Config, b, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start(context.Background(), chain, chain.CurrentBlock, chain.HasBadBlock)
+	defer b.Stop()
+
+	fullnodeSet := b.getFullnodes(0, chain.Genesis().Hash())
+	if fullnodeSet.Policy() != sport.StakeWeighted {
+		t.Fatalf("policy mismatch: have %v, want %v", fullnodeSet.Policy(), sport.StakeWeighted)
+	}
+	for i, key := range nodeKeys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		if have, want := fullnodeSet.Weight(addr), big.NewInt(int64(i*100)); have.Cmp(want) != 0 {
+			t.Errorf("weight mismatch for %v: have %v, want %v", addr, have, want)
+		}
+	}
+
+	// weights must survive the state being unavailable
+	stored, err := loadStakeWeights(memDB, chain.Genesis().Hash())
+	if err != nil {
+		t.Fatalf("weights not stored: %v", err)
+	}
+	if len(stored) != len(nodeKeys) {
+		t.Errorf("stored weights mismatch: have %d, want %d", len(stored), len(nodeKeys))
+	}
+
+	// the zero weight fullnode is never picked
+	poor := crypto.PubkeyToAddress(nodeKeys[0].PublicKey)
+	for round := uint64(0); round < 50; round++ {
+		fullnodeSet.CalcSpeaker(poor, round)
+		if fullnodeSet.IsSpeaker(poor) {
+			t.Fatalf("fullnode without stake picked as speaker at round %d", round)
+		}
+	}
+}
+
+func TestStakeWeightsWithoutState(t *testing.T) {
+	genesis, nodeKeys := getGenesisAndKeys(3)
+	memDB := rawdb.NewMemoryDatabase()
+	config := *sport.DefaultConfig
+	config.SpeakerPolicy = sport.StakeWeighted
+	b, _ := New(&config, nodeKeys[0], memDB).(*backend)
+	genesis.MustCommit(memDB)
+	chain, err := core.NewBlockChain(memDB, nil, genesis.Config, b, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start(context.Background(), chain, chain.CurrentBlock, chain.HasBadBlock)
+	defer b.Stop()
+
+	// hide the state of the chain and forget the weights read at start, the
+	// checkpoint weights can't be read anymore
+	b.chain = struct{ consensus.ChainReader }{chain}
+	b.weights.Purge()
+	genesisHash := chain.Genesis().Hash()
+	if err := memDB.Delete(append([]byte(dbKeyWeightsPrefix), genesisHash[:]...)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := b.stakeWeights(0); err != errNoStakeState {
+		t.Fatalf("error mismatch: have %v, want %v", err, errNoStakeState)
+	}
+	// no speaker is picked rather than falling back to another policy
+	if size := b.getFullnodes(0, genesisHash).Size(); size != 0 {
+		t.Fatalf("fullnodes mismatch: have %d, want 0", size)
+	}
+}
@@ -18,7 +18,11 @@
 package fullnode
 
 import (
+	"encoding/binary"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"go-smilo/src/blockchain/smilobft/consensus/sport"
 )
@@ -48,3 +52,31 @@ func roundRobinSpeaker(fullnodeSet sport.FullnodeSet, speaker common.Address, ro
 	pick := seed % uint64(fullnodeSet.Size())
 	return fullnodeSet.GetByIndex(pick)
 }
+
+// stakeWeightedSpeaker picks the speaker with a probability proportional to its
+// weight. The pick only depends on the block seed and the round, so every fullnode
+// agrees on it. Falls back to round robin while no weights are known.
+func stakeWeightedSpeaker(fullnodes sport.FullnodeSet, speaker common.Address, round uint64) sport.Fullnode {
+	set, ok := fullnodes.(*fullnodeSet)
+	if !ok {
+		return roundRobinSpeaker(fullnodes, speaker, round)
+	}
+	total := new(big.Int)
+	for _, val := range set.fullnodes {
+		total.Add(total, set.Weight(val.Address()))
+	}
+	if total.Sign() == 0 {
+		return roundRobinSpeaker(fullnodes, speaker, round)
+	}
+	var r [8]byte
+	binary.BigEndian.PutUint64(r[:], round)
+	pick := new(big.Int).SetBytes(crypto.Keccak256(set.seed.Bytes(), r[:]))
+	pick.Mod(pick, total)
+	for _, val := range set.fullnodes {
+		pick.Sub(pick, set.Weight(val.Address()))
+		if pick.Sign() < 0 {
+			return val
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@
 package fullnode
 
 import (
+	"math/big"
 	"reflect"
 	"sort"
 
@@ -112,7 +113,10 @@ func (fullnodeSet *fullnodeSet) Copy() sport.FullnodeSet {
 	for _, v := range fullnodeSet.fullnodes {
 		addresses = append(addresses, v.Address())
 	}
-	return NewFullnodeSet(addresses, fullnodeSet.policy)
+	cpy := newFullnodeSet(addresses, fullnodeSet.policy)
+	cpy.weights = fullnodeSet.weights
+	cpy.seed = fullnodeSet.seed
+	return cpy
 }
 
 func (fullnodeSet *fullnodeSet) MaxFaulty() int {
@@ -126,3 +130,22 @@ func (fullnodeSet *fullnodeSet) MinApprovers() int {
 func (fullnodeSet *fullnodeSet) E() int { return 1 }
 
 func (fullnodeSet *fullnodeSet) Policy() sport.SpeakerPolicy { return fullnodeSet.policy }
+
+// SetWeights replaces the stake of the fullnodes, fullnodes missing from weights
+// are never picked by the StakeWeighted policy. The weights map is not copied and
+// must not be modified afterwards.
+func (fullnodeSet *fullnodeSet) SetWeights(weights map[common.Address]*big.Int, seed common.Hash) {
+	fullnodeSet.fullnodeMu.Lock()
+	defer fullnodeSet.fullnodeMu.Unlock()
+	fullnodeSet.weights = weights
+	fullnodeSet.seed = seed
+}
+
+func (fullnodeSet *fullnodeSet) Weight(address common.Address) *big.Int {
+	fullnodeSet.fullnodeMu.RLock()
+	defer fullnodeSet.fullnodeMu.RUnlock()
+	if weight, ok := fullnodeSet.weights[address]; ok && weight.Sign() > 0 {
+		return new(big.Int).Set(weight)
+	}
+	return new(big.Int)
+}
@@ -18,6 +18,7 @@
 package fullnode
 
 import (
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
@@ -176,3 +177,35 @@ func TestAddAndRemoveFullnode(t *testing.T) {
 	require.Len(t, fullnodeSet.List(), 0, "the size of fullnode set should be 0")
 
 }
+
+func TestStakeWeightedSpeaker(t *testing.T) {
+	addr1 := common.HexToAddress(testAddress)
+	addr2 := common.HexToAddress(testAddress2)
+	fullnodeSet := NewFullnodeSet([]common.Address{addr1, addr2}, sport.StakeWeighted)
+
+	// without weights the speaker is picked round robin
+	fullnodeSet.CalcSpeaker(common.Address{}, 1)
+	if _, val := fullnodeSet.GetByAddress(addr2); !reflect.DeepEqual(fullnodeSet.GetSpeaker(), val) {
+		t.Errorf("speaker mismatch without weights: have %v, want %v", fullnodeSet.GetSpeaker(), val)
+	}
+
+	fullnodeSet.SetWeights(map[common.Address]*big.Int{addr1: big.NewInt(3), addr2: big.NewInt(1)}, common.HexToHash("0x01"))
+	picks := make(map[common.Address]int)
+	for round := uint64(0); round < 1000; round++ {
+		fullnodeSet.CalcSpeaker(addr1, round)
+		picks[fullnodeSet.GetSpeaker().Address()]++
+	}
+	if picks[addr1] < 650 || picks[addr1] > 850 {
+		t.Errorf("speaker distribution mismatch: have %d/1000 picks for a 3/4 stake", picks[addr1])
+	}
+
+	// the pick only depends on the seed and the round, and survives copies
+	cpy := fullnodeSet.Copy()
+	for round := uint64(0); round < 10; round++ {
+		fullnodeSet.CalcSpeaker(addr1, round)
+		cpy.CalcSpeaker(addr2, round)
+		if fullnodeSet.GetSpeaker().Address() != cpy.GetSpeaker().Address() {
+			t.Fatalf("speaker mismatch at round %d: have %v, want %v", round, cpy.GetSpeaker(), fullnodeSet.GetSpeaker())
+		}
+	}
+}
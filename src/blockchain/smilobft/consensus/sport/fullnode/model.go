@@ -18,6 +18,7 @@
 package fullnode
 
 import (
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -50,6 +51,9 @@ type fullnodeSet struct {
 	speaker    sport.Fullnode
 	fullnodeMu sync.RWMutex
 	selector   sport.BlockProposalSelector
+
+	weights map[common.Address]*big.Int // stake of each fullnode for the StakeWeighted policy
+	seed    common.Hash                 // randomness of the block for the StakeWeighted policy
 }
 
 func NewFullnodeSet(addrs []common.Address, policy sport.SpeakerPolicy) sport.FullnodeSet {
@@ -72,7 +76,12 @@ func newFullnodeSet(addrs []common.Address, policy sport.SpeakerPolicy) *fullnod
 		fullnodeSet.speaker = fullnodeSet.GetByIndex(0)
 		log.Debug("newFullnodeSet, Going to set initial speaker, ", "new speaker", fullnodeSet.speaker.String())
 	}
-	fullnodeSet.selector = roundRobinSpeaker
+	switch policy {
+	case sport.StakeWeighted:
+		fullnodeSet.selector = stakeWeightedSpeaker
+	default:
+		fullnodeSet.selector = roundRobinSpeaker
+	}
 
 	return fullnodeSet
 }
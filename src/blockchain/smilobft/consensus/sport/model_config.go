@@ -22,6 +22,9 @@ type SpeakerPolicy uint64
 
 const (
 	RoundRobin SpeakerPolicy = iota
+	// StakeWeighted picks the speaker with a probability proportional to the
+	// balance each fullnode held at the start of the epoch
+	StakeWeighted
 )
 
 type Config struct {
@@ -34,6 +37,8 @@ type Config struct {
 	MinFunds             int64         `toml:",omitempty"` // The minimum funds a node should have to be a full node
 	CommunityAddress     string        `toml:",omitempty"` // The community address for miner donations
 	MinBlocksEmptyMining *big.Int      `toml:",omitempty"` // Min Blocks to mine before Stop Mining Empty Blocks
+	Governance           bool          `toml:",omitempty"` // Whether fullnodes are voted through the governance contract
+	Journal              string        `toml:",omitempty"` // The file the consensus messages are journaled to, empty disables it
 }
 
 var DefaultConfig = &Config{
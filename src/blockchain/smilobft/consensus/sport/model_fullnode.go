@@ -18,34 +18,18 @@
 package sport
 
 import (
-	"strings"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-)
-
-type Fullnode interface {
-	// Address returns address
-	Address() common.Address
-
-	// String representation of Fullnode
-	String() string
-}
-
-// ----------------------------------------------------------------------------
-
-type Fullnodes []Fullnode
 
-func (slice Fullnodes) Len() int {
-	return len(slice)
-}
-
-func (slice Fullnodes) Less(i, j int) bool {
-	return strings.Compare(slice[i].String(), slice[j].String()) < 0
-}
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+)
 
-func (slice Fullnodes) Swap(i, j int) {
-	slice[i], slice[j] = slice[j], slice[i]
-}
+// A fullnode is a validator of the shared BFT core
+type (
+	Fullnode  = bft.Validator
+	Fullnodes = bft.Validators
+)
 
 // ----------------------------------------------------------------------------
 
@@ -78,6 +62,10 @@ type FullnodeSet interface {
 	E() int
 	// Get speaker policy
 	Policy() SpeakerPolicy
+	// Set the stake of each fullnode and the seed of the block, used by the StakeWeighted policy
+	SetWeights(weights map[common.Address]*big.Int, seed common.Hash)
+	// Get the stake of the fullnode with given address
+	Weight(address common.Address) *big.Int
 }
 
 // ----------------------------------------------------------------------------
@@ -1,51 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package sport
-
-import (
-	"io"
-
-	"github.com/ethereum/go-ethereum/rlp"
-
-	"go-smilo/src/blockchain/smilobft/core/types"
-)
-
-type Preprepare struct {
-	View          *View
-	BlockProposal BlockProposal
-}
-
-// EncodeRLP serializes b into the Ethereum RLP format.
-func (b *Preprepare) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{b.View, b.BlockProposal})
-}
-
-// DecodeRLP implements rlp.Decoder, and load the consensus fields from a RLP stream.
-func (b *Preprepare) DecodeRLP(s *rlp.Stream) error {
-	var preprepare struct {
-		View  *View
-		Block *types.Block
-	}
-
-	if err := s.Decode(&preprepare); err != nil {
-		return err
-	}
-	b.View, b.BlockProposal = preprepare.View, preprepare.Block
-
-	return nil
-}
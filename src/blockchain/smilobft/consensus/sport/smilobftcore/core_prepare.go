@@ -1,108 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"reflect"
-
-	"go-smilo/src/blockchain/smilobft/consensus/sport"
-)
-
-func (c *core) sendPrepare() {
-	logger := c.logger.New("state", c.state)
-
-	sub := c.current.Subject()
-	encodedSubject, err := Encode(sub)
-	if err != nil {
-		logger.Error("Failed to encode", "subject", sub)
-		return
-	}
-	c.broadcast(&message{
-		Code: msgPrepare,
-		Msg:  encodedSubject,
-	})
-}
-
-func (c *core) handlePrepare(msg *message, src sport.Fullnode) error {
-	// Decode PREPARE message
-	var prepare *sport.Subject
-	err := msg.Decode(&prepare)
-	if err != nil {
-		return errFailedDecodePrepare
-	}
-
-	if err := c.checkMessage(msgPrepare, prepare.View); err != nil {
-		return err
-	}
-
-	// If it is locked, it can only process on the locked block.
-	// Passing verifyPrepare and checkMessage implies it is processing on the locked block since it was verified in the Preprepared state.
-	if err := c.verifyPrepare(prepare, src); err != nil {
-		return err
-	}
-
-	c.acceptPrepare(msg, src)
-
-	// Change to Prepared state if we've received enough PREPARE messages or it is locked
-	// and we are in earlier state before Prepared state.
-	// 2F+E
-	minApprovers := c.fullnodeSet.MinApprovers()
-
-	if ((c.current.IsHashLocked() && prepare.Digest == c.current.GetLockedHash()) || c.current.GetPrepareOrCommitSize() >= minApprovers) &&
-		c.state.Cmp(StatePrepared) < 0 {
-		c.logger.Debug("66% CONSENSUS REACHED!!!", "Required Votes:", c.fullnodeSet.MinApprovers(), "Total Votes:", c.current.GetPrepareOrCommitSize())
-		c.current.LockHash()
-		c.setState(StatePrepared)
-		c.sendCommit()
-	} else {
-		c.logger.Debug("66% CONSENSUS NOT REACHED YET!!!",
-			"Required Votes: ", minApprovers,
-			"Total Votes:", c.current.GetPrepareOrCommitSize(),
-			"IsHashLocked", c.current.IsHashLocked(),
-			"prepare.Digest", prepare.Digest,
-			"LockedHash", c.current.GetLockedHash(),
-		)
-	}
-
-	return nil
-}
-
-// verifyPrepare verifies if the received PREPARE message is equivalent to our subject
-func (c *core) verifyPrepare(prepare *sport.Subject, src sport.Fullnode) error {
-	logger := c.logger.New("from", src, "state", c.state)
-
-	sub := c.current.Subject()
-	if !reflect.DeepEqual(prepare, sub) {
-		logger.Warn("Inconsistent subjects between PREPARE and proposal", "expected", sub, "got", prepare)
-		return errInconsistentSubject
-	}
-
-	return nil
-}
-
-func (c *core) acceptPrepare(msg *message, src sport.Fullnode) error {
-	logger := c.logger.New("from", src, "state", c.state)
-
-	// Add the PREPARE message to current round state
-	if err := c.current.Prepares.Add(msg); err != nil {
-		logger.Error("Failed to add PREPARE message to round state", "msg", msg, "err", err)
-		return err
-	}
-
-	return nil
-}
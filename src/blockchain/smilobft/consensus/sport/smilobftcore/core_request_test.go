@@ -1,141 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"math/big"
-	"reflect"
-	"sync"
-	"testing"
-	"time"
-
-	"go-smilo/src/blockchain/smilobft/cmn"
-
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/log"
-	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
-
-	"go-smilo/src/blockchain/smilobft/consensus/sport"
-)
-
-func TestCheckRequestMsg(t *testing.T) {
-	c := &core{
-		state: StateAcceptRequest,
-		current: newRoundState(&sport.View{
-			Sequence: big.NewInt(1),
-			Round:    big.NewInt(0),
-		}, newTestFullnodeSet(4), common.Hash{}, nil, nil, nil),
-	}
-
-	// invalid request
-	err := c.checkRequestMsg(nil)
-	if err != errInvalidMessage {
-		t.Errorf("error mismatch: have %v, want %v", err, errInvalidMessage)
-	}
-	r := &sport.Request{
-		BlockProposal: nil,
-	}
-	err = c.checkRequestMsg(r)
-	if err != errInvalidMessage {
-		t.Errorf("error mismatch: have %v, want %v", err, errInvalidMessage)
-	}
-
-	// old request
-	r = &sport.Request{
-		BlockProposal: makeBlock(0),
-	}
-	err = c.checkRequestMsg(r)
-	if err != errOldMessage {
-		t.Errorf("error mismatch: have %v, want %v", err, errOldMessage)
-	}
-
-	// future request
-	r = &sport.Request{
-		BlockProposal: makeBlock(2),
-	}
-	err = c.checkRequestMsg(r)
-	if err != errFutureMessage {
-		t.Errorf("error mismatch: have %v, want %v", err, errFutureMessage)
-	}
-
-	// current request
-	r = &sport.Request{
-		BlockProposal: makeBlock(1),
-	}
-	err = c.checkRequestMsg(r)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-}
-
-func TestStoreRequestMsg(t *testing.T) {
-	backend := &testSystemBackend{
-		events: new(cmn.TypeMux),
-	}
-	c := &core{
-		logger:  log.New("backend", "test", "id", 0),
-		backend: backend,
-		state:   StateAcceptRequest,
-		current: newRoundState(&sport.View{
-			Sequence: big.NewInt(0),
-			Round:    big.NewInt(0),
-		}, newTestFullnodeSet(4), common.Hash{}, nil, nil, nil),
-		pendingRequests:   prque.New(),
-		pendingRequestsMu: new(sync.Mutex),
-	}
-	requests := []sport.Request{
-		{
-			BlockProposal: makeBlock(1),
-		},
-		{
-			BlockProposal: makeBlock(2),
-		},
-		{
-			BlockProposal: makeBlock(3),
-		},
-	}
-
-	c.storeRequestMsg(&requests[1])
-	c.storeRequestMsg(&requests[0])
-	c.storeRequestMsg(&requests[2])
-	if c.pendingRequests.Size() != len(requests) {
-		t.Errorf("the size of pending requests mismatch: have %v, want %v", c.pendingRequests.Size(), len(requests))
-	}
-
-	c.current.sequence = big.NewInt(3)
-
-	c.subscribeEvents()
-	defer c.unsubscribeEvents()
-
-	c.processPendingRequests()
-
-	const timeoutDura = 2 * time.Second
-	timeout := time.NewTimer(timeoutDura)
-	select {
-	case ev := <-c.events.Chan():
-		e, ok := ev.Data.(sport.RequestEvent)
-		if !ok {
-			t.Errorf("unexpected event comes: %v", reflect.TypeOf(ev.Data))
-		}
-		if e.BlockProposal.Number().Cmp(requests[2].BlockProposal.Number()) != 0 {
-			t.Errorf("the number of proposal mismatch: have %v, want %v", e.BlockProposal.Number(), requests[2].BlockProposal.Number())
-		}
-	case <-timeout.C:
-		t.Error("unexpected timeout occurs")
-	}
-}
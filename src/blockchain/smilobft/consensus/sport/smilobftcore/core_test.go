@@ -1,59 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"reflect"
-	"testing"
-	"time"
-
-	elog "github.com/ethereum/go-ethereum/log"
-)
-
-func TestNewRequest(t *testing.T) {
-	testLogger.SetHandler(elog.StdoutHandler)
-
-	N := 4
-
-	sys := NewTestSystemWithBackend(N)
-
-	closeTest := sys.Run(true)
-	defer closeTest()
-
-	request1 := makeBlock(1)
-	sys.backends[0].NewRequest(request1)
-
-	<-time.After(1 * time.Second)
-
-	request2 := makeBlock(2)
-	sys.backends[0].NewRequest(request2)
-
-	<-time.After(1 * time.Second)
-
-	for _, backend := range sys.backends {
-		if len(backend.committedMsgs) != 2 {
-			t.Errorf("the number of executed requests mismatch: have %v, want 2", len(backend.committedMsgs))
-		}
-		if !reflect.DeepEqual(request1.Number(), backend.committedMsgs[0].commitBlockProposal.Number()) {
-			t.Errorf("the number of requests mismatch: have %v, want %v", request1.Number(), backend.committedMsgs[0].commitBlockProposal.Number())
-		}
-		if !reflect.DeepEqual(request2.Number(), backend.committedMsgs[1].commitBlockProposal.Number()) {
-			t.Errorf("the number of requests mismatch: have %v, want %v", request2.Number(), backend.committedMsgs[1].commitBlockProposal.Number())
-		}
-	}
-}
@@ -1,193 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"io"
-	"math/big"
-	"sync"
-
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rlp"
-
-	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/sport"
-)
-
-func (s *roundState) GetPrepareOrCommitSize() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	result := s.Prepares.Size() + s.Commits.Size()
-
-	// find duplicate one
-	for _, m := range s.Prepares.Values() {
-		if s.Commits.Get(m.Address) != nil {
-			result--
-		}
-	}
-	return result
-}
-
-func (s *roundState) Subject() *sport.Subject {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	if s.Preprepare == nil {
-		return nil
-	}
-
-	return &sport.Subject{
-		View: &sport.View{
-			Round:    new(big.Int).Set(s.round),
-			Sequence: new(big.Int).Set(s.sequence),
-		},
-		Digest: s.Preprepare.BlockProposal.Hash(),
-	}
-}
-
-func (s *roundState) SetPreprepare(preprepare *sport.Preprepare) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	//SPORT:5
-	s.Preprepare = preprepare
-}
-
-func (s *roundState) BlockProposal() sport.BlockProposal {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	if s.Preprepare != nil {
-		return s.Preprepare.BlockProposal
-	}
-
-	return nil
-}
-
-func (s *roundState) SetRound(r *big.Int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.round = new(big.Int).Set(r)
-}
-
-func (s *roundState) Round() *big.Int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return s.round
-}
-
-func (s *roundState) SetSequence(seq *big.Int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.sequence = seq
-}
-
-func (s *roundState) Sequence() *big.Int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return s.sequence
-}
-
-func (s *roundState) LockHash() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if s.Preprepare != nil {
-		s.lockedHash = s.Preprepare.BlockProposal.Hash()
-	}
-}
-
-func (s *roundState) UnlockHash() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.lockedHash = common.Hash{}
-}
-
-func (s *roundState) IsHashLocked() bool {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	if cmn.EmptyHash(s.lockedHash) {
-		return false
-	}
-	return !s.hasBadBlockProposal(s.GetLockedHash())
-}
-
-func (s *roundState) GetLockedHash() common.Hash {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return s.lockedHash
-}
-
-// The DecodeRLP method should read one value from the given
-// Stream. It is not forbidden to read less or more, but it might
-// be confusing.
-func (s *roundState) DecodeRLP(stream *rlp.Stream) error {
-	var ss struct {
-		Round          *big.Int
-		Sequence       *big.Int
-		Preprepare     *sport.Preprepare
-		Prepares       *messageSet
-		Commits        *messageSet
-		lockedHash     common.Hash
-		pendingRequest *sport.Request
-	}
-
-	if err := stream.Decode(&ss); err != nil {
-		return err
-	}
-	s.round = ss.Round
-	s.sequence = ss.Sequence
-	s.Preprepare = ss.Preprepare
-	s.Prepares = ss.Prepares
-	s.Commits = ss.Commits
-	s.lockedHash = ss.lockedHash
-	s.pendingRequest = ss.pendingRequest
-	s.mu = new(sync.RWMutex)
-
-	return nil
-}
-
-// EncodeRLP should write the RLP encoding of its receiver to w.
-// If the implementation is a pointer method, it may also be
-// called for nil pointers.
-//
-// Implementations should generate valid RLP. The data written is
-// not verified at the moment, but a future version might. It is
-// recommended to write only a single value but writing multiple
-// values or no value at all is also permitted.
-func (s *roundState) EncodeRLP(w io.Writer) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return rlp.Encode(w, []interface{}{
-		s.round,
-		s.sequence,
-		s.Preprepare,
-		s.Prepares,
-		s.Commits,
-		s.lockedHash,
-		s.pendingRequest,
-	})
-}
@@ -21,6 +21,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
+	"go-smilo/src/blockchain/smilobft/params"
 	"go-smilo/src/blockchain/smilobft/rpc"
 )
 
@@ -69,3 +71,18 @@ func (api *API) GetContractAddress() common.Address {
 func (api *API) GetWhitelist() []string {
 	return api.smilo.WhiteList()
 }
+
+// GetRewardSchedule retrieves the block reward schedule of the chain.
+func (api *API) GetRewardSchedule() *params.RewardSchedule {
+	return api.smilo.rewardSchedule(api.chain.Config())
+}
+
+// GetIssuance projects the rewards minted by a given, possibly future, block and the
+// issuance scheduled from genesis up to it, assuming every block is rewarded.
+func (api *API) GetIssuance(number *rpc.BlockNumber) *rewards.Issuance {
+	schedule := api.smilo.rewardSchedule(api.chain.Config())
+	if number == nil || *number < 0 {
+		return rewards.NewIssuance(schedule, api.chain.CurrentHeader().Number.Uint64())
+	}
+	return rewards.NewIssuance(schedule, uint64(number.Int64()))
+}
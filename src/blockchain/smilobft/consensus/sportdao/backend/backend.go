@@ -31,7 +31,8 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
-	"go-smilo/src/blockchain/smilobft/consensus/sportdao/smilobftcore"
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 )
@@ -68,7 +69,12 @@ func New(config *sportdao.Config, privateKey *ecdsa.PrivateKey, db ethdb.Databas
 		knownMessages:    knownMessages,
 		vmConfig:         vmConfig,
 	}
-	backend.core = smilobftcore.New(backend, backend.config)
+	backend.core = bftCore.New(bftBackend{backend}, &bft.Config{
+		Engine:         "sportdao",
+		RequestTimeout: config.RequestTimeout,
+		MaxTimeout:     config.MaxTimeout,
+		Journal:        config.Journal,
+	})
 	return backend
 }
 
@@ -38,6 +38,8 @@ import (
 	"github.com/orinocopay/go-etherutils"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
 	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
@@ -210,6 +212,10 @@ func (sb *Backend) verifyCascadingFields(chain consensus.ChainReader, header *ty
 		return err
 	}
 
+	if err := sb.verifyParentCommittedSeals(chain, header, parents); err != nil {
+		return err
+	}
+
 	return sb.verifyCommittedSeals(chain, header, parents)
 }
 
@@ -305,6 +311,20 @@ func (sb *Backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	}
 	header.Extra = extra
 
+	// carry the committed seals of the parent, they record which fullnodes signed it
+	var parentSeals [][]byte
+	if chain.Config().IsLiveness(header.Number) && number > 1 {
+		parentExtra, err := types.ExtractSportExtra(parent)
+		if err != nil {
+			return err
+		}
+		parentSeals = parentExtra.CommittedSeal
+	}
+	if err := writeParentCommittedSeals(header, parentSeals); err != nil {
+		log.Error("Could not add the parent committed seals to extraData.", "err", err)
+		return err
+	}
+
 	// set header's timestamp
 	header.Time = parent.Time + sb.config.BlockPeriod
 	if int64(header.Time) < time.Now().Unix() {
@@ -332,17 +352,31 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 	}
 
 	// add validators to extraData's validators section
-	if header.Extra, err = types.PrepareExtra(header.Extra, validators); err != nil {
+	if header.Extra, err = prepareExtra(header, validators); err != nil {
 		return nil, err
 	}
 
+	// jail the validators that stopped signing blocks, the parent committed seals
+	// were verified with the header
+	ac := sb.blockchain.GetAutonityContract()
+	if ac != nil && header.Number.Uint64() > 1 && chain.Config().IsLiveness(header.Number) {
+		signers, missed, err := sb.parentSigners(chain, header, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err = ac.RecordSignatures(header, state, signers, missed); err != nil {
+			log.Error("finalize. after RecordSignatures", "err", err.Error())
+			return nil, err
+		}
+	}
+
 	// warn for empty blocks
 	number := header.Number.Int64()
 
 	//Will generate rewards for every block until block 40000000
 	//From this point on, ddd block rewards in Sport only if there is transactions in it
 	if header.Number.Cmp(big.NewInt(1)) > 0 && len(txs) > 0 || number < 40000000 {
-		AccumulateRewards(sb.config.CommunityAddress, state, header)
+		rewards.Accumulate(sb.rewardSchedule(chain.Config()), state, header)
 	}
 
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
@@ -407,7 +441,7 @@ func (sb *Backend) Seal(chain consensus.ChainReader, block *types.Block, stop <-
 	// post block into Sport engine
 	go func() {
 		requestEvent := sportdao.RequestEvent{
-			BlockProposal: block,
+			Proposal: block,
 		}
 		err := sb.EventMux().Post(requestEvent)
 		if err != nil {
@@ -441,6 +475,11 @@ func (sb *Backend) APIs(chain consensus.ChainReader) []rpc.API {
 		Version:   "1.0",
 		Service:   &API{chain: chain, smilo: sb},
 		Public:    true,
+	}, {
+		Namespace: "sportdao",
+		Version:   "1.0",
+		Service:   health.NewAPI(sb.core.Status),
+		Public:    true,
 	}}
 }
 
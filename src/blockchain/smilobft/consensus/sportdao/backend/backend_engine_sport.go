@@ -32,10 +32,12 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
+	"go-smilo/src/blockchain/smilobft/consensus/rewards"
 	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
-	"go-smilo/src/blockchain/smilobft/consensus/sportdao/smilobftcore"
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
 )
 
 // SetProposedBlockHash will set the proposed hash into the backend
@@ -96,7 +98,7 @@ func (sb *Backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 
 	// Check whether the committed seals are generated by parent's fullnodes
 	validSeal := 0
-	proposalSeal := smilobftcore.PrepareCommittedSeal(header.Hash())
+	proposalSeal := bftCore.PrepareCommittedSeal(header.Hash())
 	// 1. Get committed seals from current header
 	for _, seal := range extra.CommittedSeal {
 		// 2. Get the original address by seal and parent block hash
@@ -127,27 +129,89 @@ func (sb *Backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	return nil
 }
 
-// AccumulateRewards (override from ethash) credits the coinbase of the given block with the mining reward.
-// The total reward consists of the static block reward and rewards for  the community.
-func AccumulateRewards(communityAddress string, state *state.StateDB, header *types.Header) {
-	// add reward based on chain progression
-	blockReward := getSmiloBlockReward(header.Number)
+// verifyParentCommittedSeals checks the parent committed seals carried by the header. From the
+// liveness fork block on they must be signed by distinct fullnodes of the parent block and reach
+// the quorum its own committed seals are checked against, before it they must be absent.
+func (sb *Backend) verifyParentCommittedSeals(chain consensus.ChainReader, header *types.Header, parents []*types.Header) error {
+	number := header.Number.Uint64()
+	// The genesis block has no committed seals
+	if number <= 1 || !chain.Config().IsLiveness(header.Number) {
+		extra, err := types.ExtractSportExtra(header)
+		if err != nil {
+			return err
+		}
+		if len(extra.ParentCommittedSeal) > 0 {
+			return errInvalidCommittedSeals
+		}
+		return nil
+	}
 
-	// Accumulate the rewards
-	reward := new(big.Int).Set(blockReward)
-	emptryAddress := common.Address{}
-	if header.Coinbase != emptryAddress {
+	signers, missed, err := sb.parentSigners(chain, header, parents)
+	if err != nil {
+		return err
+	}
+	// The speaker may leave out the seals above the quorum, those fullnodes are recorded as missing
+	// the parent. Seals below it would let the speaker record most of the fullnodes as missing.
+	minApprovers := fullnode.NewSet(missed, sb.config.GetProposerPolicy()).MinApprovers()
+	if len(signers) < minApprovers {
+		if new(big.Int).SetUint64(number-1).Cmp(chain.Config().SixtySixPercentBlock) > 0 || len(signers) < minApprovers-1 {
+			sb.logger.Error("The parent committed seals are less than the number of 2x faulty nodes", "number", number, "signers", len(signers), "MinApprovers", minApprovers)
+			return errInvalidCommittedSeals
+		}
+	}
+	return nil
+}
 
-		log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, block: ", "blockNum", header.Number.Int64(), "BlockReward", blockReward.Int64(), "Coinbase", header.Coinbase.Hex())
+// parentSigners returns the fullnodes of the parent of the header whose committed seals are
+// carried by the header, and the fullnodes of the parent without one.
+func (sb *Backend) parentSigners(chain consensus.ChainReader, header *types.Header, parents []*types.Header) ([]common.Address, []common.Address, error) {
+	var parent *types.Header
+	if len(parents) > 0 {
+		parent, parents = parents[len(parents)-1], parents[:len(parents)-1]
+	} else {
+		parent = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	if parent == nil {
+		return nil, nil, consensus.ErrUnknownAncestor
+	}
+	fullnodeAddresses, err := sb.retrieveValidators(parent, parents, chain)
+	if err != nil {
+		return nil, nil, err
+	}
+	fullnodes := fullnode.NewSet(fullnodeAddresses, sb.config.GetProposerPolicy())
 
-		// Accumulate the rewards to community
-		if communityAddress != "" {
-			rewardForCommunity := new(big.Int).Div(blockReward, big.NewInt(4))
-			state.AddBalance(common.HexToAddress(communityAddress), rewardForCommunity, header.Number)
-			log.Info("$$$$$$$$$$$$$$$$$$$$$ AccumulateRewards, adding reward to community ", "rewardForCommunity", rewardForCommunity, "communityAddress", communityAddress)
+	extra, err := types.ExtractSportExtra(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalSeal := bftCore.PrepareCommittedSeal(parent.Hash())
+	signers := make([]common.Address, 0, len(extra.ParentCommittedSeal))
+	for _, seal := range extra.ParentCommittedSeal {
+		addr, err := types.GetSignatureAddress(proposalSeal, seal)
+		if err != nil {
+			return nil, nil, types.ErrInvalidSignature
 		}
-		state.AddBalance(header.Coinbase, reward, header.Number)
+		// Every fullnode can have only one seal
+		if !fullnodes.RemoveFullnode(addr) {
+			return nil, nil, errInvalidCommittedSeals
+		}
+		signers = append(signers, addr)
+	}
+	missed := make([]common.Address, 0, fullnodes.Size())
+	for _, fullnode := range fullnodes.List() {
+		missed = append(missed, fullnode.Address())
 	}
+	return signers, missed, nil
+}
+
+// rewardSchedule returns the reward schedule declared in the chain config, or the
+// built-in Smilo schedule if there is none.
+func (sb *Backend) rewardSchedule(config *params.ChainConfig) *params.RewardSchedule {
+	var declared *params.RewardSchedule
+	if config.SportDAO != nil {
+		declared = config.SportDAO.Rewards
+	}
+	return rewards.Schedule(declared, smiloTokenMetricsTable, sb.config.CommunityAddress)
 }
 
 func getSmiloBlockReward(blockNum *big.Int) (blockReward *big.Int) {
@@ -171,7 +235,7 @@ func (sb *Backend) updateBlock(block *types.Block) (*types.Block, error) {
 		return nil, err
 	}
 
-	err = types.WriteSeal(header, seal)
+	err = writeSeal(header, seal)
 	if err != nil {
 		return nil, err
 	}
@@ -237,6 +301,10 @@ func prepareExtra(header *types.Header, vals []common.Address) ([]byte, error) {
 		Seal:          []byte{},
 		CommittedSeal: [][]byte{},
 	}
+	// the parent committed seals are kept when the fullnodes are written on Finalize
+	if extra, err := types.ExtractSportExtra(header); err == nil {
+		ist.ParentCommittedSeal = extra.ParentCommittedSeal
+	}
 
 	payload, err := rlp.EncodeToBytes(&ist)
 	if err != nil {
@@ -344,11 +412,36 @@ func writeCommittedSeals(h *types.Header, committedSeals [][]byte) error {
 	return nil
 }
 
+// writeParentCommittedSeals writes the extra-data field of a block header with the committed seals of its parent.
+func writeParentCommittedSeals(h *types.Header, parentSeals [][]byte) error {
+	for _, seal := range parentSeals {
+		if len(seal) != types.BFTExtraSeal {
+			return errInvalidCommittedSeals
+		}
+	}
+
+	sportExtra, err := types.ExtractSportExtra(h)
+	if err != nil {
+		return err
+	}
+
+	sportExtra.ParentCommittedSeal = make([][]byte, len(parentSeals))
+	copy(sportExtra.ParentCommittedSeal, parentSeals)
+
+	payload, err := rlp.EncodeToBytes(&sportExtra)
+	if err != nil {
+		return err
+	}
+
+	h.Extra = append(h.Extra[:types.SportExtraVanity], payload...)
+	return nil
+}
+
 func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainReader, state *state.StateDB) ([]common.Address, error) {
 	var validators []common.Address
 
-	if header.Number.Int64() == 1 {
-		log.Warn("Autonity Contract Deployer, header.Number.Int64() == 1", "Address", chain.Config().AutonityContractConfig.Deployer)
+	if sb.blockchain.GetAutonityContract().NeedsDeployment(chain, header, state) {
+		log.Warn("Autonity Contract Deployer, deploying", "Address", chain.Config().AutonityContractConfig.Deployer)
 
 		sb.blockchain.GetAutonityContract().SavedValidatorsRetriever = func(i uint64) (addresses []common.Address, e error) {
 			chain := chain
@@ -360,7 +453,7 @@ func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainRead
 			return nil, err
 		}
 		sb.autonityContractAddress = contractAddress
-		validators, err = sb.retrieveSavedValidators(1, chain)
+		validators, err = sb.retrieveSavedValidators(header.Number.Uint64(), chain)
 		if err != nil {
 			return nil, err
 		}
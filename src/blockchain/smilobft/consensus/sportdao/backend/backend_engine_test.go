@@ -569,3 +569,98 @@ func TestValidatorsSaved(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestParentCommittedSeals(t *testing.T) {
+	chain, engine, err := newBlockChain(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the test chain config is shared, the first block with a sealed parent is the liveness fork
+	chain.Config().LivenessBlock = big.NewInt(2)
+	defer func() { chain.Config().LivenessBlock = nil }()
+
+	parent, err := makeBlock(chain, engine, chain.Genesis())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(types.Blocks{parent}); err != nil {
+		t.Fatal(err)
+	}
+	parentExtra, err := types.ExtractSportExtra(parent.Header())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := makeHeader(parent, engine.config)
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatal(err)
+	}
+	extra, err := types.ExtractSportExtra(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(extra.ParentCommittedSeal, parentExtra.CommittedSeal) {
+		t.Errorf("parent committed seals mismatch: have %v, want %v", extra.ParentCommittedSeal, parentExtra.CommittedSeal)
+	}
+	signers, missed, err := engine.parentSigners(chain, header, nil)
+	if err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if !reflect.DeepEqual(signers, []common.Address{engine.Address()}) || len(missed) != 0 {
+		t.Errorf("signers mismatch: have %v %v, want %v []", signers, missed, engine.Address())
+	}
+	if err := engine.verifyParentCommittedSeals(chain, header, nil); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+
+	// the parent committed seals are covered by the block hash
+	hash := header.Hash()
+	if err := writeParentCommittedSeals(header, nil); err != nil {
+		t.Fatal(err)
+	}
+	if header.Hash() == hash {
+		t.Error("block hash doesn't cover the parent committed seals")
+	}
+	signers, missed, err = engine.parentSigners(chain, header, nil)
+	if err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if len(signers) != 0 || !reflect.DeepEqual(missed, []common.Address{engine.Address()}) {
+		t.Errorf("signers mismatch: have %v %v, want [] %v", signers, missed, engine.Address())
+	}
+	// the parent quorum must be carried from the liveness fork block on
+	if err := engine.verifyParentCommittedSeals(chain, header, nil); err != errInvalidCommittedSeals {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidCommittedSeals)
+	}
+
+	// a seal of another block
+	seal, err := engine.Sign([]byte("1234567890"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeParentCommittedSeals(header, [][]byte{seal}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.verifyParentCommittedSeals(chain, header, nil); err != errInvalidCommittedSeals {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidCommittedSeals)
+	}
+
+	// a header before the liveness fork block must not carry them
+	if err := writeParentCommittedSeals(header, parentExtra.CommittedSeal); err != nil {
+		t.Fatal(err)
+	}
+	chain.Config().LivenessBlock = big.NewInt(3)
+	if err := engine.verifyParentCommittedSeals(chain, header, nil); err != errInvalidCommittedSeals {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidCommittedSeals)
+	}
+
+	// the signers are recorded by the default contract on Finalize
+	chain.Config().LivenessBlock = big.NewInt(2)
+	state, _, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.Finalize(chain, header, state, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	"go-smilo/src/blockchain/smilobft/cmn"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 func TestBackendHandler(t *testing.T) {
@@ -53,7 +54,7 @@ func TestBackendHandler(t *testing.T) {
 	}
 
 	// 2. this message should be in cache after we handle it
-	_, err = backend.HandleMsg(addr, msg)
+	_, err = backend.HandleMsg(addr, msg, enode.ID{})
 	if err != nil {
 		t.Fatalf("handle message failed: %v", err)
 	}
@@ -81,7 +82,7 @@ func TestHandleNewBlockMessage_whenTypical(t *testing.T) {
 	arbitraryBlock, arbitraryP2PMessage := buildArbitraryP2PNewBlockMessage(t, false)
 	postAndWait(backend, arbitraryBlock, t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -107,7 +108,7 @@ func TestHandleNewBlockMessage_whenNotAProposedBlock(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
@@ -132,7 +133,7 @@ func TestHandleNewBlockMessage_whenFailToDecode(t *testing.T) {
 		MixDigest: types.SportDigest,
 	}, nil, nil, nil), t)
 
-	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage)
+	handled, err := backend.HandleMsg(arbitraryAddress, arbitraryP2PMessage, enode.ID{})
 
 	if err != nil {
 		t.Errorf("expected message being handled successfully but got %s", err)
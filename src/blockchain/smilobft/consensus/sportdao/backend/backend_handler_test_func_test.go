@@ -23,7 +23,7 @@ func postAndWait(backend *Backend, block *types.Block, t *testing.T) {
 	}
 	go eventLoop()
 	if err := backend.EventMux().Post(sportdao.RequestEvent{
-		BlockProposal: block,
+		Proposal: block,
 	}); err != nil {
 		t.Fatalf("%s", err)
 	}
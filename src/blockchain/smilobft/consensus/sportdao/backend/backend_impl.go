@@ -35,7 +35,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/core/types"
 )
 
-// Fullnodes implements sportdao.Backend.Fullnodes
+// Fullnodes returns the fullnode set of the given block height
 func (sb *Backend) Fullnodes(number uint64) sportdao.FullnodeSet {
 	proposerPolicy := sb.config.GetProposerPolicy()
 	validators, err := sb.retrieveSavedValidators(number, sb.chain)
@@ -45,7 +45,7 @@ func (sb *Backend) Fullnodes(number uint64) sportdao.FullnodeSet {
 	return fullnode.NewSet(validators, proposerPolicy)
 }
 
-// Broadcast implements sportdao.Backend.Broadcast
+// Broadcast sends a message to all fullnodes (include self)
 func (sb *Backend) Broadcast(fullnodeSet sportdao.FullnodeSet, payload []byte) error {
 	// send to others
 	err := sb.Gossip(fullnodeSet, payload)
@@ -67,7 +67,7 @@ func (sb *Backend) Broadcast(fullnodeSet sportdao.FullnodeSet, payload []byte) e
 	return nil
 }
 
-// Gossip implements sportdao.Backend.Gossip
+// Gossip sends a message to all fullnodes (exclude self)
 func (sb *Backend) Gossip(fullnodeSet sportdao.FullnodeSet, payload []byte) error {
 	hash := types.RLPHash(payload)
 	sb.knownMessages.Add(hash, true)
@@ -113,7 +113,8 @@ func (sb *Backend) Gossip(fullnodeSet sportdao.FullnodeSet, payload []byte) erro
 	return nil
 }
 
-// Commit implements sportdao.Backend.Commit
+// Commit delivers an approved proposal to backend.
+// The delivered proposal will be put into blockchain.
 func (sb *Backend) Commit(proposal sportdao.BlockProposal, seals [][]byte) error {
 	// Check if the proposal is a valid block
 	block := &types.Block{}
@@ -125,7 +126,7 @@ func (sb *Backend) Commit(proposal sportdao.BlockProposal, seals [][]byte) error
 
 	h := block.Header()
 	// Append seals into extra-data
-	err := types.WriteCommittedSeals(h, seals)
+	err := writeCommittedSeals(h, seals)
 	if err != nil {
 		return err
 	}
@@ -156,12 +157,13 @@ func (sb *Backend) Commit(proposal sportdao.BlockProposal, seals [][]byte) error
 	return nil
 }
 
-// EventMux implements sportdao.Backend.EventMux
+// EventMux returns the event mux in backend
 func (sb *Backend) EventMux() *cmn.TypeMux {
 	return sb.smilobftEventMux
 }
 
-// Verify implements sportdao.Backend.Verify
+// Verify verifies the proposal. If a consensus.ErrFutureBlock error is returned,
+// the time difference of the proposal and current time is also returned.
 func (sb *Backend) Verify(proposal sportdao.BlockProposal) (time.Duration, error) {
 	// Check if the proposal is a valid block
 	block := &types.Block{}
@@ -219,7 +221,9 @@ func (sb *Backend) Verify(proposal sportdao.BlockProposal) (time.Duration, error
 				}
 			}
 
-			validators, err = sb.blockchain.GetAutonityContract().ContractGetValidators(sb.blockchain, header, state)
+			// the contract is deployed on the first block of a consensus
+			// transition, as in Finalize
+			validators, err = sb.getValidators(header, sb.blockchain, state)
 			if err != nil {
 				return 0, err
 			}
@@ -282,13 +286,14 @@ func (sb *Backend) Verify(proposal sportdao.BlockProposal) (time.Duration, error
 	return 0, err
 }
 
-// Sign implements sportdao.Backend.Sign
+// Sign signs input data with the backend's private key
 func (sb *Backend) Sign(data []byte) ([]byte, error) {
 	hashData := crypto.Keccak256(data)
 	return crypto.Sign(hashData, sb.privateKey)
 }
 
-// CheckSignature implements sportdao.Backend.CheckSignature
+// CheckSignature verifies the signature by checking if it's signed by
+// the given fullnode
 func (sb *Backend) CheckSignature(data []byte, address common.Address, sig []byte) error {
 	signer, err := types.GetSignatureAddress(data, sig)
 	if err != nil {
@@ -302,12 +307,12 @@ func (sb *Backend) CheckSignature(data []byte, address common.Address, sig []byt
 	return nil
 }
 
-// HasBlockProposal implements sportdao.Backend.HashBlock
+// HasBlockProposal checks if the combination of the given hash and height matches any existing blocks
 func (sb *Backend) HasBlockProposal(hash common.Hash, number *big.Int) bool {
 	return sb.blockchain.GetHeader(hash, number.Uint64()) != nil
 }
 
-// GetSpeaker implements sportdao.Backend.GetSpeaker
+// GetSpeaker returns the speaker of the given block height
 func (sb *Backend) GetSpeaker(number uint64) common.Address {
 	if h := sb.blockchain.GetHeaderByNumber(number); h != nil {
 		a, _ := sb.Author(h)
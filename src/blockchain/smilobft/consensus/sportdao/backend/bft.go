@@ -0,0 +1,99 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus/bft"
+	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
+)
+
+// bftBackend adapts the SportDAO backend to the shared BFT core, the fullnodes
+// saved from the Autonity contract are the validator source of the core.
+type bftBackend struct {
+	*Backend
+}
+
+func (b bftBackend) Validators(number uint64) bft.ValidatorSet {
+	return validatorSet{b.Fullnodes(number)}
+}
+
+func (b bftBackend) Broadcast(valSet bft.ValidatorSet, payload []byte) error {
+	return b.Backend.Broadcast(valSet.(validatorSet).FullnodeSet, payload)
+}
+
+func (b bftBackend) Gossip(valSet bft.ValidatorSet, payload []byte) error {
+	return b.Backend.Gossip(valSet.(validatorSet).FullnodeSet, payload)
+}
+
+func (b bftBackend) LastProposal() (bft.Proposal, common.Address) {
+	return b.LastBlockProposal()
+}
+
+func (b bftBackend) HasPropsal(hash common.Hash, number *big.Int) bool {
+	return b.HasBlockProposal(hash, number)
+}
+
+func (b bftBackend) GetProposer(number uint64) common.Address {
+	return b.GetSpeaker(number)
+}
+
+func (b bftBackend) HasBadProposal(hash common.Hash) bool {
+	return b.HasBadBlockProposal(hash)
+}
+
+// validatorSet adapts a fullnode set to bft.ValidatorSet, the speaker is the
+// proposer of the core and MinApprovers its quorum.
+type validatorSet struct {
+	sportdao.FullnodeSet
+}
+
+func (s validatorSet) CalcProposer(lastProposer common.Address, round uint64) {
+	s.CalcSpeaker(lastProposer, round)
+}
+
+func (s validatorSet) GetProposer() bft.Validator {
+	return s.GetSpeaker()
+}
+
+func (s validatorSet) IsProposer(address common.Address) bool {
+	return s.IsSpeaker(address)
+}
+
+func (s validatorSet) AddValidator(address common.Address) bool {
+	return s.AddFullnode(address)
+}
+
+func (s validatorSet) RemoveValidator(address common.Address) bool {
+	return s.RemoveFullnode(address)
+}
+
+func (s validatorSet) Copy() bft.ValidatorSet {
+	return validatorSet{s.FullnodeSet.Copy()}
+}
+
+func (s validatorSet) F() int {
+	return s.MaxFaulty()
+}
+
+func (s validatorSet) Quorum() int {
+	return s.MinApprovers()
+}
@@ -30,8 +30,8 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	bftCore "go-smilo/src/blockchain/smilobft/consensus/bft/core"
 	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
-	"go-smilo/src/blockchain/smilobft/consensus/sportdao/smilobftcore"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 )
@@ -42,7 +42,7 @@ type Backend struct {
 	smilobftEventMux *cmn.TypeMux
 	privateKey       *ecdsa.PrivateKey
 	address          common.Address
-	core             smilobftcore.Engine
+	core             bftCore.Engine
 	logger           log.Logger
 	db               ethdb.Database
 	blockchain       *core.BlockChain
@@ -38,6 +38,7 @@ type Config struct {
 	MinFunds             int64         `toml:",omitempty"` // The minimum funds a node should have to be a full node
 	CommunityAddress     string        `toml:",omitempty"` // The community address for miner donations
 	MinBlocksEmptyMining *big.Int      `toml:",omitempty"` // Min Blocks to mine before Stop Mining Empty Blocks
+	Journal              string        `toml:",omitempty"` // The file the consensus messages are journaled to, empty disables it
 
 	sync.RWMutex
 }
@@ -17,16 +17,11 @@
 
 package sportdao
 
-// RequestEvent is posted to propose a proposal
-type RequestEvent struct {
-	BlockProposal BlockProposal
-}
+import "go-smilo/src/blockchain/smilobft/consensus/bft"
 
-// MessageEvent is posted for Sport engine communication
-type MessageEvent struct {
-	Payload []byte
-}
-
-// FinalCommittedEvent is posted when a proposal is committed
-type FinalCommittedEvent struct {
-}
+// SportDAO runs on the shared BFT core and posts its events
+type (
+	RequestEvent        = bft.RequestEvent
+	MessageEvent        = bft.MessageEvent
+	FinalCommittedEvent = bft.FinalCommittedEvent
+)
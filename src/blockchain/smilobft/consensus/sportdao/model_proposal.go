@@ -17,25 +17,7 @@
 
 package sportdao
 
-import (
-	"io"
-	"math/big"
+import "go-smilo/src/blockchain/smilobft/consensus/bft"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rlp"
-)
-
-// BlockProposal supports retrieving height and serialized block to be used during Sport consensus.
-type BlockProposal interface {
-	// Number retrieves the sequence number of this proposal.
-	Number() *big.Int
-
-	// Hash retrieves the hash of this proposal.
-	Hash() common.Hash
-
-	EncodeRLP(w io.Writer) error
-
-	DecodeRLP(s *rlp.Stream) error
-
-	String() string
-}
+// BlockProposal supports retrieving height and serialized block to be used during SportDAO consensus.
+type BlockProposal = bft.Proposal
@@ -1,72 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package sportdao
-
-import (
-	"math/big"
-	"testing"
-)
-
-func TestViewCompare(t *testing.T) {
-	// test equality
-	srvView := &View{
-		Sequence: big.NewInt(2),
-		Round:    big.NewInt(1),
-	}
-	tarView := &View{
-		Sequence: big.NewInt(2),
-		Round:    big.NewInt(1),
-	}
-	if r := srvView.Cmp(tarView); r != 0 {
-		t.Errorf("source(%v) should be equal to target(%v): have %v, want %v", srvView, tarView, r, 0)
-	}
-
-	// test larger Sequence
-	tarView = &View{
-		Sequence: big.NewInt(1),
-		Round:    big.NewInt(1),
-	}
-	if r := srvView.Cmp(tarView); r != 1 {
-		t.Errorf("source(%v) should be larger than target(%v): have %v, want %v", srvView, tarView, r, 1)
-	}
-
-	// test larger Round
-	tarView = &View{
-		Sequence: big.NewInt(2),
-		Round:    big.NewInt(0),
-	}
-	if r := srvView.Cmp(tarView); r != 1 {
-		t.Errorf("source(%v) should be larger than target(%v): have %v, want %v", srvView, tarView, r, 1)
-	}
-
-	// test smaller Sequence
-	tarView = &View{
-		Sequence: big.NewInt(3),
-		Round:    big.NewInt(1),
-	}
-	if r := srvView.Cmp(tarView); r != -1 {
-		t.Errorf("source(%v) should be smaller than target(%v): have %v, want %v", srvView, tarView, r, -1)
-	}
-	tarView = &View{
-		Sequence: big.NewInt(2),
-		Round:    big.NewInt(2),
-	}
-	if r := srvView.Cmp(tarView); r != -1 {
-		t.Errorf("source(%v) should be smaller than target(%v): have %v, want %v", srvView, tarView, r, -1)
-	}
-}
@@ -1,120 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"reflect"
-
-	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/ethereum/go-ethereum/common"
-
-	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
-)
-
-func (c *core) sendCommit() {
-	sub := c.current.Subject()
-	c.broadcastCommit(sub)
-}
-
-func (c *core) sendCommitForOldBlock(view *sportdao.View, digest common.Hash) {
-	sub := &sportdao.Subject{
-		View:   view,
-		Digest: digest,
-	}
-	c.broadcastCommit(sub)
-}
-
-func (c *core) broadcastCommit(sub *sportdao.Subject) {
-	logger := c.logger.New("state", c.state)
-
-	encodedSubject, err := Encode(sub)
-	if err != nil {
-		logger.Error("Failed to encode", "subject", sub)
-		return
-	}
-	c.broadcast(&message{
-		Code: msgCommit,
-		Msg:  encodedSubject,
-	})
-}
-
-func (c *core) handleCommit(msg *message, src sportdao.Fullnode) error {
-	// Decode COMMIT message
-	var commit *sportdao.Subject
-	err := msg.Decode(&commit)
-	if err != nil {
-		return errFailedDecodeCommit
-	}
-
-	if err := c.checkMessage(msgCommit, commit.View); err != nil {
-		return err
-	}
-
-	if err := c.verifyCommit(commit, src); err != nil {
-		return err
-	}
-
-	err = c.acceptCommit(msg, src)
-	if err != nil {
-		log.Error("handleCommit, could not process acceptCommit, ", "error", err)
-	}
-
-	// Commit the proposal once we have enough COMMIT messages and we are not in the Committed state.
-	//
-	// If we already have a proposal, we may have chance to speed up the consensus process
-	// by committing the proposal without PREPARE messages.
-	actualCommits := c.current.Commits.Size()
-	requiredMinApprovers := c.fullnodeSet.MinApprovers()
-
-	if actualCommits >= requiredMinApprovers && c.state.Cmp(StateCommitted) < 0 {
-		// Still need to call LockHash here since state can skip Prepared state and jump directly to the Committed state.
-		c.current.LockHash()
-		c.commit()
-	} else {
-		logger := c.logger.New("state", c.state)
-		logger.Debug("******* Commit the proposal waiting consensus, ", "actualCommits", actualCommits, "requiredMinApprovers", requiredMinApprovers, "StateCommitted", c.state.Cmp(StateCommitted))
-	}
-
-	return nil
-}
-
-// verifyCommit verifies if the received COMMIT message is equivalent to our subject
-func (c *core) verifyCommit(commit *sportdao.Subject, src sportdao.Fullnode) error {
-	logger := c.logger.New("from", src, "state", c.state)
-
-	sub := c.current.Subject()
-	if !reflect.DeepEqual(commit, sub) {
-		logger.Warn("Inconsistent subjects between commit and proposal", "expected", sub, "got", commit)
-		return errInconsistentSubject
-	}
-
-	return nil
-}
-
-func (c *core) acceptCommit(msg *message, src sportdao.Fullnode) error {
-	logger := c.logger.New("from", src, "state", c.state)
-
-	// Add the COMMIT message to current round state
-	if err := c.current.Commits.Add(msg); err != nil {
-		logger.Error("Failed to record commit message", "msg", msg, "err", err)
-		return err
-	}
-
-	return nil
-}
@@ -1,108 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-import (
-	"math/big"
-	"testing"
-
-	"github.com/ethereum/go-ethereum/rlp"
-
-	"go-smilo/src/blockchain/smilobft/cmn"
-	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
-)
-
-func TestMessageSetWithPreprepare(t *testing.T) {
-	fullnodeSet := newTestFullnodeSet(4)
-
-	ms := newMessageSet(fullnodeSet)
-
-	view := &sportdao.View{
-		Round:    new(big.Int),
-		Sequence: new(big.Int),
-	}
-	pp := &sportdao.Preprepare{
-		View:          view,
-		BlockProposal: makeBlock(1),
-	}
-
-	rawPP, err := rlp.EncodeToBytes(pp)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-	msg := &message{
-		Code:    msgPreprepare,
-		Msg:     rawPP,
-		Address: fullnodeSet.GetSpeaker().Address(),
-	}
-
-	err = ms.Add(msg)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-
-	err = ms.Add(msg)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-
-	if ms.Size() != 1 {
-		t.Errorf("the size of message set mismatch: have %v, want 1", ms.Size())
-	}
-}
-
-func TestMessageSetWithSubject(t *testing.T) {
-	fullnodeSet := newTestFullnodeSet(4)
-
-	ms := newMessageSet(fullnodeSet)
-
-	view := &sportdao.View{
-		Round:    new(big.Int),
-		Sequence: new(big.Int),
-	}
-
-	sub := &sportdao.Subject{
-		View:   view,
-		Digest: cmn.StringToHash("1234567890"),
-	}
-
-	rawSub, err := rlp.EncodeToBytes(sub)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-
-	msg := &message{
-		Code:    msgPrepare,
-		Msg:     rawSub,
-		Address: fullnodeSet.GetSpeaker().Address(),
-	}
-
-	err = ms.Add(msg)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-
-	err = ms.Add(msg)
-	if err != nil {
-		t.Errorf("error mismatch: have %v, want nil", err)
-	}
-
-	if ms.Size() != 1 {
-		t.Errorf("the size of message set mismatch: have %v, want 1", ms.Size())
-	}
-}
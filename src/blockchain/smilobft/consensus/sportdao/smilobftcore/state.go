@@ -1,60 +0,0 @@
-// Copyright 2019 The go-smilo Authors
-// Copyright 2017 The go-ethereum Authors
-// This file is part of the go-ethereum library.
-//
-// The go-ethereum library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-ethereum library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
-
-package smilobftcore
-
-type State uint64
-
-const (
-	StateAcceptRequest State = iota
-	StatePreprepared
-	StatePrepared
-	StateCommitted
-
-	msgPreprepare uint64 = iota
-	msgPrepare
-	msgCommit
-	msgRoundChange
-)
-
-func (s State) String() string {
-	if s == StateAcceptRequest {
-		return "Accept request"
-	} else if s == StatePreprepared {
-		return "Preprepared"
-	} else if s == StatePrepared {
-		return "Prepared"
-	} else if s == StateCommitted {
-		return "Committed"
-	} else {
-		return "Unknown"
-	}
-}
-
-// Cmp compares s and y and returns:
-//   -1 if s is the previous state of y
-//    0 if s and y are the same state
-//   +1 if s is the next state of y
-func (s State) Cmp(y State) int {
-	if uint64(s) < uint64(y) {
-		return -1
-	}
-	if uint64(s) > uint64(y) {
-		return 1
-	}
-	return 0
-}
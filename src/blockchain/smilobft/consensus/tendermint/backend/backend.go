@@ -102,7 +102,7 @@ func New(config *tendermintConfig.Config, privateKey *ecdsa.PrivateKey, db ethdb
 		vmConfig:       vmConfig,
 	}
 
-	backend.core = tendermintCore.New(backend, backend.config)
+	backend.core = tendermintCore.New(backend, backend.config, db)
 
 	backend.pendingMessages.SetCapacity(ringCapacity)
 	return backend
@@ -378,22 +378,23 @@ func (sb *Backend) VerifyProposal(proposal types.Block) (time.Duration, error) {
 
 		// Here the order of applying transaction matters
 		// We need to ensure that the block transactions applied before the Autonity contract
-		if proposalNumber == 1 {
-			//Apply the same changes from consensus/tendermint/backend/engine.go:getValidator()349-369
-			sb.logger.Info("Autonity Contract Deployer in test state", "Address", sb.blockchain.Config().AutonityContractConfig.Deployer)
-
-			_, err = sb.blockchain.GetAutonityContract().DeployAutonityContract(sb.blockchain, header, state)
-			if err != nil {
-				sb.logger.Error("Error when DeployAutonityContract Autonity Contract ", "err", err)
-				return 0, err
-			}
-		} else if proposalNumber > 1 {
+		if proposalNumber > 1 {
 			err = sb.blockchain.GetAutonityContract().ApplyPerformRedistribution(block.Transactions(), receipts, block.Header(), state)
 			if err != nil {
 				sb.logger.Error("Error when ApplyPerformRedistribution Autonity Contract ", "err", err)
 				return 0, err
 			}
 		}
+		// The contract is deployed on the first block, and on the first block
+		// of a consensus transition, as in Finalize
+		if sb.blockchain.GetAutonityContract().NeedsDeployment(sb.blockchain, header, state) {
+			sb.logger.Info("Autonity Contract Deployer in test state", "Address", sb.blockchain.Config().AutonityContractConfig.Deployer)
+
+			if _, err = sb.getValidators(header, sb.blockchain, state); err != nil {
+				sb.logger.Error("Error when DeployAutonityContract Autonity Contract ", "err", err)
+				return 0, err
+			}
+		}
 
 		//Validate the state of the proposal
 		if err = sb.blockchain.Validator().ValidateState(block, parent, state, receipts, *usedGas); err != nil {
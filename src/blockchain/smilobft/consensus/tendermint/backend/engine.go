@@ -69,6 +69,10 @@ var (
 	errInconsistentValidatorSet = errors.New("inconsistent validator set")
 	// errInvalidTimestamp is returned if the timestamp of a block is lower than the previous block's timestamp + the minimum block period.
 	errInvalidTimestamp = errors.New("invalid timestamp")
+	// errTooMuchEvidence is returned if a block carries more than MaxBlockEvidence evidence entries.
+	errTooMuchEvidence = errors.New("too much evidence")
+	// errDuplicateEvidence is returned if a block carries two evidence entries for the same offence.
+	errDuplicateEvidence = errors.New("duplicate evidence")
 )
 var (
 	defaultDifficulty = big.NewInt(1)
@@ -362,6 +366,17 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 		return nil, err
 	}
 
+	// PrepareExtra below resets the extra-data, keep the evidence included by the proposer
+	var evidence []*types.BFTEvidence
+	if extra, err := types.ExtractBFTHeaderExtra(header); err == nil {
+		evidence = extra.Evidence
+	}
+	offences, err := sb.verifyEvidence(header, evidence)
+	if err != nil {
+		sb.logger.Error("Finalize after verifyEvidence", "err", err.Error())
+		return nil, err
+	}
+
 	ac := sb.blockchain.GetAutonityContract()
 	if ac != nil && header.Number.Uint64() > 1 {
 		err = ac.ApplyPerformRedistribution(txs, receipts, header, state)
@@ -369,6 +384,12 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 			sb.logger.Error("ApplyPerformRedistribution", "err", err.Error())
 			return nil, err
 		}
+		for _, offence := range offences {
+			if err = ac.ApplySlashing(header, state, offence.Offender, offence.Hash()); err != nil {
+				sb.logger.Error("ApplySlashing", "err", err.Error())
+				return nil, err
+			}
+		}
 	}
 
 	// add validators to extraData's validators section
@@ -376,6 +397,12 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 		log.Error("Backend) Finalize( after PrepareExtra", "err", err.Error())
 		return nil, err
 	}
+	if len(evidence) > 0 {
+		if err = types.WriteEvidence(header, evidence); err != nil {
+			log.Error("Backend) Finalize( after WriteEvidence", "err", err.Error())
+			return nil, err
+		}
+	}
 	// warn for empty blocks
 	number := header.Number.Int64()
 
@@ -394,12 +421,36 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 	return types.NewBlock(header, txs, nil, receipts), nil
 }
 
+// verifyEvidence checks the equivocation evidence carried by header and returns
+// the offences it proves.
+func (sb *Backend) verifyEvidence(header *types.Header, evidence []*types.BFTEvidence) ([]*tendermintCore.Offence, error) {
+	if len(evidence) > tendermintCore.MaxBlockEvidence {
+		return nil, errTooMuchEvidence
+	}
+
+	offences := make([]*tendermintCore.Offence, 0, len(evidence))
+	seen := make(map[common.Hash]bool, len(evidence))
+	for _, ev := range evidence {
+		offence, err := tendermintCore.CheckEvidence(ev, header.Number.Uint64(), sb.Validators)
+		if err != nil {
+			return nil, err
+		}
+		hash := offence.Hash()
+		if seen[hash] {
+			return nil, errDuplicateEvidence
+		}
+		seen[hash] = true
+		offences = append(offences, offence)
+	}
+	return offences, nil
+}
+
 func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainReader, state *state.StateDB) ([]common.Address, error) {
 	sb.contractsMu.Lock()
 	defer sb.contractsMu.Unlock()
 	var validators []common.Address
 
-	if header.Number.Int64() == 1 {
+	if sb.blockchain.GetAutonityContract().NeedsDeployment(chain, header, state) {
 		log.Info("Autonity Contract Deployer", "Address", chain.Config().AutonityContractConfig.Deployer)
 
 		sb.blockchain.GetAutonityContract().SavedValidatorsRetriever = func(i uint64) (addresses []common.Address, e error) {
@@ -412,7 +463,7 @@ func (sb *Backend) getValidators(header *types.Header, chain consensus.ChainRead
 			return nil, err
 		}
 		sb.autonityContractAddress = contractAddress
-		validators, err = sb.retrieveSavedValidators(1, chain)
+		validators, err = sb.retrieveSavedValidators(header.Number.Uint64(), chain)
 		if err != nil {
 			return nil, err
 		}
@@ -574,7 +574,7 @@ func TestClose(t *testing.T) {
 			coreStarted: true,
 			stopped:     make(chan struct{}),
 		}
-		b.core = tendermintCore.New(b, b.config)
+		b.core = tendermintCore.New(b, b.config, b.db)
 
 		err := b.Close()
 		if err != nil {
@@ -24,6 +24,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/events"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	"io"
 	"io/ioutil"
 	"math/big"
@@ -43,6 +44,7 @@ const (
 type UnhandledMsg struct {
 	addr common.Address
 	msg  p2p.Msg
+	peer enode.ID
 }
 
 var (
@@ -66,14 +68,15 @@ func (sb *Backend) HandleUnhandledMsgs(ctx context.Context) {
 
 		addr := unhandled.(UnhandledMsg).addr
 		msg := unhandled.(UnhandledMsg).msg
-		if _, err := sb.HandleMsg(addr, msg); err != nil {
+		peer := unhandled.(UnhandledMsg).peer
+		if _, err := sb.HandleMsg(addr, msg, peer); err != nil {
 			sb.logger.Error("could not handle cached message", "err", err)
 		}
 	}
 }
 
 // HandleMsg implements consensus.Handler.HandleMsg
-func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
+func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg, peer enode.ID) (bool, error) {
 	sb.coreMu.Lock()
 	defer sb.coreMu.Unlock()
 
@@ -89,7 +92,7 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 			}
 			savedMsg := msg
 			savedMsg.Payload = buffer
-			sb.pendingMessages.Enqueue(UnhandledMsg{addr: addr, msg: savedMsg})
+			sb.pendingMessages.Enqueue(UnhandledMsg{addr: addr, msg: savedMsg, peer: peer})
 			return true, nil //return nil to avoid shutting down connection during block sync.
 		}
 
@@ -120,6 +123,7 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 		go func() {
 			err := sb.eventMux.Post(events.MessageEvent{
 				Payload: data,
+				Peer:    peer,
 			})
 			if err != nil {
 				log.Error("Could not send sb.eventMux.Post, tendermintMsg", "err", err, "msg", msg.String())
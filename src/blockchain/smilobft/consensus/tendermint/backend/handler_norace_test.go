@@ -7,6 +7,7 @@ import (
 	"context"
 	"go-smilo/src/blockchain/smilobft/consensus"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/events"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	"math/big"
 	"reflect"
 	"testing"
@@ -28,7 +29,7 @@ func TestUnhandledMsgs(t *testing.T) {
 			counter := big.NewInt(i).Bytes()
 			msg := makeMsg(tendermintMsg, append(counter, []byte("data")...))
 			addr := common.BytesToAddress(append(counter, []byte("addr")...))
-			if result, err := backend.HandleMsg(addr, msg); !result || err != nil {
+			if result, err := backend.HandleMsg(addr, msg, enode.ID{}); !result || err != nil {
 				t.Fatalf("handleMsg should have been successful")
 			}
 		}
@@ -74,7 +75,7 @@ func TestUnhandledMsgs(t *testing.T) {
 			counter := big.NewInt(i).Bytes()
 			msg := makeMsg(tendermintMsg, append(counter, []byte("data")...))
 			addr := common.BytesToAddress(append(counter, []byte("addr")...))
-			if result, err := backend.HandleMsg(addr, msg); !result || err != nil {
+			if result, err := backend.HandleMsg(addr, msg, enode.ID{}); !result || err != nil {
 				t.Fatalf("handleMsg should have been successful")
 			}
 		}
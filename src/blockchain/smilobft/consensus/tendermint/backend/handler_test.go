@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/golang-lru"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
 func TestTendermintMessage(t *testing.T) {
@@ -51,7 +52,7 @@ func TestTendermintMessage(t *testing.T) {
 	}
 
 	// 2. this message should be in cache after we handle it
-	_, err := backend.HandleMsg(addr, msg)
+	_, err := backend.HandleMsg(addr, msg, enode.ID{})
 	if err != nil {
 		t.Fatalf("handle message failed: %v", err)
 	}
@@ -81,7 +82,7 @@ func TestSynchronisationMessage(t *testing.T) {
 		}
 		msg := makeMsg(tendermintSyncMsg, []byte{})
 		addr := common.BytesToAddress([]byte("address"))
-		if res, err := b.HandleMsg(addr, msg); !res || err != nil {
+		if res, err := b.HandleMsg(addr, msg, enode.ID{}); !res || err != nil {
 			t.Fatalf("HandleMsg unexpected return")
 		}
 		timer := time.NewTimer(2 * time.Second)
@@ -102,7 +103,7 @@ func TestSynchronisationMessage(t *testing.T) {
 		}
 		msg := makeMsg(tendermintSyncMsg, []byte{})
 		addr := common.BytesToAddress([]byte("address"))
-		if res, err := b.HandleMsg(addr, msg); !res || err != nil {
+		if res, err := b.HandleMsg(addr, msg, enode.ID{}); !res || err != nil {
 			t.Fatalf("HandleMsg unexpected return")
 		}
 		timer := time.NewTimer(2 * time.Second)
@@ -34,6 +34,7 @@ type Config struct {
 	ProposerPolicy       ProposerPolicy `toml:",omitempty"` // The policy for proposer selection
 	Epoch                uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
 	MinBlocksEmptyMining *big.Int       `toml:",omitempty"` // Min Blocks to mine before Stop Mining Empty Blocks
+	Journal              string         `toml:",omitempty"` // The file the consensus messages are journaled to, empty disables it
 
 	sync.RWMutex
 }
@@ -97,6 +97,7 @@ func (c *core) storeBacklog(msg *Message, src validator.Validator) {
 		}
 	}
 	c.backlogs[src] = backlogPrque
+	c.trackBacklog()
 }
 
 func (c *core) processBacklog() {
@@ -157,6 +158,7 @@ func (c *core) processBacklog() {
 			})
 		}
 	}
+	c.trackBacklog()
 }
 
 func toPriority(msgCode uint64, r *big.Int, h *big.Int) float32 {
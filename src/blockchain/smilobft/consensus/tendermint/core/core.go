@@ -29,9 +29,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/ethdb"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 
 	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 )
@@ -73,11 +77,13 @@ var (
 	errMovedToNewRound = errors.New("timer expired and new round started")
 )
 
-// New creates an Tendermint consensus core
-func New(backend Backend, config *config.Config) *core {
+// New creates an Tendermint consensus core. The locked and valid values are
+// persisted to db, a nil db keeps them in memory only.
+func New(backend Backend, config *config.Config, db ethdb.Database) *core {
 	logger := log.New("addr", backend.Address().String())
 	return &core{
 		config:                       config,
+		db:                           db,
 		address:                      backend.Address(),
 		logger:                       logger,
 		backend:                      backend,
@@ -95,9 +101,11 @@ func New(backend Backend, config *config.Config) *core {
 		lockedRound:                  big.NewInt(-1),
 		validRound:                   big.NewInt(-1),
 		currentRoundState:            new(roundState),
+		evidence:                     make(map[common.Hash]*types.BFTEvidence),
 		proposeTimeout:               newTimeout(propose, logger),
 		prevoteTimeout:               newTimeout(prevote, logger),
 		precommitTimeout:             newTimeout(precommit, logger),
+		health:                       health.NewTracker("tendermint", backend.Address()),
 	}
 }
 
@@ -105,6 +113,7 @@ type core struct {
 	config  *config.Config
 	address common.Address
 	logger  log.Logger
+	db      ethdb.Database
 
 	backend Backend
 	cancel  context.CancelFunc
@@ -143,6 +152,12 @@ type core struct {
 	validRound  *big.Int
 	lockedValue *types.Block
 	validValue  *types.Block
+	// restoredLock is the lock state loaded on start, taken back when the
+	// round of its height begins
+	restoredLock *lockState
+	// sent are the messages signed at the current height, persisted with the
+	// lock state
+	sent []sentMessage
 
 	currentHeightOldRoundsStates   map[int64]*roundState
 	currentHeightOldRoundsStatesMu sync.RWMutex
@@ -153,6 +168,17 @@ type core struct {
 
 	//map[futureRoundNumber]NumberOfMessagesReceivedForTheRound
 	futureRoundsChange map[int64]int64
+
+	// pending equivocation evidence, map[offenceHash]evidence
+	evidence   map[common.Hash]*types.BFTEvidence
+	evidenceMu sync.Mutex
+
+	// journal records the consensus messages and events, nil unless enabled
+	journal *journal.Journal
+	// replaying is set while a journal is fed back into the core
+	replaying bool
+	// health tracks the round state for the metrics and the status RPC
+	health *health.Tracker
 }
 
 func (c *core) GetCurrentHeightMessages() []*Message {
@@ -229,7 +255,10 @@ func (c *core) broadcast(ctx context.Context, msg *Message) {
 
 	// Broadcast payload
 	logger.Debug("broadcasting", "msg", msg.String())
-	if err = c.backend.Broadcast(ctx, c.valSet.Copy(), payload); err != nil {
+	entry := c.journal.Message(journal.KindSent, enode.ID{}, payload)
+	err = c.backend.Broadcast(ctx, c.valSet.Copy(), payload)
+	c.journal.Failed(entry, err)
+	if err != nil {
 		logger.Error("Failed to broadcast message", "msg", msg, "err", err)
 		return
 	}
@@ -282,10 +311,18 @@ func (c *core) measureHeightRoundMetrics(round *big.Int) {
 func (c *core) startRound(ctx context.Context, round *big.Int) {
 
 	c.measureHeightRoundMetrics(round)
-	lastCommittedProposalBlock, lastCommittedProposalBlockProposer := c.backend.LastCommittedProposal()
+	lastCommittedProposalBlock, lastCommittedProposalBlockProposer := c.lastCommittedProposal()
 	height := new(big.Int).Add(lastCommittedProposalBlock.Number(), common.Big1)
 
+	// A validator restarted mid-height resumes the round it left, it must not
+	// vote again in the rounds it already took part in
+	if round.Sign() == 0 && c.restoredLock != nil && c.restoredLock.Height == height.Uint64() {
+		round = new(big.Int).SetUint64(c.restoredLock.Round)
+	}
+
 	c.setCore(round, height, lastCommittedProposalBlockProposer)
+	// The round is persisted before any message of it is sent
+	c.storeLockState()
 
 	// c.setStep(propose) will process the pending unmined blocks sent by the backed.Seal() and set c.lastestPendingRequest
 	c.setStep(propose)
@@ -309,6 +346,11 @@ func (c *core) startRound(ctx context.Context, round *big.Int) {
 			log.Debug("I AM THE PROPOSER AND getUnminedBlock!!!!!!!!!!!!!!!! ", "getUnminedBlock", p,
 				"Height", height, "Round", round, "lastCommittedProposalBlock", lastCommittedProposalBlock.Hash())
 
+			if p == nil && c.replaying {
+				// Nobody mines during a replay, the proposal waits for the
+				// journaled unmined block instead
+				return
+			}
 			if p == nil {
 				select {
 				case <-ctx.Done():
@@ -333,16 +375,22 @@ func (c *core) startRound(ctx context.Context, round *big.Int) {
 }
 
 func (c *core) setCore(r *big.Int, h *big.Int, lastProposer common.Address) {
-	// Start of new height where round is 0
-	if r.Int64() == 0 {
+	// Start of new height where round is 0, or the round resumed after a restart
+	newHeight := r.Int64() == 0 || c.restoredLock != nil
+	if newHeight {
 		// Set the shared round values to initial values
 		c.lockedRound = big.NewInt(-1)
 		c.lockedValue = nil
 		c.validRound = big.NewInt(-1)
 		c.validValue = nil
+		c.sent = nil
+		if c.restoredLock != nil && c.restoredLock.Height == h.Uint64() {
+			c.restoreLockState(c.restoredLock)
+		}
+		c.restoredLock = nil
 
 		// Set validator set for height
-		valSet := c.backend.Validators(h.Uint64())
+		valSet := c.validators(h.Uint64())
 		c.valSet.set(valSet)
 
 		// Assuming that round == 0 only when the node moves to a new height
@@ -365,7 +413,7 @@ func (c *core) setCore(r *big.Int, h *big.Int, lastProposer common.Address) {
 	// Add a copy of c.currentRoundState to c.currentHeightOldRoundsStates and then update c.currentRoundState
 	// We only add old round prevote messages to c.currentHeightOldRoundsStates, while future messages are sent to the
 	// backlog which are processed when the step is set to propose
-	if r.Int64() > 0 {
+	if !newHeight {
 		// This is a shallow copy, should be fine for now
 		c.currentHeightOldRoundsStatesMu.Lock()
 		c.currentHeightOldRoundsStates[r.Int64()-1] = c.currentRoundState
@@ -379,33 +427,39 @@ func (c *core) setCore(r *big.Int, h *big.Int, lastProposer common.Address) {
 	c.sentPrevote = false
 	c.sentPrecommit = false
 	c.setValidRoundAndValue = false
+	c.trackRound()
 }
 
-func (c *core) acceptVote(roundState *roundState, step Step, hash common.Hash, msg Message) {
+func (c *core) acceptVote(ctx context.Context, roundState *roundState, step Step, hash common.Hash, msg Message) {
 	log.Debug("Going to acceptVote!!!!!!!! ", "step", step, "hash", hash, "roundState", roundState.GetCurrentProposalHash(), "msg", msg.String())
 	emptyHash := hash == (common.Hash{})
+	var conflict *Message
 	switch step {
 	case prevote:
 		if emptyHash {
 			log.Debug("Going to acceptVote!!!!!!!! prevote, AddNilVote,", "step", step, "hash", hash, "roundState", roundState.GetCurrentProposalHash(), "msg", msg.String())
-			roundState.Prevotes.AddNilVote(msg)
+			conflict = roundState.Prevotes.AddNilVote(msg)
 		} else {
 			log.Debug("Going to acceptVote!!!!!!!! prevote, AddVote,", "step", step, "hash", hash, "roundState", roundState.GetCurrentProposalHash(), "msg", msg.String())
-			roundState.Prevotes.AddVote(hash, msg)
+			conflict = roundState.Prevotes.AddVote(hash, msg)
 		}
 	case precommit:
 		if emptyHash {
 			log.Debug("Going to acceptVote!!!!!!!! precommit, AddNilVote", "step", step, "hash", hash, "roundState", roundState.GetCurrentProposalHash(), "msg", msg.String())
-			roundState.Precommits.AddNilVote(msg)
+			conflict = roundState.Precommits.AddNilVote(msg)
 		} else {
 			log.Debug("Going to acceptVote!!!!!!!! precommit, ddVote", "step", step, "hash", hash, "roundState", roundState.GetCurrentProposalHash(), "msg", msg.String())
-			roundState.Precommits.AddVote(hash, msg)
+			conflict = roundState.Precommits.AddVote(hash, msg)
 		}
 	}
+	if conflict != nil {
+		c.reportEquivocation(ctx, conflict, &msg)
+	}
 }
 
 func (c *core) setStep(step Step) {
 	c.currentRoundState.SetStep(step)
+	c.health.Step(step.String())
 	c.processBacklog()
 }
 
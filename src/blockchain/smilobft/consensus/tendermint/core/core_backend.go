@@ -10,10 +10,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/health"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	"go-smilo/src/blockchain/smilobft/rpc"
 )
 
@@ -37,8 +39,13 @@ func (c *core) VerifySeal(chain consensus.ChainReader, header *types.Header) err
 	return c.backend.VerifySeal(chain, header)
 }
 
+// Prepare initializes the consensus fields of a block header and includes the
+// pending equivocation evidence.
 func (c *core) Prepare(chain consensus.ChainReader, header *types.Header) error {
-	return c.backend.Prepare(chain, header)
+	if err := c.backend.Prepare(chain, header); err != nil {
+		return err
+	}
+	return c.includeEvidence(header)
 }
 
 func (c *core) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction,
@@ -59,7 +66,12 @@ func (c *core) CalcDifficulty(chain consensus.ChainReader, time uint64, parent *
 }
 
 func (c *core) APIs(chain consensus.ChainReader) []rpc.API {
-	return c.backend.APIs(chain)
+	return append(c.backend.APIs(chain), rpc.API{
+		Namespace: "tendermint",
+		Version:   "1.0",
+		Service:   health.NewAPI(c.Status),
+		Public:    true,
+	})
 }
 
 func (c *core) Close() error {
@@ -70,8 +82,8 @@ func (c *core) NewChainHead() error {
 	return c.backend.NewChainHead()
 }
 
-func (c *core) HandleMsg(address common.Address, data p2p.Msg) (bool, error) {
-	return c.backend.HandleMsg(address, data)
+func (c *core) HandleMsg(address common.Address, data p2p.Msg, peer enode.ID) (bool, error) {
+	return c.backend.HandleMsg(address, data, peer)
 }
 
 func (c *core) SetBroadcaster(b consensus.Broadcaster) {
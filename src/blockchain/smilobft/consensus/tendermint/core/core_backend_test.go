@@ -19,6 +19,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	"go-smilo/src/blockchain/smilobft/rpc"
 )
 
@@ -200,11 +201,11 @@ func TestCore_CalcDifficulty(t *testing.T) {
 }
 
 func TestCore_APIs(t *testing.T) {
-	t.Run("valid params given, APIs returned", func(t *testing.T) {
+	t.Run("valid params given, APIs returned with the status API", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		var expected []rpc.API
+		expected := []rpc.API{{Namespace: "tendermint", Version: "1.0", Public: true}}
 
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().APIs(nil).Return(expected)
@@ -214,8 +215,11 @@ func TestCore_APIs(t *testing.T) {
 		}
 
 		APIS := c.APIs(nil)
-		if !reflect.DeepEqual(APIS, expected) {
-			t.Fatalf("Expected %v, got %v", expected, APIS)
+		if len(APIS) != 2 || !reflect.DeepEqual(APIS[0], expected[0]) {
+			t.Fatalf("Expected %v and the status API, got %v", expected, APIS)
+		}
+		if APIS[1].Namespace != "tendermint" {
+			t.Fatalf("Expected the status API in the tendermint namespace, got %v", APIS[1].Namespace)
 		}
 	})
 }
@@ -267,13 +271,13 @@ func TestCore_HandleMsg(t *testing.T) {
 		data := p2p.Msg{}
 
 		backendMock := NewMockBackend(ctrl)
-		backendMock.EXPECT().HandleMsg(addr, data).Return(true, nil)
+		backendMock.EXPECT().HandleMsg(addr, data, enode.ID{}).Return(true, nil)
 
 		c := &core{
 			backend: backendMock,
 		}
 
-		r, err := c.HandleMsg(addr, data)
+		r, err := c.HandleMsg(addr, data, enode.ID{})
 		if err != nil {
 			t.Fatalf("Expected <nil>, got %v", err)
 		}
@@ -292,13 +296,13 @@ func TestCore_HandleMsg(t *testing.T) {
 		expected := errors.New("some error")
 
 		backendMock := NewMockBackend(ctrl)
-		backendMock.EXPECT().HandleMsg(addr, data).Return(false, expected)
+		backendMock.EXPECT().HandleMsg(addr, data, enode.ID{}).Return(false, expected)
 
 		c := &core{
 			backend: backendMock,
 		}
 
-		_, err := c.HandleMsg(addr, data)
+		_, err := c.HandleMsg(addr, data, enode.ID{})
 		if err != expected {
 			t.Fatalf("Expected %v, got %v", expected, err)
 		}
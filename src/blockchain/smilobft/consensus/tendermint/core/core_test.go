@@ -1,11 +1,17 @@
 package core
 
 import (
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/golang/mock/gomock"
 	"math/big"
 	"testing"
+
+	"go-smilo/src/blockchain/smilobft/consensus/health"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"gopkg.in/karalabe/cookiejar.v2/collections/prque"
 )
 
 func TestCore_MeasureHeightRoundMetrics(t *testing.T) {
@@ -87,3 +93,44 @@ func TestCore_measureMetricsOnTimeOut(t *testing.T) {
 		}
 	})
 }
+
+func TestCore_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := log.New("core", "test", "id", 0)
+	valSet := newTestValidatorSet(4)
+	self := valSet.GetByIndex(0).Address()
+
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().Validators(uint64(3)).Return(valSet)
+	backendMock.EXPECT().Sign(gomock.Any()).Return([]byte{0x1}, nil)
+	backendMock.EXPECT().Broadcast(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	c := &core{
+		address:           self,
+		logger:            logger,
+		backend:           backendMock,
+		backlogs:          make(map[validator.Validator]*prque.Prque),
+		currentRoundState: NewRoundState(big.NewInt(0), big.NewInt(3)),
+		valSet:            new(validatorSet),
+		proposeTimeout:    newTimeout(propose, logger),
+		prevoteTimeout:    newTimeout(prevote, logger),
+		precommitTimeout:  newTimeout(precommit, logger),
+		health:            health.NewTracker("tendermint", self),
+	}
+	c.setCore(big.NewInt(0), big.NewInt(3), self)
+	c.setStep(propose)
+	c.handleTimeoutPropose(context.Background(), TimeoutEvent{roundWhenCalled: 0, heightWhenCalled: 3, step: msgProposal})
+
+	status := c.Status()
+	if status.Engine != "tendermint" || status.Height != 3 || status.Round != 0 {
+		t.Fatalf("Expected tendermint at height 3 round 0, got %s at height %d round %d", status.Engine, status.Height, status.Round)
+	}
+	if len(status.Validators) != 4 || status.Quorum != 3 {
+		t.Fatalf("Expected 4 validators with a quorum of 3, got %d and %d", len(status.Validators), status.Quorum)
+	}
+	if status.Step != prevote.String() || status.MissedProposals != 1 {
+		t.Fatalf("Expected the prevote step after a missed proposal, got %s and %d", status.Step, status.MissedProposals)
+	}
+}
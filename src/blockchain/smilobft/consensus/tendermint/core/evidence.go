@@ -0,0 +1,346 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+const (
+	// MaxBlockEvidence is the maximum number of evidence entries a block may carry.
+	MaxBlockEvidence = 16
+	// MaxEvidenceAge is the number of blocks after which evidence can no longer
+	// be included in a block.
+	MaxEvidenceAge = 1024
+)
+
+var (
+	// errInvalidEvidence is returned when the two messages of an evidence are not
+	// a valid pair of conflicting messages.
+	errInvalidEvidence = errors.New("invalid equivocation evidence")
+	// errEvidenceSigner is returned when a message of an evidence is not signed
+	// by the validator it claims to come from.
+	errEvidenceSigner = errors.New("evidence message signer mismatch")
+	// errEvidenceNotValidator is returned when the offender was not a validator
+	// at the height of the offence.
+	errEvidenceNotValidator = errors.New("evidence offender is not a validator")
+	// errFutureEvidence is returned when the offence is above the given height.
+	errFutureEvidence = errors.New("future evidence")
+	// errStaleEvidence is returned when the offence is older than MaxEvidenceAge.
+	errStaleEvidence = errors.New("stale evidence")
+	// errKnownEvidence is returned when the evidence is already pending.
+	errKnownEvidence = errors.New("known evidence")
+)
+
+// Offence describes an equivocation proven by an evidence: the offender signed
+// two different messages of the same type for the same height and round.
+type Offence struct {
+	Offender common.Address
+	Height   uint64
+	Round    uint64
+	Code     uint64
+}
+
+// Hash identifies the offence. Every pair of conflicting messages for the same
+// height, round and step hashes to the same value, so a validator is punished
+// once per offence no matter how many conflicting messages it signed.
+func (o *Offence) Hash() common.Hash {
+	data, _ := rlp.EncodeToBytes([]interface{}{o.Offender, o.Height, o.Round, o.Code})
+	return crypto.Keccak256Hash(data)
+}
+
+// NewEvidence builds the evidence for two conflicting messages. The messages
+// are stored in a canonical order.
+func NewEvidence(first, second *Message) (*types.BFTEvidence, error) {
+	a, err := first.Payload()
+	if err != nil {
+		return nil, err
+	}
+	b, err := second.Payload()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+	return &types.BFTEvidence{First: a, Second: b}, nil
+}
+
+// VerifyEvidence checks that ev holds two conflicting messages signed by the
+// same sender and returns the offence they prove. It does not check that the
+// sender was a validator, see CheckEvidence.
+func VerifyEvidence(ev *types.BFTEvidence) (*Offence, error) {
+	if ev == nil || bytes.Compare(ev.First, ev.Second) >= 0 {
+		return nil, errInvalidEvidence
+	}
+	first, err := decodeEvidenceMessage(ev.First)
+	if err != nil {
+		return nil, err
+	}
+	second, err := decodeEvidenceMessage(ev.Second)
+	if err != nil {
+		return nil, err
+	}
+	if first.Code != second.Code || first.Address != second.Address {
+		return nil, errInvalidEvidence
+	}
+
+	round1, height1, hash1, err := evidenceMessageView(first)
+	if err != nil {
+		return nil, err
+	}
+	round2, height2, hash2, err := evidenceMessageView(second)
+	if err != nil {
+		return nil, err
+	}
+	if round1 != round2 || height1 != height2 || hash1 == hash2 {
+		return nil, errInvalidEvidence
+	}
+
+	return &Offence{
+		Offender: first.Address,
+		Height:   height1,
+		Round:    round1,
+		Code:     first.Code,
+	}, nil
+}
+
+// CheckEvidence verifies ev for inclusion in, or gossip at, the given height:
+// on top of VerifyEvidence the offence must not be in the future nor older than
+// MaxEvidenceAge, and the offender must belong to the validator set returned by
+// validators for the height of the offence.
+func CheckEvidence(ev *types.BFTEvidence, number uint64, validators func(number uint64) validator.Set) (*Offence, error) {
+	offence, err := VerifyEvidence(ev)
+	if err != nil {
+		return nil, err
+	}
+	if offence.Height > number {
+		return nil, errFutureEvidence
+	}
+	if number-offence.Height > MaxEvidenceAge {
+		return nil, errStaleEvidence
+	}
+	if _, val := validators(offence.Height).GetByAddress(offence.Offender); val == nil {
+		return nil, errEvidenceNotValidator
+	}
+	return offence, nil
+}
+
+// decodeEvidenceMessage decodes a signed message and checks that it was signed
+// by the address it carries.
+func decodeEvidenceMessage(payload []byte) (*Message, error) {
+	msg := new(Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return nil, err
+	}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := types.GetSignatureAddress(data, msg.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != msg.Address {
+		return nil, errEvidenceSigner
+	}
+	return msg, nil
+}
+
+// evidenceMessageView returns the round, height and voted value of a consensus
+// message.
+func evidenceMessageView(msg *Message) (uint64, uint64, common.Hash, error) {
+	switch msg.Code {
+	case msgProposal:
+		proposal := &Proposal{logger: log.Root()}
+		if err := msg.Decode(proposal); err != nil || proposal.ProposalBlock == nil {
+			return 0, 0, common.Hash{}, errFailedDecodeProposal
+		}
+		if !proposal.Round.IsUint64() || !proposal.Height.IsUint64() {
+			return 0, 0, common.Hash{}, errInvalidEvidence
+		}
+		return proposal.Round.Uint64(), proposal.Height.Uint64(), proposal.ProposalBlock.Hash(), nil
+	case msgPrevote, msgPrecommit:
+		var vote Vote
+		if err := msg.Decode(&vote); err != nil {
+			return 0, 0, common.Hash{}, errFailedDecodeVote
+		}
+		if !vote.Round.IsUint64() || !vote.Height.IsUint64() {
+			return 0, 0, common.Hash{}, errInvalidEvidence
+		}
+		return vote.Round.Uint64(), vote.Height.Uint64(), vote.ProposedBlockHash, nil
+	}
+	return 0, 0, common.Hash{}, errInvalidEvidence
+}
+
+// reportEquivocation records the evidence for two conflicting messages and
+// gossips it to the other validators.
+func (c *core) reportEquivocation(ctx context.Context, first, second *Message) {
+	ev, err := NewEvidence(first, second)
+	if err != nil {
+		c.logger.Error("Failed to build equivocation evidence", "err", err)
+		return
+	}
+	offence, err := VerifyEvidence(ev)
+	if err != nil {
+		c.logger.Debug("Discarding unverifiable equivocation", "err", err)
+		return
+	}
+	if !c.addEvidence(offence, ev) {
+		return
+	}
+	c.logger.Warn("Detected equivocation", "offender", offence.Offender, "height", offence.Height, "round", offence.Round, "code", offence.Code)
+
+	data, err := Encode(ev)
+	if err != nil {
+		c.logger.Error("Failed to encode equivocation evidence", "err", err)
+		return
+	}
+	msg := &Message{
+		Code:          msgEvidence,
+		Msg:           data,
+		Address:       c.address,
+		CommittedSeal: []byte{},
+	}
+	payload, err := c.finalizeMessage(msg)
+	if err != nil {
+		c.logger.Error("Failed to finalize evidence message", "err", err)
+		return
+	}
+	c.backend.Gossip(ctx, c.valSet.Copy(), payload)
+}
+
+// handleEvidence processes equivocation evidence gossiped by another validator.
+func (c *core) handleEvidence(msg *Message) error {
+	ev := new(types.BFTEvidence)
+	if err := msg.Decode(ev); err != nil {
+		return errInvalidEvidence
+	}
+	offence, err := CheckEvidence(ev, c.currentRoundState.Height().Uint64(), c.backend.Validators)
+	if err != nil {
+		return err
+	}
+	if !c.addEvidence(offence, ev) {
+		return errKnownEvidence
+	}
+	c.logger.Warn("Received equivocation evidence", "from", msg.Address, "offender", offence.Offender, "height", offence.Height, "round", offence.Round)
+	return nil
+}
+
+// addEvidence adds ev to the pending evidence. It returns false if evidence
+// for the same offence is already pending.
+func (c *core) addEvidence(offence *Offence, ev *types.BFTEvidence) bool {
+	c.evidenceMu.Lock()
+	defer c.evidenceMu.Unlock()
+
+	hash := offence.Hash()
+	if _, ok := c.evidence[hash]; ok {
+		return false
+	}
+	c.evidence[hash] = ev
+	return true
+}
+
+// includeEvidence adds the pending evidence which is still valid at the height
+// of header to its extra-data. The evidence is kept pending while the Autonity
+// contract cannot slash, blocks carrying it would be rejected.
+func (c *core) includeEvidence(header *types.Header) error {
+	c.evidenceMu.Lock()
+	defer c.evidenceMu.Unlock()
+
+	if len(c.evidence) == 0 {
+		return nil
+	}
+	if !canSlash(c.backend.GetContractABI()) {
+		c.logger.Error("Pending equivocation evidence not included, the Autonity contract has no slash method", "evidence", len(c.evidence))
+		return nil
+	}
+
+	number := header.Number.Uint64()
+	hashes := make([]common.Hash, 0, len(c.evidence))
+	for hash, ev := range c.evidence {
+		if _, err := CheckEvidence(ev, number, c.backend.Validators); err != nil {
+			c.logger.Debug("Dropping pending evidence", "err", err)
+			delete(c.evidence, hash)
+			continue
+		}
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	if len(hashes) > MaxBlockEvidence {
+		hashes = hashes[:MaxBlockEvidence]
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	evidence := make([]*types.BFTEvidence, len(hashes))
+	for i, hash := range hashes {
+		evidence[i] = c.evidence[hash]
+	}
+	return types.WriteEvidence(header, evidence)
+}
+
+// pruneEvidence removes the evidence included in block, as well as the
+// evidence which became too old to be included, from the pending evidence.
+func (c *core) pruneEvidence(block *types.Block) {
+	c.evidenceMu.Lock()
+	defer c.evidenceMu.Unlock()
+
+	if len(c.evidence) == 0 {
+		return
+	}
+	if extra, err := types.ExtractBFTHeaderExtra(block.Header()); err == nil {
+		for _, ev := range extra.Evidence {
+			if offence, err := VerifyEvidence(ev); err == nil {
+				delete(c.evidence, offence.Hash())
+			}
+		}
+	}
+	number := block.NumberU64()
+	for hash, ev := range c.evidence {
+		if offence, err := VerifyEvidence(ev); err != nil || offence.Height+MaxEvidenceAge <= number {
+			delete(c.evidence, hash)
+		}
+	}
+}
+
+// canSlash returns whether the Autonity contract of the given ABI can punish
+// the offences proven by evidence.
+func canSlash(contractABI string) bool {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return false
+	}
+	_, ok := parsed.Methods["slash"]
+	return ok
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+func newSignedVote(t *testing.T, code uint64, round, height int64, hash common.Hash, key *ecdsa.PrivateKey) *Message {
+	t.Helper()
+
+	vote, err := Encode(&Vote{Round: big.NewInt(round), Height: big.NewInt(height), ProposedBlockHash: hash})
+	if err != nil {
+		t.Fatalf("failed to encode vote: %v", err)
+	}
+	msg := &Message{
+		Code:          code,
+		Msg:           vote,
+		Address:       crypto.PubkeyToAddress(key.PublicKey),
+		CommittedSeal: []byte{},
+	}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	if msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key); err != nil {
+		t.Fatalf("failed to sign message: %v", err)
+	}
+	return msg
+}
+
+func TestVerifyEvidence(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	hashA := common.HexToHash("0x0a")
+	hashB := common.HexToHash("0x0b")
+
+	testCases := []struct {
+		name   string
+		first  *Message
+		second *Message
+		err    error
+	}{
+		{"double prevote", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrevote, 0, 5, hashB, key), nil},
+		{"prevote and nil prevote", newSignedVote(t, msgPrevote, 1, 5, hashA, key), newSignedVote(t, msgPrevote, 1, 5, common.Hash{}, key), nil},
+		{"double precommit", newSignedVote(t, msgPrecommit, 0, 5, hashA, key), newSignedVote(t, msgPrecommit, 0, 5, hashB, key), nil},
+		{"different rounds", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrevote, 1, 5, hashB, key), errInvalidEvidence},
+		{"different heights", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrevote, 0, 6, hashB, key), errInvalidEvidence},
+		{"different steps", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrecommit, 0, 5, hashB, key), errInvalidEvidence},
+		{"different senders", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrevote, 0, 5, hashB, other), errInvalidEvidence},
+		{"same vote", newSignedVote(t, msgPrevote, 0, 5, hashA, key), newSignedVote(t, msgPrevote, 0, 5, hashA, key), errInvalidEvidence},
+	}
+	for _, test := range testCases {
+		ev, err := NewEvidence(test.first, test.second)
+		if err != nil {
+			t.Fatalf("%s: failed to build evidence: %v", test.name, err)
+		}
+		offence, err := VerifyEvidence(ev)
+		if err != test.err {
+			t.Errorf("%s: error mismatch: have %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && offence.Offender != test.first.Address {
+			t.Errorf("%s: offender mismatch: have %v, want %v", test.name, offence.Offender, test.first.Address)
+		}
+	}
+
+	// A message whose signature does not match its sender is rejected
+	forged := newSignedVote(t, msgPrevote, 0, 5, hashB, other)
+	forged.Address = crypto.PubkeyToAddress(key.PublicKey)
+	ev, err := NewEvidence(newSignedVote(t, msgPrevote, 0, 5, hashA, key), forged)
+	if err != nil {
+		t.Fatalf("failed to build evidence: %v", err)
+	}
+	if _, err := VerifyEvidence(ev); err != errEvidenceSigner {
+		t.Errorf("error mismatch: have %v, want %v", err, errEvidenceSigner)
+	}
+}
+
+func TestCheckEvidence(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	offender := crypto.PubkeyToAddress(key.PublicKey)
+	validators := func(uint64) validator.Set {
+		return validator.NewSet([]common.Address{offender}, config.RoundRobin)
+	}
+	ev, err := NewEvidence(
+		newSignedVote(t, msgPrecommit, 0, 10, common.HexToHash("0x0a"), key),
+		newSignedVote(t, msgPrecommit, 0, 10, common.HexToHash("0x0b"), key),
+	)
+	if err != nil {
+		t.Fatalf("failed to build evidence: %v", err)
+	}
+
+	if _, err := CheckEvidence(ev, 10, validators); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+	if _, err := CheckEvidence(ev, 9, validators); err != errFutureEvidence {
+		t.Errorf("error mismatch: have %v, want %v", err, errFutureEvidence)
+	}
+	if _, err := CheckEvidence(ev, 11+MaxEvidenceAge, validators); err != errStaleEvidence {
+		t.Errorf("error mismatch: have %v, want %v", err, errStaleEvidence)
+	}
+	if _, err := CheckEvidence(ev, 10, func(uint64) validator.Set { return newTestValidatorSet(4) }); err != errEvidenceNotValidator {
+		t.Errorf("error mismatch: have %v, want %v", err, errEvidenceNotValidator)
+	}
+}
+
+func TestMessageSetConflictingVote(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	hashA := common.HexToHash("0x0a")
+	hashB := common.HexToHash("0x0b")
+
+	ms := newMessageSet()
+	if conflict := ms.AddVote(hashA, *newSignedVote(t, msgPrevote, 0, 1, hashA, key)); conflict != nil {
+		t.Fatalf("unexpected conflict for first vote: %v", conflict)
+	}
+	if conflict := ms.AddVote(hashA, *newSignedVote(t, msgPrevote, 0, 1, hashA, key)); conflict != nil {
+		t.Fatalf("unexpected conflict for repeated vote: %v", conflict)
+	}
+	if conflict := ms.AddVote(hashB, *newSignedVote(t, msgPrevote, 0, 1, hashB, key)); conflict == nil {
+		t.Fatal("expected conflict for vote on another block")
+	}
+	if conflict := ms.AddNilVote(*newSignedVote(t, msgPrevote, 0, 1, common.Hash{}, key)); conflict == nil {
+		t.Fatal("expected conflict for nil vote")
+	}
+}
+
+func TestIncludeEvidence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key, _ := crypto.GenerateKey()
+	offender := crypto.PubkeyToAddress(key.PublicKey)
+	ev, err := NewEvidence(
+		newSignedVote(t, msgPrecommit, 0, 10, common.HexToHash("0x0a"), key),
+		newSignedVote(t, msgPrecommit, 0, 10, common.HexToHash("0x0b"), key),
+	)
+	if err != nil {
+		t.Fatalf("failed to build evidence: %v", err)
+	}
+	offence, _ := VerifyEvidence(ev)
+	slashABI := `[{"constant":false,"inputs":[{"name":"_offender","type":"address"},{"name":"_evidence","type":"bytes32"}],"name":"slash","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+	noSlashABI := `[{"constant":true,"inputs":[],"name":"getValidators","outputs":[{"name":"","type":"address[]"}],"payable":false,"stateMutability":"view","type":"function"}]`
+
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().GetContractABI().Return(noSlashABI)
+	backendMock.EXPECT().GetContractABI().Return(slashABI)
+	backendMock.EXPECT().Validators(uint64(10)).Return(validator.NewSet([]common.Address{offender}, config.RoundRobin))
+
+	c := &core{
+		backend:  backendMock,
+		logger:   log.New(),
+		evidence: map[common.Hash]*types.BFTEvidence{offence.Hash(): ev},
+	}
+	newHeader := func() *types.Header {
+		extra, _ := types.PrepareExtra(nil, []common.Address{offender})
+		return &types.Header{Number: big.NewInt(11), Extra: extra}
+	}
+
+	// a contract which cannot slash keeps the evidence pending
+	header := newHeader()
+	if err := c.includeEvidence(header); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if extra, _ := types.ExtractBFTHeaderExtra(header); len(extra.Evidence) != 0 {
+		t.Errorf("evidence included for a contract without slash method: %d", len(extra.Evidence))
+	}
+	if len(c.evidence) != 1 {
+		t.Errorf("pending evidence mismatch: have %d, want 1", len(c.evidence))
+	}
+
+	header = newHeader()
+	if err := c.includeEvidence(header); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if extra, _ := types.ExtractBFTHeaderExtra(header); len(extra.Evidence) != 1 || extra.Evidence[0].Hash() != ev.Hash() {
+		t.Errorf("evidence mismatch: have %v, want %v", extra.Evidence, ev)
+	}
+}
@@ -23,10 +23,12 @@ import (
 	"time"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/crypto"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/events"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -54,11 +56,16 @@ func (c *core) Start(ctx context.Context, chain consensus.ChainReader, currentBl
 	}
 
 	c.subscribeEvents()
+	if c.journal == nil {
+		c.journal = journal.OpenCore(c.config.Journal, c.address, decodeMessage, c.logger)
+	}
 
 	// set currentRoundState before starting go routines
-	lastCommittedProposalBlock, _ := c.backend.LastCommittedProposal()
+	lastCommittedProposalBlock, _ := c.lastCommittedProposal()
 	height := new(big.Int).Add(lastCommittedProposalBlock.Number(), common.Big1)
 	c.currentRoundState.Update(big.NewInt(0), height)
+	// A validator restarted mid-height must keep its lock
+	c.restoredLock = c.loadLockState(height.Uint64())
 
 	//We need a separate go routine to keep c.latestPendingUnminedBlock up to date
 	go c.handleNewUnminedBlockEvent(ctx)
@@ -102,6 +109,9 @@ func (c *core) Stop() error {
 	<-c.stopped
 	<-c.stopped
 
+	c.journal.Stop(c.logger)
+	c.journal = nil
+
 	//err := c.backend.Close()
 	//if err != nil {
 	//	return err
@@ -148,6 +158,7 @@ eventLoop:
 			}
 			newUnminedBlockEvent := e.Data.(events.NewUnminedBlockEvent)
 			pb := &newUnminedBlockEvent.NewUnminedBlock
+			c.journalBlock(journal.KindRequest, pb)
 			c.storeUnminedBlockMsg(pb)
 		case <-ctx.Done():
 			c.logger.Info("handleNewUnminedBlockEvent is stopped", "event", ctx.Err())
@@ -172,58 +183,17 @@ eventLoop:
 				break eventLoop
 			}
 			// A real ev arrived, process interesting content
-			switch e := ev.Data.(type) {
-			case events.MessageEvent:
-				if len(e.Payload) == 0 {
-					c.logger.Error("core.handleConsensusEvents Get message(MessageEvent) empty payload")
-				}
-
-				c.logger.Debug("$$$ tendermint, handleEvents, MessageEvent arrived, will send Gossip to valSet")
-
-				if err := c.handleMsg(ctx, e.Payload); err != nil {
-					c.logger.Debug("core.handleConsensusEvents Get message(MessageEvent) payload failed", "err", err)
-					continue
-				}
-				c.backend.Gossip(ctx, c.valSet.Copy(), e.Payload)
-			case backlogEvent:
-				// No need to check signature for internal messages
-				c.logger.Debug("Started handling backlogEvent")
-				err := c.handleCheckedMsg(ctx, e.msg, e.src)
-				if err != nil {
-					c.logger.Debug("core.handleConsensusEvents handleCheckedMsg message failed", "err", err)
-					continue
-				}
-
-				p, err := e.msg.Payload()
-				if err != nil {
-					c.logger.Debug("core.handleConsensusEvents Get message payload failed", "err", err)
-					continue
-				}
-
-				c.backend.Gossip(ctx, c.valSet.Copy(), p)
-			}
+			c.handleEvent(ctx, ev.Data)
 		case ev, ok := <-c.timeoutEventSub.Chan():
 			if !ok {
 				break eventLoop
 			}
-			if timeoutE, ok := ev.Data.(TimeoutEvent); ok {
-				switch timeoutE.step {
-				case msgProposal:
-					c.handleTimeoutPropose(ctx, timeoutE)
-				case msgPrevote:
-					c.handleTimeoutPrevote(ctx, timeoutE)
-				case msgPrecommit:
-					c.handleTimeoutPrecommit(ctx, timeoutE)
-				}
-			}
+			c.handleEvent(ctx, ev.Data)
 		case ev, ok := <-c.committedSub.Chan():
 			if !ok {
 				break eventLoop
 			}
-			switch ev.Data.(type) {
-			case events.CommitEvent:
-				c.handleCommit(ctx)
-			}
+			c.handleEvent(ctx, ev.Data)
 		case <-ctx.Done():
 			c.logger.Info("handleConsensusEvents is stopped", "event", ctx.Err())
 			break eventLoop
@@ -233,6 +203,68 @@ eventLoop:
 	c.stopped <- struct{}{}
 }
 
+// handleEvent journals a single consensus event before processing it, it is
+// shared by the event loop and the journal replay.
+func (c *core) handleEvent(ctx context.Context, event interface{}) {
+	switch e := event.(type) {
+	case events.MessageEvent:
+		if len(e.Payload) == 0 {
+			c.logger.Error("core.handleConsensusEvents Get message(MessageEvent) empty payload")
+		}
+
+		c.logger.Debug("$$$ tendermint, handleEvents, MessageEvent arrived, will send Gossip to valSet")
+
+		entry := c.journal.Message(journal.KindReceived, e.Peer, e.Payload)
+		err := c.handleMsg(ctx, e.Payload)
+		c.journal.Failed(entry, err)
+		if err != nil {
+			c.logger.Debug("core.handleConsensusEvents Get message(MessageEvent) payload failed", "err", err)
+			return
+		}
+		c.backend.Gossip(ctx, c.valSet.Copy(), e.Payload)
+	case backlogEvent:
+		// No need to check signature for internal messages
+		c.logger.Debug("Started handling backlogEvent")
+		var entry *journal.Entry
+		p, perr := e.msg.Payload()
+		if perr == nil {
+			entry = c.journal.Message(journal.KindBacklog, enode.ID{}, p)
+		}
+		err := c.handleCheckedMsg(ctx, e.msg, e.src)
+
+		if perr != nil {
+			c.logger.Debug("core.handleConsensusEvents Get message payload failed", "err", perr)
+			return
+		}
+		c.journal.Failed(entry, err)
+		if err != nil {
+			c.logger.Debug("core.handleConsensusEvents handleCheckedMsg message failed", "err", err)
+			return
+		}
+
+		c.backend.Gossip(ctx, c.valSet.Copy(), p)
+	case TimeoutEvent:
+		c.journalTimeout(e)
+		switch e.step {
+		case msgProposal:
+			c.handleTimeoutPropose(ctx, e)
+		case msgPrevote:
+			c.handleTimeoutPrevote(ctx, e)
+		case msgPrecommit:
+			c.handleTimeoutPrecommit(ctx, e)
+		}
+	case events.CommitEvent:
+		if c.journal != nil {
+			c.journal.Write(&journal.Entry{
+				Kind:   journal.KindCommitted,
+				Height: c.currentRoundState.Height().Uint64(),
+				Round:  c.currentRoundState.Round().Uint64(),
+			})
+		}
+		c.handleCommit(ctx)
+	}
+}
+
 func (c *core) syncLoop(ctx context.Context) {
 	/*
 		this method is responsible for asking the network to send us the current consensus state
@@ -288,6 +320,7 @@ func (c *core) handleMsg(ctx context.Context, payload []byte) error {
 		logger.Error("Failed to decode message from payload", "err", err)
 		return err
 	}
+	c.health.Heard((*sender).Address())
 
 	return c.handleCheckedMsg(ctx, msg, *sender)
 }
@@ -350,6 +383,9 @@ func (c *core) handleCheckedMsg(ctx context.Context, msg *Message, sender valida
 	case msgPrecommit:
 		logger.Debug("tendermint.MessageEvent: PRECOMMIT", "msg", msg)
 		return testBacklog(c.handlePrecommit(ctx, msg))
+	case msgEvidence:
+		logger.Debug("tendermint.MessageEvent: EVIDENCE", "msg", msg)
+		return c.handleEvidence(msg)
 	default:
 		logger.Error("Invalid message", "msg", msg)
 	}
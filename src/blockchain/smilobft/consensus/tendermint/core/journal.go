@@ -0,0 +1,124 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// codeName returns the journal name of a message code
+func codeName(code uint64) string {
+	switch code {
+	case msgProposal:
+		return "proposal"
+	case msgPrevote:
+		return "prevote"
+	case msgPrecommit:
+		return "precommit"
+	case msgEvidence:
+		return "evidence"
+	}
+	return "unknown"
+}
+
+// decodeMessage fills the code, signer and view of a journaled message
+func decodeMessage(e *journal.Entry) {
+	msg := new(Message)
+	if rlp.DecodeBytes(e.Payload, msg) != nil {
+		return
+	}
+	e.Code = codeName(msg.Code)
+	e.Address = msg.Address
+	if round, height, _, err := evidenceMessageView(msg); err == nil {
+		e.Height, e.Round = height, round
+	}
+}
+
+// journalTimeout records a timeout with the view it was scheduled for
+func (c *core) journalTimeout(ev TimeoutEvent) {
+	if c.journal == nil {
+		return
+	}
+	c.journal.Write(&journal.Entry{
+		Kind:   journal.KindTimeout,
+		Code:   codeName(ev.step),
+		Height: uint64(ev.heightWhenCalled),
+		Round:  uint64(ev.roundWhenCalled),
+	})
+}
+
+// journalBlock records a block handed to the core
+func (c *core) journalBlock(kind string, block *types.Block) {
+	if c.journal == nil {
+		return
+	}
+	payload, _ := rlp.EncodeToBytes(block)
+	c.journal.Write(&journal.Entry{Kind: kind, Height: block.NumberU64(), Payload: payload})
+}
+
+// lastCommittedProposal asks the backend for the last committed block and journals the answer
+func (c *core) lastCommittedProposal() (*types.Block, common.Address) {
+	block, proposer := c.backend.LastCommittedProposal()
+	if c.journal != nil && block != nil {
+		payload, _ := rlp.EncodeToBytes(block)
+		c.journal.Write(&journal.Entry{
+			Kind:    journal.KindHead,
+			Address: proposer,
+			Height:  block.NumberU64(),
+			Payload: payload,
+		})
+	}
+	return block, proposer
+}
+
+// validators asks the backend for the validators of a height and journals the answer
+func (c *core) validators(number uint64) validator.Set {
+	valSet := c.backend.Validators(number)
+	if c.journal != nil && valSet != nil {
+		entry := &journal.Entry{
+			Kind:   journal.KindValidators,
+			Height: number,
+			Quorum: valSet.Quorum(),
+			Faulty: valSet.F(),
+		}
+		for _, val := range valSet.List() {
+			entry.Validators = append(entry.Validators, val.Address())
+		}
+		c.journal.Write(entry)
+	}
+	return valSet
+}
+
+// verifyProposal asks the backend to verify a block and journals the answer
+func (c *core) verifyProposal(block types.Block) (time.Duration, error) {
+	duration, err := c.backend.VerifyProposal(block)
+	if c.journal != nil {
+		c.journal.Write(&journal.Entry{
+			Kind:   journal.KindVerify,
+			Height: block.NumberU64(),
+			Error:  journal.ErrString(err),
+		})
+	}
+	return duration, err
+}
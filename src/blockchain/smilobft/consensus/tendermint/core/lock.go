@@ -0,0 +1,190 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// lockStateKey is the database key of the round state of the height the node
+// is working on. Only the last height is kept.
+var lockStateKey = []byte("tendermint-lock")
+
+// lockState is the part of the round state a validator must not forget across
+// a restart: prevoting against its own lock breaks the safety of Tendermint,
+// and signing another message in a round it already took part in is an
+// equivocation. The locked and valid rounds are only meaningful when their
+// value is set.
+type lockState struct {
+	Height      uint64
+	LockedRound uint64
+	LockedValue *types.Block
+	ValidRound  uint64
+	ValidValue  *types.Block
+	Round       uint64
+	Sent        []sentMessage
+}
+
+// sentMessage is a proposal, prevote or precommit the node signed at the
+// current height.
+type sentMessage struct {
+	Code  uint64
+	Round uint64
+	Hash  common.Hash
+}
+
+// EncodeRLP implements rlp.Encoder, a nil block is encoded as an empty list
+func (s *lockState) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{
+		s.Height,
+		s.LockedRound,
+		optionalBlock(s.LockedValue),
+		s.ValidRound,
+		optionalBlock(s.ValidValue),
+		s.Round,
+		s.Sent,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder
+func (s *lockState) DecodeRLP(stream *rlp.Stream) error {
+	var state struct {
+		Height      uint64
+		LockedRound uint64
+		LockedValue []*types.Block
+		ValidRound  uint64
+		ValidValue  []*types.Block
+		Round       uint64
+		Sent        []sentMessage
+	}
+	if err := stream.Decode(&state); err != nil {
+		return err
+	}
+	s.Height, s.LockedRound, s.ValidRound = state.Height, state.LockedRound, state.ValidRound
+	s.Round, s.Sent = state.Round, state.Sent
+	if len(state.LockedValue) > 0 {
+		s.LockedValue = state.LockedValue[0]
+	}
+	if len(state.ValidValue) > 0 {
+		s.ValidValue = state.ValidValue[0]
+	}
+	return nil
+}
+
+func optionalBlock(block *types.Block) []*types.Block {
+	if block == nil {
+		return []*types.Block{}
+	}
+	return []*types.Block{block}
+}
+
+// storeLockState writes the round state of the current height to the
+// database. It has to run before any message depending on it is sent.
+func (c *core) storeLockState() error {
+	if c.db == nil {
+		return nil
+	}
+	state := &lockState{
+		Height:      c.currentRoundState.Height().Uint64(),
+		LockedValue: c.lockedValue,
+		ValidValue:  c.validValue,
+		Round:       c.currentRoundState.Round().Uint64(),
+		Sent:        c.sent,
+	}
+	if c.lockedValue != nil {
+		state.LockedRound = c.lockedRound.Uint64()
+	}
+	if c.validValue != nil {
+		state.ValidRound = c.validRound.Uint64()
+	}
+	blob, err := rlp.EncodeToBytes(state)
+	if err != nil {
+		c.logger.Error("Failed to encode the lock state", "err", err)
+		return err
+	}
+	if err := c.db.Put(lockStateKey, blob); err != nil {
+		c.logger.Error("Failed to store the lock state", "height", state.Height, "err", err)
+		return err
+	}
+	return nil
+}
+
+// recordSent persists a message of the current round before it is signed and
+// broadcast. It returns false if the node already sent another message of the
+// same kind in this round, before a restart or not, or if the message could
+// not be persisted: the message must not be sent then.
+func (c *core) recordSent(code uint64, hash common.Hash) bool {
+	round := c.currentRoundState.Round().Uint64()
+	for _, sent := range c.sent {
+		if sent.Code != code || sent.Round != round {
+			continue
+		}
+		if sent.Hash != hash {
+			c.logger.Warn("Refusing to sign a conflicting message", "code", code, "round", round, "sent", sent.Hash, "hash", hash)
+			return false
+		}
+		return true
+	}
+	c.sent = append(c.sent, sentMessage{Code: code, Round: round, Hash: hash})
+	if err := c.storeLockState(); err != nil {
+		c.sent = c.sent[:len(c.sent)-1]
+		return false
+	}
+	return true
+}
+
+// loadLockState reads the lock state stored for the given height, nil if the
+// node didn't lock or see a valid value at that height before it stopped.
+func (c *core) loadLockState(height uint64) *lockState {
+	if c.db == nil {
+		return nil
+	}
+	blob, err := c.db.Get(lockStateKey)
+	if err != nil {
+		return nil
+	}
+	state := new(lockState)
+	if err := rlp.DecodeBytes(blob, state); err != nil {
+		c.logger.Error("Failed to decode the lock state", "err", err)
+		return nil
+	}
+	if state.Height != height {
+		return nil
+	}
+	return state
+}
+
+// restoreLockState sets the locked and valid values and the sent messages of a
+// state loaded on start
+func (c *core) restoreLockState(state *lockState) {
+	c.sent = state.Sent
+	if state.LockedValue != nil {
+		c.lockedValue = state.LockedValue
+		c.lockedRound = new(big.Int).SetUint64(state.LockedRound)
+	}
+	if state.ValidValue != nil {
+		c.validValue = state.ValidValue
+		c.validRound = new(big.Int).SetUint64(state.ValidRound)
+	}
+	c.logger.Info("Restored the lock state", "height", state.Height, "round", state.Round, "lockedRound", c.lockedRound, "validRound", c.validRound, "sent", len(state.Sent))
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+func TestLockState(t *testing.T) {
+	t.Run("stored lock state is loaded at the same height only", func(t *testing.T) {
+		logger := log.New("backend", "test", "id", 0)
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3)})
+
+		c := &core{
+			db:                rawdb.NewMemoryDatabase(),
+			logger:            logger,
+			currentRoundState: NewRoundState(big.NewInt(2), big.NewInt(3)),
+			lockedRound:       big.NewInt(1),
+			lockedValue:       block,
+			validRound:        big.NewInt(2),
+			validValue:        block,
+		}
+		if err := c.storeLockState(); err != nil {
+			t.Fatal(err)
+		}
+
+		if state := c.loadLockState(4); state != nil {
+			t.Fatalf("Expected no lock state at another height, got %v", state)
+		}
+		state := c.loadLockState(3)
+		if state == nil {
+			t.Fatal("Expected a lock state")
+		}
+		if state.LockedRound != 1 || state.ValidRound != 2 || state.Round != 2 {
+			t.Fatalf("Expected locked round 1, valid round 2 and round 2, got %d, %d and %d", state.LockedRound, state.ValidRound, state.Round)
+		}
+		if state.LockedValue.Hash() != block.Hash() || state.ValidValue.Hash() != block.Hash() {
+			t.Fatalf("Expected the locked and valid values %v, got %v and %v", block.Hash(), state.LockedValue.Hash(), state.ValidValue.Hash())
+		}
+	})
+
+	t.Run("lock state without a lock keeps the values unset", func(t *testing.T) {
+		c := &core{
+			db:                rawdb.NewMemoryDatabase(),
+			logger:            log.New("backend", "test", "id", 0),
+			currentRoundState: NewRoundState(big.NewInt(0), big.NewInt(3)),
+			lockedRound:       big.NewInt(-1),
+			validRound:        big.NewInt(-1),
+		}
+		if err := c.storeLockState(); err != nil {
+			t.Fatal(err)
+		}
+
+		state := c.loadLockState(3)
+		if state == nil {
+			t.Fatal("Expected a lock state")
+		}
+		if state.LockedValue != nil || state.ValidValue != nil {
+			t.Fatalf("Expected no locked and valid values, got %v and %v", state.LockedValue, state.ValidValue)
+		}
+	})
+
+	t.Run("lock state is kept in memory without a database", func(t *testing.T) {
+		c := &core{
+			logger:            log.New("backend", "test", "id", 0),
+			currentRoundState: NewRoundState(big.NewInt(0), big.NewInt(3)),
+		}
+		if err := c.storeLockState(); err != nil {
+			t.Fatal(err)
+		}
+
+		if state := c.loadLockState(3); state != nil {
+			t.Fatalf("Expected no lock state, got %v", state)
+		}
+	})
+}
+
+func TestLockStateRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := log.New("backend", "test", "id", 0)
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3)})
+	addr := common.HexToAddress("0x0123456789")
+
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().Validators(uint64(3)).Return(validator.NewSet([]common.Address{addr}, config.RoundRobin))
+
+	c := &core{
+		backend:           backendMock,
+		logger:            logger,
+		currentRoundState: NewRoundState(big.NewInt(0), big.NewInt(3)),
+		valSet:            new(validatorSet),
+		proposeTimeout:    newTimeout(propose, logger),
+		prevoteTimeout:    newTimeout(prevote, logger),
+		precommitTimeout:  newTimeout(precommit, logger),
+		restoredLock: &lockState{
+			Height:      3,
+			LockedRound: 1,
+			LockedValue: block,
+		},
+	}
+	c.setCore(big.NewInt(0), big.NewInt(3), addr)
+
+	if c.lockedRound.Int64() != 1 || c.lockedValue != block {
+		t.Fatalf("Expected the lock of round 1 to be restored, got %v and %v", c.lockedRound, c.lockedValue)
+	}
+	if c.validRound.Int64() != -1 || c.validValue != nil {
+		t.Fatalf("Expected no valid value, got %v and %v", c.validRound, c.validValue)
+	}
+	if c.restoredLock != nil {
+		t.Fatal("Expected the restored lock to be taken back once")
+	}
+}
+
+func TestLockStateStoredBeforePrecommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := log.New("backend", "test", "id", 0)
+	addr := common.HexToAddress("0x0123456789")
+
+	proposal := NewProposal(
+		big.NewInt(2),
+		big.NewInt(3),
+		big.NewInt(-1),
+		types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3)}),
+		logger)
+
+	curRoundState := NewRoundState(big.NewInt(2), big.NewInt(3))
+	curRoundState.SetProposal(proposal, nil)
+	curRoundState.SetStep(prevote)
+
+	encodedVote, err := Encode(&Vote{
+		Round:             big.NewInt(2),
+		Height:            big.NewInt(3),
+		ProposedBlockHash: curRoundState.GetCurrentProposalHash(),
+	})
+	if err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	msg := &Message{
+		Code:          msgPrevote,
+		Msg:           encodedVote,
+		Address:       addr,
+		CommittedSeal: []byte{},
+		Signature:     []byte{0x1},
+	}
+
+	c := &core{
+		db:                rawdb.NewMemoryDatabase(),
+		address:           addr,
+		currentRoundState: curRoundState,
+		logger:            logger,
+		prevoteTimeout:    newTimeout(prevote, logger),
+		valSet:            new(validatorSet),
+	}
+
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().Sign(gomock.Any()).Return([]byte{0x1}, nil).AnyTimes()
+	backendMock.EXPECT().Broadcast(context.Background(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, valSet validator.Set, payload []byte) error {
+			state := c.loadLockState(3)
+			if state == nil || state.LockedValue == nil || state.LockedRound != 2 {
+				t.Fatalf("Expected the lock of round 2 to be stored before the precommit, got %v", state)
+			}
+			return nil
+		})
+	c.backend = backendMock
+
+	if err := c.handlePrevote(context.Background(), msg); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	if c.currentRoundState.Step() != precommit {
+		t.Fatalf("Expected the precommit step, got %v", c.currentRoundState.Step())
+	}
+}
+
+func TestLockStateRestart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := rawdb.NewMemoryDatabase()
+	addr := common.HexToAddress("0x0123456789")
+	other := common.HexToAddress("0x9876543210")
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3)})
+	conflicting := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3), Extra: []byte{0x1}})
+
+	// the proposer doesn't wait for a block to propose
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var broadcast []uint64
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().Address().Return(addr).AnyTimes()
+	backendMock.EXPECT().LastCommittedProposal().Return(parent, other).AnyTimes()
+	backendMock.EXPECT().Validators(uint64(3)).Return(validator.NewSet([]common.Address{addr, other}, config.RoundRobin)).AnyTimes()
+	backendMock.EXPECT().Sign(gomock.Any()).Return([]byte{0x1}, nil).AnyTimes()
+	backendMock.EXPECT().Post(gomock.Any()).AnyTimes()
+	backendMock.EXPECT().Broadcast(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, valSet validator.Set, payload []byte) error {
+			msg := new(Message)
+			if err := rlp.DecodeBytes(payload, msg); err != nil {
+				t.Fatal(err)
+			}
+			broadcast = append(broadcast, msg.Code)
+			return nil
+		}).AnyTimes()
+
+	propose := func(c *core, block *types.Block) {
+		proposal := NewProposal(c.currentRoundState.Round(), big.NewInt(3), big.NewInt(-1), block, c.logger)
+		c.currentRoundState.SetProposal(proposal, nil)
+	}
+	stopTimers := func(c *core) {
+		_ = c.proposeTimeout.stopTimer()
+		_ = c.prevoteTimeout.stopTimer()
+		_ = c.precommitTimeout.stopTimer()
+	}
+
+	// the node prevotes for a block in round 1 and stops before its precommit
+	c := New(backendMock, config.DefaultConfig(), db)
+	c.startRound(ctx, common.Big0)
+	c.startRound(ctx, common.Big1)
+	propose(c, block)
+	c.sendPrevote(ctx, false)
+	stopTimers(c)
+	if len(broadcast) != 1 || broadcast[0] != msgPrevote {
+		t.Fatalf("Expected a prevote to be sent, got %v", broadcast)
+	}
+
+	// after the restart it resumes round 1 and refuses to vote for anything else
+	restarted := New(backendMock, config.DefaultConfig(), db)
+	restarted.currentRoundState.Update(common.Big0, big.NewInt(3))
+	restarted.restoredLock = restarted.loadLockState(3)
+	restarted.startRound(ctx, common.Big0)
+	defer stopTimers(restarted)
+	if restarted.currentRoundState.Round().Int64() != 1 {
+		t.Fatalf("Expected round 1 to be resumed, got %v", restarted.currentRoundState.Round())
+	}
+
+	propose(restarted, conflicting)
+	restarted.sendPrevote(ctx, false)
+	restarted.sendPrevote(ctx, true)
+	if len(broadcast) != 1 {
+		t.Fatalf("Expected no conflicting prevote to be sent, got %v", broadcast)
+	}
+
+	// the same vote can be sent again, and the votes it didn't send yet are allowed
+	propose(restarted, block)
+	restarted.sendPrevote(ctx, false)
+	restarted.sendPrecommit(ctx, false)
+	if len(broadcast) != 3 || broadcast[1] != msgPrevote || broadcast[2] != msgPrecommit {
+		t.Fatalf("Expected the prevote again and a precommit, got %v", broadcast)
+	}
+	state := restarted.loadLockState(3)
+	if state == nil || state.Round != 1 || len(state.Sent) != 2 {
+		t.Fatalf("Expected the prevote and the precommit of round 1 to be stored, got %v", state)
+	}
+}
@@ -33,6 +33,7 @@ const (
 	msgProposal uint64 = iota
 	msgPrevote
 	msgPrecommit
+	msgEvidence
 )
 
 type Message struct {
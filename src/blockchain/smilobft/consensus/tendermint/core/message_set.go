@@ -38,7 +38,9 @@ type messageSet struct {
 	messagesMu *sync.RWMutex
 }
 
-func (ms *messageSet) AddVote(blockHash common.Hash, msg Message) {
+// AddVote adds a vote for blockHash to the set. If the sender already voted for
+// another value in this set, the earlier conflicting vote is returned.
+func (ms *messageSet) AddVote(blockHash common.Hash, msg Message) *Message {
 	var addressesMap map[common.Address]Message
 	var ok bool
 
@@ -49,23 +51,52 @@ func (ms *messageSet) AddVote(blockHash common.Hash, msg Message) {
 	addressesMap = ms.votes[blockHash]
 
 	if _, ok := addressesMap[msg.Address]; ok {
-		return
+		return nil
 	}
+	conflict := ms.conflictingVote(blockHash, msg.Address)
 
 	addressesMap[msg.Address] = msg
 
 	ms.messagesMu.Lock()
 	ms.messages = append(ms.messages, &msg)
 	ms.messagesMu.Unlock()
+
+	return conflict
+}
+
+// AddNilVote adds a nil vote to the set. If the sender already voted for a block
+// in this set, the earlier conflicting vote is returned.
+func (ms *messageSet) AddNilVote(msg Message) *Message {
+	if _, ok := ms.nilvotes[msg.Address]; ok {
+		return nil
+	}
+	conflict := ms.conflictingVote(common.Hash{}, msg.Address)
+
+	ms.nilvotes[msg.Address] = msg
+	ms.messagesMu.Lock()
+	ms.messages = append(ms.messages, &msg)
+	ms.messagesMu.Unlock()
+
+	return conflict
 }
 
-func (ms *messageSet) AddNilVote(msg Message) {
-	if _, ok := ms.nilvotes[msg.Address]; !ok {
-		ms.nilvotes[msg.Address] = msg
-		ms.messagesMu.Lock()
-		ms.messages = append(ms.messages, &msg)
-		ms.messagesMu.Unlock()
+// conflictingVote returns a vote of addr for any value other than blockHash,
+// the zero hash standing for nil.
+func (ms *messageSet) conflictingVote(blockHash common.Hash, addr common.Address) *Message {
+	if blockHash != (common.Hash{}) {
+		if m, ok := ms.nilvotes[addr]; ok {
+			return &m
+		}
+	}
+	for hash, votes := range ms.votes {
+		if hash == blockHash {
+			continue
+		}
+		if m, ok := votes[addr]; ok {
+			return &m
+		}
 	}
+	return nil
 }
 
 func (ms *messageSet) GetMessages() []*Message {
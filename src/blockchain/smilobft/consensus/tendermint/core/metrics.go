@@ -17,7 +17,12 @@
 package core
 
 import (
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/metrics"
+
+	"go-smilo/src/blockchain/smilobft/consensus/health"
 )
 
 var (
@@ -27,3 +32,40 @@ var (
 	tendermintPrevoteTimer      = metrics.NewRegisteredTimer("tendermint/timer/prevote", nil)
 	tendermintPrecommitTimer    = metrics.NewRegisteredTimer("tendermint/timer/precommit", nil)
 )
+
+// Status returns the current round state and the validators not heard from lately
+func (c *core) Status() *health.Status {
+	return c.health.Status()
+}
+
+// trackRound records the round that just started
+func (c *core) trackRound() {
+	if c.health == nil {
+		return
+	}
+	var proposer common.Address
+	if p := c.valSet.GetProposer(); p != nil {
+		proposer = p.Address()
+	}
+	vals := c.valSet.List()
+	validators := make([]common.Address, 0, len(vals))
+	for _, val := range vals {
+		validators = append(validators, val.Address())
+	}
+	quorum := int(math.Ceil(float64(2) / float64(3) * float64(len(vals))))
+	c.health.NewRound(c.currentRoundState.Height().Uint64(), c.currentRoundState.Round().Uint64(), proposer, validators, quorum)
+}
+
+// trackBacklog records the number of backlogged messages, backlogsMu must be held
+func (c *core) trackBacklog() {
+	if c.health == nil {
+		return
+	}
+	size := 0
+	for _, backlog := range c.backlogs {
+		if backlog != nil {
+			size += backlog.Size()
+		}
+	}
+	c.health.Backlog(size)
+}
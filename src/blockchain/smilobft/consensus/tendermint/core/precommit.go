@@ -67,6 +67,9 @@ func (c *core) sendPrecommit(ctx context.Context, isNil bool) {
 	}
 
 	c.sentPrecommit = true
+	if !c.recordSent(msgPrecommit, precommit.ProposedBlockHash) {
+		return
+	}
 	c.broadcast(ctx, msg)
 }
 
@@ -111,7 +114,7 @@ func (c *core) handlePrecommit(ctx context.Context, msg *Message) error {
 	curR := c.currentRoundState.Round().Int64()
 	curH := c.currentRoundState.Height().Int64()
 
-	c.acceptVote(c.currentRoundState, precommit, precommitHash, *msg)
+	c.acceptVote(ctx, c.currentRoundState, precommit, precommitHash, *msg)
 
 	c.logPrecommitMessageEvent("MessageEvent(Precommit): Received", preCommit, msg.Address.String(), c.address.String())
 
@@ -161,7 +164,8 @@ func (c *core) verifyPrecommitCommittedSeal(addressMsg common.Address, committed
 
 func (c *core) handleCommit(ctx context.Context) {
 	c.logger.Debug("Received a final committed proposal", "step", c.currentRoundState.Step())
-	lastBlock, _ := c.backend.LastCommittedProposal()
+	lastBlock, _ := c.lastCommittedProposal()
+	c.pruneEvidence(lastBlock)
 	height := new(big.Int).Add(lastBlock.Number(), common.Big1).Uint64()
 	if height == c.currentRoundState.Height().Uint64() {
 		c.logger.Debug("Discarding event as core is at the same height", "state_height", c.currentRoundState.Height().Uint64())
@@ -59,6 +59,9 @@ func (c *core) sendPrevote(ctx context.Context, isNil bool) {
 	logger.Debug("MessageEvent(Prevote): broadcast", "From", c.address, "targetMsg", targetMsg)
 
 	c.sentPrevote = true
+	if !c.recordSent(msgPrevote, prevote.ProposedBlockHash) {
+		return
+	}
 	c.broadcast(ctx, targetMsg)
 }
 
@@ -85,7 +88,7 @@ func (c *core) handlePrevote(ctx context.Context, msg *Message) error {
 				)
 				c.currentHeightOldRoundsStates[preVote.Round.Int64()] = oldRoundState
 			}
-			c.acceptVote(oldRoundState, prevote, preVote.ProposedBlockHash, *msg)
+			c.acceptVote(ctx, oldRoundState, prevote, preVote.ProposedBlockHash, *msg)
 		}
 		return err
 	}
@@ -95,7 +98,7 @@ func (c *core) handlePrevote(ctx context.Context, msg *Message) error {
 	// will update the step to at least prevote and when it handle its on preVote(nil), then it will also have
 	// votes from other nodes.
 	prevoteHash := preVote.ProposedBlockHash
-	c.acceptVote(c.currentRoundState, prevote, prevoteHash, *msg)
+	c.acceptVote(ctx, c.currentRoundState, prevote, prevoteHash, *msg)
 
 	c.logPrevoteMessageEvent("MessageEvent(Prevote): Received", preVote, msg.Address.String(), c.address.String())
 
@@ -115,14 +118,19 @@ func (c *core) handlePrevote(ctx context.Context, msg *Message) error {
 			}
 			c.logger.Debug("Stopped Scheduled Prevote Timeout")
 
-			if c.currentRoundState.Step() == prevote {
+			lock := c.currentRoundState.Step() == prevote
+			if lock {
 				c.lockedValue = c.currentRoundState.Proposal().ProposalBlock
 				c.lockedRound = big.NewInt(curR)
-				c.sendPrecommit(ctx, false)
-				c.setStep(precommit)
 			}
 			c.validValue = c.currentRoundState.Proposal().ProposalBlock
 			c.validRound = big.NewInt(curR)
+			// The lock has to survive a crash before the precommit leaves the node
+			c.storeLockState()
+			if lock {
+				c.sendPrecommit(ctx, false)
+				c.setStep(precommit)
+			}
 			c.setValidRoundAndValue = true
 			// Line 44 in Algorithm 1 of The latest gossip on BFT consensus
 		} else if c.currentRoundState.Step() == prevote && c.Quorum(c.currentRoundState.Prevotes.NilVotesSize()) {
@@ -432,7 +432,7 @@ func TestHandlePrevote(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().AnyTimes().Return(addr)
 
-		c := New(backendMock, nil)
+		c := New(backendMock, nil, nil)
 		c.currentRoundState = curRoundState
 		c.prevoteTimeout = newTimeout(prevote, logger)
 		c.valSet = &validatorSet{
@@ -50,6 +50,9 @@ func (c *core) sendProposal(ctx context.Context, p *types.Block) {
 		}
 
 		c.sentProposal = true
+		if !c.recordSent(msgProposal, p.Hash()) {
+			return
+		}
 		c.backend.SetProposedBlockHash(p.Hash())
 
 		msg := &Message{
@@ -87,8 +90,15 @@ func (c *core) handleProposal(ctx context.Context, msg *Message) error {
 		return errNotFromProposer
 	}
 
+	// A proposer sending two different blocks for the same round equivocates
+	if prev := c.currentRoundState.ProposalMsg(); prev != nil && prev.Address == msg.Address {
+		if prevHash := c.currentRoundState.GetCurrentProposalHash(); prevHash != proposal.ProposalBlock.Hash() {
+			c.reportEquivocation(ctx, prev, msg)
+		}
+	}
+
 	// Verify the proposal we received
-	if duration, err := c.backend.VerifyProposal(*proposal.ProposalBlock); err != nil {
+	if duration, err := c.verifyProposal(*proposal.ProposalBlock); err != nil {
 		c.logger.Warn("Verify the proposal we received", "msg", msg, "duration", duration, "proposal.ProposalBlock", proposal.ProposalBlock)
 
 		if timeoutErr := c.proposeTimeout.stopTimer(); timeoutErr != nil {
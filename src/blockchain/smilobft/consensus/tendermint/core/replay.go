@@ -0,0 +1,250 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/events"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/validator"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// errNoJournalStart is returned when a journal doesn't begin with a start entry.
+var errNoJournalStart = errors.New("journal has no start entry")
+
+// Replay feeds a consensus journal back into a fresh core. The recorded inputs
+// are handled one by one in journal order, the chain answers are served back
+// as they were recorded and the timeouts only fire from the journal, so the
+// replay is deterministic. Every step of the replayed core is journaled to out,
+// to be compared with the original journal. A journal appended over several
+// runs is replayed as one core per start entry.
+//
+// Unmined blocks are handed to the core up front, so a proposer that waited
+// for the miner may propose earlier on replay than it did originally.
+func Replay(entries []*journal.Entry, config *config.Config, out *journal.Journal) error {
+	if len(entries) == 0 || entries[0].Kind != journal.KindStart {
+		return errNoJournalStart
+	}
+	backend, err := newReplayBackend(entries, config.GetProposerPolicy())
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var c *core
+	for i, e := range entries {
+		var event interface{}
+		switch e.Kind {
+		case journal.KindStart:
+			if c != nil {
+				c.stopTimers()
+			}
+			backend.address = e.Address
+			c = New(backend, config, nil)
+			c.replaying = true
+			c.journal = out
+			c.journal.Start(c.address, decodeMessage)
+
+			lastBlock, _ := c.lastCommittedProposal()
+			c.currentRoundState.Update(big.NewInt(0), new(big.Int).Add(lastBlock.Number(), common.Big1))
+			for number, block := range backend.blocks {
+				if number > lastBlock.NumberU64() {
+					c.pendingUnminedBlocks[number] = block
+				}
+			}
+			c.startRound(ctx, common.Big0)
+		case journal.KindRequest:
+			block := new(types.Block)
+			if err := rlp.DecodeBytes(e.Payload, block); err != nil {
+				c.stopTimers()
+				return fmt.Errorf("journal entry %d: %v", i, err)
+			}
+			c.journalBlock(journal.KindRequest, block)
+			// Nobody waits on the unmined block channel during a replay, a
+			// waiting proposer proposes the block right away instead
+			c.pendingUnminedBlocksMu.Lock()
+			waiting := c.isWaitingForUnminedBlock
+			c.isWaitingForUnminedBlock = false
+			c.pendingUnminedBlocksMu.Unlock()
+			c.storeUnminedBlockMsg(block)
+			if waiting && c.currentRoundState.Step() == propose {
+				c.sendProposal(ctx, block)
+			}
+		case journal.KindReceived:
+			event = events.MessageEvent{Payload: e.Payload, Peer: e.Peer}
+		case journal.KindBacklog:
+			msg := new(Message)
+			if err := rlp.DecodeBytes(e.Payload, msg); err != nil {
+				c.stopTimers()
+				return fmt.Errorf("journal entry %d: %v", i, err)
+			}
+			_, src := c.valSet.GetByAddress(msg.Address)
+			if src == nil {
+				src = validator.New(msg.Address)
+			}
+			event = backlogEvent{src: src, msg: msg}
+		case journal.KindTimeout:
+			event = TimeoutEvent{
+				roundWhenCalled:  int64(e.Round),
+				heightWhenCalled: int64(e.Height),
+				step:             timeoutStep(e.Code),
+			}
+		case journal.KindCommitted:
+			event = events.CommitEvent{}
+		}
+		if event != nil {
+			c.handleEvent(ctx, event)
+		}
+	}
+	c.stopTimers()
+	return nil
+}
+
+func timeoutStep(code string) uint64 {
+	switch code {
+	case "prevote":
+		return msgPrevote
+	case "precommit":
+		return msgPrecommit
+	}
+	return msgProposal
+}
+
+func (c *core) stopTimers() {
+	_ = c.proposeTimeout.stopTimer()
+	_ = c.prevoteTimeout.stopTimer()
+	_ = c.precommitTimeout.stopTimer()
+	c.stopFutureProposalTimer()
+}
+
+// replayBackend serves the chain answers of a journal. Only the methods the
+// core calls while handling consensus events are implemented, the embedded
+// Backend is nil.
+type replayBackend struct {
+	Backend
+
+	address    common.Address
+	policy     config.ProposerPolicy
+	heads      []*journal.Entry
+	verifies   []*journal.Entry
+	validators map[uint64]*journal.Entry
+	blocks     map[uint64]*types.Block
+
+	head *journal.Entry
+}
+
+func newReplayBackend(entries []*journal.Entry, policy config.ProposerPolicy) (*replayBackend, error) {
+	b := &replayBackend{
+		policy:     policy,
+		validators: make(map[uint64]*journal.Entry),
+		blocks:     make(map[uint64]*types.Block),
+	}
+	for i, e := range entries {
+		switch e.Kind {
+		case journal.KindHead:
+			b.heads = append(b.heads, e)
+		case journal.KindVerify:
+			b.verifies = append(b.verifies, e)
+		case journal.KindValidators:
+			if _, ok := b.validators[e.Height]; !ok {
+				b.validators[e.Height] = e
+			}
+		case journal.KindRequest:
+			block := new(types.Block)
+			if err := rlp.DecodeBytes(e.Payload, block); err != nil {
+				return nil, fmt.Errorf("journal entry %d: %v", i, err)
+			}
+			b.blocks[block.NumberU64()] = block
+		}
+	}
+	return b, nil
+}
+
+func (b *replayBackend) Address() common.Address {
+	return b.address
+}
+
+// LastCommittedProposal returns the next recorded head, or the last one once
+// the journal runs out of heads
+func (b *replayBackend) LastCommittedProposal() (*types.Block, common.Address) {
+	if len(b.heads) > 0 {
+		b.head = b.heads[0]
+		b.heads = b.heads[1:]
+	}
+	if b.head == nil {
+		return types.NewBlockWithHeader(&types.Header{Number: new(big.Int)}), common.Address{}
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(b.head.Payload, block); err != nil {
+		return types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(b.head.Height)}), b.head.Address
+	}
+	return block, b.head.Address
+}
+
+func (b *replayBackend) Validators(number uint64) validator.Set {
+	var addrs []common.Address
+	if e, ok := b.validators[number]; ok {
+		addrs = e.Validators
+	}
+	return validator.NewSet(addrs, b.policy)
+}
+
+// VerifyProposal returns the next recorded verification outcome
+func (b *replayBackend) VerifyProposal(block types.Block) (time.Duration, error) {
+	if len(b.verifies) == 0 {
+		return 0, nil
+	}
+	e := b.verifies[0]
+	b.verifies = b.verifies[1:]
+	switch e.Error {
+	case "":
+		return 0, nil
+	case consensus.ErrFutureBlock.Error():
+		return 0, consensus.ErrFutureBlock
+	}
+	return 0, errors.New(e.Error)
+}
+
+// Sign returns an empty signature, the replayed messages are never delivered
+func (b *replayBackend) Sign(data []byte) ([]byte, error) {
+	return make([]byte, types.BFTExtraSeal), nil
+}
+
+func (b *replayBackend) Broadcast(ctx context.Context, valSet validator.Set, payload []byte) error {
+	return nil
+}
+
+func (b *replayBackend) Gossip(ctx context.Context, valSet validator.Set, payload []byte) {}
+
+func (b *replayBackend) Commit(proposalBlock types.Block, seals [][]byte) error {
+	return nil
+}
+
+func (b *replayBackend) Post(ev interface{}) {}
+
+func (b *replayBackend) SetProposedBlockHash(hash common.Hash) {}
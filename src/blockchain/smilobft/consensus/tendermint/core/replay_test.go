@@ -0,0 +1,133 @@
+package core
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/journal"
+	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+)
+
+func signedPayload(t *testing.T, key *ecdsa.PrivateKey, code uint64, val interface{}, seal []byte) []byte {
+	encoded, err := Encode(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &Message{Code: code, Msg: encoded, Address: crypto.PubkeyToAddress(key.PublicKey), CommittedSeal: []byte{}}
+	if seal != nil {
+		if msg.CommittedSeal, err = crypto.Sign(crypto.Keccak256(seal), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := msg.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func replayEntries(t *testing.T, entries []*journal.Entry) []*journal.Entry {
+	var buf bytes.Buffer
+	if err := Replay(entries, config.DefaultConfig(), journal.New(&buf)); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	replayed, err := journal.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return replayed
+}
+
+func TestReplay(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	peer := enode.PubkeyToIDV4(&key.PublicKey)
+
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Difficulty: big.NewInt(1)})
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1), ParentHash: genesis.Hash()})
+	genesisRLP, _ := rlp.EncodeToBytes(genesis)
+	blockRLP, _ := rlp.EncodeToBytes(block)
+
+	height, round := big.NewInt(1), big.NewInt(0)
+	proposal := NewProposal(round, height, big.NewInt(-1), block, log.Root())
+	vote := &Vote{Round: round, Height: height, ProposedBlockHash: block.Hash()}
+
+	// The journal of a single validator committing block 1
+	entries := []*journal.Entry{
+		{Kind: journal.KindStart, Address: addr},
+		{Kind: journal.KindHead, Payload: genesisRLP},
+		{Kind: journal.KindHead, Payload: genesisRLP},
+		{Kind: journal.KindValidators, Height: 1, Validators: []common.Address{addr}, Quorum: 1},
+		{Kind: journal.KindRequest, Height: 1, Payload: blockRLP},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgProposal, proposal, nil)},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgPrevote, vote, nil)},
+		{Kind: journal.KindReceived, Peer: peer, Payload: signedPayload(t, key, msgPrecommit, vote, PrepareCommittedSeal(block.Hash()))},
+		{Kind: journal.KindCommitted, Height: 1},
+		{Kind: journal.KindHead, Height: 1, Payload: blockRLP},
+		{Kind: journal.KindHead, Height: 1, Payload: blockRLP},
+		{Kind: journal.KindValidators, Height: 2, Validators: []common.Address{addr}, Quorum: 1},
+	}
+	replayed := replayEntries(t, entries)
+
+	var sent []string
+	for _, e := range replayed {
+		switch e.Kind {
+		case journal.KindSent:
+			if e.Height != 1 || e.Round != 0 || e.Address != addr {
+				t.Errorf("sent %s at %d/%d from %x, want 1/0 from %x", e.Code, e.Height, e.Round, e.Address, addr)
+			}
+			sent = append(sent, e.Code)
+		case journal.KindReceived:
+			if e.Peer != peer {
+				t.Errorf("received %s from peer %v, want %v", e.Code, e.Peer, peer)
+			}
+		case journal.KindFailed:
+			t.Errorf("replayed %s at %d/%d failed: %s", e.Code, e.Height, e.Round, e.Error)
+		}
+	}
+	if want := []string{"proposal", "prevote", "precommit"}; len(sent) != len(want) {
+		t.Fatalf("sent messages mismatch: have %v, want %v", sent, want)
+	} else {
+		for i := range want {
+			if sent[i] != want[i] {
+				t.Errorf("sent message %d mismatch: have %s, want %s", i, sent[i], want[i])
+			}
+		}
+	}
+	if last := replayed[len(replayed)-1]; last.Kind != journal.KindValidators || last.Height != 2 {
+		t.Errorf("replay did not move to the next height: last entry %v", last)
+	}
+
+	// Replaying the replay must take the same steps again
+	again := replayEntries(t, replayed)
+	if len(again) != len(replayed) {
+		t.Fatalf("entries mismatch: have %d, want %d", len(again), len(replayed))
+	}
+	for i := range again {
+		if again[i].String() != replayed[i].String() {
+			t.Errorf("entry %d mismatch: have %v, want %v", i, again[i], replayed[i])
+		}
+	}
+}
+
+func TestReplayWithoutStart(t *testing.T) {
+	if err := Replay([]*journal.Entry{{Kind: journal.KindTimeout}}, config.DefaultConfig(), nil); err != errNoJournalStart {
+		t.Errorf("error mismatch: have %v, want %v", err, errNoJournalStart)
+	}
+}
@@ -78,6 +78,12 @@ func (s *roundState) Proposal() *Proposal {
 	return nil
 }
 
+func (s *roundState) ProposalMsg() *Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.proposalMsg
+}
+
 func (s *roundState) SetRound(r *big.Int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
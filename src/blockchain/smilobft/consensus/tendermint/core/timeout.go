@@ -170,6 +170,7 @@ func (c *core) onTimeoutPrecommit(r int64, h int64) {
 func (c *core) handleTimeoutPropose(ctx context.Context, msg TimeoutEvent) {
 	if msg.heightWhenCalled == c.currentRoundState.Height().Int64() && msg.roundWhenCalled == c.currentRoundState.Round().Int64() && c.currentRoundState.Step() == propose {
 		c.logTimeoutEvent("TimeoutEvent(Propose): Received", "Propose", msg)
+		c.health.MissedProposal()
 		c.sendPrevote(ctx, true)
 		c.setStep(prevote)
 	}
@@ -18,6 +18,7 @@ package events
 
 import (
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -30,6 +31,7 @@ type NewUnminedBlockEvent struct {
 // MessageEvent is posted for Istanbul engine communication
 type MessageEvent struct {
 	Payload []byte
+	Peer    enode.ID // p2p id of the peer the message was received from
 }
 
 type Poster interface {
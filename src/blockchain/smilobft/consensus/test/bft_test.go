@@ -0,0 +1,170 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+// bftEngines are the engines the TestBFT scenarios can run against, the engine
+// is selected with CONSENSUS_TEST_MODE.
+var bftEngines = map[string]bool{
+	params.SportEngine:      true,
+	params.SportDAOEngine:   true,
+	params.IstanbulEngine:   true,
+	params.TendermintEngine: true,
+}
+
+func runBFTCases(t *testing.T, cases []*testCase) {
+	if testing.Short() || !bftEngines[CONSENSUS_TEST_MODE] {
+		t.Skip("skipping test in short mode")
+	}
+
+	for _, testCase := range cases {
+		testCase := testCase
+		testCase.engine = CONSENSUS_TEST_MODE
+		// Istanbul and SportDAO redistribute the block fees in the state processor
+		// only, the sealing node gets another state root for a block with
+		// transactions. Those engines run the scenarios with empty blocks.
+		if testCase.engine == params.IstanbulEngine || testCase.engine == params.SportDAOEngine {
+			testCase.txPerPeer = 0
+		}
+		t.Run(fmt.Sprintf("test case %s", testCase.name), func(t *testing.T) {
+			runTest(t, testCase)
+		})
+	}
+}
+
+func TestBFTSuccess(t *testing.T) {
+	runBFTCases(t, []*testCase{
+		{
+			name:      "no malicious",
+			numPeers:  5,
+			numBlocks: 5,
+			txPerPeer: 1,
+		},
+	})
+}
+
+func TestBFTSlowConnections(t *testing.T) {
+	runBFTCases(t, []*testCase{
+		{
+			name:      "one slow node",
+			numPeers:  5,
+			numBlocks: 5,
+			txPerPeer: 1,
+			networkRates: map[int]networkRate{
+				4: {50 * 1024, 50 * 1024},
+			},
+			latencies: map[int]time.Duration{
+				4: 500 * time.Millisecond,
+			},
+		},
+		{
+			name:      "all nodes have latency",
+			numPeers:  5,
+			numBlocks: 5,
+			txPerPeer: 1,
+			latencies: map[int]time.Duration{
+				0: 200 * time.Millisecond,
+				1: 200 * time.Millisecond,
+				2: 200 * time.Millisecond,
+				3: 200 * time.Millisecond,
+				4: 200 * time.Millisecond,
+			},
+		},
+	})
+}
+
+func TestBFTStopUpToFNodes(t *testing.T) {
+	runBFTCases(t, []*testCase{
+		{
+			name:      "one node stops at block 1",
+			numPeers:  5,
+			numBlocks: 10,
+			txPerPeer: 1,
+			beforeHooks: map[int]hook{
+				4: hookStopNode(4, 1),
+			},
+			stopTime: make(map[int]time.Time),
+			maliciousPeers: map[int]func(basic consensus.Engine) consensus.Engine{
+				4: nil,
+			},
+		},
+		{
+			name:      "F nodes stop at block 5",
+			numPeers:  7,
+			numBlocks: 10,
+			txPerPeer: 1,
+			beforeHooks: map[int]hook{
+				3: hookStopNode(3, 5),
+				4: hookStopNode(4, 5),
+			},
+			stopTime: make(map[int]time.Time),
+			maliciousPeers: map[int]func(basic consensus.Engine) consensus.Engine{
+				3: nil,
+				4: nil,
+			},
+		},
+	})
+}
+
+func TestBFTStartStopNodes(t *testing.T) {
+	runBFTCases(t, []*testCase{
+		{
+			name:      "one node stops for 10 seconds",
+			numPeers:  5,
+			numBlocks: 10,
+			txPerPeer: 1,
+			beforeHooks: map[int]hook{
+				4: hookStopNode(4, 5),
+			},
+			afterHooks: map[int]hook{
+				4: hookStartNode(4, 10),
+			},
+			stopTime: make(map[int]time.Time),
+		},
+		{
+			name:      "F nodes stop for 10 seconds",
+			numPeers:  7,
+			numBlocks: 10,
+			txPerPeer: 1,
+			beforeHooks: map[int]hook{
+				3: hookStopNode(3, 4),
+				4: hookStopNode(4, 5),
+			},
+			afterHooks: map[int]hook{
+				3: hookStartNode(3, 10),
+				4: hookStartNode(4, 10),
+			},
+			stopTime: make(map[int]time.Time),
+		},
+	})
+}
+
+func TestBFTByzantineNodes(t *testing.T) {
+	runBFTCases(t, []*testCase{
+		{
+			name:      "one node corrupts its messages",
+			numPeers:  5,
+			numBlocks: 5,
+			txPerPeer: 1,
+			maliciousPeers: map[int]func(basic consensus.Engine) consensus.Engine{
+				4: newByzantineEngine(corruptPayload),
+			},
+		},
+		{
+			name:      "F nodes corrupt their messages",
+			numPeers:  7,
+			numBlocks: 5,
+			txPerPeer: 1,
+			maliciousPeers: map[int]func(basic consensus.Engine) consensus.Engine{
+				3: newByzantineEngine(corruptPayload),
+				4: newByzantineEngine(corruptPayload),
+			},
+		},
+	})
+}
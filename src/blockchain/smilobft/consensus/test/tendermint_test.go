@@ -29,6 +29,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/eth"
 	"go-smilo/src/blockchain/smilobft/p2p/enode"
+	"go-smilo/src/blockchain/smilobft/params"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -939,16 +940,63 @@ func TestTendermintStartStopAllNodes(t *testing.T) {
 	}
 }
 
+func TestTendermintRestartBetweenPrevoteAndPrecommit(t *testing.T) {
+	if testing.Short() || CONSENSUS_TEST_MODE != "tendermint" {
+		t.Skip("skipping test in short mode")
+	}
+
+	cases := []*testCase{
+		{
+			name:      "one node restarts after locking",
+			numPeers:  5,
+			numBlocks: 10,
+			txPerPeer: 1,
+			crashBeforePrecommit: map[int]uint64{
+				4: 5,
+			},
+			afterHooks: map[int]hook{
+				4: hookStartNode(4, 5),
+			},
+			stopTime: make(map[int]time.Time),
+		},
+		{
+			name:      "f+1 nodes restart after locking at the same height",
+			numPeers:  5,
+			numBlocks: 10,
+			txPerPeer: 1,
+			crashBeforePrecommit: map[int]uint64{
+				3: 5,
+				4: 5,
+			},
+			afterHooks: map[int]hook{
+				3: hookStartNode(3, 5),
+				4: hookStartNode(4, 5),
+			},
+			stopTime: make(map[int]time.Time),
+		},
+	}
+
+	for _, testCase := range cases {
+		testCase := testCase
+		t.Run(fmt.Sprintf("test case %s", testCase.name), func(t *testing.T) {
+			runTest(t, testCase)
+		})
+	}
+}
+
 type testCase struct {
 	name                 string
 	isSkipped            bool
+	engine               string // consensus engine name, tendermint if empty
 	numPeers             int
 	numBlocks            int
 	txPerPeer            int
 	maliciousPeers       map[int]func(basic consensus.Engine) consensus.Engine //map[validatorIndex]consensusConstructor
 	networkRates         map[int]networkRate                                   //map[validatorIndex]networkRate
+	latencies            map[int]time.Duration                                 //map[validatorIndex]consensusMessageDelay
 	beforeHooks          map[int]hook                                          //map[validatorIndex]beforeHook
 	afterHooks           map[int]hook                                          //map[validatorIndex]afterHook
+	crashBeforePrecommit map[int]uint64                                        //map[validatorIndex]heightToCrashAt
 	sendTransactionHooks map[int]func(service *eth.Smilo, key *ecdsa.PrivateKey, fromAddr common.Address, toAddr common.Address) (*types.Transaction, error)
 	stopTime             map[int]time.Time
 	genesisHook          func(g *core.Genesis) *core.Genesis
@@ -1046,7 +1094,11 @@ func runTest(t *testing.T, test *testCase) {
 		)
 	}
 
-	genesis := makeGenesis(validators)
+	engine := test.engine
+	if engine == "" {
+		engine = params.TendermintEngine
+	}
+	genesis := makeGenesis(validators, engine)
 	if test.genesisHook != nil {
 		genesis = test.genesisHook(genesis)
 	}
@@ -1055,6 +1107,22 @@ func runTest(t *testing.T, test *testCase) {
 		if test.maliciousPeers != nil {
 			engineConstructor = test.maliciousPeers[i]
 		}
+		if delay, ok := test.latencies[i]; ok {
+			engineConstructor = withLatency(engineConstructor, delay)
+		}
+		if height, ok := test.crashBeforePrecommit[i]; ok {
+			i, validator := i, validator
+			engineConstructor = withCrashBeforePrecommit(engineConstructor, &crashPoint{
+				height: height,
+				stop: func() {
+					test.setStopTime(i, time.Now())
+					if err := validator.node.Stop(); err != nil {
+						log.Error("cannot crash a node", "index", i, "err", err)
+					}
+				},
+			})
+			validator.wasStopped = true
+		}
 
 		validator.listener.Close()
 
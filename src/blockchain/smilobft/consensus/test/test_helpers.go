@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"go-smilo/src/blockchain/smilobft/consensus/ethash"
@@ -8,6 +9,8 @@ import (
 	"math/big"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -15,9 +18,14 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/istanbul"
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
 	"go-smilo/src/blockchain/smilobft/consensus/tendermint/config"
+	tendermintCore "go-smilo/src/blockchain/smilobft/consensus/tendermint/core"
 	"go-smilo/src/blockchain/smilobft/core"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/eth"
@@ -113,7 +121,9 @@ func generateRandomTx(nonce uint64, toAddr common.Address, key *ecdsa.PrivateKey
 		types.HomesteadSigner{}, key)
 }
 
-func makeGenesis(validators []*testNode) *core.Genesis {
+// makeGenesis creates the genesis block of a chain sealed by the BFT engine
+// with the given name, with every test node as a validator.
+func makeGenesis(validators []*testNode, engine string) *core.Genesis {
 	// generate genesis block
 	genesis := core.DefaultGenesisBlock()
 	genesis.ExtraData = nil
@@ -124,8 +134,22 @@ func makeGenesis(validators []*testNode) *core.Genesis {
 	genesis.Nonce = 0
 	genesis.Mixhash = types.BFTDigest
 
-	genesis.Config = params.SmiloTestChainConfig
-	genesis.Config.Tendermint = &params.TendermintConfig{}
+	chainConfig := *params.SmiloTestChainConfig
+	genesis.Config = &chainConfig
+	switch engine {
+	case params.SportEngine:
+		genesis.Config.Sport = &params.SportConfig{}
+		genesis.Mixhash = types.SportDigest
+	case params.SportDAOEngine:
+		genesis.Config.SportDAO = &params.SportDAOConfig{
+			Epoch:    sportdao.DefaultConfig.Epoch,
+			MinFunds: sportdao.DefaultConfig.MinFunds,
+		}
+	case params.IstanbulEngine:
+		genesis.Config.Istanbul = &params.IstanbulConfig{}
+	default:
+		genesis.Config.Tendermint = &params.TendermintConfig{}
+	}
 	genesis.Config.Ethash = nil
 	genesis.Config.AutonityContractConfig = &params.AutonityContractGenesis{}
 
@@ -155,18 +179,20 @@ func makeGenesis(validators []*testNode) *core.Genesis {
 			Stake:   100,
 		}
 	}
-	//generate one sh
-	shKey, err := crypto.GenerateKey()
-	if err != nil {
-		log.Error("Make genesis error", "err", err)
+	//generate one sh, sport has no contract so every user would be a fullnode
+	if engine != params.SportEngine {
+		shKey, err := crypto.GenerateKey()
+		if err != nil {
+			log.Error("Make genesis error", "err", err)
+		}
+		users = append(users, params.User{
+			Address: crypto.PubkeyToAddress(shKey.PublicKey),
+			Type:    params.UserStakeHolder,
+			Stake:   200,
+		})
 	}
-	users = append(users, params.User{
-		Address: crypto.PubkeyToAddress(shKey.PublicKey),
-		Type:    params.UserStakeHolder,
-		Stake:   200,
-	})
 	genesis.Config.AutonityContractConfig.Users = users
-	err = genesis.Config.AutonityContractConfig.AddDefault().Validate()
+	err := genesis.Config.AutonityContractConfig.AddDefault().Validate()
 	if err != nil {
 		panic(err)
 	}
@@ -175,6 +201,13 @@ func makeGenesis(validators []*testNode) *core.Genesis {
 	if err != nil {
 		panic(err)
 	}
+	// SetBFT seals the genesis with every user, only the validators run here so
+	// counting the stakeholder would raise the quorum above the running nodes
+	extra, err := types.PrepareExtra(genesis.GetExtraData(), validatorsAddresses)
+	if err != nil {
+		panic(err)
+	}
+	genesis.SetExtraData(extra)
 
 	return genesis
 }
@@ -203,6 +236,18 @@ func makeValidator(genesis *core.Genesis, nodekey *ecdsa.PrivateKey, listenAddr
 		NoUSB: true,
 	}
 
+	// sport doesn't connect to the validators whitelisted by the autonity
+	// contract, so they are made static peers
+	if genesis.Config.Sport != nil {
+		for _, user := range genesis.Config.AutonityContractConfig.Users {
+			peer, err := enode.ParseV4(user.Enode)
+			if err != nil {
+				return nil, err
+			}
+			configNode.P2P.StaticNodes = append(configNode.P2P.StaticNodes, peer)
+		}
+	}
+
 	if inRate != 0 || outRate != 0 {
 		configNode.P2P.IsRated = true
 		configNode.P2P.InRate = inRate
@@ -222,10 +267,22 @@ func makeValidator(genesis *core.Genesis, nodekey *ecdsa.PrivateKey, listenAddr
 			DatabaseCache:   256,
 			DatabaseHandles: 256,
 			TxPool:          core.DefaultTxPoolConfig,
+			Sport:           *sport.DefaultConfig,
 			Tendermint:      *config.DefaultConfig(),
 		}
 		config.Ethash.PowMode = ethash.ModeFake
 
+		// the istanbul and sportdao configs hold a lock, so they are not copied
+		config.SportDAO.RequestTimeout = sportdao.DefaultConfig.RequestTimeout
+		config.SportDAO.BlockPeriod = sportdao.DefaultConfig.BlockPeriod
+		config.SportDAO.Epoch = sportdao.DefaultConfig.Epoch
+		config.SportDAO.MinFunds = sportdao.DefaultConfig.MinFunds
+		config.SportDAO.MinBlocksEmptyMining = sportdao.DefaultConfig.MinBlocksEmptyMining
+		config.Istanbul.RequestTimeout = istanbul.DefaultConfig.RequestTimeout
+		config.Istanbul.BlockPeriod = istanbul.DefaultConfig.BlockPeriod
+		config.Istanbul.Epoch = istanbul.DefaultConfig.Epoch
+		config.Istanbul.MinBlocksEmptyMining = istanbul.DefaultConfig.MinBlocksEmptyMining
+
 		return eth.New(ctx, config, cons)
 	}); err != nil {
 		return nil, err
@@ -234,3 +291,188 @@ func makeValidator(genesis *core.Genesis, nodekey *ecdsa.PrivateKey, listenAddr
 	// Start the node and return if successful
 	return stack, nil
 }
+
+// faultyEngine wraps a BFT consensus engine and interferes with the consensus
+// messages it gossips: they can be delayed to inject latency, mutated to
+// simulate a byzantine node, or cut off to simulate a crash.
+type faultyEngine struct {
+	consensus.Engine
+	delay   time.Duration
+	mutate  func(payload []byte) []byte
+	crash   *crashPoint
+	crashed uint32
+}
+
+// tendermintPrecommit is the code of a precommit message in the tendermint core
+const tendermintPrecommit = 2
+
+// crashPoint stops a tendermint node once, after it locked on a block of the
+// given height and before its precommit for the block left the node.
+type crashPoint struct {
+	height uint64
+	stop   func()
+	once   sync.Once
+}
+
+// isPrecommit returns whether the payload is a non-nil precommit at the crash height
+func (cp *crashPoint) isPrecommit(payload []byte) bool {
+	msg := new(tendermintCore.Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil || msg.Code != tendermintPrecommit {
+		return false
+	}
+	var vote tendermintCore.Vote
+	if err := msg.Decode(&vote); err != nil {
+		return false
+	}
+	return vote.Height.Uint64() == cp.height && vote.ProposedBlockHash != (common.Hash{})
+}
+
+// withCrashBeforePrecommit returns an engine constructor crashing the node of
+// the engine built with cons at the crash point. The engines built after the
+// node restarts don't crash again.
+func withCrashBeforePrecommit(cons func(basic consensus.Engine) consensus.Engine, crash *crashPoint) func(basic consensus.Engine) consensus.Engine {
+	return func(basic consensus.Engine) consensus.Engine {
+		if cons != nil {
+			basic = cons(basic)
+		}
+		return &faultyEngine{Engine: basic, crash: crash}
+	}
+}
+
+// withLatency returns an engine constructor delaying every consensus message
+// sent by the engine built with cons.
+func withLatency(cons func(basic consensus.Engine) consensus.Engine, delay time.Duration) func(basic consensus.Engine) consensus.Engine {
+	return func(basic consensus.Engine) consensus.Engine {
+		if cons != nil {
+			basic = cons(basic)
+		}
+		return &faultyEngine{Engine: basic, delay: delay}
+	}
+}
+
+// newByzantineEngine returns an engine constructor passing every consensus
+// message sent by the engine through mutate.
+func newByzantineEngine(mutate func(payload []byte) []byte) func(basic consensus.Engine) consensus.Engine {
+	return func(basic consensus.Engine) consensus.Engine {
+		return &faultyEngine{Engine: basic, mutate: mutate}
+	}
+}
+
+// corruptPayload flips a byte in the middle of the message, which breaks its
+// decoding or its signature.
+func corruptPayload(payload []byte) []byte {
+	if len(payload) == 0 {
+		return payload
+	}
+	corrupted := append([]byte{}, payload...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	return corrupted
+}
+
+// NewChainHead implements consensus.Handler.
+func (e *faultyEngine) NewChainHead() error {
+	if h, ok := e.Engine.(consensus.Handler); ok {
+		return h.NewChainHead()
+	}
+	return nil
+}
+
+// HandleMsg implements consensus.Handler.
+func (e *faultyEngine) HandleMsg(address common.Address, data p2p.Msg, peer enode.ID) (bool, error) {
+	if h, ok := e.Engine.(consensus.Handler); ok {
+		return h.HandleMsg(address, data, peer)
+	}
+	return false, nil
+}
+
+// SetBroadcaster implements consensus.Handler, the engine gets a broadcaster
+// whose peers interfere with the sent messages.
+func (e *faultyEngine) SetBroadcaster(broadcaster consensus.Broadcaster) {
+	if h, ok := e.Engine.(consensus.Handler); ok {
+		h.SetBroadcaster(&faultyBroadcaster{Broadcaster: broadcaster, engine: e})
+	}
+}
+
+// Protocol implements consensus.Handler.
+func (e *faultyEngine) Protocol() (string, uint64) {
+	if h, ok := e.Engine.(consensus.Handler); ok {
+		return h.Protocol()
+	}
+	return "", 0
+}
+
+// Start implements consensus.BFT.
+func (e *faultyEngine) Start(ctx context.Context, chain consensus.ChainReader, currentBlock func() *types.Block, hasBadBlock func(hash common.Hash) bool) error {
+	if bft, ok := e.Engine.(consensus.BFT); ok {
+		return bft.Start(ctx, chain, currentBlock, hasBadBlock)
+	}
+	return nil
+}
+
+// Stop implements consensus.BFT.
+func (e *faultyEngine) Stop() error {
+	if bft, ok := e.Engine.(consensus.BFT); ok {
+		return bft.Stop()
+	}
+	return nil
+}
+
+// SyncPeer implements consensus.Syncer.
+func (e *faultyEngine) SyncPeer(address common.Address) {
+	if syncer, ok := e.Engine.(consensus.Syncer); ok {
+		syncer.SyncPeer(address)
+	}
+}
+
+// ResetPeerCache implements consensus.Syncer.
+func (e *faultyEngine) ResetPeerCache(address common.Address) {
+	if syncer, ok := e.Engine.(consensus.Syncer); ok {
+		syncer.ResetPeerCache(address)
+	}
+}
+
+type faultyBroadcaster struct {
+	consensus.Broadcaster
+	engine *faultyEngine
+}
+
+func (b *faultyBroadcaster) FindPeers(targets map[common.Address]struct{}) map[common.Address]consensus.Peer {
+	peers := b.Broadcaster.FindPeers(targets)
+	for addr, p := range peers {
+		peers[addr] = &faultyPeer{Peer: p, engine: b.engine}
+	}
+	return peers
+}
+
+type faultyPeer struct {
+	consensus.Peer
+	engine *faultyEngine
+}
+
+func (p *faultyPeer) Send(msgcode uint64, data interface{}) error {
+	if atomic.LoadUint32(&p.engine.crashed) == 1 {
+		return nil
+	}
+	if payload, ok := data.([]byte); ok && p.engine.crash != nil && p.engine.crash.isPrecommit(payload) {
+		p.engine.crash.once.Do(func() {
+			atomic.StoreUint32(&p.engine.crashed, 1)
+			go p.engine.crash.stop()
+		})
+		if atomic.LoadUint32(&p.engine.crashed) == 1 {
+			return nil
+		}
+	}
+	if payload, ok := data.([]byte); ok && p.engine.mutate != nil {
+		data = p.engine.mutate(payload)
+	}
+	if p.engine.delay == 0 {
+		return p.Peer.Send(msgcode, data)
+	}
+	go func() {
+		time.Sleep(p.engine.delay)
+		if err := p.Peer.Send(msgcode, data); err != nil {
+			log.Debug("Delayed consensus message not sent", "peer", p.String(), "err", err)
+		}
+	}()
+	return nil
+}
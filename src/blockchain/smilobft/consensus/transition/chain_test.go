@@ -0,0 +1,189 @@
+package transition
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
+	sportbackend "go-smilo/src/blockchain/smilobft/consensus/sport/backend"
+	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
+	sportdaobackend "go-smilo/src/blockchain/smilobft/consensus/sportdao/backend"
+	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/ethdb"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+const testEnode = "enode://d73b857969c86415c0c000371bcebd9ed3cca6c376032b3f65e58e9e2b79276fbc6f59eb1e22fcd6356ab95f42a666f70afd4985933bd8f3e05beb1a2bf8fdde@172.25.0.11:30303"
+
+// newSportGenesis returns a Sport genesis sealed by the fullnode of key and
+// moving to SportDAO at the transition block.
+func newSportGenesis(t *testing.T, key *ecdsa.PrivateKey, transition *big.Int) *core.Genesis {
+	fullnode := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainConfig := *params.TestChainConfig
+	chainConfig.Ethash = nil
+	chainConfig.Sport = &params.SportConfig{}
+	chainConfig.SportDAO = &params.SportDAOConfig{
+		Epoch:    sportdao.DefaultConfig.Epoch,
+		MinFunds: sportdao.DefaultConfig.MinFunds,
+	}
+	chainConfig.AutonityContractConfig = &params.AutonityContractGenesis{
+		Users: []params.User{{Address: fullnode, Type: params.UserValidator, Enode: testEnode, Stake: 100}},
+	}
+	if err := chainConfig.AutonityContractConfig.AddDefault().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	chainConfig.ConsensusTransitions = []params.ConsensusTransition{{Block: transition, Engine: params.SportDAOEngine}}
+	if err := chainConfig.CheckConsensusTransitions(); err != nil {
+		t.Fatal(err)
+	}
+
+	extra, err := rlp.EncodeToBytes(&types.SportExtra{
+		Fullnodes:     []common.Address{fullnode},
+		Seal:          []byte{},
+		CommittedSeal: [][]byte{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := core.DefaultGenesisBlock()
+	genesis.Config = &chainConfig
+	genesis.Difficulty = big.NewInt(1)
+	genesis.Nonce = 0
+	genesis.Mixhash = types.SportDigest
+	genesis.ExtraData = append(bytes.Repeat([]byte{0x00}, types.SportExtraVanity), extra...)
+	return genesis
+}
+
+// newTransitionChain creates a chain moving from Sport to SportDAO with the
+// engines of the node of key.
+func newTransitionChain(t *testing.T, genesis *core.Genesis, key *ecdsa.PrivateKey) (*core.BlockChain, *Engine, ethdb.Database) {
+	db := rawdb.NewMemoryDatabase()
+	sportConfig := *sport.DefaultConfig
+	engine := New([]Stage{
+		{Block: common.Big0, Engine: sportbackend.New(&sportConfig, key, db)},
+		{Block: genesis.Config.ConsensusTransitions[0].Block, Engine: sportdaobackend.New(sportdao.DefaultConfig, key, db, genesis.Config, &vm.Config{})},
+	})
+	genesis.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chain, engine, db
+}
+
+// sealBlock assembles, seals and imports the block after the current head with
+// the engine of its number.
+func sealBlock(t *testing.T, chain *core.BlockChain, engine *Engine) *types.Block {
+	parent := chain.CurrentBlock()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		GasLimit:   core.CalcGasLimit(parent, 8000000, 8000000),
+		Extra:      parent.Extra(),
+		Time:       parent.Time() + 1,
+	}
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatalf("block %v: prepare: %v", header.Number, err)
+	}
+	statedb, _, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := engine.Finalize(chain, header, statedb, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("block %v: finalize: %v", header.Number, err)
+	}
+	if block, err = engine.Seal(chain, block, nil); err != nil {
+		t.Fatalf("block %v: seal: %v", header.Number, err)
+	}
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("block %v: insert: %v", header.Number, err)
+	}
+	if err := engine.NewChainHead(); err != nil {
+		t.Fatalf("block %v: new chain head: %v", header.Number, err)
+	}
+	return block
+}
+
+func TestSportToSportDAOChain(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	fullnode := crypto.PubkeyToAddress(key.PublicKey)
+	transition := big.NewInt(3)
+	genesis := newSportGenesis(t, key, transition)
+
+	chain, engine, _ := newTransitionChain(t, genesis, key)
+	defer chain.Stop()
+	if err := engine.Start(context.Background(), chain, chain.CurrentBlock, chain.HasBadBlock); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+
+	var blocks types.Blocks
+	for i := 0; i < 4; i++ {
+		blocks = append(blocks, sealBlock(t, chain, engine))
+	}
+
+	// every header is sealed by the fullnode, with the engine of its side of
+	// the transition
+	for _, block := range blocks {
+		header := block.Header()
+		author, err := engine.Author(header)
+		if err != nil || author != fullnode {
+			t.Errorf("block %v: author mismatch: have %v, %v, want %v", header.Number, author, err, fullnode)
+		}
+		extra, err := types.ExtractSportExtra(header)
+		if err != nil {
+			t.Fatalf("block %v: %v", header.Number, err)
+		}
+		if len(extra.Fullnodes) != 1 || extra.Fullnodes[0] != fullnode {
+			t.Errorf("block %v: fullnodes mismatch: have %v", header.Number, extra.Fullnodes)
+		}
+		if len(extra.CommittedSeal) == 0 {
+			t.Errorf("block %v: no committed seals", header.Number)
+		}
+		want := engine.stages[0].Engine
+		if header.Number.Cmp(transition) >= 0 {
+			want = engine.stages[1].Engine
+		}
+		if engine.EngineAt(header.Number) != want {
+			t.Errorf("block %v: sealed by the wrong engine", header.Number)
+		}
+	}
+
+	// the Autonity contract is deployed on the transition block only
+	address, err := genesis.Config.AutonityContractConfig.GetContractAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range blocks {
+		statedb, _, err := chain.StateAt(block.Root())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deployed := statedb.GetCodeSize(address) != 0; deployed != (block.Number().Cmp(transition) >= 0) {
+			t.Errorf("block %v: contract deployed %v", block.Number(), deployed)
+		}
+	}
+
+	// another node verifies the headers on both sides in a single batch
+	other, otherEngine, _ := newTransitionChain(t, genesis, key)
+	defer other.Stop()
+	otherEngine.SetChain(other)
+	if n, err := other.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: insert: %v", blocks[n].Number(), err)
+	}
+	if head := other.CurrentBlock(); head.Hash() != blocks[len(blocks)-1].Hash() {
+		t.Errorf("head mismatch: have %v, want %v", head.Number(), blocks[len(blocks)-1].Number())
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package transition implements a consensus engine that switches between other
+// consensus engines at configured block numbers.
+package transition
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/p2p"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+	"go-smilo/src/blockchain/smilobft/rpc"
+)
+
+var (
+	// errNoChain is returned if the engine is asked for the active engine before
+	// it knows the chain.
+	errNoChain = errors.New("transition engine has no chain")
+)
+
+// Stage is a consensus engine sealing the blocks from Block onwards, until the
+// Block of the next stage.
+type Stage struct {
+	Block  *big.Int
+	Engine consensus.Engine
+}
+
+// Engine delegates every header to the engine of the stage the header belongs
+// to. It implements consensus.Handler, consensus.BFT and consensus.Syncer by
+// running only the engine that seals the block after the current head, and
+// switching engines when the chain head crosses a transition block.
+type Engine struct {
+	stages []Stage
+
+	mu           sync.Mutex
+	chain        consensus.ChainReader
+	active       consensus.Engine
+	started      bool
+	ctx          context.Context
+	currentBlock func() *types.Block
+	hasBadBlock  func(hash common.Hash) bool
+}
+
+// New creates a transition engine from stages ordered by block number. The
+// first stage must start at the genesis block.
+func New(stages []Stage) *Engine {
+	return &Engine{stages: stages}
+}
+
+// EngineAt returns the engine sealing the block number.
+func (e *Engine) EngineAt(number *big.Int) consensus.Engine {
+	engine := e.stages[0].Engine
+	for _, stage := range e.stages[1:] {
+		if number == nil || stage.Block.Cmp(number) > 0 {
+			break
+		}
+		engine = stage.Engine
+	}
+	return engine
+}
+
+// SetChain sets the chain used to pick the active engine while the engine is
+// not started, e.g. to route consensus messages on a node that does not seal.
+func (e *Engine) SetChain(chain consensus.ChainReader) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.chain = chain
+}
+
+// current returns the engine sealing the block after the current head.
+func (e *Engine) current() (consensus.Engine, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.started {
+		return e.active, nil
+	}
+	if e.chain == nil {
+		return nil, errNoChain
+	}
+	return e.next(e.chain.CurrentHeader().Number), nil
+}
+
+// next returns the engine sealing the block after number.
+func (e *Engine) next(number *big.Int) consensus.Engine {
+	return e.EngineAt(new(big.Int).Add(number, common.Big1))
+}
+
+// Author implements consensus.Engine.Author
+func (e *Engine) Author(header *types.Header) (common.Address, error) {
+	return e.EngineAt(header.Number).Author(header)
+}
+
+// VerifyHeader implements consensus.Engine.VerifyHeader
+func (e *Engine) VerifyHeader(chain consensus.ChainReader, header *types.Header, seal bool) error {
+	return e.EngineAt(header.Number).VerifyHeader(chain, header, seal)
+}
+
+// VerifyHeaders implements consensus.Engine.VerifyHeaders. The batch is split
+// in runs of headers sealed by the same engine, and every run is verified by
+// its engine with the headers of the previous runs visible through the chain.
+func (e *Engine) VerifyHeaders(chain consensus.ChainReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
+	abort := make(chan struct{})
+	results := make(chan error, len(headers))
+
+	go func() {
+		batch := &batchChain{
+			ChainReader: chain,
+			byHash:      make(map[common.Hash]*types.Header),
+			byNumber:    make(map[uint64]*types.Header),
+		}
+		for start := 0; start < len(headers); {
+			engine := e.EngineAt(headers[start].Number)
+			end := start + 1
+			for end < len(headers) && e.EngineAt(headers[end].Number) == engine {
+				end++
+			}
+
+			quit, errs := engine.VerifyHeaders(batch, headers[start:end], seals[start:end])
+			for i := start; i < end; i++ {
+				select {
+				case <-abort:
+					close(quit)
+					return
+				case err := <-errs:
+					results <- err
+				}
+			}
+			close(quit)
+
+			for _, header := range headers[start:end] {
+				batch.byHash[header.Hash()] = header
+				batch.byNumber[header.Number.Uint64()] = header
+			}
+			start = end
+		}
+	}()
+	return abort, results
+}
+
+// VerifyUncles implements consensus.Engine.VerifyUncles
+func (e *Engine) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
+	return e.EngineAt(block.Number()).VerifyUncles(chain, block)
+}
+
+// VerifySeal implements consensus.Engine.VerifySeal
+func (e *Engine) VerifySeal(chain consensus.ChainReader, header *types.Header) error {
+	return e.EngineAt(header.Number).VerifySeal(chain, header)
+}
+
+// Prepare implements consensus.Engine.Prepare
+func (e *Engine) Prepare(chain consensus.ChainReader, header *types.Header) error {
+	return e.EngineAt(header.Number).Prepare(chain, header)
+}
+
+// Finalize implements consensus.Engine.Finalize
+func (e *Engine) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction,
+	uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
+	return e.EngineAt(header.Number).Finalize(chain, header, state, txs, uncles, receipts)
+}
+
+// Seal implements consensus.Engine.Seal
+func (e *Engine) Seal(chain consensus.ChainReader, block *types.Block, stop <-chan struct{}) (*types.Block, error) {
+	return e.EngineAt(block.Number()).Seal(chain, block, stop)
+}
+
+// SealHash implements consensus.Engine.SealHash
+func (e *Engine) SealHash(header *types.Header) common.Hash {
+	return e.EngineAt(header.Number).SealHash(header)
+}
+
+// CalcDifficulty implements consensus.Engine.CalcDifficulty, using the engine
+// of the block after parent.
+func (e *Engine) CalcDifficulty(chain consensus.ChainReader, time uint64, parent *types.Header) *big.Int {
+	return e.next(parent.Number).CalcDifficulty(chain, time, parent)
+}
+
+// APIs implements consensus.Engine.APIs, returning the APIs of every engine.
+func (e *Engine) APIs(chain consensus.ChainReader) []rpc.API {
+	var apis []rpc.API
+	for _, stage := range e.stages {
+		apis = append(apis, stage.Engine.APIs(chain)...)
+	}
+	return apis
+}
+
+// ProtocolOld implements consensus.Engine.ProtocolOld, returning the protocol of
+// the last engine so that the sub-protocol is the same across the transitions.
+func (e *Engine) ProtocolOld() consensus.Protocol {
+	return e.stages[len(e.stages)-1].Engine.ProtocolOld()
+}
+
+// Close implements consensus.Engine.Close, closing every engine.
+func (e *Engine) Close() error {
+	var err error
+	for _, stage := range e.stages {
+		if closeErr := stage.Engine.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Start implements consensus.BFT.Start, starting the engine sealing the block
+// after the current head.
+func (e *Engine) Start(ctx context.Context, chain consensus.ChainReader, currentBlock func() *types.Block, hasBadBlock func(hash common.Hash) bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.started {
+		return nil
+	}
+	e.ctx, e.chain, e.currentBlock, e.hasBadBlock = ctx, chain, currentBlock, hasBadBlock
+
+	engine := e.next(currentBlock().Number())
+	if err := e.start(engine); err != nil {
+		return err
+	}
+	e.active, e.started = engine, true
+	return nil
+}
+
+// Stop implements consensus.BFT.Stop
+func (e *Engine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.started {
+		return nil
+	}
+	e.started = false
+	return e.stop(e.active)
+}
+
+func (e *Engine) start(engine consensus.Engine) error {
+	if bft, ok := engine.(consensus.BFT); ok {
+		return bft.Start(e.ctx, e.chain, e.currentBlock, e.hasBadBlock)
+	}
+	return nil
+}
+
+func (e *Engine) stop(engine consensus.Engine) error {
+	if bft, ok := engine.(consensus.BFT); ok {
+		return bft.Stop()
+	}
+	return nil
+}
+
+// NewChainHead implements consensus.Handler.NewChainHead. When the new head is
+// the last block of a stage, the active engine is stopped and the engine of the
+// next stage is started from the new head.
+func (e *Engine) NewChainHead() error {
+	if switched, err := e.switchEngine(); switched || err != nil {
+		return err
+	}
+	engine, err := e.current()
+	if err != nil {
+		return err
+	}
+	if handler, ok := engine.(consensus.Handler); ok {
+		return handler.NewChainHead()
+	}
+	return nil
+}
+
+// switchEngine starts the engine sealing the block after the current head if it
+// is not the active one, and reports whether it did.
+func (e *Engine) switchEngine() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.started {
+		return false, nil
+	}
+	engine := e.next(e.currentBlock().Number())
+	if engine == e.active {
+		return false, nil
+	}
+	if err := e.stop(e.active); err != nil {
+		return true, err
+	}
+	e.active = engine
+	return true, e.start(engine)
+}
+
+// HandleMsg implements consensus.Handler.HandleMsg, passing the message to the
+// active engine only as all the engines share the same message codes.
+func (e *Engine) HandleMsg(address common.Address, data p2p.Msg, peer enode.ID) (bool, error) {
+	engine, err := e.current()
+	if err != nil {
+		return false, nil
+	}
+	if handler, ok := engine.(consensus.Handler); ok {
+		return handler.HandleMsg(address, data, peer)
+	}
+	return false, nil
+}
+
+// SetBroadcaster implements consensus.Handler.SetBroadcaster
+func (e *Engine) SetBroadcaster(broadcaster consensus.Broadcaster) {
+	for _, stage := range e.stages {
+		if handler, ok := stage.Engine.(consensus.Handler); ok {
+			handler.SetBroadcaster(broadcaster)
+		}
+	}
+}
+
+// Protocol implements consensus.Handler.Protocol
+func (e *Engine) Protocol() (protocolName string, extraMsgCodes uint64) {
+	if handler, ok := e.stages[len(e.stages)-1].Engine.(consensus.Handler); ok {
+		return handler.Protocol()
+	}
+	return "", 0
+}
+
+// SyncPeer implements consensus.Syncer.SyncPeer
+func (e *Engine) SyncPeer(address common.Address) {
+	if engine, err := e.current(); err == nil {
+		if syncer, ok := engine.(consensus.Syncer); ok {
+			syncer.SyncPeer(address)
+		}
+	}
+}
+
+// ResetPeerCache implements consensus.Syncer.ResetPeerCache
+func (e *Engine) ResetPeerCache(address common.Address) {
+	if engine, err := e.current(); err == nil {
+		if syncer, ok := engine.(consensus.Syncer); ok {
+			syncer.ResetPeerCache(address)
+		}
+	}
+}
+
+// batchChain exposes the headers already verified in a batch to the engine
+// verifying the rest of the batch.
+type batchChain struct {
+	consensus.ChainReader
+
+	byHash   map[common.Hash]*types.Header
+	byNumber map[uint64]*types.Header
+}
+
+func (c *batchChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := c.byHash[hash]; ok && header.Number.Uint64() == number {
+		return header
+	}
+	return c.ChainReader.GetHeader(hash, number)
+}
+
+func (c *batchChain) GetHeaderByNumber(number uint64) *types.Header {
+	if header, ok := c.byNumber[number]; ok {
+		return header
+	}
+	return c.ChainReader.GetHeaderByNumber(number)
+}
+
+func (c *batchChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if header, ok := c.byHash[hash]; ok {
+		return header
+	}
+	return c.ChainReader.GetHeaderByHash(hash)
+}
@@ -0,0 +1,133 @@
+package transition
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// testEngine is a BFT engine recording the headers it verifies.
+type testEngine struct {
+	consensus.Engine
+
+	author   common.Address
+	verified []uint64
+	started  bool
+	starts   int
+}
+
+func (e *testEngine) Author(header *types.Header) (common.Address, error) {
+	return e.author, nil
+}
+
+func (e *testEngine) VerifyHeaders(chain consensus.ChainReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
+	abort, results := make(chan struct{}), make(chan error, len(headers))
+	for i, header := range headers {
+		var err error
+		if i > 0 && headers[i-1].Hash() != header.ParentHash {
+			err = consensus.ErrUnknownAncestor
+		}
+		if i == 0 && chain.GetHeader(header.ParentHash, header.Number.Uint64()-1) == nil {
+			err = consensus.ErrUnknownAncestor
+		}
+		e.verified = append(e.verified, header.Number.Uint64())
+		results <- err
+	}
+	return abort, results
+}
+
+func (e *testEngine) Start(ctx context.Context, chain consensus.ChainReader, currentBlock func() *types.Block, hasBadBlock func(hash common.Hash) bool) error {
+	e.started = true
+	e.starts++
+	return nil
+}
+
+func (e *testEngine) Stop() error {
+	e.started = false
+	return nil
+}
+
+// testChain is a chain reader knowing only the genesis header.
+type testChain struct {
+	consensus.ChainReader
+
+	genesis *types.Header
+}
+
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if hash == c.genesis.Hash() && number == 0 {
+		return c.genesis
+	}
+	return nil
+}
+
+func newTestEngine() (*Engine, *testEngine, *testEngine) {
+	first := &testEngine{author: common.HexToAddress("0x01")}
+	second := &testEngine{author: common.HexToAddress("0x02")}
+	return New([]Stage{{Block: common.Big0, Engine: first}, {Block: big.NewInt(3), Engine: second}}), first, second
+}
+
+func TestEngineAt(t *testing.T) {
+	engine, first, second := newTestEngine()
+	for number, want := range []*testEngine{first, first, first, second, second} {
+		header := &types.Header{Number: big.NewInt(int64(number))}
+		author, _ := engine.Author(header)
+		if author != want.author {
+			t.Errorf("block %d: author mismatch: have %x, want %x", number, author, want.author)
+		}
+	}
+}
+
+func TestVerifyHeadersAcrossTransition(t *testing.T) {
+	engine, first, second := newTestEngine()
+
+	genesis := &types.Header{Number: big.NewInt(0)}
+	headers := []*types.Header{}
+	parent := genesis
+	for i := 1; i <= 5; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), ParentHash: parent.Hash()}
+		headers = append(headers, header)
+		parent = header
+	}
+	_, results := engine.VerifyHeaders(&testChain{genesis: genesis}, headers, make([]bool, len(headers)))
+	for i := range headers {
+		if err := <-results; err != nil {
+			t.Fatalf("header %d: verification failed: %v", i+1, err)
+		}
+	}
+	if len(first.verified) != 2 || len(second.verified) != 3 {
+		t.Errorf("verification split mismatch: have %v and %v", first.verified, second.verified)
+	}
+}
+
+func TestNewChainHeadSwitchesEngine(t *testing.T) {
+	engine, first, second := newTestEngine()
+
+	head := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	currentBlock := func() *types.Block { return head }
+	if err := engine.Start(context.Background(), nil, currentBlock, nil); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if !first.started || second.started {
+		t.Fatalf("start mismatch: first %v, second %v", first.started, second.started)
+	}
+
+	head = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})
+	if err := engine.NewChainHead(); err != nil {
+		t.Fatalf("failed to switch: %v", err)
+	}
+	if first.started || !second.started {
+		t.Fatalf("switch mismatch: first %v, second %v", first.started, second.started)
+	}
+	if err := engine.Stop(); err != nil {
+		t.Fatalf("failed to stop: %v", err)
+	}
+	if second.started {
+		t.Errorf("engine still running after stop")
+	}
+}
@@ -2,6 +2,7 @@ package autonity
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sort"
@@ -151,11 +152,30 @@ func (ac *Contract) DeployAutonityContract(chain consensus.ChainReader, header *
 	enodes := make([]string, 0, ln)
 	accTypes := make([]*big.Int, 0, ln)
 	participantStake := make([]*big.Int, 0, ln)
+	known := make(map[common.Address]bool, ln)
 	for _, v := range chain.Config().AutonityContractConfig.Users {
 		users = append(users, v.Address)
 		enodes = append(enodes, v.Enode)
 		accTypes = append(accTypes, big.NewInt(int64(v.Type.GetID())))
 		participantStake = append(participantStake, big.NewInt(int64(v.Stake)))
+		known[v.Address] = true
+	}
+
+	// On a consensus transition the validators of the previous engine keep
+	// sealing. Their enodes are not on chain, so they must all be configured
+	// as users.
+	if header.Number.Uint64() > 1 && ac.SavedValidatorsRetriever != nil {
+		carried, err := ac.SavedValidatorsRetriever(header.Number.Uint64())
+		if err != nil {
+			log.Error("SavedValidatorsRetriever returns err", "err", err)
+			return common.Address{}, err
+		}
+		for _, v := range carried {
+			if !known[v] {
+				log.Error("Validator of the previous consensus engine is not an Autonity user", "validator", v)
+				return common.Address{}, fmt.Errorf("%w: %s", ErrMissingValidatorUser, v.Hex())
+			}
+		}
 	}
 
 	//"" means contructor
@@ -176,8 +196,14 @@ func (ac *Contract) DeployAutonityContract(chain consensus.ChainReader, header *
 	gas := uint64(0xFFFFFFFF)
 	value := new(big.Int).SetUint64(0x00)
 
-	// Deploy the Autonity contract
-	_, contractAddress, _, vmerr := evm.Create(sender, data, gas, value, false)
+	// Deploy the Autonity contract. A contract deployed on a consensus
+	// transition gets the address of one deployed with the genesis, whatever
+	// the nonce of the deployer is by then.
+	contractAddress, err := chain.Config().AutonityContractConfig.GetContractAddress()
+	if err != nil {
+		return common.Address{}, err
+	}
+	_, _, _, vmerr := evm.CreateAt(sender, data, gas, value, contractAddress, false)
 	if vmerr != nil {
 		log.Error("evm.Create returns err", "err", vmerr)
 		return contractAddress, vmerr
@@ -187,9 +213,38 @@ func (ac *Contract) DeployAutonityContract(chain consensus.ChainReader, header *
 	ac.Unlock()
 	log.Info("Deployed Autonity Contract", "Address", contractAddress.String())
 
+	if config := chain.Config().AutonityContractConfig; config.HasLivenessParameters() {
+		input, err := contractABI.Pack("setLivenessParameters", new(big.Int).SetUint64(config.LivenessWindow), new(big.Int).SetUint64(config.JailThreshold))
+		if err != nil {
+			log.Error("contractABI.Pack returns err", "err", err)
+			return contractAddress, err
+		}
+		if _, _, vmerr := evm.Call(sender, contractAddress, input, gas, value, false); vmerr != nil {
+			log.Error("Error Autonity Contract setLivenessParameters()", "err", vmerr)
+			return contractAddress, vmerr
+		}
+	}
+
 	return contractAddress, nil
 }
 
+// NeedsDeployment returns whether the contract is deployed while processing
+// header: on the first block, or on the first block of a consensus transition
+// if the contract does not exist yet.
+func (ac *Contract) NeedsDeployment(chain consensus.ChainReader, header *types.Header, statedb *state.StateDB) bool {
+	if header.Number.Cmp(common.Big1) == 0 {
+		return true
+	}
+	if !chain.Config().IsConsensusTransition(header.Number) {
+		return false
+	}
+	address, err := chain.Config().AutonityContractConfig.GetContractAddress()
+	if err != nil {
+		return false
+	}
+	return statedb.GetCodeSize(address) == 0
+}
+
 func (ac *Contract) ContractGetValidators(chain consensus.ChainReader, header *types.Header, statedb *state.StateDB) ([]common.Address, error) {
 	if header.Number.Cmp(big.NewInt(1)) == 0 && ac.SavedValidatorsRetriever != nil {
 		return ac.SavedValidatorsRetriever(1)
@@ -236,6 +291,16 @@ func (ac *Contract) ContractGetValidators(chain consensus.ChainReader, header *t
 
 var ErrAutonityContract = errors.New("could not call Autonity contract")
 
+// ErrMissingContractMethod is returned when the Autonity contract of the chain
+// config lacks a method the consensus engine relies on, its bytecode and ABI
+// have to be regenerated from Autonity.sol.
+var ErrMissingContractMethod = errors.New("Autonity contract method not found")
+
+// ErrMissingValidatorUser is returned when the contract is deployed on a
+// consensus transition and a validator of the previous engine is missing from
+// the users of the Autonity contract configuration.
+var ErrMissingValidatorUser = errors.New("validator missing from the Autonity contract users")
+
 func (ac *Contract) UpdateEnodesWhitelist(state, vaultstate *state.StateDB, block *types.Block) error {
 	newWhitelist, err := ac.GetWhitelist(block, state, vaultstate)
 	if err != nil {
@@ -441,6 +506,92 @@ func (ac *Contract) ApplyPerformRedistribution(transactions types.Transactions,
 	return ac.PerformRedistribution(header, statedb, blockGas)
 }
 
+// ApplySlashing punishes offender for the offence identified by evidence. The
+// evidence must have been verified by the consensus engine, the contract only
+// guarantees that an offence is punished once. It fails if the contract has no
+// slash method.
+func (ac *Contract) ApplySlashing(header *types.Header, statedb *state.StateDB, offender common.Address, evidence common.Hash) error {
+	ABI, err := ac.abi()
+	if err != nil {
+		return err
+	}
+	if _, ok := ABI.Methods["slash"]; !ok {
+		log.Error("Autonity contract cannot slash", "offender", offender, "evidence", evidence)
+		return ErrMissingContractMethod
+	}
+
+	log.Info("ApplySlashing", "header", header.Number.Uint64(), "offender", offender, "evidence", evidence)
+	return ac.callSlash(statedb, header, offender, evidence)
+}
+
+func (ac *Contract) callSlash(state *state.StateDB, header *types.Header, offender common.Address, evidence common.Hash) error {
+	deployer := ac.bc.Config().AutonityContractConfig.Deployer
+	sender := vm.AccountRef(deployer)
+	gas := uint64(0xFFFFFFFF)
+	evm := ac.getEVM(header, deployer, state)
+
+	ABI, err := ac.abi()
+	if err != nil {
+		return err
+	}
+
+	input, err := ABI.Pack("slash", offender, evidence)
+	if err != nil {
+		log.Error("Error Autonity Contract callSlash()", "err", err)
+		return err
+	}
+
+	_, _, vmerr := evm.Call(sender, ac.Address(), input, gas, new(big.Int), false)
+	if vmerr != nil {
+		log.Error("Error Autonity Contract callSlash()", "err", vmerr)
+		return vmerr
+	}
+	return nil
+}
+
+// RecordSignatures records the fullnodes that signed the parent of header and
+// the ones that missed it. The contract jails the validators that missed too
+// many blocks at the end of each liveness window. The signers must have been
+// verified by the consensus engine from the parent committed seals of header.
+func (ac *Contract) RecordSignatures(header *types.Header, statedb *state.StateDB, signers []common.Address, missed []common.Address) error {
+	ABI, err := ac.abi()
+	if err != nil {
+		return err
+	}
+	if _, ok := ABI.Methods["recordSignatures"]; !ok {
+		log.Error("Autonity contract cannot record the block signers", "number", header.Number)
+		return ErrMissingContractMethod
+	}
+
+	log.Debug("RecordSignatures", "header", header.Number.Uint64(), "signers", len(signers), "missed", len(missed))
+	return ac.callRecordSignatures(statedb, header, signers, missed)
+}
+
+func (ac *Contract) callRecordSignatures(state *state.StateDB, header *types.Header, signers []common.Address, missed []common.Address) error {
+	deployer := ac.bc.Config().AutonityContractConfig.Deployer
+	sender := vm.AccountRef(deployer)
+	gas := uint64(0xFFFFFFFF)
+	evm := ac.getEVM(header, deployer, state)
+
+	ABI, err := ac.abi()
+	if err != nil {
+		return err
+	}
+
+	input, err := ABI.Pack("recordSignatures", signers, missed)
+	if err != nil {
+		log.Error("Error Autonity Contract callRecordSignatures()", "err", err)
+		return err
+	}
+
+	_, _, vmerr := evm.Call(sender, ac.Address(), input, gas, new(big.Int), false)
+	if vmerr != nil {
+		log.Error("Error Autonity Contract callRecordSignatures()", "err", vmerr)
+		return vmerr
+	}
+	return nil
+}
+
 func (ac *Contract) Address() common.Address {
 	if reflect.DeepEqual(ac.address, common.Address{}) {
 		addr, err := ac.bc.Config().AutonityContractConfig.GetContractAddress()
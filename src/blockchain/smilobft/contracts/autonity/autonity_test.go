@@ -0,0 +1,232 @@
+package autonity
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+func newTestContract(t *testing.T, contractABI string) *Contract {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		t.Fatalf("could not parse the contract ABI: %v", err)
+	}
+	return &Contract{contractABI: &parsed}
+}
+
+const testAddress4 = "70524d664ffe731100208a0154e556f9bb679ae3"
+
+// abiWithoutLiveness is the ABI of a contract deployed before slashing and liveness tracking
+const abiWithoutLiveness = `[{"type":"function","name":"getValidators","constant":true,"inputs":[],"outputs":[{"name":"","type":"address[]"}]}]`
+
+func TestContract_ApplySlashing(t *testing.T) {
+	t.Run("contract without slash method", func(t *testing.T) {
+		ac := newTestContract(t, abiWithoutLiveness)
+		header := &types.Header{Number: big.NewInt(2)}
+		err := ac.ApplySlashing(header, nil, common.HexToAddress(testAddress1), common.HexToHash("0x01"))
+		if err != ErrMissingContractMethod {
+			t.Fatalf("error mismatch: have %v, want %v", err, ErrMissingContractMethod)
+		}
+	})
+
+	t.Run("default contract", func(t *testing.T) {
+		offender, stakeholder := common.HexToAddress(testAddress1), common.HexToAddress(testAddress3)
+		c := newDefaultContract(t, nil)
+
+		// the default slashing rate burns 10% of the stake
+		if err := c.ac.ApplySlashing(c.header(11), c.statedb, offender, common.HexToHash("0x01")); err != nil {
+			t.Fatal(err)
+		}
+		if stake := c.view("getAccountStake", offender).(*big.Int); stake.Uint64() != 45 {
+			t.Errorf("stake mismatch: have %v, want 45", stake)
+		}
+		if supply := c.view("totalSupply").(*big.Int); supply.Uint64() != 175 {
+			t.Errorf("stake supply mismatch: have %v, want 175", supply)
+		}
+
+		// an evidence is applied once, only validators are slashed
+		if err := c.ac.ApplySlashing(c.header(12), c.statedb, offender, common.HexToHash("0x01")); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.ac.ApplySlashing(c.header(12), c.statedb, stakeholder, common.HexToHash("0x02")); err != nil {
+			t.Fatal(err)
+		}
+		if stake := c.view("getAccountStake", offender).(*big.Int); stake.Uint64() != 45 {
+			t.Errorf("stake mismatch: have %v, want 45", stake)
+		}
+		if stake := c.view("getAccountStake", stakeholder).(*big.Int); stake.Uint64() != 30 {
+			t.Errorf("stakeholder stake mismatch: have %v, want 30", stake)
+		}
+	})
+}
+
+func TestContract_RecordSignatures(t *testing.T) {
+	t.Run("contract without recordSignatures method", func(t *testing.T) {
+		ac := newTestContract(t, abiWithoutLiveness)
+		header := &types.Header{Number: big.NewInt(2)}
+		err := ac.RecordSignatures(header, nil, []common.Address{common.HexToAddress(testAddress1)}, nil)
+		if err != ErrMissingContractMethod {
+			t.Fatalf("error mismatch: have %v, want %v", err, ErrMissingContractMethod)
+		}
+	})
+
+	t.Run("default contract", func(t *testing.T) {
+		v1, v2, v3 := common.HexToAddress(testAddress1), common.HexToAddress(testAddress2), common.HexToAddress(testAddress4)
+		// every block closes the liveness window
+		c := newDefaultContract(t, func(config *params.AutonityContractGenesis) {
+			config.LivenessWindow = 1
+			config.JailThreshold = 5000
+		})
+
+		if err := c.ac.RecordSignatures(c.header(11), c.statedb, []common.Address{v1, v2}, []common.Address{v3}); err != nil {
+			t.Fatal(err)
+		}
+		validators := c.view("getValidators").([]common.Address)
+		if !reflect.DeepEqual(validators, []common.Address{v1, v2}) {
+			t.Errorf("validators mismatch: have %v, want %v", validators, []common.Address{v1, v2})
+		}
+		if jailed := c.view("jailed", v3).(bool); !jailed {
+			t.Error("offline validator is not jailed")
+		}
+	})
+}
+
+// defaultContract is the default Autonity contract deployed in a state with
+// three validators and a stakeholder
+type defaultContract struct {
+	t       *testing.T
+	ac      *Contract
+	statedb *state.StateDB
+	address common.Address
+}
+
+func newDefaultContract(t *testing.T, configure func(*params.AutonityContractGenesis)) *defaultContract {
+	t.Helper()
+	enode := "enode://d73b857969c86415c0c000371bcebd9ed3cca6c376032b3f65e58e9e2b79276fbc6f59eb1e22fcd6356ab95f42a666f70afd4985933bd8f3e05beb1a2bf8fdde@127.0.0.1:30303"
+	validators := []common.Address{common.HexToAddress(testAddress1), common.HexToAddress(testAddress2), common.HexToAddress(testAddress4)}
+	contractConfig := &params.AutonityContractGenesis{
+		Users: []params.User{
+			{Address: validators[0], Enode: enode, Type: params.UserValidator, Stake: 50},
+			{Address: validators[1], Enode: enode, Type: params.UserValidator, Stake: 50},
+			{Address: validators[2], Enode: enode, Type: params.UserValidator, Stake: 50},
+			{Address: common.HexToAddress(testAddress3), Enode: enode, Type: params.UserStakeHolder, Stake: 30},
+		},
+	}
+	if configure != nil {
+		configure(contractConfig)
+	}
+	config := *params.TestChainConfig
+	config.AutonityContractConfig = contractConfig.AddDefault()
+	chain := &testChain{config: &config}
+
+	c := &defaultContract{t: t}
+	c.statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	c.ac = NewAutonityContract(chain,
+		func(vm.StateDB, common.Address, *big.Int) bool { return true },
+		func(vm.StateDB, common.Address, common.Address, *big.Int, *big.Int) {},
+		func(*types.Header, ChainContext) func(uint64) common.Hash {
+			return func(uint64) common.Hash { return common.Hash{} }
+		})
+	c.ac.SavedValidatorsRetriever = func(uint64) ([]common.Address, error) { return validators, nil }
+	var err error
+	if c.address, err = c.ac.DeployAutonityContract(chain, c.header(10), c.statedb); err != nil {
+		t.Fatalf("failed to deploy the contract: %v", err)
+	}
+	return c
+}
+
+func (c *defaultContract) header(number int64) *types.Header {
+	return &types.Header{Number: big.NewInt(number), GasLimit: 0xFFFFFFFFF, Difficulty: common.Big1}
+}
+
+// view returns the single output of a view method of the contract
+func (c *defaultContract) view(method string, args ...interface{}) interface{} {
+	c.t.Helper()
+	ABI, err := c.ac.abi()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	input, err := ABI.Pack(method, args...)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	deployer := c.ac.bc.Config().AutonityContractConfig.Deployer
+	ret, _, err := c.ac.getEVM(c.header(20), deployer, c.statedb).StaticCall(vm.AccountRef(deployer), c.address, input, 0xFFFFFFFF, false)
+	if err != nil {
+		c.t.Fatalf("%s failed: %v", method, err)
+	}
+	out, err := ABI.Methods[method].Outputs.UnpackValues(ret)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return out[0]
+}
+
+// testChain provides the chain configuration to the contract.
+type testChain struct {
+	consensus.ChainReader
+	Blockchainer
+
+	config *params.ChainConfig
+}
+
+func (c *testChain) Config() *params.ChainConfig                             { return c.config }
+func (c *testChain) GetVMConfig() *vm.Config                                 { return &vm.Config{} }
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header { return nil }
+func (c *testChain) Engine() consensus.Engine                                { return nil }
+
+func TestContract_DeployAutonityContract(t *testing.T) {
+	validator := common.HexToAddress(testAddress1)
+	config := *params.TestChainConfig
+	config.AutonityContractConfig = (&params.AutonityContractGenesis{
+		Users: []params.User{{
+			Address: validator,
+			Enode:   "enode://d73b857969c86415c0c000371bcebd9ed3cca6c376032b3f65e58e9e2b79276fbc6f59eb1e22fcd6356ab95f42a666f70afd4985933bd8f3e05beb1a2bf8fdde@127.0.0.1:30303",
+			Type:    params.UserValidator,
+		}},
+	}).AddDefault()
+	chain := &testChain{config: &config}
+
+	deploy := func(carried ...common.Address) (common.Address, *state.StateDB, error) {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		// The deployer sent transactions before the consensus transition
+		statedb.SetNonce(config.AutonityContractConfig.Deployer, 5)
+
+		ac := NewAutonityContract(chain,
+			func(vm.StateDB, common.Address, *big.Int) bool { return true },
+			func(vm.StateDB, common.Address, common.Address, *big.Int, *big.Int) {},
+			func(*types.Header, ChainContext) func(uint64) common.Hash {
+				return func(uint64) common.Hash { return common.Hash{} }
+			})
+		ac.SavedValidatorsRetriever = func(uint64) ([]common.Address, error) { return carried, nil }
+		header := &types.Header{Number: big.NewInt(10), GasLimit: 0xFFFFFFFFF, Difficulty: common.Big1}
+		address, err := ac.DeployAutonityContract(chain, header, statedb)
+		return address, statedb, err
+	}
+
+	address, statedb, err := deploy(validator)
+	if err != nil {
+		t.Fatalf("failed to deploy the contract: %v", err)
+	}
+	want, _ := config.AutonityContractConfig.GetContractAddress()
+	if address != want || statedb.GetCodeSize(want) == 0 {
+		t.Fatalf("contract address mismatch: have %x, want %x", address, want)
+	}
+
+	if _, _, err := deploy(validator, common.HexToAddress("0x02")); !errors.Is(err, ErrMissingValidatorUser) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrMissingValidatorUser)
+	}
+}
@@ -76,6 +76,30 @@ contract Autonity {
     */
     uint256 minGasPrice = 0;
 
+    /*
+    * Fraction of the stake, in basis points, burned for each proven equivocation.
+    */
+    uint256 public slashingRate = 1000;
+
+    // evidence already applied, keyed by offence hash
+    mapping (bytes32 => bool) private processedEvidence;
+
+    /*
+    * Liveness: a validator that missed more than jailThreshold basis points of the blocks of a
+    * window of livenessWindow blocks is jailed, it is removed from the validator set until it calls
+    * unjail. A block is missed when the block after it doesn't carry the committed seal of the
+    * validator.
+    */
+    uint256 public livenessWindow = 1000;
+    uint256 public jailThreshold = 5000;
+
+    // block the current liveness window started at
+    uint256 private livenessWindowStart;
+    // blocks signed and missed by each validator in the current window
+    mapping (address => uint256) private signedBlocks;
+    mapping (address => uint256) private missedBlocks;
+    mapping (address => bool) public jailed;
+
     event Transfer(address indexed from, address indexed to, uint256 value);
     event AddValidator(address _address, uint256 _stake);
     event AddStakeholder(address _address, uint256 _stake);
@@ -85,6 +109,10 @@ contract Autonity {
     event SetCommissionRate(address _address, uint256 _value);
     event MintStake(address _address, uint256 _amount);
     event RedeemStake(address _address, uint256 _amount);
+    event Slash(address _address, uint256 _amount, bytes32 _evidence);
+    event EjectValidator(address _address);
+    event Jail(address _address, uint256 _missed, uint256 _signed);
+    event Unjail(address _address);
 
     // constructor get called at block #1
     // configured in the genesis file.
@@ -111,6 +139,7 @@ contract Autonity {
         deployer = msg.sender;
         operatorAccount = _operatorAccount;
         minGasPrice = _minGasPrice;
+        livenessWindowStart = block.number;
     }
 
 
@@ -162,6 +191,7 @@ contract Autonity {
         stakeSupply = stakeSupply.sub(u.stake);
         _removeFromArray(u.addr, usersList);
         delete users[_address];
+        delete jailed[_address];
         emit RemoveUser(_address, u.userType);
     }
 
@@ -208,6 +238,98 @@ contract Autonity {
     }
 
 
+    /*
+    * slash
+    * Burn part of the stake of a validator proven to have equivocated. The evidence is verified
+    * by the consensus engine before the call, the contract ensures each offence is applied once.
+    * A validator left without stake is ejected from the validator set.
+    * function MUST be restricted to the Deployer Account.
+    */
+    function slash(address _offender, bytes32 _evidence) public onlyDeployer(msg.sender) {
+        if (processedEvidence[_evidence] || users[_offender].userType != UserType.Validator) {
+            return;
+        }
+        processedEvidence[_evidence] = true;
+
+        User storage u = users[_offender];
+        uint256 amount = u.stake.mul(slashingRate).div(10000);
+        if (amount == 0) {
+            amount = u.stake;
+        }
+        u.stake = u.stake.sub(amount);
+        stakeSupply = stakeSupply.sub(amount);
+        emit Slash(_offender, amount, _evidence);
+
+        if (u.stake == 0) {
+            _removeFromArray(u.addr, validators);
+            u.userType = UserType.Stakeholder;
+            emit EjectValidator(_offender);
+        }
+    }
+
+    /*
+    * recordSignatures
+    * Record the validators that signed the parent block and the ones that missed it, the consensus
+    * engine verifies them from the parent committed seals carried by the block. At the end of a
+    * liveness window the validators that missed too many blocks are jailed, the last validator is
+    * never jailed.
+    * function MUST be restricted to the Deployer Account.
+    */
+    function recordSignatures(address[] memory _signers, address[] memory _missed) public onlyDeployer(msg.sender) {
+        for (uint256 i = 0; i < _signers.length; i++) {
+            signedBlocks[_signers[i]]++;
+        }
+        for (uint256 i = 0; i < _missed.length; i++) {
+            missedBlocks[_missed[i]]++;
+        }
+        if (block.number.sub(livenessWindowStart) < livenessWindow) {
+            return;
+        }
+        livenessWindowStart = block.number;
+
+        // iterate backward, jailing swaps the last validator into the current slot
+        for (uint256 i = validators.length; i > 0; i--) {
+            address v = validators[i - 1];
+            uint256 missed = missedBlocks[v];
+            uint256 signed = signedBlocks[v];
+            delete missedBlocks[v];
+            delete signedBlocks[v];
+            if (validators.length > 1 && missed.mul(10000) > missed.add(signed).mul(jailThreshold)) {
+                _removeFromArray(v, validators);
+                jailed[v] = true;
+                emit Jail(v, missed, signed);
+            }
+        }
+    }
+
+    /*
+    * unjail
+    * A jailed validator rejoins the validator set, its liveness is measured again from the next block.
+    */
+    function unjail() public {
+        require(jailed[msg.sender], "validator is not jailed");
+        require(users[msg.sender].userType == UserType.Validator, "address is not a validator");
+        jailed[msg.sender] = false;
+        delete missedBlocks[msg.sender];
+        delete signedBlocks[msg.sender];
+        validators.push(msg.sender);
+        emit Unjail(msg.sender);
+    }
+
+    /*
+    * setLivenessParameters
+    * Set the liveness window, in blocks, and the fraction of its blocks in basis points a validator may miss.
+    * function MUST be restricted to the Governance Operator account, the Deployer Account applies
+    * the parameters of the genesis file.
+    */
+    function setLivenessParameters(uint256 _window, uint256 _threshold) public {
+        require(operatorAccount == msg.sender || deployer == msg.sender, "Caller is not a operator");
+        require(_window > 0, "window must be positive");
+        require(_threshold <= 10000, "threshold is in basis points");
+        livenessWindow = _window;
+        jailThreshold = _threshold;
+    }
+
     /*
     * send
     * Moves `amount` stake tokens from the caller's account to `recipient`.
@@ -295,6 +417,14 @@ contract Autonity {
         return users[msg.sender].stake;
     }
 
+    /*
+    * getLiveness
+    * Returns the blocks signed and missed by a validator in the current liveness window and whether it is jailed.
+    */
+    function getLiveness(address _account) public view returns(uint256 signed, uint256 missed, bool isJailed) {
+        return (signedBlocks[_account], missedBlocks[_account], jailed[_account]);
+    }
+
     function getRate(address _account) public view returns(uint256) {
         return commission_rate[_account];
     }
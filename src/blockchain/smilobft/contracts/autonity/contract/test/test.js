@@ -314,4 +314,72 @@ contract('Autonity', function (accounts) {
         await token.removeUser(accounts[5], {from: governanceOperatorAccount});
     });
 
+    it('test slash validator, reduce stake and eject it', async function () {
+        const token = await Autonity.deployed();
+        const evidence1 = web3.utils.soliditySha3("evidence1");
+        const evidence2 = web3.utils.soliditySha3("evidence2");
+
+        await token.addValidator(accounts[6], 1000, "some enode", {from: governanceOperatorAccount});
+
+        try {
+            await token.slash(accounts[6], evidence1, {from: governanceOperatorAccount});
+            assert.fail('Expected throw not received');
+        } catch (e) {
+            assert(e.message.includes("Caller is not a operator"), e.message);
+        }
+
+        await token.slash(accounts[6], evidence1, {from: deployer});
+        var getStakeResult = await token.getAccountStake(accounts[6]);
+        assert(900 == getStakeResult, "stake is not slashed");
+
+        // the same evidence is applied once
+        await token.slash(accounts[6], evidence1, {from: deployer});
+        getStakeResult = await token.getAccountStake(accounts[6]);
+        assert(900 == getStakeResult, "evidence applied twice");
+
+        await token.redeemStake(accounts[6], 895, {from: governanceOperatorAccount});
+        await token.slash(accounts[6], evidence2, {from: deployer});
+        getStakeResult = await token.getAccountStake(accounts[6]);
+        assert(0 == getStakeResult, "stake is not slashed");
+
+        var getValidatorsResult = await token.getValidators();
+        assert.deepEqual(getValidatorsResult, validatorsList);
+
+        await token.removeUser(accounts[6], {from: governanceOperatorAccount});
+    });
+
+    it('test jail an offline validator and unjail it', async function () {
+        const token = await Autonity.deployed();
+        const signers = validatorsList.slice(0, 4);
+
+        try {
+            await token.recordSignatures(signers, [accounts[5]], {from: governanceOperatorAccount});
+            assert.fail('Expected throw not received');
+        } catch (e) {
+            assert(e.message.includes("Caller is not a operator"), e.message);
+        }
+
+        // every block closes the liveness window
+        await token.setLivenessParameters(1, 5000, {from: governanceOperatorAccount});
+        await token.recordSignatures(signers, [accounts[5]], {from: deployer});
+
+        var getValidatorsResult = await token.getValidators();
+        assert.deepEqual(getValidatorsResult, signers);
+        var liveness = await token.getLiveness(accounts[5]);
+        assert(liveness.isJailed, "offline validator is not jailed");
+
+        try {
+            await token.unjail({from: accounts[4]});
+            assert.fail('Expected throw not received');
+        } catch (e) {
+            assert(e.message.includes("validator is not jailed"), e.message);
+        }
+
+        await token.unjail({from: accounts[5]});
+        getValidatorsResult = await token.getValidators();
+        assert.deepEqual(getValidatorsResult, validatorsList);
+
+        await token.setLivenessParameters(1000, 5000, {from: governanceOperatorAccount});
+    });
+
 });
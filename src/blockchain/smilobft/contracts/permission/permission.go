@@ -0,0 +1,239 @@
+package permission
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+// contractsABI declares the view functions of the permissions interface and
+// of the account, org, role and function rule managers the access of an
+// account is read from. The output names only select the fields the values
+// are unpacked into.
+const contractsABI = `[
+{"constant":true,"inputs":[],"name":"getNetworkBootStatus","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_account","type":"address"}],"name":"getAccountDetails","outputs":[{"name":"account","type":"address"},{"name":"orgId","type":"string"},{"name":"roleId","type":"string"},{"name":"status","type":"uint256"},{"name":"orgAdmin","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_orgId","type":"string"}],"name":"checkOrgExists","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_orgId","type":"string"}],"name":"_getOrgIndex","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_orgIndex","type":"uint256"}],"name":"getOrgInfo","outputs":[{"name":"orgId","type":"string"},{"name":"parentId","type":"string"},{"name":"ultimateParent","type":"string"},{"name":"level","type":"uint256"},{"name":"status","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_roleId","type":"string"},{"name":"_orgId","type":"string"}],"name":"getRoleDetails","outputs":[{"name":"roleId","type":"string"},{"name":"orgId","type":"string"},{"name":"accessType","type":"uint256"},{"name":"voter","type":"bool"},{"name":"admin","type":"bool"},{"name":"active","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_orgId","type":"string"},{"name":"_roleId","type":"string"}],"name":"getNumberOfRoleRules","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_orgId","type":"string"},{"name":"_roleId","type":"string"},{"name":"_index","type":"uint256"}],"name":"getRoleRuleFromIndex","outputs":[{"name":"contractAddress","type":"address"},{"name":"functionSig","type":"bytes4"},{"name":"active","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"}
+]`
+
+// noAccount is the org id the account manager returns for unknown accounts
+const noAccount = "NONE"
+
+type accountDetails struct {
+	Account  common.Address
+	OrgId    string
+	RoleId   string
+	Status   *big.Int
+	OrgAdmin bool
+}
+
+type orgInfo struct {
+	OrgId          string
+	ParentId       string
+	UltimateParent string
+	Level          *big.Int
+	Status         *big.Int
+}
+
+type roleDetails struct {
+	RoleId     string
+	OrgId      string
+	AccessType *big.Int
+	Voter      bool
+	Admin      bool
+	Active     bool
+}
+
+type functionRule struct {
+	ContractAddress common.Address
+	FunctionSig     [4]byte
+	Active          bool
+}
+
+// Contracts reads the access of the accounts from the permission contracts in
+// the state of a block. Unlike the permission caches of core/types, which
+// follow the head of the chain, its answers only depend on the state they are
+// read from, so every node takes the same decision when processing a block.
+type Contracts struct {
+	config *params.PermissionContractsConfig
+	abi    abi.ABI
+}
+
+// New creates a reader of the permission contracts deployed at the addresses of config
+func New(config *params.PermissionContractsConfig) (*Contracts, error) {
+	ABI, err := abi.JSON(strings.NewReader(contractsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Contracts{config: config, abi: ABI}, nil
+}
+
+// AccountAccess returns the access of an account in the state of evm. It
+// follows the rules of PermissionCache.GetAcctAccess: the account and its orgs
+// must be active, admins have full access and the other accounts the access of
+// their role. Accounts without an active role are read only. The rules are not
+// enforced until the network is booted, the accounts deploying and booting the
+// permission contracts aren't known to them before.
+func (c *Contracts) AccountAccess(evm *vm.EVM, account common.Address) (types.AccessType, error) {
+	if !c.networkBooted(evm) {
+		return types.FullAccess, nil
+	}
+
+	var acct accountDetails
+	if err := c.call(evm, c.config.AccountAddress, &acct, "getAccountDetails", account); err != nil {
+		return types.ReadOnly, err
+	}
+	if acct.OrgId == noAccount || acct.Status.Uint64() != uint64(types.AcctActive) {
+		return types.ReadOnly, nil
+	}
+
+	org, err := c.org(evm, acct.OrgId)
+	if err != nil || org == nil || !orgActive(org) {
+		return types.ReadOnly, err
+	}
+	ultimateParent, err := c.org(evm, org.UltimateParent)
+	if err != nil || ultimateParent == nil || !orgActive(ultimateParent) {
+		return types.ReadOnly, err
+	}
+	if acct.OrgAdmin {
+		return types.FullAccess, nil
+	}
+
+	for _, orgId := range []string{acct.OrgId, org.UltimateParent} {
+		var role roleDetails
+		if err := c.call(evm, c.config.RoleAddress, &role, "getRoleDetails", acct.RoleId, orgId); err != nil {
+			return types.ReadOnly, err
+		}
+		if role.Active {
+			return types.AccessType(role.AccessType.Uint64()), nil
+		}
+	}
+	return types.ReadOnly, nil
+}
+
+// CheckFunctionAccess returns an error if the function rules of the role of an
+// account in the state of evm don't allow the contract call of tx. It follows
+// the rules of PermissionCache.CheckFunctionAccess: admins and accounts without
+// an active role aren't restricted. The rules are not enforced until the
+// network is booted and the function rule manager is deployed.
+func (c *Contracts) CheckFunctionAccess(evm *vm.EVM, account common.Address, tx *types.Transaction) error {
+	if !c.networkBooted(evm) || evm.StateDB.GetCodeSize(c.config.RuleAddress) == 0 {
+		return nil
+	}
+
+	var acct accountDetails
+	if err := c.call(evm, c.config.AccountAddress, &acct, "getAccountDetails", account); err != nil {
+		return err
+	}
+	if acct.OrgId == noAccount || acct.Status.Uint64() != uint64(types.AcctActive) || acct.OrgAdmin {
+		return nil
+	}
+	org, err := c.org(evm, acct.OrgId)
+	if err != nil || org == nil {
+		return err
+	}
+
+	for _, orgId := range []string{acct.OrgId, org.UltimateParent} {
+		var role roleDetails
+		if err := c.call(evm, c.config.RoleAddress, &role, "getRoleDetails", acct.RoleId, orgId); err != nil {
+			return err
+		}
+		if role.Active {
+			rules, err := c.roleRules(evm, orgId, acct.RoleId)
+			if err != nil {
+				return err
+			}
+			return types.CheckFunctionRules(rules, tx.To(), tx.Data(), tx.IsPrivate())
+		}
+	}
+	return nil
+}
+
+// networkBooted tells whether the network admin has booted the network in the
+// state of evm. The interface fails to answer until the upgradable contract
+// links it to the implementation, the network isn't booted then either.
+func (c *Contracts) networkBooted(evm *vm.EVM) bool {
+	if evm.StateDB.GetCodeSize(c.config.InterfAddress) == 0 {
+		return false
+	}
+	input, err := c.abi.Pack("getNetworkBootStatus")
+	if err != nil {
+		return false
+	}
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), c.config.InterfAddress, input, uint64(0xFFFFFFFF), false)
+	if err != nil {
+		return false
+	}
+	var booted bool
+	return c.abi.Unpack(&booted, "getNetworkBootStatus", ret) == nil && booted
+}
+
+// roleRules returns the function rules of a role of an org
+func (c *Contracts) roleRules(evm *vm.EVM, orgId, roleId string) ([]types.FunctionRule, error) {
+	count := new(big.Int)
+	if err := c.call(evm, c.config.RuleAddress, &count, "getNumberOfRoleRules", orgId, roleId); err != nil {
+		return nil, err
+	}
+	rules := make([]types.FunctionRule, 0, count.Uint64())
+	for i := int64(0); i < count.Int64(); i++ {
+		var rule functionRule
+		if err := c.call(evm, c.config.RuleAddress, &rule, "getRoleRuleFromIndex", orgId, roleId, big.NewInt(i)); err != nil {
+			return nil, err
+		}
+		rules = append(rules, types.FunctionRule{
+			OrgId:    orgId,
+			RoleId:   roleId,
+			Contract: rule.ContractAddress,
+			Selector: rule.FunctionSig,
+			Active:   rule.Active,
+		})
+	}
+	return rules, nil
+}
+
+// org returns the details of an org, nil if it doesn't exist
+func (c *Contracts) org(evm *vm.EVM, orgId string) (*orgInfo, error) {
+	var exists bool
+	if err := c.call(evm, c.config.OrgAddress, &exists, "checkOrgExists", orgId); err != nil || !exists {
+		return nil, err
+	}
+	index := new(big.Int)
+	if err := c.call(evm, c.config.OrgAddress, &index, "_getOrgIndex", orgId); err != nil {
+		return nil, err
+	}
+	org := new(orgInfo)
+	if err := c.call(evm, c.config.OrgAddress, org, "getOrgInfo", index); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func orgActive(org *orgInfo) bool {
+	status := types.OrgStatus(org.Status.Uint64())
+	return status == types.OrgApproved || status == types.OrgPendingSuspension
+}
+
+func (c *Contracts) call(evm *vm.EVM, contract common.Address, result interface{}, method string, args ...interface{}) error {
+	input, err := c.abi.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), contract, input, uint64(0xFFFFFFFF), false)
+	if err != nil {
+		log.Error("Error permission contract call", "method", method, "contract", contract, "err", err)
+		return err
+	}
+	return c.abi.Unpack(result, method, ret)
+}
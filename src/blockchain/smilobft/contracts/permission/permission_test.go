@@ -0,0 +1,177 @@
+package permission_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/contracts/permission"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
+)
+
+var (
+	guardian = common.HexToAddress("0x0000000000000000000000000000000000000a01")
+	stranger = common.HexToAddress("0x0000000000000000000000000000000000000a02")
+)
+
+// testNetwork holds the permission contracts deployed by the guardian in a
+// state, as the node of the guardian deploys them before booting the network
+type testNetwork struct {
+	t       *testing.T
+	statedb *state.StateDB
+	config  *params.PermissionContractsConfig
+}
+
+func newTestNetwork(t *testing.T) *testNetwork {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	n := &testNetwork{t: t, statedb: statedb}
+
+	upgr := n.deploy(pbind.PermUpgrABI, pbind.PermUpgrBin, guardian)
+	interf := n.deploy(pbind.PermInterfaceABI, pbind.PermInterfaceBin, upgr)
+	node := n.deploy(pbind.NodeManagerABI, pbind.NodeManagerBin, upgr)
+	role := n.deploy(pbind.RoleManagerABI, pbind.RoleManagerBin, upgr)
+	account := n.deploy(pbind.AcctManagerABI, pbind.AcctManagerBin, upgr)
+	org := n.deploy(pbind.OrgManagerABI, pbind.OrgManagerBin, upgr)
+	voter := n.deploy(pbind.VoterManagerABI, pbind.VoterManagerBin, upgr)
+	impl := n.deploy(pbind.PermImplABI, pbind.PermImplBin, upgr, org, role, account, voter, node)
+	n.transact(pbind.PermUpgrABI, upgr, "init", interf, impl)
+
+	n.config = &params.PermissionContractsConfig{
+		InterfAddress:  interf,
+		OrgAddress:     org,
+		RoleAddress:    role,
+		AccountAddress: account,
+	}
+	return n
+}
+
+func (n *testNetwork) evm() *vm.EVM {
+	context := vm.Context{
+		CanTransfer: func(vm.StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(vm.StateDB, common.Address, common.Address, *big.Int, *big.Int) {},
+		Origin:      guardian,
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		Difficulty:  big.NewInt(1),
+		GasLimit:    0xFFFFFFFFF,
+		GasPrice:    new(big.Int),
+	}
+	// the permission implementation exceeds the contract size limit of EIP-158
+	config := *params.AllEthashProtocolChanges
+	config.EIP158Block = nil
+	return vm.NewEVM(context, n.statedb, n.statedb, &config, vm.Config{})
+}
+
+func (n *testNetwork) deploy(contractABI, bin string, args ...interface{}) common.Address {
+	n.t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		n.t.Fatal(err)
+	}
+	input, err := parsed.Pack("", args...)
+	if err != nil {
+		n.t.Fatal(err)
+	}
+	_, address, _, err := n.evm().Create(vm.AccountRef(guardian), append(common.FromHex(bin), input...), 0xFFFFFFFF, new(big.Int), false)
+	if err != nil {
+		n.t.Fatalf("failed to deploy a permission contract: %v", err)
+	}
+	return address
+}
+
+func (n *testNetwork) transact(contractABI string, contract common.Address, method string, args ...interface{}) {
+	n.t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		n.t.Fatal(err)
+	}
+	input, err := parsed.Pack(method, args...)
+	if err != nil {
+		n.t.Fatal(err)
+	}
+	if _, _, err := n.evm().Call(vm.AccountRef(guardian), contract, input, 0xFFFFFFFF, new(big.Int), false); err != nil {
+		n.t.Fatalf("%s failed: %v", method, err)
+	}
+}
+
+// boot runs the boot up of the network of the permission service, the
+// guardian becomes the network admin
+func (n *testNetwork) boot() {
+	n.transact(pbind.PermInterfaceABI, n.config.InterfAddress, "setPolicy", "NWADMIN", "NWADMIN", "ORGADMIN")
+	n.transact(pbind.PermInterfaceABI, n.config.InterfAddress, "init", big.NewInt(4), big.NewInt(4))
+	n.transact(pbind.PermInterfaceABI, n.config.InterfAddress, "addAdminAccount", guardian)
+	n.transact(pbind.PermInterfaceABI, n.config.InterfAddress, "updateNetworkBootStatus")
+}
+
+func (n *testNetwork) access(c *permission.Contracts, account common.Address) types.AccessType {
+	n.t.Helper()
+	access, err := c.AccountAccess(n.evm(), account)
+	if err != nil {
+		n.t.Fatalf("failed to read the access of %x: %v", account, err)
+	}
+	return access
+}
+
+func TestContracts_AccountAccess(t *testing.T) {
+	n := newTestNetwork(t)
+	c, err := permission.New(n.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the guardian deploys and boots the network before any account is known
+	if access := n.access(c, stranger); access != types.FullAccess {
+		t.Errorf("access before the network boot mismatch: have %v, want %v", access, types.FullAccess)
+	}
+	n.transact(pbind.PermInterfaceABI, n.config.InterfAddress, "setPolicy", "NWADMIN", "NWADMIN", "ORGADMIN")
+	if access := n.access(c, stranger); access != types.FullAccess {
+		t.Errorf("access during the network boot mismatch: have %v, want %v", access, types.FullAccess)
+	}
+
+	n.boot()
+	if access := n.access(c, guardian); access != types.FullAccess {
+		t.Errorf("network admin access mismatch: have %v, want %v", access, types.FullAccess)
+	}
+	if access := n.access(c, stranger); access != types.ReadOnly {
+		t.Errorf("unknown account access mismatch: have %v, want %v", access, types.ReadOnly)
+	}
+}
+
+func TestContracts_AccountAccessWithoutContracts(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	n := &testNetwork{t: t, statedb: statedb}
+	c, err := permission.New(&params.PermissionContractsConfig{
+		InterfAddress:  common.HexToAddress("0x01"),
+		AccountAddress: common.HexToAddress("0x02"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access := n.access(c, stranger); access != types.FullAccess {
+		t.Errorf("access mismatch: have %v, want %v", access, types.FullAccess)
+	}
+}
+
+func TestContracts_CheckFunctionAccess(t *testing.T) {
+	n := newTestNetwork(t)
+	n.config.RuleAddress = common.HexToAddress("0x0b")
+	// any code stands for the rule manager, the network isn't booted
+	n.statedb.SetCode(n.config.RuleAddress, []byte{0xfe})
+	c, err := permission.New(n.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x0c")
+	tx := types.NewTransaction(0, to, new(big.Int), 21000, new(big.Int), []byte{1, 2, 3, 4})
+	if err := c.CheckFunctionAccess(n.evm(), stranger, tx); err != nil {
+		t.Errorf("function rules enforced before the network boot: %v", err)
+	}
+}
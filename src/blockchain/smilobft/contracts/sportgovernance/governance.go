@@ -0,0 +1,396 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package sportgovernance implements the SPoRT fullnode governance contract.
+//
+// The contract is a system account living at a fixed address. Its code is a
+// single STOP so that transactions to it always succeed, and the SPoRT engine
+// interprets their calldata when finalizing the block. All proposals, votes and
+// their history are kept in the account storage so that they are part of the
+// consensus state and can be read back at any block.
+//
+// The storage layout follows the Solidity conventions:
+//
+//	slot 0: threshold, the percentage of fullnodes that must vote for a proposal
+//	slot 1: fullnodes, a dynamic array of addresses
+//	slot 2: proposals, a dynamic array of the addresses with pending votes
+//	slot 3: votes, a mapping of keccak(voter, candidate) to voteAuthorize or voteDrop
+//	slot 4: history, a dynamic array of records, three words each
+package sportgovernance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"go-smilo/src/blockchain/smilobft/core/state"
+)
+
+// Address is where the governance contract is deployed at genesis.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000005350")
+
+// DefaultThreshold is the percentage of fullnodes that must vote for a proposal
+// when the genesis does not configure one, matching the header voting majority.
+const DefaultThreshold = 50
+
+const (
+	slotThreshold uint64 = iota
+	slotFullnodes
+	slotProposals
+	slotVotes
+	slotHistory
+)
+
+const (
+	voteNone uint64 = iota
+	voteAuthorize
+	voteDrop
+)
+
+// Kinds of history records.
+const (
+	KindGenesis  uint8 = iota // fullnode set at genesis
+	KindVote                  // a fullnode voted on a proposal
+	KindDiscard               // a fullnode withdrew its vote
+	KindApplied               // a proposal passed at an epoch boundary
+	KindRejected              // a proposal expired at an epoch boundary
+)
+
+var (
+	// code is the contract code, a single STOP.
+	code = []byte{0x00}
+
+	proposeSelector = crypto.Keccak256([]byte("propose(address,bool)"))[:4]
+	discardSelector = crypto.Keccak256([]byte("discard(address)"))[:4]
+
+	// ErrNotDeployed is returned if the governance contract is not in the state.
+	ErrNotDeployed = errors.New("sport governance contract is not deployed")
+	// ErrUnknownMethod is returned if the calldata doesn't match any method.
+	ErrUnknownMethod = errors.New("unknown governance method")
+	// ErrUnauthorizedVoter is returned if the sender is not a fullnode.
+	ErrUnauthorizedVoter = errors.New("voter is not a fullnode")
+	// ErrInvalidProposal is returned when voting to authorize a fullnode or to
+	// drop an account that is not one.
+	ErrInvalidProposal = errors.New("invalid proposal")
+	// ErrInsufficientFunds is returned when proposing an account that doesn't
+	// hold the minimum funds of a fullnode.
+	ErrInsufficientFunds = errors.New("insufficient funds for a fullnode")
+)
+
+// Record is an entry of the governance history.
+type Record struct {
+	Block     uint64         `json:"block"`     // Block number the record was written in
+	Kind      uint8          `json:"kind"`      // What happened, one of the Kind constants
+	Voter     common.Address `json:"voter"`     // Fullnode that voted, empty for epoch records
+	Address   common.Address `json:"address"`   // Account being voted on
+	Authorize bool           `json:"authorize"` // Whether to authorize or deauthorize the account
+}
+
+// Proposal is a pending change of the fullnode set.
+type Proposal struct {
+	Address   common.Address   `json:"address"`   // Account being voted on
+	Authorize bool             `json:"authorize"` // Whether to authorize or deauthorize the account
+	Voters    []common.Address `json:"voters"`    // Fullnodes that voted for the proposal
+}
+
+// Deploy creates the governance contract with the initial fullnodes.
+func Deploy(statedb *state.StateDB, fullnodes []common.Address, threshold uint64) {
+	if threshold == 0 || threshold >= 100 {
+		threshold = DefaultThreshold
+	}
+	statedb.SetCode(Address, code)
+	statedb.SetState(Address, slotKey(slotThreshold), common.BigToHash(new(big.Int).SetUint64(threshold)))
+	setAddresses(statedb, slotFullnodes, sorted(fullnodes))
+	for _, f := range fullnodes {
+		appendRecord(statedb, Record{Kind: KindGenesis, Address: f, Authorize: true})
+	}
+}
+
+// Deployed reports whether the governance contract exists in the state.
+func Deployed(statedb *state.StateDB) bool {
+	return bytes.Equal(statedb.GetCode(Address), code)
+}
+
+// Threshold returns the percentage of fullnodes that must vote for a proposal.
+func Threshold(statedb *state.StateDB) uint64 {
+	return statedb.GetState(Address, slotKey(slotThreshold)).Big().Uint64()
+}
+
+// Fullnodes returns the fullnodes in ascending order.
+func Fullnodes(statedb *state.StateDB) []common.Address {
+	return addresses(statedb, slotFullnodes)
+}
+
+// Proposals returns the pending proposals with the fullnodes that voted for them.
+func Proposals(statedb *state.StateDB) []Proposal {
+	fullnodes := Fullnodes(statedb)
+	candidates := addresses(statedb, slotProposals)
+	proposals := make([]Proposal, 0, len(candidates))
+	for _, candidate := range candidates {
+		proposal := Proposal{Address: candidate, Authorize: !contains(fullnodes, candidate)}
+		for _, voter := range fullnodes {
+			if vote(statedb, voter, candidate) != voteNone {
+				proposal.Voters = append(proposal.Voters, voter)
+			}
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+// History returns every record written since genesis, in chronological order.
+func History(statedb *state.StateDB) []Record {
+	length := statedb.GetState(Address, slotKey(slotHistory)).Big().Uint64()
+	records := make([]Record, length)
+	for i := uint64(0); i < length; i++ {
+		records[i] = record(statedb, i)
+	}
+	return records
+}
+
+// Apply executes a call to the governance contract sent by voter in block number.
+// Accounts proposed as fullnodes must hold at least minBalance. Calls that fail
+// leave the state untouched.
+func Apply(statedb *state.StateDB, number uint64, voter common.Address, input []byte, minBalance *big.Int) error {
+	if !Deployed(statedb) {
+		return ErrNotDeployed
+	}
+	if len(input) < 4 {
+		return ErrUnknownMethod
+	}
+	switch selector, args := input[:4], input[4:]; {
+	case bytes.Equal(selector, proposeSelector) && len(args) == 64:
+		candidate, authorize := common.BytesToAddress(args[:32]), new(big.Int).SetBytes(args[32:]).Sign() != 0
+		if authorize && minBalance != nil && statedb.GetBalance(candidate).Cmp(minBalance) < 0 {
+			return ErrInsufficientFunds
+		}
+		return propose(statedb, number, voter, candidate, authorize)
+	case bytes.Equal(selector, discardSelector) && len(args) == 32:
+		return discard(statedb, number, voter, common.BytesToAddress(args))
+	}
+	return ErrUnknownMethod
+}
+
+// ProposeInput returns the calldata of a vote to authorize or drop address.
+func ProposeInput(address common.Address, authorize bool) []byte {
+	input := append(common.CopyBytes(proposeSelector), common.LeftPadBytes(address.Bytes(), 32)...)
+	flag := make([]byte, 32)
+	if authorize {
+		flag[31] = 1
+	}
+	return append(input, flag...)
+}
+
+// DiscardInput returns the calldata withdrawing a vote on address.
+func DiscardInput(address common.Address) []byte {
+	return append(common.CopyBytes(discardSelector), common.LeftPadBytes(address.Bytes(), 32)...)
+}
+
+// NextFullnodes returns the fullnodes that Tally would leave in place, without
+// modifying the state.
+func NextFullnodes(statedb *state.StateDB) []common.Address {
+	next, _ := tally(statedb)
+	return next
+}
+
+// Tally closes the epoch at block number. Proposals voted by more than the
+// threshold are applied, the others are rejected, and every vote is cleared.
+// It returns the new fullnodes in ascending order.
+func Tally(statedb *state.StateDB, number uint64) []common.Address {
+	fullnodes := Fullnodes(statedb)
+	next, passed := tally(statedb)
+	for _, proposal := range Proposals(statedb) {
+		kind := KindRejected
+		if passed[proposal.Address] {
+			kind = KindApplied
+		}
+		appendRecord(statedb, Record{Block: number, Kind: kind, Address: proposal.Address, Authorize: proposal.Authorize})
+		for _, voter := range fullnodes {
+			setVote(statedb, voter, proposal.Address, voteNone)
+		}
+	}
+	setAddresses(statedb, slotProposals, nil)
+	setAddresses(statedb, slotFullnodes, next)
+	return next
+}
+
+// tally computes the fullnodes after applying the passing proposals.
+func tally(statedb *state.StateDB) ([]common.Address, map[common.Address]bool) {
+	fullnodes := Fullnodes(statedb)
+	threshold := Threshold(statedb)
+	passed := make(map[common.Address]bool)
+
+	next := make(map[common.Address]bool)
+	for _, f := range fullnodes {
+		next[f] = true
+	}
+	for _, proposal := range Proposals(statedb) {
+		if uint64(len(proposal.Voters))*100 <= threshold*uint64(len(fullnodes)) {
+			continue
+		}
+		// Never drop the last fullnode, the chain could not make progress anymore
+		if !proposal.Authorize && len(next) == 1 {
+			continue
+		}
+		passed[proposal.Address] = true
+		if proposal.Authorize {
+			next[proposal.Address] = true
+		} else {
+			delete(next, proposal.Address)
+		}
+	}
+	result := make([]common.Address, 0, len(next))
+	for f := range next {
+		result = append(result, f)
+	}
+	return sorted(result), passed
+}
+
+func propose(statedb *state.StateDB, number uint64, voter, candidate common.Address, authorize bool) error {
+	fullnodes := Fullnodes(statedb)
+	if !contains(fullnodes, voter) {
+		return ErrUnauthorizedVoter
+	}
+	if contains(fullnodes, candidate) == authorize {
+		return ErrInvalidProposal
+	}
+	if !contains(addresses(statedb, slotProposals), candidate) {
+		appendAddress(statedb, slotProposals, candidate)
+	}
+	if authorize {
+		setVote(statedb, voter, candidate, voteAuthorize)
+	} else {
+		setVote(statedb, voter, candidate, voteDrop)
+	}
+	appendRecord(statedb, Record{Block: number, Kind: KindVote, Voter: voter, Address: candidate, Authorize: authorize})
+	return nil
+}
+
+func discard(statedb *state.StateDB, number uint64, voter, candidate common.Address) error {
+	fullnodes := Fullnodes(statedb)
+	if !contains(fullnodes, voter) {
+		return ErrUnauthorizedVoter
+	}
+	previous := vote(statedb, voter, candidate)
+	if previous == voteNone {
+		return ErrInvalidProposal
+	}
+	setVote(statedb, voter, candidate, voteNone)
+	appendRecord(statedb, Record{Block: number, Kind: KindDiscard, Voter: voter, Address: candidate, Authorize: previous == voteAuthorize})
+	return nil
+}
+
+// slotKey returns the storage key of a fixed slot.
+func slotKey(slot uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(slot))
+}
+
+// elemKey returns the storage key of the word i of the dynamic array at slot.
+func elemKey(slot uint64, i uint64) common.Hash {
+	base := new(big.Int).SetBytes(crypto.Keccak256(slotKey(slot).Bytes()))
+	return common.BigToHash(base.Add(base, new(big.Int).SetUint64(i)))
+}
+
+// voteKey returns the storage key of the vote of voter on candidate.
+func voteKey(voter, candidate common.Address) common.Hash {
+	return crypto.Keccak256Hash(voter.Bytes(), candidate.Bytes(), slotKey(slotVotes).Bytes())
+}
+
+func vote(statedb *state.StateDB, voter, candidate common.Address) uint64 {
+	return statedb.GetState(Address, voteKey(voter, candidate)).Big().Uint64()
+}
+
+func setVote(statedb *state.StateDB, voter, candidate common.Address, vote uint64) {
+	statedb.SetState(Address, voteKey(voter, candidate), common.BigToHash(new(big.Int).SetUint64(vote)))
+}
+
+func addresses(statedb *state.StateDB, slot uint64) []common.Address {
+	length := statedb.GetState(Address, slotKey(slot)).Big().Uint64()
+	result := make([]common.Address, length)
+	for i := uint64(0); i < length; i++ {
+		result[i] = common.BytesToAddress(statedb.GetState(Address, elemKey(slot, i)).Bytes())
+	}
+	return result
+}
+
+func setAddresses(statedb *state.StateDB, slot uint64, list []common.Address) {
+	length := statedb.GetState(Address, slotKey(slot)).Big().Uint64()
+	for i := uint64(len(list)); i < length; i++ {
+		statedb.SetState(Address, elemKey(slot, i), common.Hash{})
+	}
+	for i, addr := range list {
+		statedb.SetState(Address, elemKey(slot, uint64(i)), common.BytesToHash(addr.Bytes()))
+	}
+	statedb.SetState(Address, slotKey(slot), common.BigToHash(big.NewInt(int64(len(list)))))
+}
+
+func appendAddress(statedb *state.StateDB, slot uint64, addr common.Address) {
+	length := statedb.GetState(Address, slotKey(slot)).Big().Uint64()
+	statedb.SetState(Address, elemKey(slot, length), common.BytesToHash(addr.Bytes()))
+	statedb.SetState(Address, slotKey(slot), common.BigToHash(new(big.Int).SetUint64(length+1)))
+}
+
+// record reads the history entry i. The first word packs the block number in
+// its lowest 8 bytes, followed by the kind and the authorize flag.
+func record(statedb *state.StateDB, i uint64) Record {
+	word := statedb.GetState(Address, elemKey(slotHistory, 3*i))
+	return Record{
+		Block:     binary.BigEndian.Uint64(word[24:]),
+		Kind:      word[23],
+		Authorize: word[22] != 0,
+		Voter:     common.BytesToAddress(statedb.GetState(Address, elemKey(slotHistory, 3*i+1)).Bytes()),
+		Address:   common.BytesToAddress(statedb.GetState(Address, elemKey(slotHistory, 3*i+2)).Bytes()),
+	}
+}
+
+func appendRecord(statedb *state.StateDB, r Record) {
+	length := statedb.GetState(Address, slotKey(slotHistory)).Big().Uint64()
+
+	var word common.Hash
+	binary.BigEndian.PutUint64(word[24:], r.Block)
+	word[23] = r.Kind
+	if r.Authorize {
+		word[22] = 1
+	}
+	statedb.SetState(Address, elemKey(slotHistory, 3*length), word)
+	statedb.SetState(Address, elemKey(slotHistory, 3*length+1), common.BytesToHash(r.Voter.Bytes()))
+	statedb.SetState(Address, elemKey(slotHistory, 3*length+2), common.BytesToHash(r.Address.Bytes()))
+	statedb.SetState(Address, slotKey(slotHistory), common.BigToHash(new(big.Int).SetUint64(length+1)))
+}
+
+func contains(list []common.Address, addr common.Address) bool {
+	for _, a := range list {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func sorted(list []common.Address) []common.Address {
+	result := make([]common.Address, len(list))
+	copy(result, list)
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i][:], result[j][:]) < 0
+	})
+	return result
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package sportgovernance
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+)
+
+var (
+	nodeA = common.HexToAddress("0x000000000000000000000000000000000000000a")
+	nodeB = common.HexToAddress("0x000000000000000000000000000000000000000b")
+	nodeC = common.HexToAddress("0x000000000000000000000000000000000000000c")
+	nodeD = common.HexToAddress("0x000000000000000000000000000000000000000d")
+)
+
+func newTestState(t *testing.T, fullnodes ...common.Address) *state.StateDB {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Deploy(statedb, fullnodes, 0)
+	return statedb
+}
+
+func TestDeploy(t *testing.T) {
+	statedb := newTestState(t, nodeB, nodeA)
+	if !Deployed(statedb) {
+		t.Fatal("contract not deployed")
+	}
+	if threshold := Threshold(statedb); threshold != DefaultThreshold {
+		t.Errorf("threshold mismatch: have %d, want %d", threshold, DefaultThreshold)
+	}
+	if fullnodes := Fullnodes(statedb); !reflect.DeepEqual(fullnodes, []common.Address{nodeA, nodeB}) {
+		t.Errorf("fullnodes mismatch: have %v", fullnodes)
+	}
+	if history := History(statedb); len(history) != 2 || history[0].Kind != KindGenesis || history[0].Address != nodeB {
+		t.Errorf("genesis history mismatch: have %+v", history)
+	}
+}
+
+func TestApply(t *testing.T) {
+	statedb := newTestState(t, nodeA, nodeB, nodeC)
+	statedb.AddBalance(nodeD, big.NewInt(10), big.NewInt(0))
+
+	tests := []struct {
+		voter      common.Address
+		input      []byte
+		minBalance *big.Int
+		err        error
+	}{
+		{nodeD, ProposeInput(nodeD, true), nil, ErrUnauthorizedVoter},            // not a fullnode
+		{nodeA, ProposeInput(nodeB, true), nil, ErrInvalidProposal},              // already a fullnode
+		{nodeA, ProposeInput(nodeD, false), nil, ErrInvalidProposal},             // not a fullnode to drop
+		{nodeA, ProposeInput(nodeD, true), big.NewInt(11), ErrInsufficientFunds}, // too poor
+		{nodeA, DiscardInput(nodeD), nil, ErrInvalidProposal},                    // nothing to discard
+		{nodeA, []byte{0x01, 0x02}, nil, ErrUnknownMethod},
+		{nodeA, ProposeInput(nodeD, true)[:36], nil, ErrUnknownMethod},
+		{nodeA, ProposeInput(nodeD, true), big.NewInt(10), nil},
+		{nodeB, ProposeInput(nodeD, true), nil, nil},
+		{nodeB, DiscardInput(nodeD), nil, nil},
+		{nodeC, ProposeInput(nodeA, false), nil, nil},
+	}
+	for i, test := range tests {
+		if err := Apply(statedb, uint64(i+1), test.voter, test.input, test.minBalance); err != test.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+	want := []Proposal{
+		{Address: nodeD, Authorize: true, Voters: []common.Address{nodeA}},
+		{Address: nodeA, Authorize: false, Voters: []common.Address{nodeC}},
+	}
+	if proposals := Proposals(statedb); !reflect.DeepEqual(proposals, want) {
+		t.Errorf("proposals mismatch: have %+v, want %+v", proposals, want)
+	}
+	history := History(statedb)
+	if len(history) != 7 {
+		t.Fatalf("history length mismatch: have %d, want 7", len(history))
+	}
+	if want := (Record{Block: 10, Kind: KindDiscard, Voter: nodeB, Address: nodeD, Authorize: true}); history[5] != want {
+		t.Errorf("discard record mismatch: have %+v, want %+v", history[5], want)
+	}
+}
+
+func TestTally(t *testing.T) {
+	statedb := newTestState(t, nodeA, nodeB, nodeC)
+	statedb.AddBalance(nodeD, big.NewInt(10), big.NewInt(0))
+
+	// Two out of three fullnodes authorize D, only one drops C
+	for _, voter := range []common.Address{nodeA, nodeB} {
+		if err := Apply(statedb, 1, voter, ProposeInput(nodeD, true), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Apply(statedb, 2, nodeA, ProposeInput(nodeC, false), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []common.Address{nodeA, nodeB, nodeC, nodeD}
+	if next := NextFullnodes(statedb); !reflect.DeepEqual(next, want) {
+		t.Errorf("next fullnodes mismatch: have %v, want %v", next, want)
+	}
+	if next := Tally(statedb, 3); !reflect.DeepEqual(next, want) {
+		t.Errorf("tallied fullnodes mismatch: have %v, want %v", next, want)
+	}
+	if fullnodes := Fullnodes(statedb); !reflect.DeepEqual(fullnodes, want) {
+		t.Errorf("fullnodes mismatch: have %v, want %v", fullnodes, want)
+	}
+	if proposals := Proposals(statedb); len(proposals) != 0 {
+		t.Errorf("proposals not cleared: %+v", proposals)
+	}
+	history := History(statedb)
+	applied, rejected := history[len(history)-2], history[len(history)-1]
+	if applied != (Record{Block: 3, Kind: KindApplied, Address: nodeD, Authorize: true}) {
+		t.Errorf("applied record mismatch: have %+v", applied)
+	}
+	if rejected != (Record{Block: 3, Kind: KindRejected, Address: nodeC}) {
+		t.Errorf("rejected record mismatch: have %+v", rejected)
+	}
+	// Votes don't carry over the epoch
+	if err := Apply(statedb, 4, nodeD, ProposeInput(nodeC, false), nil); err != nil {
+		t.Fatal(err)
+	}
+	if proposals := Proposals(statedb); len(proposals) != 1 || len(proposals[0].Voters) != 1 {
+		t.Errorf("proposals mismatch: have %+v", proposals)
+	}
+}
+
+func TestTallyKeepsLastFullnode(t *testing.T) {
+	statedb := newTestState(t, nodeA)
+	if err := Apply(statedb, 1, nodeA, ProposeInput(nodeA, false), nil); err != nil {
+		t.Fatal(err)
+	}
+	if next := Tally(statedb, 2); !reflect.DeepEqual(next, []common.Address{nodeA}) {
+		t.Errorf("fullnodes mismatch: have %v", next)
+	}
+}
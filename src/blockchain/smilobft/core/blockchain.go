@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"go-smilo/src/blockchain/smilobft/contracts/autonity"
+	"go-smilo/src/blockchain/smilobft/contracts/permission"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -187,6 +188,8 @@ type BlockChain struct {
 	terminateInsert   func(common.Hash, uint64) bool // Testing hook used to terminate ancient receipt chain insertion.
 
 	autonityContract *autonity.Contract
+
+	permissionContracts *permission.Contracts // the account permissions are read from, nil if not enforced
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -258,6 +261,12 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		//panic(logmsg)
 	}
 
+	if chainConfig.PermissionContracts != nil {
+		if bc.permissionContracts, err = permission.New(chainConfig.PermissionContracts); err != nil {
+			return nil, err
+		}
+	}
+
 	// The first thing the node will do is reconstruct the verification data for
 	// the head block (ethash cache or clique voting snapshot). Might as well do
 	// it in advance.
@@ -582,6 +591,8 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, *state.StateDB,
 	if privateStateDbErr != nil {
 		return nil, nil, privateStateDbErr
 	}
+	publicStateDb.SetSmiloPayFixedPointBlock(bc.chainConfig.SmiloPayFixedPointBlock)
+	privateStateDb.SetSmiloPayFixedPointBlock(bc.chainConfig.SmiloPayFixedPointBlock)
 
 	return publicStateDb, privateStateDb, nil
 }
@@ -1388,14 +1399,14 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 	}
 
 	log.Warn("Call network permissioning logic before committing the state, UpdateEnodesWhitelist")
-	if bc.chainConfig.Istanbul != nil || bc.chainConfig.SportDAO != nil || bc.chainConfig.Tendermint != nil {
+	if bc.chainConfig.IsAutonity(block.Number()) {
 		err = bc.GetAutonityContract().UpdateEnodesWhitelist(state, privateState, block)
 		if err != nil && err != autonity.ErrAutonityContract {
 			log.Error("Could not UpdateEnodesWhitelist with SmartContract, ", "err", err)
 			return NonStatTy, err
 		}
 		// Measure network economic metrics.
-		if bc.chainConfig.Tendermint != nil {
+		if bc.chainConfig.ConsensusEngine(block.Number()) == params.TendermintEngine {
 			bc.GetAutonityContract().MeasureMetricsOfNetworkEconomic(block.Header(), state)
 		}
 
@@ -1512,6 +1523,9 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 		// Write the positional metadata for transaction/receipt lookups and preimages
 		rawdb.WriteTxLookupEntries(batch, block)
 		rawdb.WritePreimages(batch, state.Preimages())
+		if err := writeCanonicalPrivateBloom(batch, block, receipts); err != nil {
+			return NonStatTy, err
+		}
 
 		status = CanonStatTy
 	} else {
@@ -1849,9 +1863,6 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, []
 		blockWriteTimer.Update(time.Since(substart) - thisstate.AccountCommits - thisstate.StorageCommits)
 		blockInsertTimer.UpdateSince(start)
 
-		if err := WritePrivateBlockBloom(bc.db, block.NumberU64(), vaultReceipts); err != nil {
-			return it.index, events, coalescedLogs, err
-		}
 		switch status {
 		case CanonStatTy:
 			log.Debug("Inserted new block", "number", block.Number(), "hash", block.Hash(),
@@ -2139,6 +2150,12 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		// Collect reborn logs due to chain reorg
 		collectLogs(newChain[i].Hash(), false)
 
+		// The vault bloom is indexed by number, replace the one of the old chain
+		receipts := rawdb.ReadRawReceipts(bc.db, newChain[i].Hash(), newChain[i].NumberU64())
+		if err := writeCanonicalPrivateBloom(bc.db, newChain[i], receipts); err != nil {
+			return err
+		}
+
 		// Write lookup entries for hash based transaction/receipt searches
 		rawdb.WriteTxLookupEntries(bc.db, newChain[i])
 		addedTxs = append(addedTxs, newChain[i].Transactions()...)
@@ -234,6 +234,7 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if err != nil {
 			panic(err)
 		}
+		statedb.SetSmiloPayFixedPointBlock(config.SmiloPayFixedPointBlock)
 		block, receipt := genblock(i, parent, statedb)
 		blocks[i] = block
 		receipts[i] = receipt
@@ -26,6 +26,7 @@ import (
 	"strings"
 	"sync"
 
+	"go-smilo/src/blockchain/smilobft/contracts/sportgovernance"
 	"go-smilo/src/blockchain/smilobft/core/types"
 
 	"go-smilo/src/blockchain/smilobft/params"
@@ -295,6 +296,15 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 			statedb.SetState(addr, key, value)
 		}
 	}
+	if g.Config != nil && g.Config.Sport != nil && g.Config.Sport.Governance {
+		// Deploy the fullnode governance contract with the genesis fullnodes
+		sportExtra, err := types.ExtractSportExtra(&types.Header{Extra: g.ExtraData})
+		if err != nil {
+			log.Error("Could not deploy the sport governance contract, invalid genesis extra-data", "err", err)
+		} else {
+			sportgovernance.Deploy(statedb, sportExtra.Fullnodes, g.Config.Sport.Threshold)
+		}
+	}
 	root := statedb.IntermediateRoot(false)
 
 	g.mu.RLock()
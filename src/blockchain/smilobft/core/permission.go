@@ -0,0 +1,64 @@
+// Copyright 2019 The go-smilo Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+)
+
+// CheckTransactionPermission returns an error if the sender of tx isn't allowed
+// to send it in the block of header. The access of the sender is read from the
+// permission contracts of the chain config in statedb, so that every node takes
+// the same decision. Besides the access type of the sender, the function rules
+// of its role must allow the contract call.
+// The rules apply from the QIP714 block on.
+func (bc *BlockChain) CheckTransactionPermission(header *types.Header, statedb *state.StateDB, tx *types.Transaction) error {
+	contracts := bc.permissionContracts
+	if contracts == nil || !bc.chainConfig.IsQIP714(header.Number) {
+		return nil
+	}
+	from, err := types.Sender(types.MakeSigner(bc.chainConfig, header.Number), tx)
+	if err != nil {
+		return err
+	}
+
+	context := vm.Context{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		GetHash:     GetHashFn(header, bc),
+		Origin:      from,
+		Coinbase:    header.Coinbase,
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        new(big.Int).SetUint64(header.Time),
+		Difficulty:  new(big.Int).Set(header.Difficulty),
+		GasLimit:    header.GasLimit,
+		GasPrice:    new(big.Int),
+	}
+	evm := vm.NewEVM(context, statedb, statedb, bc.chainConfig, bc.vmConfig)
+	access, err := contracts.AccountAccess(evm, from)
+	if err != nil {
+		return err
+	}
+	if err := types.CheckAccountAccess(access, tx.To()); err != nil {
+		return err
+	}
+	return contracts.CheckFunctionAccess(evm, from, tx)
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/consensus/ethash"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+)
+
+const permissionTestABI = `[
+{"constant":true,"inputs":[],"name":"getNetworkBootStatus","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+{"constant":true,"inputs":[{"name":"_account","type":"address"}],"name":"getAccountDetails","outputs":[{"name":"","type":"address"},{"name":"","type":"string"},{"name":"","type":"string"},{"name":"","type":"uint256"},{"name":"","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"}
+]`
+
+// cannedCode returns the code of a contract answering ret to any call
+func cannedCode(ret []byte) []byte {
+	size := []byte{byte(len(ret) >> 8), byte(len(ret))}
+	code := []byte{
+		byte(vm.PUSH2), size[0], size[1],
+		byte(vm.PUSH1), 14,
+		byte(vm.PUSH1), 0,
+		byte(vm.CODECOPY),
+		byte(vm.PUSH2), size[0], size[1],
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	}
+	return append(code, ret...)
+}
+
+func TestCheckTransactionPermission(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	config := *params.TestChainConfig
+	config.QIP714Block = big.NewInt(2)
+	config.PermissionContracts = &params.PermissionContractsConfig{
+		InterfAddress:  common.HexToAddress("0x0a01"),
+		OrgAddress:     common.HexToAddress("0x0a02"),
+		RoleAddress:    common.HexToAddress("0x0a03"),
+		AccountAddress: common.HexToAddress("0x0a04"),
+	}
+	db := rawdb.NewMemoryDatabase()
+	(&Genesis{Config: &config}).MustCommit(db)
+	bc, err := NewBlockChain(db, nil, &config, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bc.Stop()
+
+	parsed, err := abi.JSON(strings.NewReader(permissionTestABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bootStatus := func(booted bool) []byte {
+		ret, _ := parsed.Methods["getNetworkBootStatus"].Outputs.Pack(booted)
+		return cannedCode(ret)
+	}
+	// the account manager doesn't know the sender
+	unknown, err := parsed.Methods["getAccountDetails"].Outputs.Pack(from, "NONE", "", new(big.Int), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		number int64
+		interf []byte
+		want   error
+	}{
+		{name: "before the QIP714 block", number: 1, interf: bootStatus(true), want: nil},
+		{name: "interface not deployed", number: 2, want: nil},
+		{name: "network not booted", number: 2, interf: bootStatus(false), want: nil},
+		{name: "network booted", number: 2, interf: bootStatus(true), want: types.ErrReadOnlyAccount},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statedb, _, err := bc.State()
+			if err != nil {
+				t.Fatal(err)
+			}
+			statedb.SetCode(config.PermissionContracts.InterfAddress, test.interf)
+			statedb.SetCode(config.PermissionContracts.AccountAddress, cannedCode(unknown))
+
+			header := &types.Header{Number: big.NewInt(test.number), Difficulty: big.NewInt(1), GasLimit: 10000000}
+			signer := types.MakeSigner(&config, header.Number)
+			tx, err := types.SignTx(types.NewTransaction(0, common.HexToAddress("0x0b"), new(big.Int), 21000, new(big.Int), nil), signer, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bc.CheckTransactionPermission(header, statedb, tx); err != test.want {
+				t.Errorf("error mismatch: have %v, want %v", err, test.want)
+			}
+		})
+	}
+}
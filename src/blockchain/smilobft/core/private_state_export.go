@@ -0,0 +1,188 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+)
+
+// privateStateEntry is the export format of the private state of a single
+// block. Nodes holds the trie nodes and contract code reachable from Root which
+// were not already part of an earlier entry of the same export.
+type privateStateEntry struct {
+	Number   uint64
+	Hash     common.Hash
+	Root     common.Hash
+	Nodes    [][]byte
+	Receipts []*types.ReceiptForStorage
+}
+
+// ExportPrivateState writes the private state tries and the receipts of the
+// canonical blocks first to last to the given writer as a stream of RLP
+// encoded entries, one per block. Trie nodes shared between blocks are only
+// written once.
+func (bc *BlockChain) ExportPrivateState(w io.Writer, first, last uint64) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if first > last {
+		return fmt.Errorf("export failed: first (%d) is greater than last (%d)", first, last)
+	}
+	log.Info("Exporting private state", "count", last-first+1)
+
+	var (
+		triedb   = bc.privateStateCache.TrieDB()
+		exported = make(map[common.Hash]struct{})
+
+		start, reported = time.Now(), time.Now()
+	)
+	for nr := first; nr <= last; nr++ {
+		block := bc.GetBlockByNumber(nr)
+		if block == nil {
+			return fmt.Errorf("export failed on #%d: not found", nr)
+		}
+		entry := &privateStateEntry{
+			Number: nr,
+			Hash:   block.Hash(),
+			Root:   GetPrivateStateRoot(bc.db, block.Root()),
+		}
+		privateState, err := state.New(entry.Root, bc.privateStateCache)
+		if err != nil {
+			return fmt.Errorf("export failed on #%d: %v", nr, err)
+		}
+		it := state.NewNodeIterator(privateState)
+		for it.Next() {
+			if it.Hash == (common.Hash{}) {
+				continue
+			}
+			if _, ok := exported[it.Hash]; ok {
+				continue
+			}
+			blob, err := triedb.Node(it.Hash)
+			if err != nil {
+				return fmt.Errorf("export failed on #%d: %v", nr, err)
+			}
+			entry.Nodes = append(entry.Nodes, blob)
+			exported[it.Hash] = struct{}{}
+		}
+		if it.Error != nil {
+			return fmt.Errorf("export failed on #%d: %v", nr, it.Error)
+		}
+		for _, receipt := range rawdb.ReadRawReceipts(bc.db, entry.Hash, nr) {
+			entry.Receipts = append(entry.Receipts, (*types.ReceiptForStorage)(receipt))
+		}
+		if err := rlp.Encode(w, entry); err != nil {
+			return err
+		}
+		if time.Since(reported) >= statsReportLimit {
+			log.Info("Exporting private state", "exported", nr-first, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	return nil
+}
+
+// ImportPrivateState reads a private state export produced by
+// ExportPrivateState and writes the trie nodes, private state roots, receipts
+// and private blooms to the database. Every exported block must already be
+// part of the local canonical chain, whose receipts are kept for the public
+// transactions, only the receipts of the private transactions are replaced.
+// It returns the number of imported blocks.
+func (bc *BlockChain) ImportPrivateState(r io.Reader) (int, error) {
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	var (
+		stream  = rlp.NewStream(r, 0)
+		batch   = bc.db.NewBatch()
+		stateDb = state.NewDatabase(bc.db)
+		count   int
+	)
+	for {
+		var entry privateStateEntry
+		if err := stream.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return count, fmt.Errorf("import failed after %d blocks: %v", count, err)
+		}
+		block := bc.GetBlockByNumber(entry.Number)
+		if block == nil || block.Hash() != entry.Hash {
+			return count, fmt.Errorf("import failed on #%d [%x…]: block not in the canonical chain", entry.Number, entry.Hash[:4])
+		}
+		if len(entry.Receipts) != len(block.Transactions()) {
+			return count, fmt.Errorf("import failed on #%d: %d receipts for %d transactions", entry.Number, len(entry.Receipts), len(block.Transactions()))
+		}
+		for _, blob := range entry.Nodes {
+			if err := batch.Put(crypto.Keccak256(blob), blob); err != nil {
+				return count, err
+			}
+		}
+		// The nodes must be on disk before the root can be checked
+		if err := batch.Write(); err != nil {
+			return count, err
+		}
+		batch.Reset()
+
+		if err := checkPrivateState(stateDb, entry.Root); err != nil {
+			return count, fmt.Errorf("import failed on #%d: incomplete private state: %v", entry.Number, err)
+		}
+		receipts := rawdb.ReadRawReceipts(bc.db, entry.Hash, entry.Number)
+		if len(receipts) != len(block.Transactions()) {
+			return count, fmt.Errorf("import failed on #%d: %d local receipts for %d transactions", entry.Number, len(receipts), len(block.Transactions()))
+		}
+		for i, tx := range block.Transactions() {
+			if tx.IsPrivate() {
+				receipts[i] = (*types.Receipt)(entry.Receipts[i])
+			}
+		}
+		if err := WritePrivateStateRoot(bc.db, block.Root(), entry.Root); err != nil {
+			return count, err
+		}
+		if err := writeCanonicalPrivateBloom(bc.db, block, receipts); err != nil {
+			return count, err
+		}
+		rawdb.WriteReceipts(bc.db, entry.Hash, entry.Number, receipts)
+		count++
+	}
+	bc.receiptsCache.Purge()
+	return count, nil
+}
+
+// checkPrivateState walks the whole private state at root, failing if any trie
+// node or contract code is missing from the database
+func checkPrivateState(db state.Database, root common.Hash) error {
+	privateState, err := state.New(root, db)
+	if err != nil {
+		return err
+	}
+	it := state.NewNodeIterator(privateState)
+	for it.Next() {
+	}
+	return it.Error
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/consensus/ethash"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/ethdb"
+	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
+)
+
+func TestExportImportPrivateState(t *testing.T) {
+	db, chain, err := newCanonical(ethash.NewFaker(), 5, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Attach a private state holding a contract to a block in the middle
+	var (
+		addr = common.HexToAddress("0x1234")
+		key  = common.HexToHash("0x01")
+		val  = common.HexToHash("0x02")
+		code = []byte{0x60, 0x00}
+	)
+	privateState, _ := state.New(common.Hash{}, chain.privateStateCache)
+	privateState.SetBalance(addr, big.NewInt(42), big.NewInt(3))
+	privateState.SetCode(addr, code)
+	privateState.SetState(addr, key, val)
+	root, err := privateState.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit private state: %v", err)
+	}
+	if err := chain.privateStateCache.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to flush private state: %v", err)
+	}
+	block := chain.GetBlockByNumber(3)
+	if err := WritePrivateStateRoot(db, block.Root(), root); err != nil {
+		t.Fatalf("failed to write private state root: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := chain.ExportPrivateState(&buf, 1, 5); err != nil {
+		t.Fatalf("failed to export private state: %v", err)
+	}
+	export := buf.Bytes()
+
+	// Import into an identical chain lacking the private state
+	db2, chain2, err := newCanonical(ethash.NewFaker(), 5, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer chain2.Stop()
+
+	count, err := chain2.ImportPrivateState(bytes.NewReader(export))
+	if err != nil {
+		t.Fatalf("failed to import private state: %v", err)
+	}
+	if count != 5 {
+		t.Fatalf("imported block count mismatch: have %d, want 5", count)
+	}
+	if have := GetPrivateStateRoot(db2, block.Root()); have != root {
+		t.Fatalf("private state root mismatch: have %x, want %x", have, root)
+	}
+	imported, err := state.New(root, state.NewDatabase(db2))
+	if err != nil {
+		t.Fatalf("failed to open imported private state: %v", err)
+	}
+	if balance := imported.GetBalance(addr); balance.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("balance mismatch: have %v, want 42", balance)
+	}
+	if have := imported.GetCode(addr); !bytes.Equal(have, code) {
+		t.Errorf("code mismatch: have %x, want %x", have, code)
+	}
+	if have := imported.GetState(addr, key); have != val {
+		t.Errorf("storage mismatch: have %x, want %x", have, val)
+	}
+
+	// An export missing the contract code must be rejected before the private
+	// state root is written
+	var (
+		stream = rlp.NewStream(bytes.NewReader(export), 0)
+		broken bytes.Buffer
+	)
+	for {
+		var entry privateStateEntry
+		if err := stream.Decode(&entry); err != nil {
+			break
+		}
+		for i, blob := range entry.Nodes {
+			if bytes.Equal(blob, code) {
+				entry.Nodes = append(entry.Nodes[:i], entry.Nodes[i+1:]...)
+				break
+			}
+		}
+		rlp.Encode(&broken, &entry)
+	}
+	db3, chain3, err := newCanonical(ethash.NewFaker(), 5, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer chain3.Stop()
+
+	if count, err := chain3.ImportPrivateState(&broken); err == nil || count != 2 {
+		t.Fatalf("import of an incomplete private state: have count %d, err %v", count, err)
+	}
+	if have := GetPrivateStateRoot(db3, block.Root()); have == root {
+		t.Fatalf("private state root of an incomplete state written: %x", have)
+	}
+
+	// A chain which doesn't contain the exported blocks must be rejected
+	_, short, err := newCanonical(ethash.NewFaker(), 2, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer short.Stop()
+
+	if count, err := short.ImportPrivateState(bytes.NewReader(export)); err == nil || count != 2 {
+		t.Fatalf("import into a short chain: have count %d, err %v", count, err)
+	}
+}
+
+func TestImportPrivateStateReceipts(t *testing.T) {
+	// The node doesn't take part in the private transaction
+	defer func(vault private.BlackboxVault) { private.VaultInstance = vault }(private.VaultInstance)
+	private.VaultInstance = &StubPrivateTransactionManager{
+		responses: map[string][]interface{}{"Get": {nil, private.ErrPayloadNotFound}},
+	}
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{address: {Balance: big.NewInt(1000000000)}},
+		}
+		signer = types.NewEIP155Signer(gspec.Config.ChainID)
+		gendb  = rawdb.NewMemoryDatabase()
+	)
+	blocks, _ := GenerateChain(gspec.Config, gspec.MustCommit(gendb), ethash.NewFaker(), gendb, 1, func(i int, block *BlockGen) {
+		public, _ := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1000), params.TxGas, nil, nil), signer, key)
+		block.AddTx(public)
+		vault, _ := types.SignTx(types.NewTransaction(1, common.Address{0x02}, big.NewInt(0), 100000, nil, make([]byte, 64)), types.HomesteadSigner{}, key)
+		vault.SetPrivate()
+		block.AddTx(vault)
+	})
+	newChain := func() (ethdb.Database, *BlockChain) {
+		db := rawdb.NewMemoryDatabase()
+		gspec.MustCommit(db)
+		chain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil)
+		if n, err := chain.InsertChain(blocks); err != nil {
+			t.Fatalf("failed to insert block %d: %v", n, err)
+		}
+		return db, chain
+	}
+	block := blocks[0]
+
+	// The exporting node is a party, its receipt of the private transaction
+	// has the logs of the contract. Its public receipt is tampered with, the
+	// importing node must keep its own.
+	db, chain := newChain()
+	defer chain.Stop()
+	party := rawdb.ReadRawReceipts(db, block.Hash(), 1)
+	party[0].CumulativeGasUsed++
+	party[1].Logs = []*types.Log{{Address: common.Address{0x02}, Data: []byte{0xca, 0xfe}}}
+	rawdb.WriteReceipts(db, block.Hash(), 1, party)
+
+	var buf bytes.Buffer
+	if err := chain.ExportPrivateState(&buf, 1, 1); err != nil {
+		t.Fatalf("failed to export private state: %v", err)
+	}
+	db2, chain2 := newChain()
+	defer chain2.Stop()
+	local := rawdb.ReadRawReceipts(db2, block.Hash(), 1)
+
+	if _, err := chain2.ImportPrivateState(&buf); err != nil {
+		t.Fatalf("failed to import private state: %v", err)
+	}
+	imported := rawdb.ReadRawReceipts(db2, block.Hash(), 1)
+	if imported[0].CumulativeGasUsed != local[0].CumulativeGasUsed {
+		t.Errorf("public receipt replaced: have gas %d, want %d", imported[0].CumulativeGasUsed, local[0].CumulativeGasUsed)
+	}
+	if len(imported[1].Logs) != 1 || !bytes.Equal(imported[1].Logs[0].Data, []byte{0xca, 0xfe}) {
+		t.Errorf("private receipt not imported: have logs %v", imported[1].Logs)
+	}
+}
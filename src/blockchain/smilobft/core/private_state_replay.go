@@ -0,0 +1,139 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/private"
+)
+
+// PrivateStateMismatch is a block whose stored private state root differs
+// from the one obtained by replaying its transactions.
+type PrivateStateMismatch struct {
+	Number   uint64      `json:"number"`
+	Hash     common.Hash `json:"hash"`
+	Stored   common.Hash `json:"stored"`
+	Computed common.Hash `json:"computed"`
+}
+
+// ReplayPrivateState re-executes the canonical blocks first to last, fetching
+// every private payload from the vault again, and compares the resulting
+// private state roots with the stored ones. The replay starts from the stored
+// private state of the parent of first and carries the recomputed state from
+// block to block, so a single corrupted block is reported along with every
+// block built on top of it.
+//
+// The public state of every replayed block's parent must be available, which
+// limits pruned nodes to the most recent blocks. If repair is set, the
+// recomputed private states are written to the database and their roots
+// replace the stored ones.
+//
+// The vault must be up: a payload it fails to return would be replayed as if
+// the node was not a party, so any vault error aborts the replay.
+func (bc *BlockChain) ReplayPrivateState(first, last uint64, repair bool) ([]PrivateStateMismatch, error) {
+	if first == 0 {
+		return nil, fmt.Errorf("the genesis block cannot be replayed")
+	}
+	if first > last {
+		return nil, fmt.Errorf("invalid block range %d-%d", first, last)
+	}
+	if err := bc.checkReplayVault(); err != nil {
+		return nil, err
+	}
+	parent := bc.GetBlockByNumber(first - 1)
+	if parent == nil {
+		return nil, fmt.Errorf("block #%d not found", first-1)
+	}
+	var (
+		publicDb  = state.NewDatabase(bc.db)
+		privateDb = state.NewDatabase(bc.db)
+
+		privateRoot = GetPrivateStateRoot(bc.db, parent.Root())
+		mismatches  []PrivateStateMismatch
+	)
+	for number := first; number <= last; number++ {
+		block := bc.GetBlockByNumber(number)
+		if block == nil {
+			return mismatches, fmt.Errorf("block #%d not found", number)
+		}
+		publicState, err := state.New(parent.Root(), publicDb)
+		if err != nil {
+			return mismatches, fmt.Errorf("missing public state of block #%d: %v", number-1, err)
+		}
+		privateState, err := state.New(privateRoot, privateDb)
+		if err != nil {
+			return mismatches, fmt.Errorf("missing private state of block #%d: %v", number-1, err)
+		}
+		if _, _, _, _, err := bc.processor.Process(block, publicState, privateState, bc.vmConfig); err != nil {
+			return mismatches, fmt.Errorf("failed to replay block #%d: %v", number, err)
+		}
+		computed, err := privateState.Commit(bc.chainConfig.IsEIP158(block.Number()))
+		if err != nil {
+			return mismatches, err
+		}
+		// Matching roots may still miss trie nodes on disk, so a repair
+		// flushes every replayed private state.
+		if repair {
+			if err := privateDb.TrieDB().Commit(computed, false); err != nil {
+				return mismatches, err
+			}
+		}
+		if stored := GetPrivateStateRoot(bc.db, block.Root()); stored != computed {
+			log.Warn("Private state root mismatch", "number", number, "hash", block.Hash(), "stored", stored, "computed", computed)
+			mismatches = append(mismatches, PrivateStateMismatch{
+				Number:   number,
+				Hash:     block.Hash(),
+				Stored:   stored,
+				Computed: computed,
+			})
+			if repair {
+				if err := WritePrivateStateRoot(bc.db, block.Root(), computed); err != nil {
+					return mismatches, err
+				}
+				log.Info("Repaired private state", "number", number, "hash", block.Hash(), "root", computed)
+			}
+		}
+		parent, privateRoot = block, computed
+	}
+	return mismatches, nil
+}
+
+// checkReplayVault refuses to replay private transactions unless the vault of
+// the node is configured, takes part in private transactions and is healthy.
+func (bc *BlockChain) checkReplayVault() error {
+	vault := bc.vmConfig.Vault
+	if vault == nil {
+		vault = private.VaultInstance
+	}
+	if vault == nil {
+		return fmt.Errorf("cannot replay private state: %v", private.ErrVaultUnavailable)
+	}
+	reporter, ok := vault.(private.HealthReporter)
+	if !ok {
+		return fmt.Errorf("cannot replay private state: vault backend %T does not report its health", vault)
+	}
+	if status := reporter.Health(); !status.Up || status.CircuitOpen {
+		return fmt.Errorf("cannot replay private state: vault is not healthy: %s", status.LastError)
+	}
+	return nil
+}
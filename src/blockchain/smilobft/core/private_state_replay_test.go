@@ -0,0 +1,90 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/consensus/ethash"
+	"go-smilo/src/blockchain/smilobft/private"
+)
+
+func TestReplayPrivateState(t *testing.T) {
+	db, chain, err := newCanonical(ethash.NewFaker(), 5, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer chain.Stop()
+	chain.vmConfig.Vault = private.NewMemoryVault()
+
+	mismatches, err := chain.ReplayPrivateState(1, 5, false)
+	if err != nil {
+		t.Fatalf("failed to verify private state: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Fatalf("untouched chain reported mismatches: %v", mismatches)
+	}
+
+	// Corrupt the private state root of a block in the middle of the chain
+	block := chain.GetBlockByNumber(3)
+	want := GetPrivateStateRoot(db, block.Root())
+	if err := WritePrivateStateRoot(db, block.Root(), common.HexToHash("0xdead")); err != nil {
+		t.Fatalf("failed to corrupt private state root: %v", err)
+	}
+	for _, repair := range []bool{false, true} {
+		mismatches, err = chain.ReplayPrivateState(1, 5, repair)
+		if err != nil {
+			t.Fatalf("repair %v: failed to replay private state: %v", repair, err)
+		}
+		if len(mismatches) != 1 || mismatches[0].Number != 3 || mismatches[0].Computed != want {
+			t.Fatalf("repair %v: mismatch report wrong: %+v", repair, mismatches)
+		}
+	}
+	if root := GetPrivateStateRoot(db, block.Root()); root != want {
+		t.Fatalf("private state root not repaired: have %x, want %x", root, want)
+	}
+	if mismatches, _ = chain.ReplayPrivateState(1, 5, false); len(mismatches) != 0 {
+		t.Fatalf("repaired chain reported mismatches: %v", mismatches)
+	}
+	if _, err := chain.ReplayPrivateState(0, 5, false); err == nil {
+		t.Fatal("expected an error replaying the genesis block")
+	}
+}
+
+func TestReplayPrivateStateUnhealthyVault(t *testing.T) {
+	_, chain, err := newCanonical(ethash.NewFaker(), 2, true)
+	if err != nil {
+		t.Fatalf("failed to create canonical chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// A vault ignoring private transactions would replay every block as if
+	// the node was not a party
+	ignore, err := private.NewVault(&private.VaultConfig{Backend: private.IPCBackend, Path: "ignore"})
+	if err != nil {
+		t.Fatalf("failed to create vault: %v", err)
+	}
+	chain.vmConfig.Vault = ignore
+	for _, repair := range []bool{false, true} {
+		if _, err := chain.ReplayPrivateState(1, 2, repair); err == nil {
+			t.Fatalf("repair %v: replayed with a vault ignoring private transactions", repair)
+		}
+	}
+}
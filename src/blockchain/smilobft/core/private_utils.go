@@ -34,11 +34,24 @@ func WritePrivateStateRoot(db ethdb.Database, blockRoot, root common.Hash) error
 
 // WritePrivateBlockBloom creates a bloom filter for the given receipts and saves it to the database
 // with the number given as identifier (i.e. block number).
-func WritePrivateBlockBloom(db ethdb.Database, number uint64, receipts types.Receipts) error {
+func WritePrivateBlockBloom(db ethdb.KeyValueWriter, number uint64, receipts types.Receipts) error {
 	rbloom := types.CreateBloom(receipts)
 	return db.Put(append(privateBloomPrefix, encodeBlockNumber(number)...), rbloom[:])
 }
 
+// writeCanonicalPrivateBloom stores the vault bloom of a canonical block. The
+// receipts are the merged receipts of the block, the ones belonging to private
+// transactions are the vault receipts.
+func writeCanonicalPrivateBloom(db ethdb.KeyValueWriter, block *types.Block, receipts types.Receipts) error {
+	var vaultReceipts types.Receipts
+	for i, tx := range block.Transactions() {
+		if tx.IsPrivate() && i < len(receipts) {
+			vaultReceipts = append(vaultReceipts, receipts[i])
+		}
+	}
+	return WritePrivateBlockBloom(db, block.NumberU64(), vaultReceipts)
+}
+
 // GetPrivateBlockBloom retrieves the vault bloom associated with the given number.
 func GetPrivateBlockBloom(db ethdb.Database, number uint64) (bloom types.Bloom) {
 	data, _ := db.Get(append(privateBloomPrefix, encodeBlockNumber(number)...))
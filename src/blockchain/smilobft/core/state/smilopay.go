@@ -20,6 +20,27 @@ import (
 	"math/big"
 )
 
+// Fixed-point SmiloPay parameters. All amounts are in wei and every operation
+// is an integer one, rounding down:
+//
+//	sqrtBalance = isqrt(floor(balance / 1e18) * 1e36)
+//	max         = 5e15 + sqrtBalance / 1e4
+//	speed       = 5e11 + sqrtBalance / 1.5e6
+//	smiloPay    = min(max, prevSmiloPay + (newBlock - prevBlock) * speed)
+//
+// sqrtBalance is the square root of the balance in whole smilos, scaled by
+// 1e18, so the values match the floating point formulas up to the rounding.
+var (
+	smiloPayUnit         = big.NewInt(1e18)
+	smiloPaySqrtScale    = new(big.Int).Mul(smiloPayUnit, smiloPayUnit)
+	smiloPayMaxBase      = big.NewInt(5e15)
+	smiloPayMaxDivisor   = big.NewInt(1e4)
+	smiloPaySpeedBase    = big.NewInt(5e11)
+	smiloPaySpeedDivisor = big.NewInt(15e5)
+)
+
+// CalculateSmiloPay returns the SmiloPay of an account at newBlock using big.Float
+// arithmetic. It is used until the SmiloPay fixed point fork.
 func CalculateSmiloPay(prevBlock, newBlock, prevSmiloPay, balance *big.Int) *big.Int {
 	//if balance.Cmp(big.NewInt(1e+16)) < 0 {
 	//	log.Debug("CalculateSmiloPay, Balance:", balance.Int64(), ", Min required: ", big.NewInt(1e+16).Int64(), ", returned: ",common.Big0)
@@ -55,6 +76,8 @@ func CalculateSmiloPay(prevBlock, newBlock, prevSmiloPay, balance *big.Int) *big
 	return floatToBigInt(smiloPayFloat, big.NewInt(1))
 }
 
+// MaxSmiloPay returns the maximum SmiloPay of a balance using big.Float arithmetic,
+// and the balance in whole smilos.
 func MaxSmiloPay(balance *big.Int) (maxSmiloPayReturn *big.Int, balanceSmilo *big.Float) {
 	balanceDecimals := new(big.Int).Div(balance, big.NewInt(1e+18))
 	balanceSmilo = new(big.Float).SetInt(balanceDecimals)
@@ -83,3 +106,52 @@ func floatToBigInt(bigval *big.Float, precision *big.Int) *big.Int {
 
 	return result
 }
+
+// CalculateSmiloPayFixedPoint returns the SmiloPay of an account at newBlock
+// using integer arithmetic only.
+func CalculateSmiloPayFixedPoint(prevBlock, newBlock, prevSmiloPay, balance *big.Int) *big.Int {
+	//if block did not change, return prevSmiloPay
+	if prevBlock.Cmp(newBlock) >= 0 {
+		return prevSmiloPay
+	}
+	sqrtBalance := smiloPaySqrtBalance(balance)
+	maxSmiloPay := maxSmiloPayFixedPoint(sqrtBalance)
+
+	blockGap := new(big.Int).Sub(newBlock, prevBlock)
+	smiloPay := blockGap.Mul(blockGap, smiloPaySpeedFixedPoint(sqrtBalance))
+	smiloPay.Add(smiloPay, prevSmiloPay)
+	if smiloPay.Cmp(maxSmiloPay) > 0 {
+		return maxSmiloPay
+	}
+	return smiloPay
+}
+
+// MaxSmiloPayFixedPoint returns the maximum SmiloPay of a balance using integer
+// arithmetic only.
+func MaxSmiloPayFixedPoint(balance *big.Int) *big.Int {
+	return maxSmiloPayFixedPoint(smiloPaySqrtBalance(balance))
+}
+
+// SmiloPaySpeedFixedPoint returns the SmiloPay regenerated per block for a
+// balance using integer arithmetic only.
+func SmiloPaySpeedFixedPoint(balance *big.Int) *big.Int {
+	return smiloPaySpeedFixedPoint(smiloPaySqrtBalance(balance))
+}
+
+func smiloPaySqrtBalance(balance *big.Int) *big.Int {
+	if balance.Sign() <= 0 {
+		return new(big.Int)
+	}
+	smilos := new(big.Int).Div(balance, smiloPayUnit)
+	return smilos.Sqrt(smilos.Mul(smilos, smiloPaySqrtScale))
+}
+
+func maxSmiloPayFixedPoint(sqrtBalance *big.Int) *big.Int {
+	max := new(big.Int).Div(sqrtBalance, smiloPayMaxDivisor)
+	return max.Add(max, smiloPayMaxBase)
+}
+
+func smiloPaySpeedFixedPoint(sqrtBalance *big.Int) *big.Int {
+	speed := new(big.Int).Div(sqrtBalance, smiloPaySpeedDivisor)
+	return speed.Add(speed, smiloPaySpeedBase)
+}
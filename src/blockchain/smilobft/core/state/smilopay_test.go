@@ -17,11 +17,16 @@
 package state
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"math/big"
 	"testing"
 
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+
 	"github.com/orinocopay/go-etherutils"
 	"github.com/stretchr/testify/require"
 )
@@ -342,3 +347,53 @@ func TestSmiloPayCalculations(t *testing.T) {
 		})
 	}
 }
+
+func TestSmiloPayFixedPointVectors(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/smilopay_fixed_point.json")
+	require.NoError(t, err)
+	var vectors []struct {
+		Balance      *big.Int `json:"balance"`
+		PrevBlock    int64    `json:"prevBlock"`
+		NewBlock     int64    `json:"newBlock"`
+		PrevSmiloPay *big.Int `json:"prevSmiloPay"`
+		Max          *big.Int `json:"max"`
+		Speed        *big.Int `json:"speed"`
+		SmiloPay     *big.Int `json:"smiloPay"`
+	}
+	require.NoError(t, json.Unmarshal(data, &vectors))
+	require.NotEmpty(t, vectors)
+
+	for i, v := range vectors {
+		require.Equal(t, v.Max, MaxSmiloPayFixedPoint(v.Balance), "vector %d: max", i)
+		require.Equal(t, v.Speed, SmiloPaySpeedFixedPoint(v.Balance), "vector %d: speed", i)
+		smiloPay := CalculateSmiloPayFixedPoint(big.NewInt(v.PrevBlock), big.NewInt(v.NewBlock), v.PrevSmiloPay, v.Balance)
+		require.Equal(t, v.SmiloPay, smiloPay, "vector %d: smiloPay", i)
+	}
+}
+
+func TestSmiloPayFixedPointMatchesFloat(t *testing.T) {
+	// The float formulas round the last digit either way
+	for i := 0; i < 10; i++ {
+		balance, _ := etherutils.StringToWei(fmt.Sprintf("%d ether", i))
+		maxSmiloPay, _ := MaxSmiloPay(balance)
+		diff := new(big.Int).Sub(maxSmiloPay, MaxSmiloPayFixedPoint(balance))
+		require.True(t, diff.CmpAbs(common.Big1) <= 0, "balance %d: float %v, fixed point %v", i, maxSmiloPay, MaxSmiloPayFixedPoint(balance))
+	}
+	balance, _ := etherutils.StringToWei("110 ether")
+	smiloPay := CalculateSmiloPayFixedPoint(big.NewInt(100), big.NewInt(110), big.NewInt(0), balance)
+	require.Equal(t, big.NewInt(74920589878010), smiloPay)
+}
+
+func TestSmiloPayFixedPointFork(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+	addr := common.BytesToAddress([]byte("smilopay"))
+	balance, _ := etherutils.StringToWei("3 ether")
+	state.SetBalance(addr, balance, big.NewInt(0))
+
+	state.SetSmiloPayFixedPointBlock(big.NewInt(1000))
+	require.Equal(t, CalculateSmiloPay(big.NewInt(0), big.NewInt(999), big.NewInt(0), balance), state.GetSmiloPay(addr, big.NewInt(999)))
+	require.Equal(t, CalculateSmiloPayFixedPoint(big.NewInt(0), big.NewInt(1000), big.NewInt(0), balance), state.GetSmiloPay(addr, big.NewInt(1000)))
+
+	// Copies keep calculating with the same rules
+	require.Equal(t, state.GetSmiloPay(addr, big.NewInt(1000)), state.Copy().GetSmiloPay(addr, big.NewInt(1000)))
+}
@@ -390,7 +390,7 @@ func (s *stateObject) SetSmiloPay(amount *big.Int) {
 func (s *stateObject) UpdateSmiloPay(blockNumber *big.Int) {
 	prevsmiloPay := s.data.SmiloPay
 	prevblock := s.data.BlockNumber
-	smiloPay := CalculateSmiloPay(prevblock, blockNumber, prevsmiloPay, s.data.Balance)
+	smiloPay := s.db.calculateSmiloPay(prevblock, blockNumber, prevsmiloPay, s.data.Balance)
 	s.db.journal.append(blockChange{
 		account:      &s.address,
 		prevSmiloPay: prevsmiloPay,
@@ -96,6 +96,9 @@ type StateDB struct {
 	validRevisions []revision
 	nextRevisionId int
 
+	// Block from which SmiloPay is calculated with integer arithmetic
+	smiloPayFixedPointBlock *big.Int
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads   time.Duration
 	AccountHashes  time.Duration
@@ -231,11 +234,26 @@ func (self *StateDB) GetBalance(addr common.Address) *big.Int {
 	return common.Big0
 }
 
+// SetSmiloPayFixedPointBlock sets the block from which SmiloPay is calculated
+// with integer arithmetic, nil keeps the big.Float calculation.
+func (self *StateDB) SetSmiloPayFixedPointBlock(block *big.Int) {
+	self.smiloPayFixedPointBlock = block
+}
+
+// calculateSmiloPay calculates the SmiloPay at newBlock with the rules active
+// at newBlock.
+func (self *StateDB) calculateSmiloPay(prevBlock, newBlock, prevSmiloPay, balance *big.Int) *big.Int {
+	if self.smiloPayFixedPointBlock != nil && newBlock != nil && self.smiloPayFixedPointBlock.Cmp(newBlock) <= 0 {
+		return CalculateSmiloPayFixedPoint(prevBlock, newBlock, prevSmiloPay, balance)
+	}
+	return CalculateSmiloPay(prevBlock, newBlock, prevSmiloPay, balance)
+}
+
 func (self *StateDB) GetSmiloPay(addr common.Address, blockNumber *big.Int) *big.Int {
 	stateObject := self.getStateObject(addr)
 	ret := common.Big0
 	if stateObject != nil {
-		ret = CalculateSmiloPay(stateObject.BlockNumber(), blockNumber, stateObject.SmiloPay(), stateObject.Balance())
+		ret = self.calculateSmiloPay(stateObject.BlockNumber(), blockNumber, stateObject.SmiloPay(), stateObject.Balance())
 	}
 	//fmt.Println("Available SmiloPay: ", ret.Int64())
 	return ret
@@ -621,6 +639,8 @@ func (self *StateDB) Copy() *StateDB {
 		logSize:           self.logSize,
 		preimages:         make(map[common.Hash][]byte, len(self.preimages)),
 		journal:           newJournal(),
+
+		smiloPayFixedPointBlock: self.smiloPayFixedPointBlock,
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range self.journal.dirties {
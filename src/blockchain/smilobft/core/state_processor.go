@@ -77,13 +77,16 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 
 		vaultReceipts types.Receipts
 	)
+	statedb.SetSmiloPayFixedPointBlock(p.config.SmiloPayFixedPointBlock)
+	privateState.SetSmiloPayFixedPointBlock(p.config.SmiloPayFixedPointBlock)
+
 	// Mutate the the block and state according to any hard-fork specs
 	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
 		misc.ApplyDAOHardFork(statedb, block.Number())
 	}
 
 	var contractMinGasPrice = new(big.Int)
-	if (p.bc.Config().Istanbul != nil || p.bc.Config().SportDAO != nil || p.bc.Config().Tendermint != nil) && p.autonityContract != nil {
+	if p.bc.Config().IsAutonity(block.Number()) && p.autonityContract != nil {
 		minGasPrice, err := p.autonityContract.GetMinimumGasPrice(block, statedb, privateState)
 		if err == nil {
 			contractMinGasPrice.SetUint64(minGasPrice)
@@ -97,7 +100,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 	for i, tx := range block.Transactions() {
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
 
-		if (p.bc.Config().Istanbul != nil || p.bc.Config().SportDAO != nil || p.bc.Config().Tendermint != nil) && p.autonityContract != nil {
+		if p.bc.Config().IsAutonity(block.Number()) && p.autonityContract != nil {
 			if contractMinGasPrice.Uint64() != 0 {
 				if tx.GasPrice().Cmp(contractMinGasPrice) == -1 {
 					return nil, nil, nil, 0, errors.New("autonityContract, gas price must be greater minGasPrice")
@@ -109,6 +112,10 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 			//panic(msg)
 		}
 
+		if err := p.bc.CheckTransactionPermission(header, statedb, tx); err != nil {
+			return nil, nil, nil, 0, err
+		}
+
 		privateState.Prepare(tx.Hash(), block.Hash(), i)
 
 		receipt, vaultReceipt, _, err := ApplyTransaction(p.config, p.bc, nil, gp, statedb, privateState, header, tx, usedGas, cfg)
@@ -125,7 +132,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 			allLogs = append(allLogs, vaultReceipt.Logs...)
 		}
 	}
-	if (p.bc.chainConfig.Istanbul != nil || p.bc.chainConfig.SportDAO != nil || p.bc.chainConfig.Tendermint != nil) && p.autonityContract != nil {
+	if p.bc.chainConfig.IsAutonity(block.Number()) && p.autonityContract != nil {
 		err := p.autonityContract.ApplyPerformRedistribution(block.Transactions(), receipts, block.Header(), statedb)
 		if err != nil {
 			log.Error("Could not ApplyPerformRedistribution on smart contract, ", "err", err)
@@ -151,6 +158,8 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	if !config.IsSmilo || !tx.IsPrivate() {
 		privateState = statedb
 	}
+	statedb.SetSmiloPayFixedPointBlock(config.SmiloPayFixedPointBlock)
+	privateState.SetSmiloPayFixedPointBlock(config.SmiloPayFixedPointBlock)
 
 	if !config.IsGas && tx.GasPrice() != nil && tx.GasPrice().Cmp(common.Big0) > 0 {
 		return nil, nil, 0, ErrInvalidGasPrice
@@ -171,6 +180,11 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	if err != nil {
 		return nil, nil, 0, err
 	}
+	// Record the private state hash of protected transactions for the
+	// parties to compare
+	if vmenv.TxPrivacyMetadata().Protected() && !failed {
+		vm.WritePrivateStateHash(privateState, tx.Hash(), vmenv.PrivateStateHash())
+	}
 	// Update the state with pending changes
 	var root []byte
 	if config.IsByzantium(header.Number) {
@@ -218,13 +218,51 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	isPrivate := false
 	publicState := st.state
 	if msg, ok := msg.(VaultMessage); ok && isSmilo && msg.IsPrivate() {
-		if private.VaultInstance == nil {
-			log.Error("&*&*&*&*& state_transition TransitionDb, Got Vault message but Vault is offline. Please report to SystemAdmin. ", "st.data", cmn.Bytes2Hex(st.data), "contractCreation", contractCreation, "isPrivate", isPrivate, "len(ret)", len(ret), "st.gasUsed", st.gasUsed(), "st.gasPrice", st.gasPrice, "sender.Address", sender.Address())
+		vault := st.evm.Vault()
+		if vault == nil {
+			// Nodes started without PRIVATE_CONFIG take no part in private
+			// transactions and skip them like any non party. A configured
+			// vault that failed to connect is not nil, its lookups fail with
+			// ErrVaultUnavailable below.
+			log.Warn("Got a private transaction but no vault is configured, skipping it", "st.data", cmn.Bytes2Hex(st.data), "contractCreation", contractCreation, "sender.Address", sender.Address())
 			publicState.SetNonce(sender.Address(), publicState.GetNonce(sender.Address())+1)
 			return nil, 0, false, nil
 		} else {
 			isPrivate = true
-			data, err = private.VaultInstance.Get(st.data)
+			data, err = vault.Get(st.data)
+			switch err {
+			case nil:
+				var meta *private.PrivacyMetadata
+				if meta, data, err = private.UnwrapPayload(data); err != nil {
+					// Every party reads the same payload, so all of them
+					// skip the transaction alike.
+					log.Warn("Skipping private transaction with invalid privacy metadata", "sender", sender.Address(), "err", err)
+				} else if meta.Protected() {
+					err = private.ResolveParticipants(vault, st.data, meta)
+					switch err {
+					case nil:
+					case private.ErrParticipantsUnverifiable:
+						// Party protection can't be enforced without knowing
+						// who the parties are, the transaction is rejected.
+						log.Warn("Skipping party protection transaction, the vault can't verify its participants", "sender", sender.Address())
+					default:
+						log.Error("Private payload parties lookup failed, refusing to process private transaction", "tx data", cmn.Bytes2Hex(st.data), "sender", sender.Address(), "err", err)
+						return nil, 0, false, err
+					}
+				}
+				if err == nil {
+					st.evm.SetTxPrivacyMetadata(meta)
+				}
+			case private.ErrPayloadNotFound, private.ErrVaultNotStarted:
+				log.Debug("Not a party to private transaction, skipping", "sender", sender.Address(), "err", err)
+			default:
+				// Unreachable, unauthorized or corrupt vaults cannot tell
+				// whether we are a party. Treating the payload as empty would
+				// silently diverge the private state of this node, so the
+				// transaction, and the block holding it, fails instead.
+				log.Error("Private payload lookup failed, refusing to process private transaction", "tx data", cmn.Bytes2Hex(st.data), "sender", sender.Address(), "err", err)
+				return nil, 0, false, err
+			}
 			// Increment the public account nonce if:
 			// 1. Tx is vault and *not* a participant of the group and either call or create
 			// 2. Tx is vault we are part of the group and is a call
@@ -299,7 +337,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		log.Debug("############### state_transition, VM returned with NO error after executing evm, ", "contractCreation", contractCreation, "isPrivate", isPrivate, "gasNotUsed", gasNotUsed, "len(ret)", len(ret), "st.gasUsed()", st.gasUsed(), "st.gasPrice", st.gasPrice)
 	}
 
-	if st.evm.ChainConfig().AutonityContractConfig != nil && (st.evm.ChainConfig().Istanbul != nil || st.evm.ChainConfig().SportDAO != nil || st.evm.ChainConfig().Tendermint != nil) {
+	if st.evm.ChainConfig().AutonityContractConfig != nil && st.evm.ChainConfig().IsAutonity(st.evm.BlockNumber) {
 
 		st.refundGas()
 		addr, innerErr := st.evm.ChainConfig().AutonityContractConfig.GetContractAddress()
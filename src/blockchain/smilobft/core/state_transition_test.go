@@ -19,6 +19,7 @@ package core
 import (
 	"fmt"
 	"go-smilo/src/blockchain/smilobft/private"
+	"go-smilo/src/blockchain/smilobft/private/privatetransactionmanager"
 	"math/big"
 	"testing"
 
@@ -34,12 +35,6 @@ import (
 )
 
 func verifyGasPoolCalculation(t *testing.T, pm private.BlackboxVault) {
-	saved := private.VaultInstance
-	defer func() {
-		private.VaultInstance = saved
-	}()
-	private.VaultInstance = pm
-
 	txGasLimit := uint64(100000)
 	gasPool := new(GasPool).AddGas(200000)
 	// this payload would give us 25288 intrinsic gas
@@ -60,7 +55,7 @@ func verifyGasPoolCalculation(t *testing.T, pm private.BlackboxVault) {
 		},
 	}
 	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
-	evm := vm.NewEVM(ctx, publicState, privateState, params.SmiloTestChainConfig, vm.Config{})
+	evm := vm.NewEVM(ctx, publicState, privateState, params.SmiloTestChainConfig, vm.Config{Vault: pm})
 	arbitraryBalance := big.NewInt(100000000)
 	publicState.SetBalance(evm.Coinbase, arbitraryBalance, big.NewInt(1))
 	publicState.SetBalance(msg.From(), arbitraryBalance, big.NewInt(1))
@@ -112,6 +107,75 @@ func TestStateTransitionPrivate(t *testing.T) {
 	}
 }
 
+func TestStateTransitionPrivateVaultErrors(t *testing.T) {
+	for _, x := range []struct {
+		err      error
+		nonParty bool
+	}{
+		{err: private.ErrPayloadNotFound, nonParty: true},
+		{err: private.ErrVaultNotStarted, nonParty: true},
+		{err: private.ErrUnauthorized},
+		{err: private.ErrCorruptPayload},
+		{err: private.ErrVaultUnavailable},
+	} {
+		t.Run(x.err.Error(), func(t *testing.T) {
+			vault := &StubPrivateTransactionManager{
+				responses: map[string][]interface{}{
+					"Get": {nil, x.err},
+				},
+			}
+			nonce, err := applyPrivateCall(vault)
+			if x.nonParty {
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), nonce, "non party must increment the public nonce")
+			} else {
+				require.Equal(t, x.err, err, "vault failure must fail the transaction")
+				require.Equal(t, uint64(0), nonce, "failed transaction must not touch the nonce")
+			}
+		})
+	}
+}
+
+func TestStateTransitionWithoutVault(t *testing.T) {
+	defer func(vault private.BlackboxVault) { private.VaultInstance = vault }(private.VaultInstance)
+
+	// nodes started without PRIVATE_CONFIG skip private transactions
+	private.VaultInstance = nil
+	nonce, err := applyPrivateCall(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce, "node without a vault must increment the public nonce")
+
+	// a configured vault that failed to connect can't tell whether we are a party
+	private.VaultInstance = (*privatetransactionmanager.BlackboxVault)(nil)
+	nonce, err = applyPrivateCall(nil)
+	require.Equal(t, private.ErrVaultUnavailable, err, "unconnected vault must fail the transaction")
+	require.Equal(t, uint64(0), nonce, "failed transaction must not touch the nonce")
+}
+
+// applyPrivateCall applies a private call with the given vault and returns the
+// public nonce of the sender after it.
+func applyPrivateCall(vault private.BlackboxVault) (uint64, error) {
+	db := rawdb.NewMemoryDatabase()
+	privateState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	publicState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	msg := privateCallMsg{
+		callmsg: callmsg{
+			addr:     common.Address{2},
+			to:       &common.Address{},
+			value:    new(big.Int),
+			gas:      100000,
+			gasPrice: big.NewInt(0),
+			data:     common.Hex2Bytes("4ab80888354582b92ab442a317828386"),
+		},
+	}
+	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	evm := vm.NewEVM(ctx, publicState, privateState, params.SmiloTestChainConfig, vm.Config{Vault: vault})
+	publicState.SetBalance(msg.From(), big.NewInt(100000000), big.NewInt(1))
+
+	_, _, _, err := NewStateTransition(evm, msg, new(GasPool).AddGas(200000)).TransitionDb()
+	return publicState.GetNonce(msg.From()), err
+}
+
 type privateCallMsg struct {
 	callmsg
 }
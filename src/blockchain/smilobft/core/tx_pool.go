@@ -242,6 +242,8 @@ type TxPool struct {
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	permissions types.AccountPermissions // Account permissions of the node, nil until the permission service sets them
+
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
@@ -444,6 +446,23 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 	log.Info("Transaction pool price threshold updated", "price", price)
 }
 
+// SetPermissions sets the account permissions the transactions are checked
+// against once the QIP714 block is reached.
+func (pool *TxPool) SetPermissions(permissions types.AccountPermissions) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.permissions = permissions
+}
+
+// Permissions returns the account permissions of the pool, nil if none are set.
+func (pool *TxPool) Permissions() types.AccountPermissions {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.permissions
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *TxPool) Nonce(addr common.Address) uint64 {
@@ -538,6 +557,8 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	IsPrivate := tx.IsPrivate()
 	gasPrice := tx.GasPrice()
 	gas := tx.Gas()
+	// Autonity contract rules apply from the block the transaction goes into
+	isAutonity := pool.chainconfig.IsAutonity(new(big.Int).Add(pool.chain.CurrentBlock().Number(), common.Big1))
 
 	customSizeLimit := pool.chainconfig.CustomTransactionSizeLimit
 	if customSizeLimit == 0 {
@@ -568,7 +589,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if err != nil {
 		return ErrInvalidSender
 	}
-	if (pool.chain.Config().Istanbul != nil || pool.chain.Config().SportDAO != nil || pool.chain.Config().Tendermint != nil) && pool.chain.GetAutonityContract() != nil {
+	if isAutonity && pool.chain.GetAutonityContract() != nil {
 
 		//if blacklistlist, err := pool.chain.GetAutonityContract().GetBlacklist(pool.chain.CurrentBlock(), pool.currentState, pool.currentState); err == nil {
 		//
@@ -579,6 +600,15 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 			return ErrInvalidSender
 		}
 	}
+	// Permission rules apply from the block the transaction goes into
+	if pool.permissions != nil && pool.chainconfig.IsQIP714(new(big.Int).Add(pool.chain.CurrentBlock().Number(), common.Big1)) {
+		if err := types.CheckAccountAccess(pool.permissions.GetAcctAccess(from), tx.To()); err != nil {
+			return err
+		}
+		if err := pool.permissions.CheckFunctionAccess(from, tx); err != nil {
+			return err
+		}
+	}
 	// Drop non-local transactions (when isGas=true and tx IsPrivate=false) under our own minimal accepted gas price
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
 	if !isGas || !local && pool.gasPrice.Cmp(tx.GasPrice()) > 0 && !IsPrivate {
@@ -598,7 +628,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		log.Error("ErrInsufficientFunds", "from", from.String(), "TX COST", tx.Cost(), "TX-Hash", tx.Hash().Hex(), "balance", pool.currentState.GetBalance(from), "tx.Value()", tx.Value())
 		return ErrInsufficientFunds
 	}
-	if (pool.chain.Config().Istanbul != nil || pool.chain.Config().SportDAO != nil || pool.chain.Config().Tendermint != nil) && pool.chain.GetAutonityContract() != nil {
+	if isAutonity && pool.chain.GetAutonityContract() != nil {
 		// Ensure the transaction has more gas than the basic tx fee.
 		intrGas, err := IntrinsicGas(tx.Data(), tx.To() == nil, true)
 		if err != nil {
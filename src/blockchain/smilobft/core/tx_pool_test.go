@@ -432,6 +432,58 @@ func TestTransactionNegativeValue(t *testing.T) {
 	}
 }
 
+func TestTransactionAccountPermissions(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	blockchain := &testBlockChain{statedb, statedb, 1000000, new(event.Feed)}
+	config := *params.TestChainConfig
+	config.QIP714Block = big.NewInt(0)
+	pool := NewTxPool(testTxPoolConfig, &config, blockchain)
+	defer pool.Stop()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(from, big.NewInt(0xffffffffffffff), big.NewInt(1))
+
+	// without permissions every account has full access
+	if err := pool.AddRemote(transaction(0, 100000, key)); err != nil {
+		t.Error("expected", nil, "; got", err)
+	}
+	pool.removeTx(transaction(0, 100000, key).Hash(), true)
+
+	// accounts unknown to the permission contracts are read only
+	permissions := types.NewPermissionCache()
+	permissions.SetDefaults("NWADMIN", "OADMIN")
+	permissions.SetDefaultAccess()
+	pool.SetPermissions(permissions)
+	if err := pool.AddRemote(transaction(0, 100000, key)); err != types.ErrReadOnlyAccount {
+		t.Error("expected", types.ErrReadOnlyAccount, "; got", err)
+	}
+
+	// accounts with the transact access can't create contracts
+	permissions.OrgInfoMap.UpsertOrg("ORG1", "", "ORG1", big.NewInt(1), types.OrgApproved)
+	permissions.RoleInfoMap.UpsertRole("ORG1", "ROLE1", false, false, types.Transact, true)
+	permissions.AcctInfoMap.UpsertAccount("ORG1", "ROLE1", from, false, types.AcctActive)
+
+	create, _ := types.SignTx(types.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+	if err := pool.AddRemote(create); err != types.ErrContractCreationNotAllowed {
+		t.Error("expected", types.ErrContractCreationNotAllowed, "; got", err)
+	}
+	if err := pool.AddRemote(transaction(0, 100000, key)); err != nil {
+		t.Error("expected", nil, "; got", err)
+	}
+
+	// a role with function rules may only call the contracts of its rules
+	token := common.BytesToAddress([]byte("token"))
+	permissions.RuleInfoMap.UpsertRule(types.FunctionRule{OrgId: "ORG1", RoleId: "ROLE1", Contract: token, Selector: types.AnySelector, Active: true})
+	if err := pool.AddRemote(transaction(1, 100000, key)); err != types.ErrFunctionNotAllowed {
+		t.Error("expected", types.ErrFunctionNotAllowed, "; got", err)
+	}
+	permissions.RuleInfoMap.UpsertRule(types.FunctionRule{OrgId: "ORG1", RoleId: "ROLE1", Contract: common.Address{}, Selector: types.AnySelector, Active: true})
+	if err := pool.AddRemote(transaction(1, 100000, key)); err != nil {
+		t.Error("expected", nil, "; got", err)
+	}
+}
+
 func TestTransactionChainFork(t *testing.T) {
 	//t.Parallel()
 
@@ -55,15 +55,60 @@ type BFTExtra struct {
 	Validators    []common.Address
 	Seal          []byte
 	CommittedSeal [][]byte
+	Evidence      []*BFTEvidence
+	// Extensions are the extensions of the extra-data of other kinds, they are
+	// kept so that the extra-data is encoded back as it was.
+	Extensions []*ExtraExtension
 }
 
-// EncodeRLP serializes pos into the Ethereum RLP format.
+// Kinds of the extensions of the extra-data
+const (
+	ExtraEvidence            uint64 = iota + 1 // equivocation evidence, a list of BFTEvidence
+	ExtraParentCommittedSeal                   // committed seals of the parent block, a list of seals
+)
+
+// ExtraExtension is an optional field of the extra-data of the BFT and Sport
+// headers, appended after the committed seals. Its kind tells the extensions
+// of the consensus engines apart, so that the headers of a consensus
+// transition decode with both extra-data formats.
+type ExtraExtension struct {
+	Kind uint64
+	Data []byte
+}
+
+// BFTEvidence is a pair of conflicting consensus messages signed by the same
+// validator. The messages are kept in their signed wire encoding, the consensus
+// engine that produced them is responsible for verifying them.
+type BFTEvidence struct {
+	First  []byte
+	Second []byte
+}
+
+// Hash returns the keccak256 hash of the RLP encoding of the evidence.
+func (ev *BFTEvidence) Hash() common.Hash {
+	return rlpHash(ev)
+}
+
+// EncodeRLP serializes pos into the Ethereum RLP format. The evidence and the
+// other extensions are appended after the committed seals so that headers
+// without them keep their original encoding.
 func (pos *BFTExtra) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	fields := []interface{}{
 		pos.Validators,
 		pos.Seal,
 		pos.CommittedSeal,
-	})
+	}
+	if len(pos.Evidence) > 0 {
+		data, err := rlp.EncodeToBytes(pos.Evidence)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, &ExtraExtension{Kind: ExtraEvidence, Data: data})
+	}
+	for _, ext := range pos.Extensions {
+		fields = append(fields, ext)
+	}
+	return rlp.Encode(w, fields)
 }
 
 // DecodeRLP implements rlp.Decoder, and load the pos fields from a RLP stream.
@@ -72,11 +117,23 @@ func (pos *BFTExtra) DecodeRLP(s *rlp.Stream) error {
 		Validators    []common.Address
 		Seal          []byte
 		CommittedSeal [][]byte
+		Extensions    []*ExtraExtension `rlp:"tail"`
 	}
 	if err := s.Decode(&bftExtra); err != nil {
 		return err
 	}
 	pos.Validators, pos.Seal, pos.CommittedSeal = bftExtra.Validators, bftExtra.Seal, bftExtra.CommittedSeal
+	for _, ext := range bftExtra.Extensions {
+		if ext.Kind != ExtraEvidence {
+			pos.Extensions = append(pos.Extensions, ext)
+			continue
+		}
+		var evidence []*BFTEvidence
+		if err := rlp.DecodeBytes(ext.Data, &evidence); err != nil {
+			return err
+		}
+		pos.Evidence = append(pos.Evidence, evidence...)
+	}
 	return nil
 }
 
@@ -138,7 +195,11 @@ func SigHash(header *Header) (hash common.Hash) {
 	hasher := sha3.NewLegacyKeccak256()
 
 	// Clean seal is required for calculating proposer seal.
-	err := rlp.Encode(hasher, BFTFilteredHeader(header, false))
+	filtered := BFTFilteredHeader(header, false)
+	if header.MixDigest == SportDigest {
+		filtered = SportFilteredHeader(header, false)
+	}
+	err := rlp.Encode(hasher, filtered)
 	if err != nil {
 		log.Error("can't hash the header", "err", err, "header", header)
 		return common.Hash{}
@@ -155,12 +216,12 @@ func Ecrecover(header *Header) (common.Address, error) {
 	}
 
 	// Retrieve the signature from the header extra-data
-	bftExtra, err := ExtractBFTHeaderExtra(header)
+	seal, err := headerSeal(header)
 	if err != nil {
 		return common.Address{}, err
 	}
 
-	addr, err := GetSignatureAddress(SigHash(header).Bytes(), bftExtra.Seal)
+	addr, err := GetSignatureAddress(SigHash(header).Bytes(), seal)
 	if err != nil {
 		return addr, err
 	}
@@ -168,6 +229,22 @@ func Ecrecover(header *Header) (common.Address, error) {
 	return addr, nil
 }
 
+// headerSeal returns the proposer seal of a BFT or Sport header
+func headerSeal(header *Header) ([]byte, error) {
+	if header.MixDigest == SportDigest {
+		sportExtra, err := ExtractSportExtra(header)
+		if err != nil {
+			return nil, err
+		}
+		return sportExtra.Seal, nil
+	}
+	bftExtra, err := ExtractBFTHeaderExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	return bftExtra.Seal, nil
+}
+
 // PrepareExtra returns a extra-data of the given header and validators
 func PrepareExtra(extraData []byte, vals []common.Address) ([]byte, error) {
 	extraDataCopy := append([]byte{}, extraData...)
@@ -224,6 +301,24 @@ func WriteSeal(h *Header, seal []byte) error {
 	return nil
 }
 
+// WriteEvidence writes the extra-data field of the given header with the given
+// equivocation evidence, replacing any evidence already present.
+func WriteEvidence(h *Header, evidence []*BFTEvidence) error {
+	bftExtra, err := ExtractBFTHeaderExtra(h)
+	if err != nil {
+		return err
+	}
+
+	bftExtra.Evidence = evidence
+	payload, err := rlp.EncodeToBytes(&bftExtra)
+	if err != nil {
+		return err
+	}
+
+	h.Extra = append(h.Extra[:BFTExtraVanity], payload...)
+	return nil
+}
+
 // WriteCommittedSeals writes the extra-data field of a block header with given committed seals.
 func WriteCommittedSeals(h *Header, committedSeals [][]byte) error {
 	if len(committedSeals) == 0 {
@@ -86,3 +86,31 @@ func TestExtractToBFT(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteEvidence(t *testing.T) {
+	extra, err := PrepareExtra(nil, []common.Address{common.HexToAddress("0x44add0ec310f115a0e603b2d7db9f067778eaf8a")})
+	if err != nil {
+		t.Fatalf("failed to prepare extra: %v", err)
+	}
+	header := &Header{Extra: extra}
+	evidence := []*BFTEvidence{{First: []byte{0x01}, Second: []byte{0x02}}}
+	if err := WriteEvidence(header, evidence); err != nil {
+		t.Fatalf("failed to write evidence: %v", err)
+	}
+
+	bftExtra, err := ExtractBFTHeaderExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract extra: %v", err)
+	}
+	if !reflect.DeepEqual(bftExtra.Evidence, evidence) {
+		t.Errorf("evidence mismatch: have %v, want %v", bftExtra.Evidence, evidence)
+	}
+
+	// Removing the evidence must restore the original encoding.
+	if err := WriteEvidence(header, nil); err != nil {
+		t.Fatalf("failed to clear evidence: %v", err)
+	}
+	if !bytes.Equal(header.Extra, extra) {
+		t.Errorf("extra mismatch: have %x, want %x", header.Extra, extra)
+	}
+}
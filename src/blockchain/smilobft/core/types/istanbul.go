@@ -37,15 +37,35 @@ type SportExtra struct {
 	Fullnodes     []common.Address
 	Seal          []byte
 	CommittedSeal [][]byte
+	// ParentCommittedSeal are the committed seals of the parent block. Unlike
+	// the committed seals they are covered by the block hash.
+	ParentCommittedSeal [][]byte
+	// Extensions are the extensions of the extra-data of other kinds, written
+	// by the BFT engines before a consensus transition. They are kept so that
+	// the extra-data is encoded back as it was.
+	Extensions []*ExtraExtension
 }
 
-// EncodeRLP serializes ist into the Ethereum RLP format.
+// EncodeRLP serializes ist into the Ethereum RLP format. The parent committed
+// seals and the other extensions are appended after the committed seals so
+// that headers without them keep their original encoding.
 func (ist *SportExtra) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	fields := []interface{}{
 		ist.Fullnodes,
 		ist.Seal,
 		ist.CommittedSeal,
-	})
+	}
+	if len(ist.ParentCommittedSeal) > 0 {
+		data, err := rlp.EncodeToBytes(ist.ParentCommittedSeal)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, &ExtraExtension{Kind: ExtraParentCommittedSeal, Data: data})
+	}
+	for _, ext := range ist.Extensions {
+		fields = append(fields, ext)
+	}
+	return rlp.Encode(w, fields)
 }
 
 // DecodeRLP implements rlp.Decoder, and load the sport fields from a RLP stream.
@@ -54,11 +74,23 @@ func (ist *SportExtra) DecodeRLP(s *rlp.Stream) error {
 		Fullnodes     []common.Address
 		Seal          []byte
 		CommittedSeal [][]byte
+		Extensions    []*ExtraExtension `rlp:"tail"`
 	}
 	if err := s.Decode(&sportExtra); err != nil {
 		return err
 	}
 	ist.Fullnodes, ist.Seal, ist.CommittedSeal = sportExtra.Fullnodes, sportExtra.Seal, sportExtra.CommittedSeal
+	for _, ext := range sportExtra.Extensions {
+		if ext.Kind != ExtraParentCommittedSeal {
+			ist.Extensions = append(ist.Extensions, ext)
+			continue
+		}
+		var seals [][]byte
+		if err := rlp.DecodeBytes(ext.Data, &seals); err != nil {
+			return err
+		}
+		ist.ParentCommittedSeal = append(ist.ParentCommittedSeal, seals...)
+	}
 	return nil
 }
 
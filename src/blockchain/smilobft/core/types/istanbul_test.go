@@ -98,3 +98,119 @@ func TestExtractToSport(t *testing.T) {
 		}
 	}
 }
+
+// TestExtraTransition checks that the headers of a consensus transition decode with the
+// extra-data formats of both the BFT and the Sport engines, and keep their encoding and
+// hash through the filtering of either engine.
+func TestExtraTransition(t *testing.T) {
+	validators := []common.Address{
+		common.HexToAddress("0x44add0ec310f115a0e603b2d7db9f067778eaf8a"),
+		common.HexToAddress("0x294fc7e8f22b3bcdcf955dd7ff3ba2ed833f8212"),
+	}
+	extra, err := types.PrepareExtra(nil, validators)
+	if err != nil {
+		t.Fatalf("failed to prepare extra: %v", err)
+	}
+	header := &types.Header{Extra: extra}
+	evidence := []*types.BFTEvidence{{First: []byte{0x01}, Second: []byte{0x02}}}
+	if err := types.WriteEvidence(header, evidence); err != nil {
+		t.Fatalf("failed to write evidence: %v", err)
+	}
+
+	// a BFT header read by the Sport engine
+	sportExtra, err := types.ExtractSportExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract sport extra: %v", err)
+	}
+	if !reflect.DeepEqual(sportExtra.Fullnodes, validators) {
+		t.Errorf("fullnodes mismatch: have %v, want %v", sportExtra.Fullnodes, validators)
+	}
+	if len(sportExtra.Extensions) != 1 || sportExtra.Extensions[0].Kind != types.ExtraEvidence {
+		t.Errorf("extensions mismatch: have %v", sportExtra.Extensions)
+	}
+	payload, err := rlp.EncodeToBytes(sportExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(payload, header.Extra[types.SportExtraVanity:]) {
+		t.Errorf("sport encoding mismatch: have %x, want %x", payload, header.Extra[types.SportExtraVanity:])
+	}
+	if filtered := types.SportFilteredHeader(header, true); filtered == nil || !bytes.Equal(filtered.Extra, header.Extra) {
+		t.Errorf("sport filtered header mismatch: have %v", filtered)
+	}
+
+	// the evidence is still read by the BFT engine after a Sport rewrite
+	header.Extra = append(header.Extra[:types.SportExtraVanity:types.SportExtraVanity], payload...)
+	bftExtra, err := types.ExtractBFTHeaderExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract bft extra: %v", err)
+	}
+	if !reflect.DeepEqual(bftExtra.Evidence, evidence) || len(bftExtra.Extensions) != 0 {
+		t.Errorf("evidence mismatch: have %v %v, want %v", bftExtra.Evidence, bftExtra.Extensions, evidence)
+	}
+
+	// a Sport header read by the BFT engine, the extensions of other kinds are kept
+	sportExtra.Extensions = append(sportExtra.Extensions, &types.ExtraExtension{Kind: 0xff, Data: []byte{0x03}})
+	payload, err = rlp.EncodeToBytes(sportExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Extra = append(header.Extra[:types.SportExtraVanity:types.SportExtraVanity], payload...)
+	bftExtra, err = types.ExtractBFTHeaderExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract bft extra: %v", err)
+	}
+	if !reflect.DeepEqual(bftExtra.Validators, validators) || !reflect.DeepEqual(bftExtra.Evidence, evidence) {
+		t.Errorf("bft extra mismatch: have %v", bftExtra)
+	}
+	if len(bftExtra.Extensions) != 1 || bftExtra.Extensions[0].Kind != 0xff {
+		t.Errorf("extensions mismatch: have %v", bftExtra.Extensions)
+	}
+	if filtered := types.BFTFilteredHeader(header, true); filtered == nil || !bytes.Equal(filtered.Extra, header.Extra) {
+		t.Errorf("bft filtered header mismatch: have %v", filtered)
+	}
+}
+
+func TestSportExtraParentCommittedSeal(t *testing.T) {
+	seals := [][]byte{bytes.Repeat([]byte{0x01}, types.SportExtraSeal), bytes.Repeat([]byte{0x02}, types.SportExtraSeal)}
+	sportExtra := &types.SportExtra{
+		Fullnodes:           []common.Address{common.HexToAddress("0x44add0ec310f115a0e603b2d7db9f067778eaf8a")},
+		Seal:                []byte{},
+		CommittedSeal:       [][]byte{bytes.Repeat([]byte{0x03}, types.SportExtraSeal)},
+		ParentCommittedSeal: seals,
+	}
+	payload, err := rlp.EncodeToBytes(sportExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := &types.Header{Extra: append(make([]byte, types.SportExtraVanity), payload...)}
+
+	decoded, err := types.ExtractSportExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract sport extra: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.ParentCommittedSeal, seals) || len(decoded.Extensions) != 0 {
+		t.Errorf("parent committed seals mismatch: have %v %v, want %v", decoded.ParentCommittedSeal, decoded.Extensions, seals)
+	}
+
+	// unlike the committed seals, they are covered by the block hash
+	filtered := types.SportFilteredHeader(header, false)
+	if decoded, err = types.ExtractSportExtra(filtered); err != nil {
+		t.Fatalf("failed to extract sport extra: %v", err)
+	}
+	if len(decoded.CommittedSeal) != 0 || !reflect.DeepEqual(decoded.ParentCommittedSeal, seals) {
+		t.Errorf("filtered extra mismatch: have %v", decoded)
+	}
+
+	// the BFT engines keep them across a transition
+	bftExtra, err := types.ExtractBFTHeaderExtra(header)
+	if err != nil {
+		t.Fatalf("failed to extract bft extra: %v", err)
+	}
+	if len(bftExtra.Extensions) != 1 || bftExtra.Extensions[0].Kind != types.ExtraParentCommittedSeal {
+		t.Errorf("extensions mismatch: have %v", bftExtra.Extensions)
+	}
+	if filtered := types.BFTFilteredHeader(header, true); filtered == nil || filtered.Hash() != types.SportFilteredHeader(header, true).Hash() {
+		t.Errorf("bft filtered header mismatch: have %v", filtered)
+	}
+}
@@ -1,17 +1,37 @@
 package types
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 	lru "github.com/hashicorp/golang-lru"
 )
 
 type AccessType uint8
 
+var (
+	// ErrReadOnlyAccount is returned if a read only account sends a transaction.
+	ErrReadOnlyAccount = errors.New("read only account, cannot transact")
+
+	// ErrContractCreationNotAllowed is returned if an account without the
+	// contract deploy access creates a contract.
+	ErrContractCreationNotAllowed = errors.New("account does not have contract create permissions")
+
+	// ErrFunctionNotAllowed is returned if an account calls a contract
+	// function the function rules of its role don't allow.
+	ErrFunctionNotAllowed = errors.New("account role not allowed to call the contract function")
+
+	// ErrNodeNotAllowed is returned if an account sends a transaction through
+	// a node that isn't one of the nodes of its organization.
+	ErrNodeNotAllowed = errors.New("account cannot transact through this node")
+)
+
 const (
 	ReadOnly AccessType = iota
 	Transact
@@ -84,6 +104,33 @@ type AccountInfo struct {
 	Status     AcctStatus     `json:"status"`
 }
 
+// FunctionSelector is the 4-byte selector of a contract function, the zero
+// selector stands for every function of the contract
+type FunctionSelector [4]byte
+
+// AnySelector is the selector of the rules allowing every function of a contract
+var AnySelector FunctionSelector
+
+// MarshalText encodes s as a hex string with 0x prefix.
+func (s FunctionSelector) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(s[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FunctionSelector) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("FunctionSelector", input, s[:])
+}
+
+// FunctionRule lets the accounts of a role call a function of a contract. A
+// role with active rules may only call the functions its rules allow.
+type FunctionRule struct {
+	OrgId    string           `json:"orgId"`
+	RoleId   string           `json:"roleId"`
+	Contract common.Address   `json:"contract"`
+	Selector FunctionSelector `json:"selector"`
+	Active   bool             `json:"active"`
+}
+
 type OrgDetailInfo struct {
 	NodeList   []NodeInfo    `json:"nodeList"`
 	RoleList   []RoleInfo    `json:"roleList"`
@@ -101,6 +148,7 @@ type PermissionConfig struct {
 	RoleAddress    common.Address `json:"roleMgrAddress"`
 	VoterAddress   common.Address `json:"voterMgrAddress"`
 	OrgAddress     common.Address `json:"orgMgrAddress"`
+	RuleAddress    common.Address `json:"ruleMgrAddress"` // optional, no function rules are enforced without it
 	NwAdminOrg     string         `json:"nwAdminOrg"`
 	NwAdminRole    string         `json:"nwAdminRole"`
 	OrgAdminRole   string         `json:"orgAdminRole"`
@@ -128,87 +176,189 @@ type AccountKey struct {
 	AcctId common.Address
 }
 
+const defaultOrgMapLimit = 2000
+const defaultRoleMapLimit = 2500
+const defaultNodeMapLimit = 1000
+const defaultAccountMapLimit = 6000
+const defaultRuleMapLimit = 2500
+
+// AccountPermissions answers the permission checks of the transaction pool and
+// the RPC for the accounts sending transactions
+type AccountPermissions interface {
+	// GetAcctAccess returns the access of an account
+	GetAcctAccess(acctId common.Address) AccessType
+
+	// ValidateNodeForTxn tells whether an account may send transactions
+	// through the given node
+	ValidateNodeForTxn(hexnodeId string, from common.Address) bool
+
+	// CheckFunctionAccess returns an error if the function rules of the role
+	// of an account don't allow the contract call of tx
+	CheckFunctionAccess(from common.Address, tx *Transaction) error
+}
+
+// PermissionCache holds the orgs, nodes, roles and accounts of the permission
+// contracts as seen by a node. It is filled by the permission service of the
+// node and handed to its transaction pool and p2p server. The caches are
+// bounded, once one of them has evicted an entry the entries it misses are
+// read again with the function set by its PopulateCacheFunc, and its lists
+// with the function set by its PopulateListFunc.
+type PermissionCache struct {
+	OrgInfoMap  *OrgCache
+	NodeInfoMap *NodeCache
+	RoleInfoMap *RoleCache
+	AcctInfoMap *AcctCache
+	RuleInfoMap *RuleCache
+
+	mux              sync.RWMutex
+	syncStarted      bool
+	defaultAccess    AccessType
+	qip714Reached    bool
+	networkAdminRole string
+	orgAdminRole     string
+}
+
+// NewPermissionCache creates empty permission caches of the default sizes
+func NewPermissionCache() *PermissionCache {
+	return &PermissionCache{
+		OrgInfoMap:    NewOrgCache(defaultOrgMapLimit),
+		NodeInfoMap:   NewNodeCache(defaultNodeMapLimit),
+		RoleInfoMap:   NewRoleCache(defaultRoleMapLimit),
+		AcctInfoMap:   NewAcctCache(defaultAccountMapLimit),
+		RuleInfoMap:   NewRuleCache(defaultRuleMapLimit),
+		defaultAccess: FullAccess,
+	}
+}
+
 type OrgCache struct {
-	c   *lru.Cache
-	mux sync.Mutex
+	c                 *lru.Cache
+	mux               sync.Mutex
+	evicted           bool
+	populateCacheFunc func(orgId string) (*OrgInfo, error)
+	populateListFunc  func() ([]OrgInfo, error)
 }
 
 type NodeCache struct {
-	c *lru.Cache
+	c                 *lru.Cache
+	mux               sync.Mutex
+	evicted           bool
+	populateCacheFunc func(url string) (*NodeInfo, error)
+	populateListFunc  func() ([]NodeInfo, error)
 }
 
 type RoleCache struct {
-	c *lru.Cache
+	c                 *lru.Cache
+	mux               sync.Mutex
+	evicted           bool
+	populateCacheFunc func(orgId, roleId string) (*RoleInfo, error)
+	populateListFunc  func() ([]RoleInfo, error)
 }
 
 type AcctCache struct {
-	c *lru.Cache
+	c                 *lru.Cache
+	mux               sync.Mutex
+	evicted           bool
+	populateCacheFunc func(acct common.Address) (*AccountInfo, error)
+	populateListFunc  func() ([]AccountInfo, error)
 }
 
-func NewOrgCache() *OrgCache {
-	c, _ := lru.New(defaultOrgMapLimit)
-	return &OrgCache{c, sync.Mutex{}}
+// RuleCache holds the function rules of the roles, active or not
+type RuleCache struct {
+	c                 *lru.Cache
+	mux               sync.Mutex
+	evicted           bool
+	populateCacheFunc func(orgId, roleId string) ([]FunctionRule, error)
+	populateListFunc  func() ([]FunctionRule, error)
 }
 
-func NewNodeCache() *NodeCache {
-	c, _ := lru.New(defaultNodeMapLimit)
-	return &NodeCache{c}
+func NewOrgCache(cacheSize int) *OrgCache {
+	o := &OrgCache{}
+	o.c, _ = lru.NewWithEvict(cacheSize, func(key, value interface{}) { o.evicted = true })
+	return o
 }
 
-func NewRoleCache() *RoleCache {
-	c, _ := lru.New(defaultRoleMapLimit)
-	return &RoleCache{c}
+func NewNodeCache(cacheSize int) *NodeCache {
+	n := &NodeCache{}
+	n.c, _ = lru.NewWithEvict(cacheSize, func(key, value interface{}) { n.evicted = true })
+	return n
 }
 
-func NewAcctCache() *AcctCache {
-	c, _ := lru.New(defaultAccountMapLimit)
-	return &AcctCache{c}
+func NewRoleCache(cacheSize int) *RoleCache {
+	r := &RoleCache{}
+	r.c, _ = lru.NewWithEvict(cacheSize, func(key, value interface{}) { r.evicted = true })
+	return r
 }
 
-var syncStarted = false
-
-var DefaultAccess = FullAccess
-var QIP714BlockReached = false
-var networkAdminRole string
-var orgAdminRole string
-
-const defaultOrgMapLimit = 2000
-const defaultRoleMapLimit = 2500
-const defaultNodeMapLimit = 1000
-const defaultAccountMapLimit = 6000
+func NewAcctCache(cacheSize int) *AcctCache {
+	a := &AcctCache{}
+	a.c, _ = lru.NewWithEvict(cacheSize, func(key, value interface{}) { a.evicted = true })
+	return a
+}
 
-var OrgInfoMap = NewOrgCache()
-var NodeInfoMap = NewNodeCache()
-var RoleInfoMap = NewRoleCache()
-var AcctInfoMap = NewAcctCache()
+func NewRuleCache(cacheSize int) *RuleCache {
+	r := &RuleCache{}
+	r.c, _ = lru.NewWithEvict(cacheSize, func(key, value interface{}) { r.evicted = true })
+	return r
+}
 
 func (pc *PermissionConfig) IsEmpty() bool {
 	return pc.InterfAddress == common.HexToAddress("0x0")
 }
 
-func SetSyncStatus() {
-	syncStarted = true
+func (pc *PermissionCache) SetSyncStatus() {
+	pc.mux.Lock()
+	defer pc.mux.Unlock()
+	pc.syncStarted = true
 }
 
-func GetSyncStatus() bool {
-	return syncStarted
+func (pc *PermissionCache) GetSyncStatus() bool {
+	pc.mux.RLock()
+	defer pc.mux.RUnlock()
+	return pc.syncStarted
 }
 
 // sets the default access to Readonly upon QIP714Blokc
-func SetDefaultAccess() {
-	DefaultAccess = ReadOnly
-	QIP714BlockReached = true
+func (pc *PermissionCache) SetDefaultAccess() {
+	pc.mux.Lock()
+	defer pc.mux.Unlock()
+	pc.defaultAccess = ReadOnly
+	pc.qip714Reached = true
 }
 
 // sets default access to readonly and initializes the values for
 // network admin role and org admin role
-func SetDefaults(nwRoleId, oaRoleId string) {
-	networkAdminRole = nwRoleId
-	orgAdminRole = oaRoleId
+func (pc *PermissionCache) SetDefaults(nwRoleId, oaRoleId string) {
+	pc.mux.Lock()
+	defer pc.mux.Unlock()
+	pc.networkAdminRole = nwRoleId
+	pc.orgAdminRole = oaRoleId
+}
+
+func (pc *PermissionCache) GetDefaults() (string, string, AccessType) {
+	pc.mux.RLock()
+	defer pc.mux.RUnlock()
+	return pc.networkAdminRole, pc.orgAdminRole, pc.defaultAccess
 }
 
-func GetDefaults() (string, string, AccessType) {
-	return networkAdminRole, orgAdminRole, DefaultAccess
+func (pc *PermissionCache) qip714BlockReached() bool {
+	pc.mux.RLock()
+	defer pc.mux.RUnlock()
+	return pc.qip714Reached
+}
+
+// PopulateCacheFunc sets the function reading an org missing from the cache
+func (o *OrgCache) PopulateCacheFunc(cf func(orgId string) (*OrgInfo, error)) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	o.populateCacheFunc = cf
+}
+
+// PopulateListFunc sets the function reading all the orgs once the cache
+// misses some of them
+func (o *OrgCache) PopulateListFunc(cf func() ([]OrgInfo, error)) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	o.populateListFunc = cf
 }
 
 func (o *OrgCache) UpsertOrg(orgId, parentOrg, ultimateParent string, level *big.Int, status OrgStatus) {
@@ -242,6 +392,7 @@ func containsKey(s []string, e string) bool {
 	return false
 }
 
+// GetOrg returns the org with the given full id, nil if it doesn't exist
 func (o *OrgCache) GetOrg(orgId string) *OrgInfo {
 	defer o.mux.Unlock()
 	o.mux.Lock()
@@ -249,10 +400,46 @@ func (o *OrgCache) GetOrg(orgId string) *OrgInfo {
 	if ent, ok := o.c.Get(key); ok {
 		return ent.(*OrgInfo)
 	}
-	return nil
+	// as long as nothing was evicted the cache holds every org
+	if !o.evicted || o.populateCacheFunc == nil {
+		return nil
+	}
+	org, err := o.populateCacheFunc(orgId)
+	if err != nil {
+		log.Error("Failed to read the org from the permission contracts", "org", orgId, "err", err)
+		return nil
+	}
+	if org == nil {
+		return nil
+	}
+	// the sub orgs still cached are the only ones known without a scan of
+	// the contract
+	for _, k := range o.c.Keys() {
+		if v, ok := o.c.Peek(k); ok && v.(*OrgInfo).ParentOrgId == orgId {
+			org.SubOrgList = append(org.SubOrgList, v.(*OrgInfo).FullOrgId)
+		}
+	}
+	o.c.Add(key, org)
+	return org
 }
 
+// GetOrgList returns every org, read from the permission contracts once the
+// cache has evicted some of them
 func (o *OrgCache) GetOrgList() []OrgInfo {
+	defer o.mux.Unlock()
+	o.mux.Lock()
+	if o.evicted {
+		if o.populateListFunc == nil {
+			log.Error("The permission cache evicted some of the orgs, their list is unknown")
+			return nil
+		}
+		olist, err := o.populateListFunc()
+		if err != nil {
+			log.Error("Failed to read the orgs from the permission contracts", "err", err)
+			return nil
+		}
+		return olist
+	}
 	olist := make([]OrgInfo, len(o.c.Keys()))
 	for i, k := range o.c.Keys() {
 		v, _ := o.c.Get(k)
@@ -262,12 +449,32 @@ func (o *OrgCache) GetOrgList() []OrgInfo {
 	return olist
 }
 
+// PopulateCacheFunc sets the function reading a node missing from the cache
+func (n *NodeCache) PopulateCacheFunc(cf func(url string) (*NodeInfo, error)) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.populateCacheFunc = cf
+}
+
+// PopulateListFunc sets the function reading all the nodes once the cache
+// misses some of them
+func (n *NodeCache) PopulateListFunc(cf func() ([]NodeInfo, error)) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.populateListFunc = cf
+}
+
 func (n *NodeCache) UpsertNode(orgId string, url string, status NodeStatus) {
+	defer n.mux.Unlock()
+	n.mux.Lock()
 	key := NodeKey{OrgId: orgId, Url: url}
 	n.c.Add(key, &NodeInfo{orgId, url, status})
 }
 
+// GetNodeByUrl returns the node with the given enode url, nil if it doesn't exist
 func (n *NodeCache) GetNodeByUrl(url string) *NodeInfo {
+	defer n.mux.Unlock()
+	n.mux.Lock()
 	for _, k := range n.c.Keys() {
 		ent := k.(NodeKey)
 		if ent.Url == url {
@@ -275,10 +482,38 @@ func (n *NodeCache) GetNodeByUrl(url string) *NodeInfo {
 			return v.(*NodeInfo)
 		}
 	}
-	return nil
+	if !n.evicted || n.populateCacheFunc == nil {
+		return nil
+	}
+	node, err := n.populateCacheFunc(url)
+	if err != nil {
+		log.Error("Failed to read the node from the permission contracts", "url", url, "err", err)
+		return nil
+	}
+	if node == nil {
+		return nil
+	}
+	n.c.Add(NodeKey{OrgId: node.OrgId, Url: node.Url}, node)
+	return node
 }
 
+// GetNodeList returns every node, read from the permission contracts once the
+// cache has evicted some of them
 func (n *NodeCache) GetNodeList() []NodeInfo {
+	defer n.mux.Unlock()
+	n.mux.Lock()
+	if n.evicted {
+		if n.populateListFunc == nil {
+			log.Error("The permission cache evicted some of the nodes, their list is unknown")
+			return nil
+		}
+		nlist, err := n.populateListFunc()
+		if err != nil {
+			log.Error("Failed to read the nodes from the permission contracts", "err", err)
+			return nil
+		}
+		return nlist
+	}
 	olist := make([]NodeInfo, len(n.c.Keys()))
 	for i, k := range n.c.Keys() {
 		v, _ := n.c.Get(k)
@@ -288,19 +523,67 @@ func (n *NodeCache) GetNodeList() []NodeInfo {
 	return olist
 }
 
+// PopulateCacheFunc sets the function reading an account missing from the cache
+func (a *AcctCache) PopulateCacheFunc(cf func(acct common.Address) (*AccountInfo, error)) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.populateCacheFunc = cf
+}
+
+// PopulateListFunc sets the function reading all the accounts once the cache
+// misses some of them
+func (a *AcctCache) PopulateListFunc(cf func() ([]AccountInfo, error)) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.populateListFunc = cf
+}
+
 func (a *AcctCache) UpsertAccount(orgId string, role string, acct common.Address, orgAdmin bool, status AcctStatus) {
+	defer a.mux.Unlock()
+	a.mux.Lock()
 	key := AccountKey{acct}
 	a.c.Add(key, &AccountInfo{orgId, role, acct, orgAdmin, status})
 }
 
+// GetAccount returns the details of an account, nil if it doesn't exist
 func (a *AcctCache) GetAccount(acct common.Address) *AccountInfo {
+	defer a.mux.Unlock()
+	a.mux.Lock()
 	if v, ok := a.c.Get(AccountKey{acct}); ok {
 		return v.(*AccountInfo)
 	}
-	return nil
+	if !a.evicted || a.populateCacheFunc == nil {
+		return nil
+	}
+	ac, err := a.populateCacheFunc(acct)
+	if err != nil {
+		log.Error("Failed to read the account from the permission contracts", "account", acct, "err", err)
+		return nil
+	}
+	if ac == nil {
+		return nil
+	}
+	a.c.Add(AccountKey{acct}, ac)
+	return ac
 }
 
+// GetAcctList returns every account, read from the permission contracts once
+// the cache has evicted some of them
 func (a *AcctCache) GetAcctList() []AccountInfo {
+	defer a.mux.Unlock()
+	a.mux.Lock()
+	if a.evicted {
+		if a.populateListFunc == nil {
+			log.Error("The permission cache evicted some of the accounts, their list is unknown")
+			return nil
+		}
+		alist, err := a.populateListFunc()
+		if err != nil {
+			log.Error("Failed to read the accounts from the permission contracts", "err", err)
+			return nil
+		}
+		return alist
+	}
 	alist := make([]AccountInfo, len(a.c.Keys()))
 	for i, k := range a.c.Keys() {
 		v, _ := a.c.Get(k)
@@ -312,44 +595,93 @@ func (a *AcctCache) GetAcctList() []AccountInfo {
 
 func (a *AcctCache) GetAcctListOrg(orgId string) []AccountInfo {
 	var alist []AccountInfo
-	for _, k := range a.c.Keys() {
-		v, _ := a.c.Get(k)
-		vp := v.(*AccountInfo)
-		if vp.OrgId == orgId {
-			alist = append(alist, *vp)
+	for _, ac := range a.GetAcctList() {
+		if ac.OrgId == orgId {
+			alist = append(alist, ac)
 		}
 	}
 	return alist
 }
 
-func (a *AcctCache) GetAcctListRole(orgId, roleId string) []AccountInfo {
+// GetAcctListRole returns the accounts of the cache linked to a role of an org
+// or of its ultimate parent
+func (pc *PermissionCache) GetAcctListRole(orgId, roleId string) []AccountInfo {
 	var alist []AccountInfo
-	for _, k := range a.c.Keys() {
-		v, _ := a.c.Get(k)
-		vp := v.(*AccountInfo)
-
-		if vp.RoleId == roleId && (vp.OrgId == orgId || OrgInfoMap.GetOrg(vp.OrgId).UltimateParent == orgId) {
-			alist = append(alist, *vp)
+	for _, ac := range pc.AcctInfoMap.GetAcctList() {
+		if ac.RoleId != roleId {
+			continue
+		}
+		if ac.OrgId == orgId {
+			alist = append(alist, ac)
+		} else if o := pc.OrgInfoMap.GetOrg(ac.OrgId); o != nil && o.UltimateParent == orgId {
+			alist = append(alist, ac)
 		}
 	}
 	return alist
 }
 
+// PopulateCacheFunc sets the function reading a role missing from the cache
+func (r *RoleCache) PopulateCacheFunc(cf func(orgId, roleId string) (*RoleInfo, error)) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.populateCacheFunc = cf
+}
+
+// PopulateListFunc sets the function reading all the roles once the cache
+// misses some of them
+func (r *RoleCache) PopulateListFunc(cf func() ([]RoleInfo, error)) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.populateListFunc = cf
+}
+
 func (r *RoleCache) UpsertRole(orgId string, role string, voter bool, admin bool, access AccessType, active bool) {
+	defer r.mux.Unlock()
+	r.mux.Lock()
 	key := RoleKey{orgId, role}
 	r.c.Add(key, &RoleInfo{orgId, role, voter, admin, access, active})
-
 }
 
+// GetRole returns a role of an org, nil if it doesn't exist
 func (r *RoleCache) GetRole(orgId string, roleId string) *RoleInfo {
+	defer r.mux.Unlock()
+	r.mux.Lock()
 	key := RoleKey{OrgId: orgId, RoleId: roleId}
 	if ent, ok := r.c.Get(key); ok {
 		return ent.(*RoleInfo)
 	}
-	return nil
+	if !r.evicted || r.populateCacheFunc == nil {
+		return nil
+	}
+	role, err := r.populateCacheFunc(orgId, roleId)
+	if err != nil {
+		log.Error("Failed to read the role from the permission contracts", "org", orgId, "role", roleId, "err", err)
+		return nil
+	}
+	if role == nil {
+		return nil
+	}
+	r.c.Add(key, role)
+	return role
 }
 
+// GetRoleList returns every role, read from the permission contracts once the
+// cache has evicted some of them
 func (r *RoleCache) GetRoleList() []RoleInfo {
+	defer r.mux.Unlock()
+	r.mux.Lock()
+	if r.evicted {
+		if r.populateListFunc == nil {
+			log.Error("The permission cache evicted some of the roles, their list is unknown")
+			return nil
+		}
+		rlist, err := r.populateListFunc()
+		if err != nil {
+			log.Error("Failed to read the roles from the permission contracts", "err", err)
+			return nil
+		}
+		return rlist
+	}
 	rlist := make([]RoleInfo, len(r.c.Keys()))
 	for i, k := range r.c.Keys() {
 		v, _ := r.c.Get(k)
@@ -359,41 +691,193 @@ func (r *RoleCache) GetRoleList() []RoleInfo {
 	return rlist
 }
 
+// PopulateCacheFunc sets the function reading the rules of a role missing from
+// the cache
+func (r *RuleCache) PopulateCacheFunc(cf func(orgId, roleId string) ([]FunctionRule, error)) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.populateCacheFunc = cf
+}
+
+// PopulateListFunc sets the function reading all the function rules once the cache
+// misses some of them
+func (r *RuleCache) PopulateListFunc(cf func() ([]FunctionRule, error)) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.populateListFunc = cf
+}
+
+// UpsertRule adds a rule to the rules of its role or updates its status
+func (r *RuleCache) UpsertRule(rule FunctionRule) {
+	defer r.mux.Unlock()
+	r.mux.Lock()
+	key := RoleKey{rule.OrgId, rule.RoleId}
+	var rules []FunctionRule
+	if ent, ok := r.c.Get(key); ok {
+		rules = ent.([]FunctionRule)
+	}
+	updated := make([]FunctionRule, 0, len(rules)+1)
+	for _, ru := range rules {
+		if ru.Contract != rule.Contract || ru.Selector != rule.Selector {
+			updated = append(updated, ru)
+		}
+	}
+	r.c.Add(key, append(updated, rule))
+}
+
+// GetRoleRules returns the rules of a role of an org, active or not
+func (r *RuleCache) GetRoleRules(orgId, roleId string) []FunctionRule {
+	defer r.mux.Unlock()
+	r.mux.Lock()
+	key := RoleKey{OrgId: orgId, RoleId: roleId}
+	if ent, ok := r.c.Get(key); ok {
+		return ent.([]FunctionRule)
+	}
+	if !r.evicted || r.populateCacheFunc == nil {
+		return nil
+	}
+	rules, err := r.populateCacheFunc(orgId, roleId)
+	if err != nil {
+		log.Error("Failed to read the function rules from the permission contracts", "org", orgId, "role", roleId, "err", err)
+		return nil
+	}
+	r.c.Add(key, rules)
+	return rules
+}
+
+// GetRuleList returns every function rule, read from the permission contracts
+// once the cache has evicted some of them
+func (r *RuleCache) GetRuleList() []FunctionRule {
+	defer r.mux.Unlock()
+	r.mux.Lock()
+	if r.evicted {
+		if r.populateListFunc == nil {
+			log.Error("The permission cache evicted some of the function rules, their list is unknown")
+			return nil
+		}
+		rlist, err := r.populateListFunc()
+		if err != nil {
+			log.Error("Failed to read the function rules from the permission contracts", "err", err)
+			return nil
+		}
+		return rlist
+	}
+	var rlist []FunctionRule
+	for _, k := range r.c.Keys() {
+		v, _ := r.c.Get(k)
+		rlist = append(rlist, v.([]FunctionRule)...)
+	}
+	return rlist
+}
+
 // Returns the access type for an account. If not found returns
 // default access
-func GetAcctAccess(acctId common.Address) AccessType {
+func (pc *PermissionCache) GetAcctAccess(acctId common.Address) AccessType {
+	networkAdminRole, orgAdminRole, defaultAccess := pc.GetDefaults()
 	//if we have not reached QIP714 block return default access
 	//which will be full access
-	if !QIP714BlockReached {
-		return DefaultAccess
+	if !pc.qip714BlockReached() {
+		return defaultAccess
 	}
 
 	// check if the org status is fine to do the transaction
-	a := AcctInfoMap.GetAccount(acctId)
+	a := pc.AcctInfoMap.GetAccount(acctId)
 	if a != nil && a.Status == AcctActive {
 		// get the org details and ultimate org details. check org status
 		// if the org is not approved or pending suspension
-		o := OrgInfoMap.GetOrg(a.OrgId)
+		o := pc.OrgInfoMap.GetOrg(a.OrgId)
 		if o != nil && (o.Status == OrgApproved || o.Status == OrgPendingSuspension) {
-			u := OrgInfoMap.GetOrg(o.UltimateParent)
+			u := pc.OrgInfoMap.GetOrg(o.UltimateParent)
 			if u != nil && (u.Status == OrgApproved || u.Status == OrgPendingSuspension) {
 				if a.RoleId == networkAdminRole || a.RoleId == orgAdminRole {
 					return FullAccess
 				}
-				if r := RoleInfoMap.GetRole(a.OrgId, a.RoleId); r != nil && r.Active {
+				if r := pc.RoleInfoMap.GetRole(a.OrgId, a.RoleId); r != nil && r.Active {
 					return r.Access
 				}
-				if r := RoleInfoMap.GetRole(o.UltimateParent, a.RoleId); r != nil && r.Active {
+				if r := pc.RoleInfoMap.GetRole(o.UltimateParent, a.RoleId); r != nil && r.Active {
 					return r.Access
 				}
 			}
 		}
 	}
-	return DefaultAccess
+	return defaultAccess
+}
+
+// CheckAccountAccess returns the error of a transaction to the given recipient
+// from an account with the given access, nil if the access allows it. A nil
+// recipient is a contract creation.
+func CheckAccountAccess(access AccessType, to *common.Address) error {
+	switch access {
+	case ReadOnly:
+		return ErrReadOnlyAccount
+	case Transact:
+		if to == nil {
+			return ErrContractCreationNotAllowed
+		}
+	}
+	return nil
 }
 
-func ValidateNodeForTxn(hexnodeId string, from common.Address) bool {
-	if !QIP714BlockReached || hexnodeId == "" {
+// CheckFunctionAccess returns an error if the function rules of the role of an
+// account don't allow the contract call of tx. Admin accounts and accounts
+// without an active role aren't restricted by function rules, the access
+// type of GetAcctAccess already applies to them.
+func (pc *PermissionCache) CheckFunctionAccess(from common.Address, tx *Transaction) error {
+	networkAdminRole, orgAdminRole, _ := pc.GetDefaults()
+	if !pc.qip714BlockReached() {
+		return nil
+	}
+	a := pc.AcctInfoMap.GetAccount(from)
+	if a == nil || a.Status != AcctActive || a.IsOrgAdmin || a.RoleId == networkAdminRole || a.RoleId == orgAdminRole {
+		return nil
+	}
+	o := pc.OrgInfoMap.GetOrg(a.OrgId)
+	if o == nil {
+		return nil
+	}
+	for _, orgId := range []string{a.OrgId, o.UltimateParent} {
+		if r := pc.RoleInfoMap.GetRole(orgId, a.RoleId); r != nil && r.Active {
+			return CheckFunctionRules(pc.RuleInfoMap.GetRoleRules(orgId, a.RoleId), tx.To(), tx.Data(), tx.IsPrivate())
+		}
+	}
+	return nil
+}
+
+// CheckFunctionRules returns the error of a transaction to the given recipient
+// with the given input from an account whose role has the given rules, nil if
+// the rules allow it. Without active rules every call is allowed. Contract
+// creations are only subject to the access type of the account. The input of
+// private transactions is encrypted, so any rule on the recipient allows them.
+func CheckFunctionRules(rules []FunctionRule, to *common.Address, data []byte, private bool) error {
+	if to == nil {
+		return nil
+	}
+	var selector FunctionSelector
+	if len(data) >= len(selector) {
+		copy(selector[:], data)
+	}
+	restricted := false
+	for _, rule := range rules {
+		if !rule.Active {
+			continue
+		}
+		restricted = true
+		if rule.Contract != *to {
+			continue
+		}
+		if rule.Selector == AnySelector || private || (len(data) >= len(selector) && rule.Selector == selector) {
+			return nil
+		}
+	}
+	if restricted {
+		return ErrFunctionNotAllowed
+	}
+	return nil
+}
+
+func (pc *PermissionCache) ValidateNodeForTxn(hexnodeId string, from common.Address) bool {
+	if !pc.qip714BlockReached() || hexnodeId == "" {
 		return true
 	}
 
@@ -402,15 +886,18 @@ func ValidateNodeForTxn(hexnodeId string, from common.Address) bool {
 		return false
 	}
 
-	ac := AcctInfoMap.GetAccount(from)
+	ac := pc.AcctInfoMap.GetAccount(from)
 	if ac == nil {
 		return true
 	}
 
-	ultimateParent := OrgInfoMap.GetOrg(ac.OrgId).UltimateParent
+	org := pc.OrgInfoMap.GetOrg(ac.OrgId)
+	if org == nil {
+		return false
+	}
 	// scan through the node list and validate
-	for _, n := range NodeInfoMap.GetNodeList() {
-		if OrgInfoMap.GetOrg(n.OrgId).UltimateParent == ultimateParent {
+	for _, n := range pc.NodeInfoMap.GetNodeList() {
+		if o := pc.OrgInfoMap.GetOrg(n.OrgId); o != nil && o.UltimateParent == org.UltimateParent {
 			recEnodeId, _ := enode.ParseV4(n.Url)
 			if recEnodeId.ID() == passedEnodeId.ID() {
 				return true
@@ -23,222 +23,389 @@ var Acct2 = common.BytesToAddress([]byte("perm-test"))
 
 func TestSetSyncStatus(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
-	SetSyncStatus()
+	pc.SetSyncStatus()
 
 	// check if the value is set properly by calling Get
-	syncStatus := GetSyncStatus()
+	syncStatus := pc.GetSyncStatus()
 	assert.True(syncStatus == true, fmt.Sprintf("Expected syncstatus %v . Got %v ", true, syncStatus))
 }
 
 func TestSetDefaults(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
-	SetDefaults(NETWORKADMIN, ORGADMIN)
+	pc.SetDefaults(NETWORKADMIN, ORGADMIN)
 
 	// get the default values and confirm the same
-	networkAdminRole, orgAdminRole, defaultAccess := GetDefaults()
+	networkAdminRole, orgAdminRole, defaultAccess := pc.GetDefaults()
 
 	assert.True(networkAdminRole == NETWORKADMIN, fmt.Sprintf("Expected network admin role %v, got %v", NETWORKADMIN, networkAdminRole))
 	assert.True(orgAdminRole == ORGADMIN, fmt.Sprintf("Expected network admin role %v, got %v", ORGADMIN, orgAdminRole))
 	assert.True(defaultAccess == FullAccess, fmt.Sprintf("Expected network admin role %v, got %v", FullAccess, defaultAccess))
 
-	SetDefaultAccess()
-	networkAdminRole, orgAdminRole, defaultAccess = GetDefaults()
+	pc.SetDefaultAccess()
+	networkAdminRole, orgAdminRole, defaultAccess = pc.GetDefaults()
 	assert.True(defaultAccess == ReadOnly, fmt.Sprintf("Expected network admin role %v, got %v", ReadOnly, defaultAccess))
 }
 
 func TestOrgCache_UpsertOrg(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
 	//add a org and get the org details
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
-	orgInfo := OrgInfoMap.GetOrg(NETWORKADMIN)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	orgInfo := pc.OrgInfoMap.GetOrg(NETWORKADMIN)
 
 	assert.False(orgInfo == nil, fmt.Sprintf("Expected org details, got nil"))
 	assert.True(orgInfo.OrgId == NETWORKADMIN, fmt.Sprintf("Expected org id %v, got %v", NETWORKADMIN, orgInfo.OrgId))
 
 	// update org status to suspended
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgSuspended)
-	orgInfo = OrgInfoMap.GetOrg(NETWORKADMIN)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgSuspended)
+	orgInfo = pc.OrgInfoMap.GetOrg(NETWORKADMIN)
 
 	assert.True(orgInfo.Status == OrgSuspended, fmt.Sprintf("Expected org status %v, got %v", OrgSuspended, orgInfo.Status))
 
 	//add another org and check get org list
-	OrgInfoMap.UpsertOrg(ORGADMIN, "", ORGADMIN, big.NewInt(1), OrgApproved)
-	orgList := OrgInfoMap.GetOrgList()
+	pc.OrgInfoMap.UpsertOrg(ORGADMIN, "", ORGADMIN, big.NewInt(1), OrgApproved)
+	orgList := pc.OrgInfoMap.GetOrgList()
 	assert.True(len(orgList) == 2, fmt.Sprintf("Expected 2 entries, got %v", len(orgList)))
 
 	//add sub org and check get orglist
-	OrgInfoMap.UpsertOrg("SUB1", ORGADMIN, ORGADMIN, big.NewInt(2), OrgApproved)
-	orgList = OrgInfoMap.GetOrgList()
+	pc.OrgInfoMap.UpsertOrg("SUB1", ORGADMIN, ORGADMIN, big.NewInt(2), OrgApproved)
+	orgList = pc.OrgInfoMap.GetOrgList()
 	assert.True(len(orgList) == 3, fmt.Sprintf("Expected 3 entries, got %v", len(orgList)))
 
 	//suspend the sub org and check get orglist
-	OrgInfoMap.UpsertOrg("SUB1", ORGADMIN, ORGADMIN, big.NewInt(2), OrgSuspended)
-	orgList = OrgInfoMap.GetOrgList()
+	pc.OrgInfoMap.UpsertOrg("SUB1", ORGADMIN, ORGADMIN, big.NewInt(2), OrgSuspended)
+	orgList = pc.OrgInfoMap.GetOrgList()
 	assert.True(len(orgList) == 3, fmt.Sprintf("Expected 3 entries, got %v", len(orgList)))
 }
 
 func TestNodeCache_UpsertNode(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
 	// add a node into the cache and validate
-	NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
-	nodeInfo := NodeInfoMap.GetNodeByUrl(NODE1)
+	pc.NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
+	nodeInfo := pc.NodeInfoMap.GetNodeByUrl(NODE1)
 	assert.False(nodeInfo == nil, fmt.Sprintf("Expected node details, got nil"))
 	assert.True(nodeInfo.OrgId == NETWORKADMIN, fmt.Sprintf("Expected org id for node %v, got %v", NETWORKADMIN, nodeInfo.OrgId))
 	assert.True(nodeInfo.Url == NODE1, fmt.Sprintf("Expected node id %v, got %v", NODE1, nodeInfo.Url))
 
 	// add another node and validate the list function
-	NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeApproved)
-	nodeList := NodeInfoMap.GetNodeList()
+	pc.NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeApproved)
+	nodeList := pc.NodeInfoMap.GetNodeList()
 	assert.True(len(nodeList) == 2, fmt.Sprintf("Expected 2 entries, got %v", len(nodeList)))
 
 	// check node details update by updating node status
-	NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeDeactivated)
-	nodeInfo = NodeInfoMap.GetNodeByUrl(NODE2)
+	pc.NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeDeactivated)
+	nodeInfo = pc.NodeInfoMap.GetNodeByUrl(NODE2)
 	assert.True(nodeInfo.Status == NodeDeactivated, fmt.Sprintf("Expected node status %v, got %v", NodeDeactivated, nodeInfo.Status))
 }
 
 func TestRoleCache_UpsertRole(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
 	// add a role into the cache and validate
-	RoleInfoMap.UpsertRole(NETWORKADMIN, NETWORKADMIN, true, true, FullAccess, true)
-	roleInfo := RoleInfoMap.GetRole(NETWORKADMIN, NETWORKADMIN)
+	pc.RoleInfoMap.UpsertRole(NETWORKADMIN, NETWORKADMIN, true, true, FullAccess, true)
+	roleInfo := pc.RoleInfoMap.GetRole(NETWORKADMIN, NETWORKADMIN)
 	assert.False(roleInfo == nil, fmt.Sprintf("Expected role details, got nil"))
 	assert.True(roleInfo.OrgId == NETWORKADMIN, fmt.Sprintf("Expected org id for node %v, got %v", NETWORKADMIN, roleInfo.OrgId))
 	assert.True(roleInfo.RoleId == NETWORKADMIN, fmt.Sprintf("Expected node id %v, got %v", NETWORKADMIN, roleInfo.RoleId))
 
 	// add another role and validate the list function
-	RoleInfoMap.UpsertRole(ORGADMIN, ORGADMIN, true, true, FullAccess, true)
-	roleList := RoleInfoMap.GetRoleList()
+	pc.RoleInfoMap.UpsertRole(ORGADMIN, ORGADMIN, true, true, FullAccess, true)
+	roleList := pc.RoleInfoMap.GetRoleList()
 	assert.True(len(roleList) == 2, fmt.Sprintf("Expected 2 entries, got %v", len(roleList)))
 
 	// update role status and validate
-	RoleInfoMap.UpsertRole(ORGADMIN, ORGADMIN, true, true, FullAccess, false)
-	roleInfo = RoleInfoMap.GetRole(ORGADMIN, ORGADMIN)
+	pc.RoleInfoMap.UpsertRole(ORGADMIN, ORGADMIN, true, true, FullAccess, false)
+	roleInfo = pc.RoleInfoMap.GetRole(ORGADMIN, ORGADMIN)
 	assert.True(roleInfo.Active == false, fmt.Sprintf("Expected role active status to be %v, got %v", true, roleInfo.Active))
 }
 
 func TestAcctCache_UpsertAccount(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
 	// add an account into the cache and validate
-	AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
-	acctInfo := AcctInfoMap.GetAccount(Acct1)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	acctInfo := pc.AcctInfoMap.GetAccount(Acct1)
 	assert.False(acctInfo == nil, fmt.Sprintf("Expected account details, got nil"))
 	assert.True(acctInfo.OrgId == NETWORKADMIN, fmt.Sprintf("Expected org id for the account to be %v, got %v", NETWORKADMIN, acctInfo.OrgId))
 	assert.True(acctInfo.AcctId == Acct1, fmt.Sprintf("Expected account id %x, got %x", Acct1, acctInfo.AcctId))
 
 	// add a second account and validate the list function
-	AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctActive)
-	acctList := AcctInfoMap.GetAcctList()
+	pc.AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctActive)
+	acctList := pc.AcctInfoMap.GetAcctList()
 	assert.True(len(acctList) == 2, fmt.Sprintf("Expected 2 entries, got %v", len(acctList)))
 
 	// update account status and validate
-	AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctBlacklisted)
-	acctInfo = AcctInfoMap.GetAccount(Acct2)
+	pc.AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctBlacklisted)
+	acctInfo = pc.AcctInfoMap.GetAccount(Acct2)
 	assert.True(acctInfo.Status == AcctBlacklisted, fmt.Sprintf("Expected account status to be %v, got %v", AcctBlacklisted, acctInfo.Status))
 
 	// validate the list for org and role functions
-	acctList = AcctInfoMap.GetAcctListOrg(NETWORKADMIN)
+	acctList = pc.AcctInfoMap.GetAcctListOrg(NETWORKADMIN)
 	assert.True(len(acctList) == 1, fmt.Sprintf("Expected number of accounts for the org to be 1, got %v", len(acctList)))
-	acctList = AcctInfoMap.GetAcctListRole(NETWORKADMIN, NETWORKADMIN)
+	acctList = pc.GetAcctListRole(NETWORKADMIN, NETWORKADMIN)
 	assert.True(len(acctList) == 1, fmt.Sprintf("Expected number of accounts for the role to be 1, got %v", len(acctList)))
 }
 
 func TestGetAcctAccess(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 
 	// default access when the cache is not populated, should return default access
-	SetDefaults(NETWORKADMIN, ORGADMIN)
-	SetDefaultAccess()
-	access := GetAcctAccess(Acct1)
+	pc.SetDefaults(NETWORKADMIN, ORGADMIN)
+	pc.SetDefaultAccess()
+	access := pc.GetAcctAccess(Acct1)
 	assert.True(access == ReadOnly, fmt.Sprintf("Expected account access to be %v, got %v", ReadOnly, access))
 
 	// Create an org with two roles and two accounts linked to different roles. Validate account access
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
-	RoleInfoMap.UpsertRole(NETWORKADMIN, NETWORKADMIN, true, true, FullAccess, true)
-	RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", true, true, FullAccess, true)
-	AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
-	AcctInfoMap.UpsertAccount(NETWORKADMIN, "ROLE1", Acct2, true, AcctActive)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	pc.RoleInfoMap.UpsertRole(NETWORKADMIN, NETWORKADMIN, true, true, FullAccess, true)
+	pc.RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", true, true, FullAccess, true)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, "ROLE1", Acct2, true, AcctActive)
 
-	access = GetAcctAccess(Acct1)
+	access = pc.GetAcctAccess(Acct1)
 	assert.True(access == FullAccess, fmt.Sprintf("Expected account access to be %v, got %v", FullAccess, access))
 
 	// mark the org as pending suspension. The account access should not change
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgPendingSuspension)
-	access = GetAcctAccess(Acct1)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgPendingSuspension)
+	access = pc.GetAcctAccess(Acct1)
 	assert.True(access == FullAccess, fmt.Sprintf("Expected account access to be %v, got %v", FullAccess, access))
 
 	// suspend the org and the account access should be readonly now
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgSuspended)
-	access = GetAcctAccess(Acct1)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgSuspended)
+	access = pc.GetAcctAccess(Acct1)
 	assert.True(access == ReadOnly, fmt.Sprintf("Expected account access to be %v, got %v", ReadOnly, access))
 
 	// mark the role as inactive and account access should now nbe read only
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
-	RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", true, true, FullAccess, false)
-	access = GetAcctAccess(Acct2)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	pc.RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", true, true, FullAccess, false)
+	access = pc.GetAcctAccess(Acct2)
 	assert.True(access == ReadOnly, fmt.Sprintf("Expected account access to be %v, got %v", ReadOnly, access))
 }
 
+func TestCheckAccountAccess(t *testing.T) {
+	assert := testifyassert.New(t)
+	to := common.BytesToAddress([]byte("contract"))
+
+	testCases := []struct {
+		access   AccessType
+		to       *common.Address
+		expected error
+	}{
+		{ReadOnly, &to, ErrReadOnlyAccount},
+		{ReadOnly, nil, ErrReadOnlyAccount},
+		{Transact, &to, nil},
+		{Transact, nil, ErrContractCreationNotAllowed},
+		{ContractDeploy, nil, nil},
+		{FullAccess, nil, nil},
+	}
+	for _, test := range testCases {
+		err := CheckAccountAccess(test.access, test.to)
+		assert.True(err == test.expected, fmt.Sprintf("Expected %v for access %v, got %v", test.expected, test.access, err))
+	}
+}
+
+func TestCheckFunctionRules(t *testing.T) {
+	assert := testifyassert.New(t)
+	token := common.BytesToAddress([]byte("token"))
+	other := common.BytesToAddress([]byte("other"))
+	transfer := FunctionSelector{0xa9, 0x05, 0x9c, 0xbb}
+	approve := []byte{0x09, 0x5e, 0xa7, 0xb3, 0x01}
+	rules := []FunctionRule{
+		{OrgId: ORGADMIN, RoleId: "ROLE1", Contract: token, Selector: transfer, Active: true},
+		{OrgId: ORGADMIN, RoleId: "ROLE1", Contract: other, Selector: AnySelector, Active: false},
+	}
+
+	testCases := []struct {
+		rules    []FunctionRule
+		to       *common.Address
+		data     []byte
+		private  bool
+		expected error
+	}{
+		{nil, &token, approve, false, nil},
+		{rules[1:], &token, approve, false, nil},
+		{rules, &token, append(transfer[:], 0x01), false, nil},
+		{rules, &token, approve, false, ErrFunctionNotAllowed},
+		{rules, &token, nil, false, ErrFunctionNotAllowed},
+		{rules, &token, approve, true, nil},
+		{rules, &other, approve, false, ErrFunctionNotAllowed},
+		{rules, &other, approve, true, ErrFunctionNotAllowed},
+		{rules, nil, approve, false, nil},
+		{append(rules, FunctionRule{Contract: other, Selector: AnySelector, Active: true}), &other, nil, false, nil},
+	}
+	for i, test := range testCases {
+		err := CheckFunctionRules(test.rules, test.to, test.data, test.private)
+		assert.True(err == test.expected, fmt.Sprintf("Test %d: expected %v, got %v", i, test.expected, err))
+	}
+}
+
+func TestCheckFunctionAccess(t *testing.T) {
+	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
+	token := common.BytesToAddress([]byte("token"))
+	transfer := FunctionSelector{0xa9, 0x05, 0x9c, 0xbb}
+	call := NewTransaction(0, token, big.NewInt(0), 0, big.NewInt(0), []byte{0x09, 0x5e, 0xa7, 0xb3})
+
+	pc.SetDefaults(NETWORKADMIN, ORGADMIN)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	pc.OrgInfoMap.UpsertOrg("SUB1", NETWORKADMIN, NETWORKADMIN, big.NewInt(2), OrgApproved)
+	pc.RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", false, false, Transact, true)
+	pc.AcctInfoMap.UpsertAccount("NWADMIN.SUB1", "ROLE1", Acct1, false, AcctActive)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct2, true, AcctActive)
+	pc.RuleInfoMap.UpsertRule(FunctionRule{NETWORKADMIN, "ROLE1", token, transfer, true})
+
+	// rules aren't enforced before QIP714
+	assert.Nil(pc.CheckFunctionAccess(Acct1, call))
+
+	// the rules of the role defined by the ultimate parent apply to the sub org
+	pc.SetDefaultAccess()
+	assert.Equal(ErrFunctionNotAllowed, pc.CheckFunctionAccess(Acct1, call))
+
+	// admins aren't restricted
+	assert.Nil(pc.CheckFunctionAccess(Acct2, call))
+
+	// removing the last active rule lifts the restriction
+	pc.RuleInfoMap.UpsertRule(FunctionRule{NETWORKADMIN, "ROLE1", token, transfer, false})
+	assert.Nil(pc.CheckFunctionAccess(Acct1, call))
+	assert.True(len(pc.RuleInfoMap.GetRoleRules(NETWORKADMIN, "ROLE1")) == 1, "Expected the rule to be updated in place")
+}
+
 func TestValidateNodeForTxn(t *testing.T) {
 	assert := testifyassert.New(t)
+	pc := NewPermissionCache()
 	// pass the enode as null and the response should be true
-	txnAllowed := ValidateNodeForTxn("", Acct1)
+	txnAllowed := pc.ValidateNodeForTxn("", Acct1)
 	assert.True(txnAllowed == true, "Expected access %v, got %v", true, txnAllowed)
 
-	SetDefaultAccess()
+	pc.SetDefaultAccess()
 
 	// if a proper enode id is not passed, return should be false
-	txnAllowed = ValidateNodeForTxn("ABCDE", Acct1)
+	txnAllowed = pc.ValidateNodeForTxn("ABCDE", Acct1)
 	assert.True(txnAllowed == false, "Expected access %v, got %v", true, txnAllowed)
 
 	// if cache is not populated but the enode and account details are proper,
 	// should return true
-	txnAllowed = ValidateNodeForTxn(NODE1, Acct1)
+	txnAllowed = pc.ValidateNodeForTxn(NODE1, Acct1)
 	assert.True(txnAllowed == true, "Expected access %v, got %v", true, txnAllowed)
 
 	// populate an org, account and node. validate access
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
-	NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
-	AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
-	txnAllowed = ValidateNodeForTxn(NODE1, Acct1)
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	pc.NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	txnAllowed = pc.ValidateNodeForTxn(NODE1, Acct1)
 	assert.True(txnAllowed == true, "Expected access %v, got %v", true, txnAllowed)
 
 	// test access from a node not linked to the org. should return false
-	OrgInfoMap.UpsertOrg(ORGADMIN, "", ORGADMIN, big.NewInt(1), OrgApproved)
-	NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeApproved)
-	AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctActive)
-	txnAllowed = ValidateNodeForTxn(NODE1, Acct2)
+	pc.OrgInfoMap.UpsertOrg(ORGADMIN, "", ORGADMIN, big.NewInt(1), OrgApproved)
+	pc.NodeInfoMap.UpsertNode(ORGADMIN, NODE2, NodeApproved)
+	pc.AcctInfoMap.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctActive)
+	txnAllowed = pc.ValidateNodeForTxn(NODE1, Acct2)
 	assert.True(txnAllowed == false, "Expected access %v, got %v", true, txnAllowed)
 }
 
 // This is to make sure enode.ParseV4() honors single hexNodeId value eventhough it does follow enode URI scheme
 func TestValidateNodeForTxn_whenUsingOnlyHexNodeId(t *testing.T) {
-	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
-	NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
-	AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	pc := NewPermissionCache()
+	pc.OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	pc.NodeInfoMap.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
+	pc.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
 	arbitraryPrivateKey, _ := crypto.GenerateKey()
 	hexNodeId := fmt.Sprintf("%x", crypto.FromECDSAPub(&arbitraryPrivateKey.PublicKey)[1:])
 
-	SetDefaultAccess()
+	pc.SetDefaultAccess()
 
-	txnAllowed := ValidateNodeForTxn(hexNodeId, Acct1)
+	txnAllowed := pc.ValidateNodeForTxn(hexNodeId, Acct1)
 
 	testifyassert.False(t, txnAllowed)
 }
 
+func TestPermissionCaches_areIndependent(t *testing.T) {
+	pc1, pc2 := NewPermissionCache(), NewPermissionCache()
+	pc1.SetDefaultAccess()
+	pc1.AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+
+	testifyassert.True(t, pc1.GetAcctAccess(Acct2) == ReadOnly)
+	testifyassert.True(t, pc2.GetAcctAccess(Acct2) == FullAccess)
+	testifyassert.Nil(t, pc2.AcctInfoMap.GetAccount(Acct1))
+}
+
+func TestCacheMiss_readsContractsAfterEviction(t *testing.T) {
+	assert := testifyassert.New(t)
+	reads := 0
+	acctCache := NewAcctCache(1)
+	acctCache.PopulateCacheFunc(func(acct common.Address) (*AccountInfo, error) {
+		reads++
+		if acct != Acct1 {
+			return nil, nil
+		}
+		return &AccountInfo{OrgId: NETWORKADMIN, RoleId: NETWORKADMIN, AcctId: acct, Status: AcctActive}, nil
+	})
+
+	// nothing was evicted yet, a miss means the account doesn't exist
+	assert.Nil(acctCache.GetAccount(Acct1))
+	assert.True(reads == 0, fmt.Sprintf("Expected no contract read, got %v", reads))
+
+	// the second account evicts the first one which is read back on a miss
+	acctCache.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	acctCache.UpsertAccount(ORGADMIN, ORGADMIN, Acct2, true, AcctActive)
+	acctInfo := acctCache.GetAccount(Acct1)
+	assert.False(acctInfo == nil, "Expected account details, got nil")
+	assert.True(acctInfo.OrgId == NETWORKADMIN, fmt.Sprintf("Expected org id %v, got %v", NETWORKADMIN, acctInfo.OrgId))
+	assert.True(reads == 1, fmt.Sprintf("Expected one contract read, got %v", reads))
+
+	// the account read from the contracts is cached again
+	acctCache.GetAccount(Acct1)
+	assert.True(reads == 1, fmt.Sprintf("Expected one contract read, got %v", reads))
+
+	orgCache := NewOrgCache(1)
+	orgCache.PopulateCacheFunc(func(orgId string) (*OrgInfo, error) {
+		return &OrgInfo{OrgId: orgId, FullOrgId: orgId, UltimateParent: orgId, Level: big.NewInt(1), Status: OrgApproved}, nil
+	})
+	orgCache.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	orgCache.UpsertOrg(ORGADMIN, "", ORGADMIN, big.NewInt(1), OrgApproved)
+	orgInfo := orgCache.GetOrg(NETWORKADMIN)
+	assert.False(orgInfo == nil, "Expected org details, got nil")
+	assert.True(orgInfo.Status == OrgApproved, fmt.Sprintf("Expected org status %v, got %v", OrgApproved, orgInfo.Status))
+}
+
+func TestListAfterEviction_readsContracts(t *testing.T) {
+	assert := testifyassert.New(t)
+	nodes := []NodeInfo{{OrgId: NETWORKADMIN, Url: NODE1, Status: NodeApproved}, {OrgId: ORGADMIN, Url: NODE2, Status: NodeApproved}}
+	nodeCache := NewNodeCache(1)
+
+	// nothing was evicted yet, the cache holds every node
+	nodeCache.UpsertNode(NETWORKADMIN, NODE1, NodeApproved)
+	assert.True(len(nodeCache.GetNodeList()) == 1, fmt.Sprintf("Expected 1 node, got %v", len(nodeCache.GetNodeList())))
+
+	// the second node evicts the first one, the list can't be read without the contracts
+	nodeCache.UpsertNode(ORGADMIN, NODE2, NodeApproved)
+	assert.Nil(nodeCache.GetNodeList())
+
+	nodeCache.PopulateListFunc(func() ([]NodeInfo, error) { return nodes, nil })
+	assert.Equal(nodes, nodeCache.GetNodeList())
+
+	nodeCache.PopulateListFunc(func() ([]NodeInfo, error) { return nil, fmt.Errorf("no contract") })
+	assert.Nil(nodeCache.GetNodeList())
+}
+
 // test the cache limit
 func TestLRUCacheLimit(t *testing.T) {
+	pc := NewPermissionCache()
 	for i := 0; i < defaultOrgMapLimit ; i++ {
 		orgName := "ORG" + strconv.Itoa(i)
-		OrgInfoMap.UpsertOrg(orgName, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+		pc.OrgInfoMap.UpsertOrg(orgName, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
 	}
 
-	o := OrgInfoMap.GetOrg("ORG1")
+	o := pc.OrgInfoMap.GetOrg("ORG1")
 	testifyassert.True(t, o != nil)
 }
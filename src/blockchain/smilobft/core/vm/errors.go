@@ -31,4 +31,7 @@ var (
 	ErrReadOnlyValueTransfer          = errors.New("vm in read-only mode. Value transfer prohibited")
 	ErrReadOnlyMutateOpcode           = errors.New("vm in read-only mode. Mutating opcode prohibited")
 	ErrIsPrivateDiffThenIsPrivateOnDB = errors.New("IsPrivate method input is different than IsPrivate on the DB")
+
+	ErrPartyProtection            = errors.New("party protection: participants differ from the contract's creation participants")
+	ErrPartyProtectionUnprotected = errors.New("party protection: contract was not created in party protection mode")
 )
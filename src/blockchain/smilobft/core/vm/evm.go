@@ -25,6 +25,7 @@ import (
 
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 
 	"time"
 
@@ -158,6 +159,11 @@ type EVM struct {
 	// Smilo read only state. Inside Vault State towards Public State read.
 	smiloReadOnly bool
 	readOnlyDepth uint
+
+	// Party protection of the private transaction being executed
+	privateTx         bool
+	txPrivacyMetadata *private.PrivacyMetadata
+	affectedContracts map[common.Address]struct{}
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -233,6 +239,11 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		log.Debug("&*&*&*&*&*& evm.Call, ErrIsPrivateDiffThenIsPrivateOnDB, ", "from", caller.Address().Hex(), "to", addr.Hex(), "gas", gas, "value", value, "input", cmn.Bytes2Hex(input), "evm.smiloReadOnly", evm.smiloReadOnly, "IsPrivate", IsPrivate, "IsPrivateOnDB", IsPrivateOnDB)
 		IsPrivate = IsPrivateOnDB
 	}
+	if IsPrivateOnDB {
+		if err := evm.checkPartyProtection(addr); err != nil {
+			return nil, gas, err
+		}
+	}
 
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
@@ -337,6 +348,11 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 		log.Debug("&*&*&*&*&*& evm.CallCode, ErrIsPrivateDiffThenIsPrivateOnDB, ", "from", caller.Address().Hex(), "to", addr.Hex(), "gas", gas, "value", value, "input", cmn.Bytes2Hex(input), "evm.smiloReadOnly", evm.smiloReadOnly, "IsPrivate", IsPrivate, "IsPrivateOnDB", IsPrivateOnDB)
 		IsPrivate = IsPrivateOnDB
 	}
+	if IsPrivateOnDB {
+		if err := evm.checkPartyProtection(addr); err != nil {
+			return nil, gas, err
+		}
+	}
 
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
@@ -383,6 +399,11 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 		log.Debug("&*&*&*&*&*& evm.DelegateCall, ErrIsPrivateDiffThenIsPrivateOnDB, ", "from", caller.Address().Hex(), "to", addr.Hex(), "gas", gas, "input", cmn.Bytes2Hex(input), "evm.smiloReadOnly", evm.smiloReadOnly, "IsPrivate", IsPrivate, "IsPrivateOnDB", IsPrivateOnDB)
 		IsPrivate = IsPrivateOnDB
 	}
+	if IsPrivateOnDB {
+		if err := evm.checkPartyProtection(addr); err != nil {
+			return nil, gas, err
+		}
+	}
 
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
@@ -426,6 +447,11 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 		log.Debug("&*&*&*&*&*& evm.StaticCall, ErrIsPrivateDiffThenIsPrivateOnDB, ", "from", caller.Address().Hex(), "to", addr.Hex(), "gas", gas, "input", cmn.Bytes2Hex(input), "evm.smiloReadOnly", evm.smiloReadOnly, "IsPrivate", IsPrivate, "IsPrivateOnDB", IsPrivateOnDB)
 		IsPrivate = IsPrivateOnDB
 	}
+	if IsPrivateOnDB {
+		if err := evm.checkPartyProtection(addr); err != nil {
+			return nil, gas, err
+		}
+	}
 
 	var (
 		to       = AccountRef(addr)
@@ -515,6 +541,9 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if evm.chainRules.IsEIP158 {
 		evm.StateDB.SetNonce(address, 1)
 	}
+	if evm.StateDB == evm.privateState {
+		evm.recordPartyProtection(address)
+	}
 
 	if evm.ChainConfig().IsSmilo {
 		if value.Sign() != 0 {
@@ -606,9 +635,25 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 	return evm.create(caller, codeAndHash, gas, endowment, contractAddr, IsPrivate)
 }
 
+// CreateAt creates a new contract using code as deployment code at the given
+// address, for system contracts whose address must not depend on the nonce of
+// their deployer.
+func (evm *EVM) CreateAt(caller ContractRef, code []byte, gas uint64, value *big.Int, address common.Address, IsPrivate bool) (ret []byte, contractAddr common.Address, leftOverGas uint64, err error) {
+	return evm.create(caller, &codeAndHash{code: code}, gas, value, address, IsPrivate)
+}
+
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
 
+// Vault returns the vault used to resolve private payloads, falling back to
+// the process wide private.VaultInstance when none was configured.
+func (evm *EVM) Vault() private.BlackboxVault {
+	if evm.vmConfig.Vault != nil {
+		return evm.vmConfig.Vault
+	}
+	return private.VaultInstance
+}
+
 func getPrivateOrPublicStateDB(env *EVM, addr common.Address) (IsPrivate bool, thisState StateDB) {
 	// priv: (a) -> (b)  (vault)
 	// pub:   a  -> [b]  (vault -> public)
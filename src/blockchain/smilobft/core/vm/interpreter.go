@@ -28,6 +28,8 @@ import (
 	"hash"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // Config are the configuration options for the Interpreter
@@ -45,6 +47,8 @@ type Config struct {
 	EstimateGas bool
 
 	ExtraEips []int // Additional EIPS that are to be enabled
+
+	Vault private.BlackboxVault // Vault resolving private payloads, private.VaultInstance if nil
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -0,0 +1,140 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"go-smilo/src/blockchain/smilobft/private"
+)
+
+// PrivacyMetadataAddress is the private state account holding the party
+// protection records: the participants of every protected contract and the
+// private state hash of every protected transaction.
+var PrivacyMetadataAddress = common.BytesToAddress(crypto.Keccak256([]byte("smilo.privacy.metadata")))
+
+func contractParticipantsKey(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("participants"), addr.Bytes())
+}
+
+func privateStateHashKey(txHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("statehash"), txHash.Bytes())
+}
+
+// ContractParticipants returns the participants hash recorded for a contract
+// created in party protection mode, or the zero hash for standard contracts.
+func ContractParticipants(db SmiloAPIState, addr common.Address) common.Hash {
+	return db.GetState(PrivacyMetadataAddress, contractParticipantsKey(addr))
+}
+
+// PrivateStateHash returns the private state hash recorded for a protected
+// transaction, or the zero hash if there is none.
+func PrivateStateHash(db SmiloAPIState, txHash common.Hash) common.Hash {
+	return db.GetState(PrivacyMetadataAddress, privateStateHashKey(txHash))
+}
+
+// WritePrivateStateHash records the private state hash of a protected
+// transaction in the private state.
+func WritePrivateStateHash(db StateDB, txHash, hash common.Hash) {
+	setPrivacyMetadata(db, privateStateHashKey(txHash), hash)
+}
+
+func setPrivacyMetadata(db StateDB, key, value common.Hash) {
+	// A nonce keeps the storage only account from being deleted as empty
+	if db.GetNonce(PrivacyMetadataAddress) == 0 {
+		db.SetNonce(PrivacyMetadataAddress, 1)
+	}
+	db.SetState(PrivacyMetadataAddress, key, value)
+}
+
+// SetTxPrivacyMetadata marks the transaction executed by the EVM as private,
+// with the given privacy metadata, nil for standard private transactions.
+// Party protection is only enforced for private transactions.
+func (evm *EVM) SetTxPrivacyMetadata(meta *private.PrivacyMetadata) {
+	evm.privateTx = true
+	evm.txPrivacyMetadata = meta
+	evm.affectedContracts = make(map[common.Address]struct{})
+}
+
+// TxPrivacyMetadata returns the privacy metadata of the private transaction
+// executed by the EVM.
+func (evm *EVM) TxPrivacyMetadata() *private.PrivacyMetadata {
+	return evm.txPrivacyMetadata
+}
+
+// checkPartyProtection rejects calls into private contracts whose recorded
+// participants differ from the ones of the current transaction.
+func (evm *EVM) checkPartyProtection(addr common.Address) error {
+	if !evm.privateTx {
+		return nil
+	}
+	participants := ContractParticipants(evm.privateState, addr)
+	protected := evm.txPrivacyMetadata.Protected()
+	switch {
+	case participants == (common.Hash{}) && !protected:
+		return nil
+	case participants == (common.Hash{}):
+		return ErrPartyProtectionUnprotected
+	case !protected || evm.txPrivacyMetadata.Participants != participants:
+		return ErrPartyProtection
+	}
+	evm.affectedContracts[addr] = struct{}{}
+	return nil
+}
+
+// recordPartyProtection binds a contract created in the private state by a
+// protected transaction to the participants of the transaction.
+func (evm *EVM) recordPartyProtection(addr common.Address) {
+	if !evm.privateTx || !evm.txPrivacyMetadata.Protected() {
+		return
+	}
+	setPrivacyMetadata(evm.privateState, contractParticipantsKey(addr), evm.txPrivacyMetadata.Participants)
+	evm.affectedContracts[addr] = struct{}{}
+}
+
+// PrivateStateHash digests the current state of the private contracts touched
+// by the protected transaction. All the parties of the transaction hold the
+// same contracts, so a differing hash reveals diverged private states.
+func (evm *EVM) PrivateStateHash() common.Hash {
+	addrs := make([]common.Address, 0, len(evm.affectedContracts))
+	for addr := range evm.affectedContracts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+
+	var entries []interface{}
+	for _, addr := range addrs {
+		var storageRoot common.Hash
+		if trie := evm.privateState.StorageTrie(addr); trie != nil {
+			storageRoot = trie.Hash()
+		}
+		entries = append(entries, []interface{}{
+			addr,
+			evm.privateState.GetNonce(addr),
+			evm.privateState.GetCodeHash(addr),
+			storageRoot,
+		})
+	}
+	blob, _ := rlp.EncodeToBytes(entries)
+	return crypto.Keccak256Hash(blob)
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/state"
+	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
+)
+
+func newPrivacyTestEVM(publicState, privateState *state.StateDB, meta *private.PrivacyMetadata) *EVM {
+	ctx := Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int, *big.Int) {},
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(0),
+		Difficulty:  big.NewInt(0),
+		GasLimit:    10000000,
+	}
+	evm := NewEVM(ctx, publicState, privateState, params.SmiloTestChainConfig, Config{})
+	evm.SetTxPrivacyMetadata(meta)
+	return evm
+}
+
+func TestPartyProtection(t *testing.T) {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	publicState, _ := state.New(common.Hash{}, db)
+	privateState, _ := state.New(common.Hash{}, db)
+
+	var (
+		sender    = AccountRef(common.Address{1})
+		initCode  = []byte{0x00} // STOP, deploys an empty contract
+		protected = &private.PrivacyMetadata{
+			Flag:         private.PrivacyFlagPartyProtection,
+			Participants: private.ParticipantsHash("A", "B"),
+		}
+		outsider = &private.PrivacyMetadata{
+			Flag:         private.PrivacyFlagPartyProtection,
+			Participants: private.ParticipantsHash("A", "C"),
+		}
+	)
+	evm := newPrivacyTestEVM(publicState, privateState, protected)
+	_, protectedAddr, _, err := evm.Create(sender, initCode, 100000, new(big.Int), true)
+	if err != nil {
+		t.Fatalf("failed to create protected contract: %v", err)
+	}
+	if have := ContractParticipants(privateState, protectedAddr); have != protected.Participants {
+		t.Fatalf("participants not recorded: have %x, want %x", have, protected.Participants)
+	}
+	hash := evm.PrivateStateHash()
+
+	evm = newPrivacyTestEVM(publicState, privateState, nil)
+	_, standardAddr, _, err := evm.Create(sender, initCode, 100000, new(big.Int), true)
+	if err != nil {
+		t.Fatalf("failed to create standard contract: %v", err)
+	}
+	if have := ContractParticipants(privateState, standardAddr); have != (common.Hash{}) {
+		t.Fatalf("standard contract has participants %x", have)
+	}
+
+	for _, x := range []struct {
+		name string
+		meta *private.PrivacyMetadata
+		addr common.Address
+		err  error
+	}{
+		{"same participants", protected, protectedAddr, nil},
+		{"other participants", outsider, protectedAddr, ErrPartyProtection},
+		{"standard call to protected", nil, protectedAddr, ErrPartyProtection},
+		{"protected call to standard", protected, standardAddr, ErrPartyProtectionUnprotected},
+		{"standard call to standard", nil, standardAddr, nil},
+	} {
+		evm := newPrivacyTestEVM(publicState, privateState, x.meta)
+		if _, _, err := evm.Call(sender, x.addr, nil, 100000, new(big.Int), true); err != x.err {
+			t.Errorf("%s: have error %v, want %v", x.name, err, x.err)
+		}
+	}
+
+	// Untouched contracts must hash the same for every party
+	evm = newPrivacyTestEVM(publicState, privateState, protected)
+	if _, _, err := evm.Call(sender, protectedAddr, nil, 100000, new(big.Int), true); err != nil {
+		t.Fatalf("failed to call protected contract: %v", err)
+	}
+	if have := evm.PrivateStateHash(); have != hash {
+		t.Errorf("private state hash mismatch: have %x, want %x", have, hash)
+	}
+}
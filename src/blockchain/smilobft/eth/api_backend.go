@@ -43,6 +43,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/eth/gasprice"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // EthAPIBackend implements ethapi.Backend for full nodes
@@ -166,6 +167,9 @@ func (b *EthAPIBackend) GetEVM(ctx context.Context, msg core.Message, state vm.S
 		privateState = statedb.(EthAPIState).State
 	}
 
+	if vmCfg.Vault == nil {
+		vmCfg.Vault = b.eth.vault
+	}
 	return vm.NewEVM(context, statedb.(EthAPIState).State, statedb.(EthAPIState).PrivateState, b.eth.chainConfig, vmCfg), vmError, nil
 }
 
@@ -226,6 +230,18 @@ func (b *EthAPIBackend) TxPoolContent() (map[common.Address]types.Transactions,
 	return b.eth.TxPool().Content()
 }
 
+func (b *EthAPIBackend) AccountPermissions() types.AccountPermissions {
+	return b.eth.TxPool().Permissions()
+}
+
+// LocalEnode returns the enode URL of the node, empty until its p2p server is started
+func (b *EthAPIBackend) LocalEnode() string {
+	if b.eth.server == nil {
+		return ""
+	}
+	return b.eth.server.NodeInfo().Enode
+}
+
 func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return b.eth.TxPool().SubscribeNewTxsEvent(ch)
 }
@@ -292,6 +308,10 @@ func (b *EthAPIBackend) GetSmiloCodeAnalysisPath() string {
 	return codeAnalysisPath
 }
 
+func (b *EthAPIBackend) Vault() private.BlackboxVault {
+	return b.eth.vault
+}
+
 type EthAPIState struct {
 	State, PrivateState *state.StateDB
 }
@@ -20,6 +20,7 @@ package eth
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"runtime"
 	"sync"
@@ -34,6 +35,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/consensus/sportdao"
 	tendermintBackend "go-smilo/src/blockchain/smilobft/consensus/tendermint/backend"
 	tendermintCore "go-smilo/src/blockchain/smilobft/consensus/tendermint/core"
+	"go-smilo/src/blockchain/smilobft/consensus/transition"
 	"go-smilo/src/blockchain/smilobft/p2p/enode"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -65,6 +67,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/node"
 	"go-smilo/src/blockchain/smilobft/p2p"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 type LesServer interface {
@@ -116,6 +119,8 @@ type Smilo struct {
 
 	glienickeCh  chan core.WhitelistEvent
 	glienickeSub event.Subscription
+
+	vault private.BlackboxVault // Blackbox vault resolving the private transactions of this node
 }
 
 func (s *Smilo) ChainConfig() *params.ChainConfig {
@@ -173,16 +178,32 @@ func New(ctx *node.ServiceContext, config *Config, cons func(basic consensus.Eng
 		}
 	}
 
+	if err := chainConfig.CheckConsensusTransitions(); err != nil {
+		return nil, err
+	}
+	if err := chainConfig.CheckRewardSchedules(); err != nil {
+		return nil, err
+	}
+	if err := chainConfig.CheckLiveness(); err != nil {
+		return nil, err
+	}
+
 	if !core.GetIsSmiloEIP155Activated(chainDb) && chainConfig.ChainID != nil {
 		//Upon starting the node, write the flag to disallow changing ChainID/EIP155 block after HF
 		core.WriteSmiloEIP155Activation(chainDb)
 	}
 
+	vault, err := private.NewVault(&config.Vault)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
 			EWASMInterpreter:        config.EWASMInterpreter,
 			EVMInterpreter:          config.EVMInterpreter,
+			Vault:                   vault,
 		}
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit: config.TrieCleanCache,
@@ -210,6 +231,7 @@ func New(ctx *node.ServiceContext, config *Config, cons func(basic consensus.Eng
 		bloomRequests:  make(chan chan *bloombits.Retrieval),
 		bloomIndexer:   NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		glienickeCh:    make(chan core.WhitelistEvent),
+		vault:          vault,
 	}
 
 	// force to set the etherbase to node key address
@@ -250,6 +272,9 @@ func New(ctx *node.ServiceContext, config *Config, cons func(basic consensus.Eng
 	if err != nil {
 		return nil, err
 	}
+	if t, ok := eth.engine.(*transition.Engine); ok {
+		t.SetChain(eth.blockchain)
+	}
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
 		log.Warn("Rewinding chain to upgrade configuration", "err", compat)
@@ -324,13 +349,32 @@ func makeExtraData(extra []byte, isSmilo bool) []byte {
 func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig *params.ChainConfig, config *Config, notify []string, noverify bool, db ethdb.Database, vmConfig *vm.Config) consensus.Engine {
 	log.Info("****************** Going to create the required type of consensus engine instance for an Smilo service !!!!!!!!!!!!!!!!!!!")
 
+	genesisEngine := createConsensusEngine(ctx, chainConfig.GenesisConsensusEngine(), chainConfig, config, db, vmConfig)
+	if len(chainConfig.ConsensusTransitions) == 0 {
+		return genesisEngine
+	}
+
+	// The consensus engine changes at the transition blocks, wrap all of them
+	stages := []transition.Stage{{Block: common.Big0, Engine: genesisEngine}}
+	for _, t := range chainConfig.ConsensusTransitions {
+		log.Info("Consensus transition scheduled", "block", t.Block, "engine", t.Engine)
+		stages = append(stages, transition.Stage{
+			Block:  t.Block,
+			Engine: createConsensusEngine(ctx, t.Engine, chainConfig, config, db, vmConfig),
+		})
+	}
+	return transition.New(stages)
+}
+
+// createConsensusEngine creates the consensus engine with the given name.
+func createConsensusEngine(ctx *node.ServiceContext, name string, chainConfig *params.ChainConfig, config *Config, db ethdb.Database, vmConfig *vm.Config) consensus.Engine {
+	switch name {
 	// If proof-of-authority is requested, set it up
-	if chainConfig.Clique != nil {
+	case params.CliqueEngine:
 		log.Warn("$$$ Clique is requested, set it up", "chainConfig", chainConfig)
 		return clique.New(chainConfig.Clique, db)
-	}
 	// If Sport is requested, set it up
-	if chainConfig.Sport != nil {
+	case params.SportEngine:
 		log.Warn("$$$ Sport Consensus activated, will set it up", "chainConfig.Sport", chainConfig.Sport, "chainConfig", chainConfig)
 		if chainConfig.Sport.Epoch != 0 {
 			config.Sport.Epoch = chainConfig.Sport.Epoch
@@ -340,10 +384,10 @@ func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig *params.ChainCo
 		if chainConfig.Sport.MinFunds != 0 {
 			config.Sport.MinFunds = chainConfig.Sport.MinFunds
 		}
+		config.Sport.Governance = chainConfig.Sport.Governance
 
 		return smiloBackend.New(&config.Sport, ctx.NodeKey(), db)
-	}
-	if chainConfig.SportDAO != nil {
+	case params.SportDAOEngine:
 		if chainConfig.SportDAO.Epoch != 0 {
 			config.SportDAO.Epoch = chainConfig.SportDAO.Epoch
 		}
@@ -363,19 +407,16 @@ func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig *params.ChainCo
 
 		log.Warn("$$$ SportDAO Consensus activated, will set it up", "&config.SportDAO", &config.SportDAO, "chainConfig", chainConfig)
 		return smiloDAOBackend.New(&config.SportDAO, ctx.NodeKey(), db, chainConfig, vmConfig)
-	}
-
-	if chainConfig.Istanbul != nil {
+	case params.IstanbulEngine:
 		if config.Istanbul.MaxTimeout == 0 {
 			config.Istanbul.MaxTimeout = istanbul.DefaultConfig.MaxTimeout
 		}
 		log.Warn("$$$ Istanbul Consensus activated, will set it up", "chainConfig.Istanbul", chainConfig.Istanbul, "chainConfig", chainConfig)
 		return istanbulBackend.New(&config.Istanbul, ctx.NodeKey(), db, chainConfig, vmConfig)
-	}
-	if chainConfig.Tendermint != nil {
+	case params.TendermintEngine:
 		log.Warn("$$$ Tendermint Consensus activated, will set it up", "chainConfig.Tendermint", chainConfig.Tendermint, "chainConfig", chainConfig)
 		back := tendermintBackend.New(&config.Tendermint, ctx.NodeKey(), db, chainConfig, vmConfig)
-		return tendermintCore.New(back, &config.Tendermint)
+		return tendermintCore.New(back, &config.Tendermint, db)
 	}
 
 	// Otherwise assume proof-of-work
@@ -639,6 +680,7 @@ func (s *Smilo) NetVersion() uint64                 { return s.networkID }
 func (s *Smilo) Downloader() *downloader.Downloader { return s.protocolManager.downloader }
 func (s *Smilo) Synced() bool                       { return atomic.LoadUint32(&s.protocolManager.acceptTxs) == 1 }
 func (s *Smilo) ArchiveMode() bool                  { return s.config.NoPruning }
+func (s *Smilo) Vault() private.BlackboxVault       { return s.vault }
 
 // Protocols implements node.Service, returning all the currently configured
 // network protocols to start.
@@ -661,6 +703,7 @@ func (s *Smilo) Protocols() []p2p.Protocol {
 // Start implements node.Service, starting all internal goroutines needed by the
 // Smilo protocol implementation.
 func (s *Smilo) Start(srvr *p2p.Server) error {
+	s.server = srvr
 
 	if (s.blockchain.Config().Tendermint != nil || s.blockchain.Config().Istanbul != nil || s.blockchain.Config().SportDAO != nil) && s.blockchain.Config().AutonityContractConfig != nil {
 		//if srvr.EnableNodePermissionFlag {
@@ -735,6 +778,16 @@ func (s *Smilo) glienickeEventLoop(server *p2p.Server) {
 
 // Stop implements node.Service, terminating all internal goroutines used by the
 // Smilo protocol.
+// closeVault stops the vault built from the node configuration. The process
+// wide private.VaultInstance may be shared with other nodes and is left open.
+func (s *Smilo) closeVault() {
+	if closer, ok := s.vault.(io.Closer); ok && s.vault != private.VaultInstance {
+		if err := closer.Close(); err != nil {
+			log.Error("Failed to close vault", "err", err)
+		}
+	}
+}
+
 func (s *Smilo) Stop() error {
 	s.bloomIndexer.Close()
 	if s.glienickeSub != nil {
@@ -749,6 +802,7 @@ func (s *Smilo) Stop() error {
 	s.txPool.Stop()
 	s.miner.Stop()
 	s.eventMux.Stop()
+	s.closeVault()
 
 	s.chainDb.Close()
 	close(s.shutdownChan)
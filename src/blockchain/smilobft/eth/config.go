@@ -39,6 +39,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/eth/downloader"
 	"go-smilo/src/blockchain/smilobft/eth/gasprice"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // DefaultConfig contains default settings for use on the Smilo main net.
@@ -182,6 +183,9 @@ type Config struct {
 	PowMode               Mode
 	SolcPath              string
 	SmiloCodeAnalysisPath string
+
+	// Vault selects the Blackbox backend resolving private transactions
+	Vault private.VaultConfig
 }
 
 type Mode uint
@@ -169,7 +169,9 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 }
 
 // indexedLogs returns the logs matching the filter criteria based on the bloom
-// bits indexed available locally or via the network.
+// bits indexed available locally or via the network. The bloom bits only index
+// the public blooms, the vault blooms of the blocks in between the matches are
+// checked one by one.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
 	// Create a matcher session and request servicing from the backend
 	matches := make(chan uint64, 64)
@@ -192,10 +194,20 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 			if !ok {
 				err := session.Error()
 				if err == nil {
+					found, err := f.privateLogs(ctx, uint64(f.begin), end+1)
+					logs = append(logs, found...)
+					if err != nil {
+						return logs, err
+					}
 					f.begin = int64(end) + 1
 				}
 				return logs, err
 			}
+			found, err := f.privateLogs(ctx, uint64(f.begin), number)
+			logs = append(logs, found...)
+			if err != nil {
+				return logs, err
+			}
 			f.begin = int64(number) + 1
 
 			// Retrieve the suggested block and pull any truly matching logs
@@ -203,8 +215,7 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 			if header == nil || err != nil {
 				return logs, err
 			}
-			found, err := f.checkMatches(ctx, header)
-			if err != nil {
+			if found, err = f.checkMatches(ctx, header); err != nil {
 				return logs, err
 			}
 			logs = append(logs, found...)
@@ -215,34 +226,55 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 	}
 }
 
+// privateLogs returns the logs matching the filter criteria of the blocks from
+// begin up to, but excluding, end whose vault bloom matches. The start of the
+// filter is updated to the block being checked.
+func (f *Filter) privateLogs(ctx context.Context, begin, end uint64) ([]*types.Log, error) {
+	var logs []*types.Log
+
+	for number := begin; number < end; number++ {
+		if err := ctx.Err(); err != nil {
+			return logs, err
+		}
+		f.begin = int64(number)
+		if !bloomFilter(core.GetPrivateBlockBloom(f.db, number), f.addresses, f.topics) {
+			continue
+		}
+		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if header == nil || err != nil {
+			return logs, err
+		}
+		found, err := f.checkMatches(ctx, header)
+		if err != nil {
+			return logs, err
+		}
+		logs = append(logs, found...)
+	}
+	return logs, nil
+}
+
 // indexedLogs returns the logs matching the filter criteria based on raw block
 // iteration and bloom matching.
 func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
 	var logs []*types.Log
 
 	for ; f.begin <= int64(end); f.begin++ {
-		blockNumber := rpc.BlockNumber(f.begin)
 		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.begin))
 		if header == nil || err != nil {
 			return logs, err
 		}
-
-		bloomMatches := bloomFilter(header.Bloom, f.addresses, f.topics) ||
-			bloomFilter(core.GetPrivateBlockBloom(f.db, uint64(blockNumber)), f.addresses, f.topics)
-		if bloomMatches {
-			found, err := f.blockLogs(ctx, header)
-			if err != nil {
-				return logs, err
-			}
-			logs = append(logs, found...)
+		found, err := f.blockLogs(ctx, header)
+		if err != nil {
+			return logs, err
 		}
+		logs = append(logs, found...)
 	}
 	return logs, nil
 }
 
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(ctx context.Context, header *types.Header) (logs []*types.Log, err error) {
-	if bloomFilter(header.Bloom, f.addresses, f.topics) {
+	if f.bloomMatches(header) {
 		found, err := f.checkMatches(ctx, header)
 		if err != nil {
 			return logs, err
@@ -252,6 +284,13 @@ func (f *Filter) blockLogs(ctx context.Context, header *types.Header) (logs []*t
 	return logs, nil
 }
 
+// bloomMatches checks whether the public bloom of the header or the vault bloom
+// of the block may contain logs matching the filter criteria.
+func (f *Filter) bloomMatches(header *types.Header) bool {
+	return bloomFilter(header.Bloom, f.addresses, f.topics) ||
+		bloomFilter(core.GetPrivateBlockBloom(f.db, header.Number.Uint64()), f.addresses, f.topics)
+}
+
 // checkMatches checks if the receipts belonging to the given header contain any log events that
 // match the filter criteria. This function is called when the bloom filter signals a potential match.
 func (f *Filter) checkMatches(ctx context.Context, header *types.Header) (logs []*types.Log, err error) {
@@ -28,6 +28,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/cmn"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bitutil"
 	"github.com/ethereum/go-ethereum/event"
 
 	"go-smilo/src/blockchain/smilobft/rpc"
@@ -146,7 +147,9 @@ func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.Matc
 				for i, section := range task.Sections {
 					if rand.Int()%4 != 0 { // Handle occasional missing deliveries
 						head := rawdb.ReadCanonicalHash(b.db, (section+1)*params.BloomBitsBlocks-1)
-						task.Bitsets[i], _ = rawdb.ReadBloomBits(b.db, task.Bit, section, head)
+						if comp, err := rawdb.ReadBloomBits(b.db, task.Bit, section, head); err == nil {
+							task.Bitsets[i], _ = bitutil.DecompressBytes(comp, int(params.BloomBitsBlocks/8))
+						}
 					}
 				}
 				request <- task
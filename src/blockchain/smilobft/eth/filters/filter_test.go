@@ -26,11 +26,13 @@ import (
 	"go-smilo/src/blockchain/smilobft/cmn"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bitutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 
 	"go-smilo/src/blockchain/smilobft/consensus/ethash"
 	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/bloombits"
 	"go-smilo/src/blockchain/smilobft/core/rawdb"
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/params"
@@ -275,3 +277,146 @@ func TestFilters(t *testing.T) {
 	}
 
 }
+
+func TestFiltersVaultBloomOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filtertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		db, _      = rawdb.NewLevelDBDatabase(dir, 0, 0, "")
+		mux        = new(cmn.TypeMux)
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		addr       = common.BytesToAddress([]byte("private contract"))
+		topic      = common.BytesToHash([]byte("privateTopic"))
+	)
+	defer db.Close()
+
+	genesis := core.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	chain, _ := core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 5, func(i int, gen *core.BlockGen) {
+		if i == 2 {
+			gen.AddUncheckedTx(types.NewTransaction(2, common.HexToAddress("0x2"), big.NewInt(2), 2, big.NewInt(2), nil))
+		}
+	})
+	for _, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+	}
+	// Store a vault receipt whose log is absent from the public header bloom
+	block := chain[2]
+	vaultReceipt := types.NewReceipt(nil, false, 0)
+	vaultReceipt.Logs = []*types.Log{{Address: addr, Topics: []common.Hash{topic}}}
+	rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), types.Receipts{vaultReceipt})
+	if err := core.WritePrivateBlockBloom(db, block.NumberU64(), types.Receipts{vaultReceipt}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filter := range []*Filter{
+		NewRangeFilter(backend, 0, -1, []common.Address{addr}, nil),
+		NewRangeFilter(backend, 0, -1, nil, [][]common.Hash{{topic}}),
+		NewBlockFilter(backend, block.Hash(), []common.Address{addr}, nil),
+	} {
+		logs, err := filter.Logs(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) != 1 {
+			t.Fatalf("expected 1 log, got %d", len(logs))
+		}
+		if logs[0].TxHash != block.Transactions()[0].Hash() {
+			t.Errorf("log tx hash mismatch: have %x, want %x", logs[0].TxHash, block.Transactions()[0].Hash())
+		}
+	}
+}
+
+func TestFiltersVaultBloomIndexed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filtertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		db, _      = rawdb.NewLevelDBDatabase(dir, 0, 0, "")
+		mux        = new(cmn.TypeMux)
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 1, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		addr       = common.BytesToAddress([]byte("private contract"))
+
+		// Blocks of the first, indexed, section with a log of addr, the
+		// public one is found through the bloom bits
+		privateBlocks = map[uint64]bool{100: true, 4000: true}
+		publicBlock   = uint64(200)
+	)
+	defer db.Close()
+
+	genesis := core.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	chain, _ := core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, int(params.BloomBitsBlocks)+10, func(i int, gen *core.BlockGen) {
+		if number := uint64(i + 1); privateBlocks[number] || number == publicBlock {
+			gen.AddUncheckedTx(types.NewTransaction(number, common.HexToAddress("0x2"), big.NewInt(2), 2, big.NewInt(2), nil))
+		}
+	})
+	var want []common.Hash
+	for _, block := range chain {
+		number := block.NumberU64()
+		if privateBlocks[number] || number == publicBlock {
+			receipt := types.NewReceipt(nil, false, 0)
+			receipt.Logs = []*types.Log{{Address: addr}}
+			receipts := types.Receipts{receipt}
+			if number == publicBlock {
+				header := block.Header()
+				header.Bloom = types.CreateBloom(receipts)
+				block = types.NewBlockWithHeader(header).WithBody(block.Transactions(), block.Uncles())
+			} else if err := core.WritePrivateBlockBloom(db, number, receipts); err != nil {
+				t.Fatal(err)
+			}
+			rawdb.WriteReceipts(db, block.Hash(), number, receipts)
+			want = append(want, block.Transactions()[0].Hash())
+		}
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), number)
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+	}
+	// Index the public blooms of the first section
+	gen, err := bloombits.NewGenerator(uint(params.BloomBitsBlocks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(0); i < params.BloomBitsBlocks; i++ {
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, i), i)
+		if err := gen.AddBloom(uint(i), header.Bloom); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head := rawdb.ReadCanonicalHash(db, params.BloomBitsBlocks-1)
+	for i := 0; i < types.BloomBitLength; i++ {
+		bits, err := gen.Bitset(uint(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawdb.WriteBloomBits(db, uint(i), 0, head, bitutil.CompressBytes(bits))
+	}
+
+	logs, err := NewRangeFilter(backend, 0, -1, []common.Address{addr}, nil).Logs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != len(want) {
+		t.Fatalf("expected %d logs, got %d", len(want), len(logs))
+	}
+	for i, log := range logs {
+		if log.TxHash != want[i] {
+			t.Errorf("log %d tx hash mismatch: have %x, want %x", i, log.TxHash, want[i])
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/eth/gasprice"
 	"go-smilo/src/blockchain/smilobft/miner"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -62,6 +63,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		PowMode                  Mode
 		SolcPath                 string
 		SmiloCodeAnalysisPath    string
+		Vault                    private.VaultConfig
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -103,6 +105,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.PowMode = c.PowMode
 	enc.SolcPath = c.SolcPath
 	enc.SmiloCodeAnalysisPath = c.SmiloCodeAnalysisPath
+	enc.Vault = c.Vault
 	return &enc, nil
 }
 
@@ -148,6 +151,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		PowMode                  *Mode
 		SolcPath                 *string
 		SmiloCodeAnalysisPath    *string
+		Vault                    *private.VaultConfig
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -270,5 +274,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SmiloCodeAnalysisPath != nil {
 		c.SmiloCodeAnalysisPath = *dec.SmiloCodeAnalysisPath
 	}
+	if dec.Vault != nil {
+		c.Vault = *dec.Vault
+	}
 	return nil
 }
@@ -433,7 +433,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 		return err
 	}
 
-	if pm.chainconfig.Istanbul != nil || pm.chainconfig.SportDAO != nil || pm.chainconfig.Tendermint != nil {
+	if pm.chainconfig.IsAutonity(head.Number) {
 		if pm.EnableNodePermissionFlag {
 			whitelisted := false
 			log.Warn("eth/handler.go, pm.EnableNodePermissionFlag, enodesWhitelist, ", pm.enodesWhitelist)
@@ -488,8 +488,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	// after this will be sent via broadcasts.
 	pm.syncTransactions(p)
 
-	if pm.blockchain.Config().Tendermint != nil {
-		syncer := pm.blockchain.Engine().(consensus.Syncer)
+	if syncer, ok := pm.blockchain.Engine().(consensus.Syncer); ok && pm.blockchain.Config().Tendermint != nil {
 		address := crypto.PubkeyToAddress(*p.Node().Pubkey())
 		syncer.ResetPeerCache(address)
 		syncer.SyncPeer(address)
@@ -553,7 +552,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			//log.Warn("eth/handler.go, handleMsg, pubKey valid, ", "msg", msg)
 		}
 		addr := crypto.PubkeyToAddress(*pubKey)
-		handled, err := handler.HandleMsg(addr, msg)
+		handled, err := handler.HandleMsg(addr, msg, p.ID())
 		if handled {
 			return err
 		}
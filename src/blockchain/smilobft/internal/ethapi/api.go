@@ -22,7 +22,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"go-smilo/src/blockchain/smilobft/private"
 	"math/big"
 	"strings"
 	"time"
@@ -56,6 +55,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/core/vm"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/tyler-smith/go-bip39"
@@ -412,10 +412,17 @@ func (s *PrivateAccountAPI) SendTransaction(ctx context.Context, args SendTxArgs
 	isPrivate := args.IsPrivate()
 
 	if isPrivate {
+		vault := s.b.Vault()
+		if vault == nil {
+			return common.Hash{}, fmt.Errorf("vault is not enabled")
+		}
 		data := []byte(*args.Data)
 		if len(data) > 0 {
+			if data, err = args.privatePayload(vault, data); err != nil {
+				return common.Hash{}, err
+			}
 			log.Info("sending private tx", "data", fmt.Sprintf("%x", data), "privatefrom", args.PrivateFrom, "privatefor", args.PrivateFor)
-			data, err = private.VaultInstance.Post(data, args.PrivateFrom, args.PrivateFor)
+			data, err = vault.Post(data, args.PrivateFrom, args.PrivateFor)
 			log.Info("sent private tx", "data", fmt.Sprintf("%x", data), "privatefrom", args.PrivateFrom, "privatefor", args.PrivateFor)
 			if err != nil {
 				return common.Hash{}, err
@@ -1459,6 +1466,8 @@ type SendTxArgs struct {
 	PrivateFor    []string `json:"privateFor"`
 	PrivateTxType string   `json:"restriction"`
 	//End-Quorum
+
+	PrivacyFlag private.PrivacyFlag `json:"privacyFlag"`
 }
 
 func (s SendTxArgs) IsPrivate() bool {
@@ -1566,20 +1575,35 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction, Is
 		return common.Hash{}, vm.ErrReadOnlyValueTransfer
 	}
 
-	if err := b.SendTx(ctx, tx); err != nil {
+	var signer types.Signer
+	if tx.IsPrivate() {
+		signer = types.QuorumPrivateTxSigner{}
+	} else {
+		signer = types.MakeSigner(b.ChainConfig(), b.CurrentBlock().Number())
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
 		return common.Hash{}, err
 	}
-	if tx.To() == nil {
-		var signer types.Signer
-		if tx.IsPrivate() {
-			signer = types.QuorumPrivateTxSigner{}
-		} else {
-			signer = types.MakeSigner(b.ChainConfig(), b.CurrentBlock().Number())
+	// Permission rules apply from the block the transaction goes into
+	if permissions := b.AccountPermissions(); permissions != nil && b.ChainConfig().IsQIP714(new(big.Int).Add(b.CurrentBlock().Number(), common.Big1)) {
+		if err := types.CheckAccountAccess(permissions.GetAcctAccess(from), tx.To()); err != nil {
+			return common.Hash{}, err
 		}
-		from, err := types.Sender(signer, tx)
-		if err != nil {
+		if err := permissions.CheckFunctionAccess(from, tx); err != nil {
 			return common.Hash{}, err
 		}
+		// the other nodes can't tell which node a transaction was sent through,
+		// the node of the organization is only checked on submission
+		if !permissions.ValidateNodeForTxn(b.LocalEnode(), from) {
+			return common.Hash{}, types.ErrNodeNotAllowed
+		}
+	}
+
+	if err := b.SendTx(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if tx.To() == nil {
 		addr := crypto.CreateAddress(from, tx.Nonce())
 		log.Info("Submitted contract creation", "fullhash", tx.Hash().Hex(), "contract", addr.Hex())
 	} else {
@@ -1612,7 +1636,7 @@ func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args Sen
 		if isPrivate && args.Value != nil && args.Value.ToInt().Sign() != 0 {
 			return common.Hash{}, vm.ErrReadOnlyValueTransfer
 		}
-		d, err := SendVaultTransactionWithExtraCheck(args)
+		d, err := SendVaultTransactionWithExtraCheck(s.b.Vault(), args)
 		if err != nil {
 			return common.Hash{}, err
 		}
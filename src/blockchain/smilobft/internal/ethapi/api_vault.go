@@ -28,20 +28,24 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
 	"go-smilo/src/blockchain/smilobft/private"
+	"go-smilo/src/blockchain/smilobft/rpc"
 )
 
 // Quorum
 //
 // Additional arguments in order to support transaction privacy
 type PrivateTxArgs struct {
-	PrivateFor []string `json:"privateFor"`
+	PrivateFor  []string            `json:"privateFor"`
+	PrivacyFlag private.PrivacyFlag `json:"privacyFlag"`
 }
 
 // SendRawTransactionVault will add the signed transaction to the Vault and to the transaction pool.
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (s *PublicTransactionPoolAPI) SendRawTransactionVault(ctx context.Context, encodedTx hexutil.Bytes, args PrivateTxArgs) (common.Hash, error) {
-	if private.VaultInstance == nil {
+	vault := s.b.Vault()
+	if vault == nil {
 		return common.Hash{}, fmt.Errorf("vault is not enabled")
 	}
 
@@ -54,9 +58,12 @@ func (s *PublicTransactionPoolAPI) SendRawTransactionVault(ctx context.Context,
 	IsPrivate := args.PrivateFor != nil
 
 	if IsPrivate {
+		if err := checkRawPrivacyFlag(vault, data, args.PrivacyFlag); err != nil {
+			return common.Hash{}, err
+		}
 		if len(data) > 0 {
 			log.Info("sending vault tx", "data", fmt.Sprintf("%x", data), "privatefrom", args.PrivateFor)
-			data, err := private.VaultInstance.PostRawTransaction(data, args.PrivateFor)
+			data, err := vault.PostRawTransaction(data, args.PrivateFor)
 			log.Info("sent vault tx", "data", fmt.Sprintf("%x", data), "privatefrom", args.PrivateFor)
 
 			if err != nil {
@@ -70,9 +77,37 @@ func (s *PublicTransactionPoolAPI) SendRawTransactionVault(ctx context.Context,
 	return SubmitTransaction(ctx, s.b, tx, IsPrivate)
 }
 
+// checkRawPrivacyFlag checks that the payload stored under key, before the raw
+// transaction was signed, was wrapped with the privacy flag asked for. The
+// node can't wrap the payload of a signed transaction itself.
+func checkRawPrivacyFlag(vault private.BlackboxVault, key []byte, flag private.PrivacyFlag) error {
+	if flag == private.PrivacyFlagStandard {
+		return nil
+	}
+	if err := checkPartiesVault(vault, flag); err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("privacy flag %d requires a private payload", flag)
+	}
+	payload, err := vault.Get(key)
+	if err != nil {
+		return err
+	}
+	meta, _, err := private.UnwrapPayload(payload)
+	if err != nil {
+		return err
+	}
+	if meta == nil || meta.Flag != flag {
+		return fmt.Errorf("private payload was not stored with privacy flag %d", flag)
+	}
+	return nil
+}
+
 // Get the Vault Transaction content
 func (s *PublicBlockChainAPI) GetVaultTransaction(digestHex string) (data string, err error) {
-	if private.VaultInstance == nil {
+	vault := s.b.Vault()
+	if vault == nil {
 		err = fmt.Errorf("vault is not enabled")
 		return data, err
 	}
@@ -94,10 +129,60 @@ func (s *PublicBlockChainAPI) GetVaultTransaction(digestHex string) (data string
 		return data, err
 	}
 	var responseData []byte
-	responseData, err = private.VaultInstance.Get(b)
+	responseData, err = vault.Get(b)
+	if err == private.ErrPayloadNotFound {
+		// Not being a party is reported as an empty payload
+		return "0x", nil
+	}
 	if err != nil {
 		return data, err
 	}
+	if _, responseData, err = private.UnwrapPayload(responseData); err != nil {
+		return data, err
+	}
 	data = fmt.Sprintf("0x%x", responseData)
 	return data, nil
 }
+
+// PublicVaultAPI exposes the state of the Blackbox vault of the node.
+type PublicVaultAPI struct {
+	b Backend
+}
+
+// NewPublicVaultAPI creates a new vault API.
+func NewPublicVaultAPI(b Backend) *PublicVaultAPI {
+	return &PublicVaultAPI{b}
+}
+
+// Health returns the health of the Blackbox node, as tracked by the
+// background monitor of the vault.
+func (s *PublicVaultAPI) Health() (*private.HealthStatus, error) {
+	vault := s.b.Vault()
+	if vault == nil {
+		return nil, fmt.Errorf("vault is not enabled")
+	}
+	reporter, ok := vault.(private.HealthReporter)
+	if !ok {
+		return nil, fmt.Errorf("vault backend %T does not report its health", vault)
+	}
+	status := reporter.Health()
+	return &status, nil
+}
+
+// PrivateStateHash returns the private state hash this node recorded for a
+// transaction sent in party protection mode. The hash digests the private
+// contracts touched by the transaction, every party must report the same.
+func (s *PublicVaultAPI) PrivateStateHash(ctx context.Context, txHash common.Hash) (common.Hash, error) {
+	tx, _, blockNumber, _, err := s.b.GetTransaction(ctx, txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if tx == nil {
+		return common.Hash{}, fmt.Errorf("transaction %x not found", txHash)
+	}
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+	if state == nil || err != nil {
+		return common.Hash{}, err
+	}
+	return vm.PrivateStateHash(state, txHash), nil
+}
@@ -40,6 +40,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/eth/downloader"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // Backend interface provides the common API services (that are provided by
@@ -80,6 +81,8 @@ type Backend interface {
 	GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error)
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	AccountPermissions() types.AccountPermissions
+	LocalEnode() string
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 
 	// Filter API
@@ -93,6 +96,9 @@ type Backend interface {
 	CurrentBlock() *types.Block
 	GetSolcPath() string
 	GetSmiloCodeAnalysisPath() string
+
+	// Vault API
+	Vault() private.BlackboxVault
 }
 
 func GetAPIs(apiBackend Backend) []rpc.API {
@@ -137,6 +143,11 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Version:   "1.0",
 			Service:   NewPrivateAccountAPI(apiBackend, nonceLock),
 			Public:    false,
+		}, {
+			Namespace: "vault",
+			Version:   "1.0",
+			Service:   NewPublicVaultAPI(apiBackend),
+			Public:    true,
 		},
 	}
 
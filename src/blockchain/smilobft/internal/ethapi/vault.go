@@ -30,13 +30,15 @@ import (
 
 	"go-smilo/src/blockchain/smilobft/rpc"
 
+	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/vm"
 	"go-smilo/src/blockchain/smilobft/private"
 )
 
 // GetSmiloPayload returns the contents of a private transaction
 func (s *PublicBlockChainAPI) GetSmiloPayload(digestHex string) (string, error) {
-	if private.VaultInstance == nil {
+	vault := s.b.Vault()
+	if vault == nil {
 		return "", fmt.Errorf("vault is not enabled")
 	}
 	if len(digestHex) < 3 {
@@ -52,8 +54,12 @@ func (s *PublicBlockChainAPI) GetSmiloPayload(digestHex string) (string, error)
 	if len(b) != 64 {
 		return "", fmt.Errorf("expected a Smilo digest of length 64, but got %d", len(b))
 	}
-	data, err := private.VaultInstance.Get(b)
-	if err != nil {
+	data, err := vault.Get(b)
+	// Not being a party is reported as an empty payload
+	if err != nil && err != private.ErrPayloadNotFound {
+		return "", err
+	}
+	if _, data, err = private.UnwrapPayload(data); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("0x%x", data), nil
@@ -74,9 +80,52 @@ func (s *PublicBlockChainAPI) GetSmiloPay(ctx context.Context, address common.Ad
 	return b, nil
 }
 
+// maxSmiloPayCurveBlocks caps the number of points returned by GetSmiloPayCurve.
+const maxSmiloPayCurveBlocks = 4096
+
+// SmiloPayCurve describes how the SmiloPay of a balance regenerates from zero.
+type SmiloPayCurve struct {
+	FixedPoint  bool            `json:"fixedPoint"`
+	Max         *hexutil.Big    `json:"max"`
+	BlocksToMax *hexutil.Uint64 `json:"blocksToMax,omitempty"`
+	Curve       []*hexutil.Big  `json:"curve"`
+}
+
+// GetSmiloPayCurve returns the SmiloPay regenerated by balance after 0 to blocks
+// blocks, starting from an empty SmiloPay, with the rules of the next block.
+// The curve stops once the maximum SmiloPay is reached, BlocksToMax is only set
+// if it is reached within the requested blocks.
+func (s *PublicBlockChainAPI) GetSmiloPayCurve(ctx context.Context, balance hexutil.Big, blocks hexutil.Uint64) (*SmiloPayCurve, error) {
+	if blocks > maxSmiloPayCurveBlocks {
+		return nil, fmt.Errorf("too many blocks requested, max %d", maxSmiloPayCurveBlocks)
+	}
+	var (
+		next      = new(big.Int).Add(s.b.CurrentBlock().Number(), common.Big1)
+		calculate = state.CalculateSmiloPay
+		max, _    = state.MaxSmiloPay(balance.ToInt())
+		result    = &SmiloPayCurve{FixedPoint: s.b.ChainConfig().IsSmiloPayFixedPoint(next)}
+	)
+	if result.FixedPoint {
+		calculate = state.CalculateSmiloPayFixedPoint
+		max = state.MaxSmiloPayFixedPoint(balance.ToInt())
+	}
+	result.Max = (*hexutil.Big)(max)
+
+	for i := uint64(0); i <= uint64(blocks); i++ {
+		smiloPay := calculate(common.Big0, new(big.Int).SetUint64(i), common.Big0, balance.ToInt())
+		result.Curve = append(result.Curve, (*hexutil.Big)(smiloPay))
+		if smiloPay.Cmp(max) >= 0 {
+			blocksToMax := hexutil.Uint64(i)
+			result.BlocksToMax = &blocksToMax
+			break
+		}
+	}
+	return result, nil
+}
+
 // SendVaultTransaction will POST data to local blackbox node if data is valid; used by PublicTransactionPoolAPI.SendTransaction
-func SendVaultTransactionWithExtraCheck(args SendTxArgs) (d hexutil.Bytes, err error) {
-	if private.VaultInstance == nil {
+func SendVaultTransactionWithExtraCheck(vault private.BlackboxVault, args SendTxArgs) (d hexutil.Bytes, err error) {
+	if vault == nil {
 		return d, fmt.Errorf("failed to get VaultInstance, is Vault node running ?? ")
 	} else if args.Value != nil && args.Value.ToInt().Sign() != 0 {
 		return d, vm.ErrReadOnlyValueTransfer
@@ -91,8 +140,11 @@ func SendVaultTransactionWithExtraCheck(args SendTxArgs) (d hexutil.Bytes, err e
 
 	//Send transaction Blackbox node
 	if len(data) > 0 {
+		if data, err = args.privatePayload(vault, data); err != nil {
+			return nil, err
+		}
 		log.Info("sending vault tx", "data", fmt.Sprintf("%x", data), "vaultfrom", args.PrivateFrom, "sharedwith", args.PrivateFor)
-		data, err = private.VaultInstance.Post(data, args.PrivateFrom, args.PrivateFor)
+		data, err = vault.Post(data, args.PrivateFrom, args.PrivateFor)
 		log.Info("sent vault tx", "data", fmt.Sprintf("%x", data), "vaultfrom", args.PrivateFrom, "sharedwith", args.PrivateFor)
 		if err != nil {
 			return nil, err
@@ -104,15 +156,18 @@ func SendVaultTransactionWithExtraCheck(args SendTxArgs) (d hexutil.Bytes, err e
 }
 
 // SendVaultTransaction will POST data to local blackbox node if data is valid; used by PublicTransactionPoolAPI.SendTransaction
-func SendVaultTransaction(args SendTxArgs) (d hexutil.Bytes, err error) {
+func SendVaultTransaction(vault private.BlackboxVault, args SendTxArgs) (d hexutil.Bytes, err error) {
 	if args.Value != nil && args.Value.ToInt().Sign() != 0 {
 		return d, vm.ErrReadOnlyValueTransfer
 	}
 
 	data := []byte(*args.Data)
 	if len(data) > 0 {
+		if data, err = args.privatePayload(vault, data); err != nil {
+			return nil, err
+		}
 		log.Info("sending vault tx", "data", fmt.Sprintf("%x", data), "PrivateFrom", args.PrivateFrom, "PrivateFor", args.PrivateFor)
-		data, err := private.VaultInstance.Post(data, args.PrivateFrom, args.PrivateFor)
+		data, err := vault.Post(data, args.PrivateFrom, args.PrivateFor)
 		log.Info("sent vault tx", "data", fmt.Sprintf("%x", data), "PrivateFrom", args.PrivateFrom, "PrivateFor", args.PrivateFor)
 		if err != nil {
 			return nil, err
@@ -123,3 +178,32 @@ func SendVaultTransaction(args SendTxArgs) (d hexutil.Bytes, err error) {
 
 	return d, nil
 }
+
+// privatePayload wraps the transaction data with the privacy flag asked for by
+// the arguments, before the data is posted to the vault.
+func (args *SendTxArgs) privatePayload(vault private.BlackboxVault, data []byte) ([]byte, error) {
+	if args.PrivacyFlag == private.PrivacyFlagStandard {
+		return data, nil
+	}
+	if err := checkPartiesVault(vault, args.PrivacyFlag); err != nil {
+		return nil, err
+	}
+	return private.WrapPayload(&private.PrivacyMetadata{Flag: args.PrivacyFlag}, data)
+}
+
+// checkPartiesVault refuses privacy flags the vault can't enforce. The
+// participants of a protected transaction are the parties the vault vouches
+// for, a vault unable to tell them would reject the transaction it sent.
+func checkPartiesVault(vault private.BlackboxVault, flag private.PrivacyFlag) error {
+	switch flag {
+	case private.PrivacyFlagStandard:
+		return nil
+	case private.PrivacyFlagPartyProtection:
+	default:
+		return private.ErrInvalidPrivacyFlag
+	}
+	if _, ok := vault.(private.PartiesReader); !ok {
+		return fmt.Errorf("privacy flag %d is not supported by vault backend %T: %v", flag, vault, private.ErrParticipantsUnverifiable)
+	}
+	return nil
+}
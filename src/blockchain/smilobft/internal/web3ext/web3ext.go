@@ -35,6 +35,7 @@ var Modules = map[string]string{
 	"les":              LESJs,
 	"smilobft":         SmiloBFTJS,
 	"istanbul":         Istanbul_JS,
+	"sport":            Sport_JS,
 	"sportdao":         SportDAO_JS,
 	"tendermint":       TendermintJs,
 	"quorumPermission": QUORUM_NODE_JS,
@@ -224,6 +225,10 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'permissionedNodes',
+			getter: 'admin_permissionedNodes'
+		}),
 	]
 });
 `
@@ -538,6 +543,12 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getSmiloPayCurve',
+			call: 'eth_getSmiloPayCurve',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
 		new web3._extend.Method({
 			name: 'storageRoot',
 			call: 'eth_storageRoot',
@@ -879,6 +890,35 @@ web3._extend({
 			name: 'discard',
 			call: 'smilobft_discard',
 			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'governanceFullnodes',
+			call: 'smilobft_governanceFullnodes',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'governanceProposals',
+			call: 'smilobft_governanceProposals',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'governanceHistory',
+			call: 'smilobft_governanceHistory',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getRewardSchedule',
+			call: 'smilobft_getRewardSchedule',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getIssuance',
+			call: 'smilobft_getIssuance',
+			params: 1,
+			inputFormatter: [null]
 		})
 	],
 	properties:
@@ -1010,6 +1050,24 @@ web3._extend({
                        params: 1,
                        inputFormatter: [null]
                }),
+               new web3._extend.Method({
+                       name: 'addFunctionRule',
+                       call: 'quorumPermission_addFunctionRule',
+                       params: 5,
+                       inputFormatter: [null,null,web3._extend.formatters.inputAddressFormatter,null,web3._extend.formatters.inputTransactionFormatter]
+               }),
+               new web3._extend.Method({
+                       name: 'auditLog',
+                       call: 'quorumPermission_auditLog',
+                       params: 1,
+                       inputFormatter: [null]
+               }),
+               new web3._extend.Method({
+                       name: 'removeFunctionRule',
+                       call: 'quorumPermission_removeFunctionRule',
+                       params: 5,
+                       inputFormatter: [null,null,web3._extend.formatters.inputAddressFormatter,null,web3._extend.formatters.inputTransactionFormatter]
+               }),
 
        ],
        properties:
@@ -1030,6 +1088,10 @@ web3._extend({
 					   name: 'acctList',
 				       getter: 'quorumPermission_acctList'
 			  }), 
+              new web3._extend.Property({
+					   name: 'functionRuleList',
+				       getter: 'quorumPermission_functionRuleList'
+			  }),
        ]
 })
 `
@@ -1072,6 +1134,11 @@ web3._extend({
 			name: 'getWhitelist',
 			call: 'istanbul_getWhitelist',
 			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'status',
+			call: 'istanbul_status',
+			params: 0
 		})
 	]
 });
@@ -1079,42 +1146,26 @@ web3._extend({
 
 const SportDAO_JS = `
 web3._extend({
-	property: 'istanbul',
+	property: 'sportdao',
 	methods:
 	[
 		new web3._extend.Method({
-			name: 'getValidators',
-			call: 'istanbul_getValidators',
-			params: 1
-		}),
-		new web3._extend.Method({
-			name: 'getValidatorsAtHash',
-			call: 'istanbul_getValidatorsAtHash',
-			params: 1
-		}),
-		new web3._extend.Method({
-			name: 'getSomaContractAddress',
-			call: 'istanbul_getSomaContractAddress',
-			params: 0
-		}),
-		new web3._extend.Method({
-			name: 'getSomaContractABI',
-			call: 'istanbul_getSomaContractABI',
-			params: 0
-		}),
-		new web3._extend.Method({
-			name: 'getGlienickeContractAddress',
-			call: 'istanbul_getGlienickeContractAddress',
-			params: 0
-		}),
-		new web3._extend.Method({
-			name: 'getGlienickeContractABI',
-			call: 'istanbul_getGlienickeContractABI',
+			name: 'status',
+			call: 'sportdao_status',
 			params: 0
-		}),
+		})
+	]
+});
+`
+
+const Sport_JS = `
+web3._extend({
+	property: 'sport',
+	methods:
+	[
 		new web3._extend.Method({
-			name: 'getWhitelist',
-			call: 'istanbul_getWhitelist',
+			name: 'status',
+			call: 'sport_status',
 			params: 0
 		})
 	]
@@ -1160,6 +1211,11 @@ web3._extend({
 			name: 'getWhitelist',
 			call: 'tendermint_getWhitelist',
 			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'status',
+			call: 'tendermint_status',
+			params: 0
 		})
 	]
 });
@@ -42,6 +42,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/ethdb"
 	"go-smilo/src/blockchain/smilobft/light"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 type LesApiBackend struct {
@@ -94,7 +95,7 @@ func (b *LesApiBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	return light.NewState(ctx, header, b.eth.odr), header, nil
+	return light.NewState(ctx, b.eth.chainConfig, header, b.eth.odr), header, nil
 }
 
 func (b *LesApiBackend) GetHeader(ctx context.Context, hash common.Hash) *types.Header {
@@ -127,6 +128,9 @@ func (b *LesApiBackend) GetEVM(ctx context.Context, msg core.Message, apiState v
 	statedb := apiState.(*state.StateDB)
 	statedb.SetBalance(msg.From(), math.MaxBig256, header.Number)
 	context := core.NewEVMContext(msg, header, b.eth.blockchain, nil)
+	if vmCfg.Vault == nil {
+		vmCfg.Vault = b.eth.vault
+	}
 	return vm.NewEVM(context, statedb, statedb, b.eth.chainConfig, vmCfg), statedb.Error, nil
 }
 
@@ -162,6 +166,16 @@ func (b *LesApiBackend) TxPoolContent() (map[common.Address]types.Transactions,
 	return b.eth.txPool.Content()
 }
 
+// AccountPermissions returns nil, light clients don't run the permission service
+func (b *LesApiBackend) AccountPermissions() types.AccountPermissions {
+	return nil
+}
+
+// LocalEnode returns an empty URL, the node of light clients is never checked
+func (b *LesApiBackend) LocalEnode() string {
+	return ""
+}
+
 func (b *LesApiBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return b.eth.txPool.SubscribeNewTxsEvent(ch)
 }
@@ -245,3 +259,7 @@ func (b *LesApiBackend) GetSmiloCodeAnalysisPath() string {
 	codeAnalysisPath := b.eth.config.SmiloCodeAnalysisPath
 	return codeAnalysisPath
 }
+
+func (b *LesApiBackend) Vault() private.BlackboxVault {
+	return b.eth.vault
+}
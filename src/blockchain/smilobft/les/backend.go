@@ -19,6 +19,7 @@ package les
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -50,6 +51,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/p2p"
 	"go-smilo/src/blockchain/smilobft/p2p/discv5"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/private"
 )
 
 type LightEthereum struct {
@@ -81,6 +83,8 @@ type LightEthereum struct {
 	networkId     uint64
 	netRPCService *ethapi.PublicNetAPI
 
+	vault private.BlackboxVault // Blackbox vault used to submit private transactions
+
 	wg sync.WaitGroup
 }
 
@@ -95,6 +99,11 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	}
 	log.Info("$$$ LES, Initialised chain configuration", "config", chainConfig)
 
+	vault, err := private.NewVault(&config.Vault)
+	if err != nil {
+		return nil, err
+	}
+
 	peers := newPeerSet()
 	quitSync := make(chan struct{})
 
@@ -114,6 +123,7 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 		networkId:      config.NetworkId,
 		bloomRequests:  make(chan chan *bloombits.Retrieval),
 		bloomIndexer:   eth.NewBloomIndexer(chainDb, params.BloomBitsBlocksClient, params.HelperTrieConfirmations),
+		vault:          vault,
 	}
 	leth.serverPool = newServerPool(chainDb, quitSync, &leth.wg, leth.config.UltraLightServers)
 	leth.retriever = newRetrieveManager(peers, leth.reqDist, leth.serverPool)
@@ -283,6 +293,9 @@ func (s *LightEthereum) Stop() error {
 	//s.engine.Close()
 
 	s.eventMux.Stop()
+	if closer, ok := s.vault.(io.Closer); ok && s.vault != private.VaultInstance {
+		closer.Close()
+	}
 
 	time.Sleep(time.Millisecond * 200)
 	s.chainDb.Close()
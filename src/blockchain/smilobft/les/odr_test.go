@@ -92,7 +92,7 @@ func odrAccounts(ctx context.Context, db ethdb.Database, config *params.ChainCon
 			st, err = state.New(header.Root, state.NewDatabase(db))
 		} else {
 			header := lc.GetHeaderByHash(bhash)
-			st = light.NewState(ctx, header, lc.Odr())
+			st = light.NewState(ctx, lc.Config(), header, lc.Odr())
 		}
 		if err == nil {
 			bal := st.GetBalance(addr)
@@ -137,7 +137,7 @@ func odrContractCall(ctx context.Context, db ethdb.Database, config *params.Chai
 			}
 		} else {
 			header := lc.GetHeaderByHash(bhash)
-			state := light.NewState(ctx, header, lc.Odr())
+			state := light.NewState(ctx, lc.Config(), header, lc.Odr())
 			state.SetBalance(bankAddr, math.MaxBig256, big.NewInt(0))
 			msg := callmsg{types.NewMessage(bankAddr, &testContractAddr, 0, new(big.Int), 100000, new(big.Int), data, false)}
 			context := core.NewEVMContext(msg, header, lc, nil)
@@ -33,6 +33,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"go-smilo/src/blockchain/smilobft/consensus"
+	"go-smilo/src/blockchain/smilobft/consensus/sport"
 	"go-smilo/src/blockchain/smilobft/core"
 	"go-smilo/src/blockchain/smilobft/core/rawdb"
 	"go-smilo/src/blockchain/smilobft/core/state"
@@ -471,8 +472,9 @@ func (lc *LightChain) Config() *params.ChainConfig { return lc.hc.Config() }
 // SyncCheckpoint fetches the checkpoint point block header according to
 // the checkpoint provided by the remote peer.
 //
-// Note if we are running the clique, fetches the last epoch snapshot header
-// which covered by checkpoint.
+// Note if we are running the clique or sport, fetches the last epoch snapshot
+// header which covered by checkpoint. The BFT engines declaring their validators
+// in every header (istanbul, sportdao, tendermint) can continue from any header.
 func (lc *LightChain) SyncCheckpoint(ctx context.Context, checkpoint *params.TrustedCheckpoint) bool {
 	// Ensure the remote checkpoint head is ahead of us
 	head := lc.CurrentHeader().Number.Uint64()
@@ -481,6 +483,13 @@ func (lc *LightChain) SyncCheckpoint(ctx context.Context, checkpoint *params.Tru
 	if clique := lc.hc.Config().Clique; clique != nil {
 		latest -= latest % clique.Epoch // epoch snapshot for clique
 	}
+	if lc.hc.Config().ConsensusEngine(new(big.Int).SetUint64(latest)) == params.SportEngine {
+		epoch := sport.DefaultConfig.Epoch
+		if cfg := lc.hc.Config().Sport; cfg != nil && cfg.Epoch != 0 {
+			epoch = cfg.Epoch
+		}
+		latest -= latest % epoch // epoch snapshot for sport
+	}
 	if head >= latest {
 		return true
 	}
@@ -147,7 +147,7 @@ func odrAccounts(ctx context.Context, db ethdb.Database, bc *core.BlockChain, lc
 	var st *state.StateDB
 	if bc == nil {
 		header := lc.GetHeaderByHash(bhash)
-		st = NewState(ctx, header, lc.Odr())
+		st = NewState(ctx, lc.Config(), header, lc.Odr())
 	} else {
 		header := bc.GetHeaderByHash(bhash)
 		st, _ = state.New(header.Root, state.NewDatabase(db))
@@ -186,7 +186,7 @@ func odrContractCall(ctx context.Context, db ethdb.Database, bc *core.BlockChain
 		if bc == nil {
 			chain = lc
 			header = lc.GetHeaderByHash(bhash)
-			st = NewState(ctx, header, lc.Odr())
+			st = NewState(ctx, lc.Config(), header, lc.Odr())
 		} else {
 			chain = bc
 			header = bc.GetHeaderByHash(bhash)
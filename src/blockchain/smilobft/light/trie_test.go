@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"go-smilo/src/blockchain/smilobft/core/rawdb"
@@ -58,6 +59,40 @@ func TestNodeIterator(t *testing.T) {
 	}
 }
 
+func TestNewStateSmiloPay(t *testing.T) {
+	var (
+		fulldb  = rawdb.NewMemoryDatabase()
+		lightdb = rawdb.NewMemoryDatabase()
+		config  = *params.TestChainConfig
+		gspec   = core.Genesis{Config: &config, Alloc: core.GenesisAlloc{testBankAddress: {Balance: testBankFunds}}}
+		genesis = gspec.MustCommit(fulldb)
+	)
+	config.SmiloPayFixedPointBlock = big.NewInt(2)
+	gspec.MustCommit(lightdb)
+	blockchain, _ := core.NewBlockChain(fulldb, nil, &config, ethash.NewFullFaker(), vm.Config{}, nil)
+	gchain, _ := core.GenerateChain(&config, genesis, ethash.NewFaker(), fulldb, 4, testChainGen)
+	if _, err := blockchain.InsertChain(gchain); err != nil {
+		t.Fatal(err)
+	}
+
+	// the light state calculates SmiloPay with the rules of the full node
+	ctx := context.Background()
+	odr := &testOdr{sdb: fulldb, ldb: lightdb, indexerConfig: TestClientIndexerConfig}
+	head := blockchain.CurrentHeader()
+	fullState, _, err := blockchain.StateAt(head.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lightState := NewState(ctx, &config, head, odr)
+	for _, number := range []int64{1, 1000, 100000} {
+		block := new(big.Int).Add(head.Number, big.NewInt(number))
+		want := fullState.GetSmiloPay(testBankAddress, block)
+		if have := lightState.GetSmiloPay(testBankAddress, block); have.Cmp(want) != 0 {
+			t.Errorf("block %v: SmiloPay mismatch: have %v, want %v", block, have, want)
+		}
+	}
+}
+
 func diffTries(t1, t2 state.Trie) error {
 	i1 := trie.NewIterator(t1.NodeIterator(nil))
 	i2 := trie.NewIterator(t2.NodeIterator(nil))
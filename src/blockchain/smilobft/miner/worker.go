@@ -37,7 +37,6 @@ import (
 	"go-smilo/src/blockchain/smilobft/core"
 	"go-smilo/src/blockchain/smilobft/core/state"
 	"go-smilo/src/blockchain/smilobft/core/types"
-	"go-smilo/src/blockchain/smilobft/core/vm"
 	"go-smilo/src/blockchain/smilobft/ethdb"
 	"go-smilo/src/blockchain/smilobft/params"
 )
@@ -677,6 +676,11 @@ func (env *Work) commitTransactions(mux *cmn.TypeMux, txs *types.TransactionsByP
 			log.Trace("Skipping account with hight nonce", "sender", from, "nonce", tx.Nonce())
 			txs.Pop()
 
+		case types.ErrReadOnlyAccount, types.ErrContractCreationNotAllowed, types.ErrFunctionNotAllowed:
+			// The permission contracts don't allow the sender to transact, skip the account
+			log.Trace("Skipping account without the permission to transact", "sender", from, "err", err)
+			txs.Pop()
+
 		case nil:
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
@@ -712,10 +716,14 @@ func (env *Work) commitTransactions(mux *cmn.TypeMux, txs *types.TransactionsByP
 }
 
 func (env *Work) commitTransaction(tx *types.Transaction, bc *core.BlockChain, coinbase common.Address, gp *core.GasPool) (error, []*types.Log) {
+	if err := bc.CheckTransactionPermission(env.header, env.state, tx); err != nil {
+		return err, nil
+	}
+
 	snap := env.state.Snapshot()
 	vaultSnap := env.privateState.Snapshot()
 
-	receipt, vaultReceipt, _, err := core.ApplyTransaction(env.chainConfig, bc, &coinbase, gp, env.state, env.privateState, env.header, tx, &env.header.GasUsed, vm.Config{})
+	receipt, vaultReceipt, _, err := core.ApplyTransaction(env.chainConfig, bc, &coinbase, gp, env.state, env.privateState, env.header, tx, &env.header.GasUsed, *bc.GetVMConfig())
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.privateState.RevertToSnapshot(vaultSnap)
@@ -304,6 +304,20 @@ func (api *PublicAdminAPI) NodeInfo() (*QuorumNodeInfo, error) {
 	}, nil
 }
 
+// PermissionedNodes retrieves the allowed and disallowed nodes of the node list
+// the connecting peers are checked against.
+func (api *PublicAdminAPI) PermissionedNodes() (*p2p.NodeListInfo, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	nodeList := server.NodeList()
+	if nodeList == nil {
+		return nil, ErrNodePermissionDisabled
+	}
+	return nodeList.Info(), nil
+}
+
 // Datadir retrieves the current data directory the node is using.
 func (api *PublicAdminAPI) Datadir() string {
 	return api.node.DataDir()
@@ -29,6 +29,8 @@ var (
 	ErrNodeRunning    = errors.New("node already running")
 	ErrServiceUnknown = errors.New("unknown service")
 
+	ErrNodePermissionDisabled = errors.New("node permissioning is disabled")
+
 	datadirInUseErrnos = map[uint]bool{11: true, 32: true, 35: true}
 )
 
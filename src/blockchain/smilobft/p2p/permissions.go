@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -14,62 +16,252 @@ import (
 const (
 	NODE_NAME_LENGTH    = 32
 	PERMISSIONED_CONFIG = "permissioned-nodes.json"
+	DISALLOWED_CONFIG   = "disallowed-nodes.json"
+
+	// nodeListReloadInterval is how often the files of the node list are
+	// checked for changes made on disk
+	nodeListReloadInterval = 3 * time.Second
 )
 
-// check if a given node is permissioned to connect to the change
-func IsNodePermissioned(nodeID string, currentNode string, datadir string, direction string) bool {
+// NodeListInfo is the content of the node list reported over RPC
+type NodeListInfo struct {
+	Allowed    []string `json:"allowed"`
+	Disallowed []string `json:"disallowed"`
+}
+
+// NodeList is the in-memory allowlist and denylist of a server with node
+// permissioning enabled. A node may connect if it is allowed and not
+// disallowed. The lists are loaded from the permissioned-nodes.json and
+// disallowed-nodes.json files of the data directory, reloaded when the files
+// change on disk and written back when they are updated, e.g. by the events of
+// the permission contracts.
+type NodeList struct {
+	mu         sync.RWMutex
+	allowed    *nodeFile
+	disallowed *nodeFile
+
+	changed chan struct{} // signals the server to drop the peers not permitted anymore
+}
 
-	var permissionedList []string
-	nodes := ParsePermissionedNodes(datadir)
-	for _, v := range nodes {
-		permissionedList = append(permissionedList, v.ID().String())
+// NewNodeList creates the node list of the given data directory
+func NewNodeList(datadir string) *NodeList {
+	l := &NodeList{
+		allowed:    newNodeFile(filepath.Join(datadir, PERMISSIONED_CONFIG)),
+		disallowed: newNodeFile(filepath.Join(datadir, DISALLOWED_CONFIG)),
+		changed:    make(chan struct{}, 1),
 	}
+	l.Reload()
+	return l
+}
+
+// IsPermitted tells whether the node with the given id may connect
+func (l *NodeList) IsPermitted(id enode.ID) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, allowed := l.allowed.nodes[id]
+	_, disallowed := l.disallowed.nodes[id]
+	return allowed && !disallowed
+}
 
-	log.Trace("isNodePermissioned", "permissionedList", permissionedList)
-	for _, v := range permissionedList {
-		if v == nodeID {
-			log.Debug("isNodePermissioned", "connection", direction, "nodename", nodeID[:NODE_NAME_LENGTH], "ALLOWED-BY", currentNode[:NODE_NAME_LENGTH])
-			return true
+// Reload reads the files changed on disk since they were last read
+func (l *NodeList) Reload() {
+	l.mu.Lock()
+	changed := false
+	for _, f := range []*nodeFile{l.allowed, l.disallowed} {
+		loaded, err := f.load()
+		if err != nil {
+			log.Error("Failed to load the node list", "file", f.path, "err", err)
+			continue
+		}
+		if loaded {
+			log.Info("Loaded the node list", "file", f.path, "nodes", len(f.nodes))
 		}
+		changed = changed || loaded
+	}
+	l.mu.Unlock()
+
+	if changed {
+		l.notify()
 	}
-	log.Warn("isNodePermissioned", "connection", direction, "nodename", nodeID[:NODE_NAME_LENGTH], "DENIED-BY", currentNode[:NODE_NAME_LENGTH])
-	return false
 }
 
-func ParsePermissionedNodes(DataDir string) []*enode.Node {
+// Allow adds a node to the allowlist
+func (l *NodeList) Allow(url string) error {
+	return l.update(l.allowed, url, true)
+}
 
-	log.Trace("parsePermissionedNodes", "DataDir", DataDir, "file", PERMISSIONED_CONFIG)
+// RemoveAllowed removes a node from the allowlist
+func (l *NodeList) RemoveAllowed(url string) error {
+	return l.update(l.allowed, url, false)
+}
 
-	path := filepath.Join(DataDir, PERMISSIONED_CONFIG)
-	if _, err := os.Stat(path); err != nil {
-		log.Error("Read Error for permissioned-nodes.json file. This is because 'permissioned' flag is specified but no permissioned-nodes.json file is present.", "err", err)
-		return nil
+// Disallow adds a node to the denylist
+func (l *NodeList) Disallow(url string) error {
+	return l.update(l.disallowed, url, true)
+}
+
+// RemoveDisallowed removes a node from the denylist
+func (l *NodeList) RemoveDisallowed(url string) error {
+	return l.update(l.disallowed, url, false)
+}
+
+// Info returns the urls of the allowed and disallowed nodes
+func (l *NodeList) Info() *NodeListInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return &NodeListInfo{
+		Allowed:    append([]string{}, l.allowed.urls...),
+		Disallowed: append([]string{}, l.disallowed.urls...),
 	}
-	// Load the nodes from the config file
-	blob, err := ioutil.ReadFile(path)
+}
+
+func (l *NodeList) update(f *nodeFile, url string, add bool) error {
+	node, err := enode.ParseV4(url)
 	if err != nil {
-		log.Error("parsePermissionedNodes: Failed to access nodes", "err", err)
-		return nil
+		return err
 	}
+	l.mu.Lock()
+	updated, err := f.update(url, node.ID(), add)
+	l.mu.Unlock()
 
-	nodelist := []string{}
-	if err := json.Unmarshal(blob, &nodelist); err != nil {
-		log.Error("parsePermissionedNodes: Failed to load nodes", "err", err)
-		return nil
+	if updated {
+		l.notify()
 	}
-	// Interpret the list as a discovery node array
-	var nodes []*enode.Node
-	for _, url := range nodelist {
+	return err
+}
+
+func (l *NodeList) notify() {
+	select {
+	case l.changed <- struct{}{}:
+	default:
+	}
+}
+
+// nodeFile is a list of enode urls stored as a json array
+type nodeFile struct {
+	path    string
+	urls    []string
+	nodes   map[enode.ID]*enode.Node
+	modTime time.Time
+	size    int64
+}
+
+func newNodeFile(path string) *nodeFile {
+	return &nodeFile{path: path, nodes: make(map[enode.ID]*enode.Node)}
+}
+
+// load reads the file if it changed since it was last read or written, it
+// reports whether the list was read again. A missing file is an empty list.
+func (f *nodeFile) load() (bool, error) {
+	info, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		if f.modTime.IsZero() && len(f.urls) == 0 {
+			return false, nil
+		}
+		f.modTime, f.size = time.Time{}, 0
+		f.set(nil)
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+	blob, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return false, err
+	}
+	var urls []string
+	if len(blob) > 0 {
+		if err := json.Unmarshal(blob, &urls); err != nil {
+			return false, err
+		}
+	}
+	f.modTime, f.size = info.ModTime(), info.Size()
+	f.set(urls)
+	return true, nil
+}
+
+func (f *nodeFile) set(urls []string) {
+	f.urls = urls
+	f.nodes = make(map[enode.ID]*enode.Node, len(urls))
+	for _, url := range urls {
 		if url == "" {
-			log.Error("parsePermissionedNodes: Node URL blank")
+			log.Error("Node URL blank", "file", f.path)
 			continue
 		}
 		node, err := enode.ParseV4(url)
 		if err != nil {
-			log.Error("parsePermissionedNodes: Node URL", "url", url, "err", err)
+			log.Error("Invalid node URL", "file", f.path, "url", url, "err", err)
 			continue
 		}
-		nodes = append(nodes, node)
+		f.nodes[node.ID()] = node
+	}
+}
+
+// update adds or removes the node with the given id and writes the file back,
+// it reports whether the list changed
+func (f *nodeFile) update(url string, id enode.ID, add bool) (bool, error) {
+	if _, ok := f.nodes[id]; ok == add {
+		return false, nil
+	}
+	var urls []string
+	for _, u := range f.urls {
+		if node, err := enode.ParseV4(u); err != nil || node.ID() != id {
+			urls = append(urls, u)
+		}
+	}
+	if add {
+		urls = append(urls, url)
+	}
+	if urls == nil {
+		urls = []string{}
+	}
+	blob, err := json.Marshal(urls)
+	if err != nil {
+		return false, err
+	}
+	f.set(urls)
+	if err := ioutil.WriteFile(f.path, blob, 0644); err != nil {
+		return true, err
+	}
+	if info, err := os.Stat(f.path); err == nil {
+		f.modTime, f.size = info.ModTime(), info.Size()
+	}
+	return true, nil
+}
+
+// NodeList returns the node list of the server, nil if node permissioning is
+// disabled or the server isn't running
+func (srv *Server) NodeList() *NodeList {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	return srv.nodeList
+}
+
+// nodeListLoop reloads the node list when its files change and drops the peers
+// the list doesn't permit anymore
+func (srv *Server) nodeListLoop() {
+	defer srv.loopWG.Done()
+
+	reload := time.NewTicker(nodeListReloadInterval)
+	defer reload.Stop()
+	for {
+		select {
+		case <-reload.C:
+			srv.nodeList.Reload()
+		case <-srv.nodeList.changed:
+			for _, p := range srv.Peers() {
+				if !srv.nodeList.IsPermitted(p.ID()) {
+					p.log.Warn("Disconnecting peer not permitted anymore")
+					p.Disconnect(DiscRequested)
+				}
+			}
+		case <-srv.quit:
+			return
+		}
 	}
-	return nodes
 }
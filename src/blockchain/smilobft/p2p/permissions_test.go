@@ -0,0 +1,152 @@
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+)
+
+func testNodeURL() string {
+	key := newkey()
+	return enode.NewV4(&key.PublicKey, net.ParseIP("127.0.0.1"), 30303, 0).URLv4()
+}
+
+func readNodeFile(t *testing.T, path string) []string {
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", path, err)
+	}
+	var urls []string
+	if err := json.Unmarshal(blob, &urls); err != nil {
+		t.Fatalf("could not decode %s: %v", path, err)
+	}
+	return urls
+}
+
+func writeNodeFile(t *testing.T, path string, urls []string) {
+	blob, _ := json.Marshal(urls)
+	if err := ioutil.WriteFile(path, blob, 0644); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
+
+func TestNodeListPermissions(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "nodelist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(datadir)
+
+	allowed, other := testNodeURL(), testNodeURL()
+	writeNodeFile(t, filepath.Join(datadir, PERMISSIONED_CONFIG), []string{allowed})
+
+	l := NewNodeList(datadir)
+	allowedID := enode.MustParseV4(allowed).ID()
+	otherID := enode.MustParseV4(other).ID()
+	if !l.IsPermitted(allowedID) {
+		t.Error("node of permissioned-nodes.json not permitted")
+	}
+	if l.IsPermitted(otherID) {
+		t.Error("unknown node permitted")
+	}
+
+	// disallowed nodes are denied even if they are allowed
+	if err := l.Disallow(allowed); err != nil {
+		t.Fatal(err)
+	}
+	if l.IsPermitted(allowedID) {
+		t.Error("disallowed node permitted")
+	}
+	if urls := readNodeFile(t, filepath.Join(datadir, DISALLOWED_CONFIG)); !reflect.DeepEqual(urls, []string{allowed}) {
+		t.Errorf("disallowed-nodes.json mismatch: got %v, want %v", urls, []string{allowed})
+	}
+	if err := l.RemoveDisallowed(allowed); err != nil {
+		t.Fatal(err)
+	}
+	if !l.IsPermitted(allowedID) {
+		t.Error("node removed from the denylist not permitted")
+	}
+
+	// updates are written back to permissioned-nodes.json
+	if err := l.Allow(other); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RemoveAllowed(allowed); err != nil {
+		t.Fatal(err)
+	}
+	if l.IsPermitted(allowedID) || !l.IsPermitted(otherID) {
+		t.Error("allowlist not updated")
+	}
+	if urls := readNodeFile(t, filepath.Join(datadir, PERMISSIONED_CONFIG)); !reflect.DeepEqual(urls, []string{other}) {
+		t.Errorf("permissioned-nodes.json mismatch: got %v, want %v", urls, []string{other})
+	}
+
+	info := l.Info()
+	if !reflect.DeepEqual(info.Allowed, []string{other}) || len(info.Disallowed) != 0 {
+		t.Errorf("node list info mismatch: got %+v", info)
+	}
+}
+
+func TestNodeListReload(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "nodelist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(datadir)
+
+	url := testNodeURL()
+	id := enode.MustParseV4(url).ID()
+	l := NewNodeList(datadir)
+	if l.IsPermitted(id) {
+		t.Fatal("node permitted without permissioned-nodes.json")
+	}
+	select {
+	case <-l.changed:
+		t.Fatal("change signaled for missing files")
+	default:
+	}
+
+	// a file written on disk is picked up by the next reload
+	path := filepath.Join(datadir, PERMISSIONED_CONFIG)
+	writeNodeFile(t, path, []string{url})
+	l.Reload()
+	if !l.IsPermitted(id) {
+		t.Fatal("node not permitted after the reload")
+	}
+	select {
+	case <-l.changed:
+	default:
+		t.Fatal("change not signaled after the reload")
+	}
+
+	// an unchanged file isn't read again
+	l.Reload()
+	select {
+	case <-l.changed:
+		t.Fatal("change signaled for an unchanged file")
+	default:
+	}
+
+	// emptying the file revokes the permissions
+	later := time.Now().Add(time.Second)
+	writeNodeFile(t, path, []string{})
+	os.Chtimes(path, later, later)
+	l.Reload()
+	if l.IsPermitted(id) {
+		t.Fatal("node permitted after it was removed from the file")
+	}
+
+	// a missing file is an empty list
+	os.Remove(path)
+	l.Reload()
+	if len(l.Info().Allowed) != 0 {
+		t.Fatalf("allowlist not empty after the file was removed: %v", l.Info().Allowed)
+	}
+}
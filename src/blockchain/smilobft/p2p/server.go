@@ -180,6 +180,8 @@ type Server struct {
 	lock    sync.Mutex // protects running
 	running bool
 
+	nodeList *NodeList // allowed and disallowed nodes, set if node permissioning is enabled
+
 	nodedb       *enode.DB
 	localnode    *enode.LocalNode
 	ntab         discoverTable
@@ -537,6 +539,10 @@ func (srv *Server) Start() (err error) {
 		srv.StaticNodes = nil
 		//srv.TrustedNodes = nil //-> breaks TestServerAtCap
 		dialer = newDialState(srv.localnode.ID(), nil, 0, &Config{NetRestrict: srv.Config.NetRestrict})
+
+		srv.nodeList = NewNodeList(srv.DataDir)
+		srv.loopWG.Add(1)
+		go srv.nodeListLoop()
 	}
 
 	//dialer := newDialState(srv.localnode.ID(), srv.ntab, dynPeers, &srv.Config)
@@ -1056,9 +1062,11 @@ func (srv *Server) setupConn(c *conn, flags connFlag, dialDest *enode.Node) erro
 			log.Trace("NodeID Permissioning", "Connection Direction", direction)
 		}
 
-		if !IsNodePermissioned(NodeID, currentNode, srv.DataDir, direction) {
+		if !srv.nodeList.IsPermitted(c.node.ID()) {
+			clog.Warn("isNodePermissioned", "connection", direction, "nodename", NodeID[:NODE_NAME_LENGTH], "DENIED-BY", currentNode[:NODE_NAME_LENGTH])
 			return nil
 		}
+		clog.Debug("isNodePermissioned", "connection", direction, "nodename", NodeID[:NODE_NAME_LENGTH], "ALLOWED-BY", currentNode[:NODE_NAME_LENGTH])
 	} else {
 		clog.Trace("Node Permissioning is Disabled.")
 	}
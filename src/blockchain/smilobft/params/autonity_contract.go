@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
 	"go-smilo/src/blockchain/smilobft/p2p/enode"
 )
 
@@ -52,8 +54,16 @@ type AutonityContractGenesis struct {
 	MinGasPrice uint64         `json:"minGasPrice" toml:",omitempty"`
 	Operator    common.Address `json:"operator" toml:",omitempty"`
 	Users       []User         `json:"users" toml:",omitempty"`
+	// Liveness parameters applied on deployment, the contract defaults are kept if LivenessWindow
+	// is 0. From the liveness fork block on, a validator that missed more than JailThreshold basis
+	// points of the blocks of a window of LivenessWindow blocks is jailed.
+	LivenessWindow uint64 `json:"livenessWindow,omitempty" toml:",omitempty"`
+	JailThreshold  uint64 `json:"jailThreshold,omitempty" toml:",omitempty"`
 }
 
+// livenessMethods are the methods of the contract the liveness tracking relies on
+var livenessMethods = []string{"recordSignatures", "setLivenessParameters"}
+
 func (ac *AutonityContractGenesis) AddDefault() *AutonityContractGenesis {
 	if len(ac.Bytecode) == 0 || len(ac.ABI) == 0 {
 		log.Info("Default Validator smart contract set")
@@ -97,6 +107,46 @@ func (ac *AutonityContractGenesis) Validate() error {
 			return err
 		}
 	}
+	if ac.JailThreshold > 10000 {
+		return errors.New("jail threshold is in basis points, it must not exceed 10000")
+	}
+	if ac.HasLivenessParameters() {
+		return ac.checkLivenessMethods()
+	}
+	return nil
+}
+
+// HasLivenessParameters returns whether the liveness window and the jail threshold
+// of the contract are set by the config
+func (ac *AutonityContractGenesis) HasLivenessParameters() bool {
+	return ac.LivenessWindow > 0
+}
+
+func (ac *AutonityContractGenesis) checkLivenessMethods() error {
+	contractABI, err := abi.JSON(strings.NewReader(ac.ABI))
+	if err != nil {
+		return err
+	}
+	for _, method := range livenessMethods {
+		if _, ok := contractABI.Methods[method]; !ok {
+			return fmt.Errorf("autonity contract has no %s method, liveness tracking needs a contract compiled from Autonity.sol", method)
+		}
+	}
+	return nil
+}
+
+// CheckLiveness verifies that the Autonity contract records the signers of the
+// blocks from the liveness fork block on.
+func (c *ChainConfig) CheckLiveness() error {
+	if c.LivenessBlock == nil {
+		return nil
+	}
+	if c.AutonityContractConfig == nil {
+		return fmt.Errorf("missing autonity contract configuration for the liveness fork at block %v", c.LivenessBlock)
+	}
+	if err := c.AutonityContractConfig.checkLivenessMethods(); err != nil {
+		return fmt.Errorf("invalid liveness fork at block %v: %v", c.LivenessBlock, err)
+	}
 	return nil
 }
 
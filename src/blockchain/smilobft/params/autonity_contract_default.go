@@ -5,7 +5,7 @@ import "github.com/ethereum/go-ethereum/common"
 var (
 	DefaultDeployer   = common.HexToAddress("0x1336000000000000000000000000000000000000")
 	DefaultGovernance = common.HexToAddress("0x1336000000000000000000000000000000000000")
-	DefaultBytecode   = "6080604052606460055560006009556000600a553480156200002057600080fd5b506040516200505538038062005055833981018060405262000046919081019062000a42565b8451865114801562000059575083518651145b801562000067575082518651145b1515620000ab576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620000a29062000c25565b60405180910390fd5b60008090505b8651811015620001eb57600073ffffffffffffffffffffffffffffffffffffffff168782815181101515620000e257fe5b9060200190602002015173ffffffffffffffffffffffffffffffffffffffff161415151562000148576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016200013f9062000be1565b60405180910390fd5b600085828151811015156200015957fe5b9060200190602002015160028111156200016f57fe5b9050600088838151811015156200018257fe5b906020019060200201519050620001db818985815181101515620001a257fe5b90602001906020020151848987815181101515620001bc57fe5b9060200190602002015162000281640100000000026401000000009004565b50508080600101915050620000b1565b5033600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555081600360006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555080600a8190555050505050505062000d91565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff1614151515620002f6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620002ed9062000be1565b60405180910390fd5b620003006200069a565b6080604051908101604052808673ffffffffffffffffffffffffffffffffffffffff1681526020018460028111156200033557fe5b81526020018381526020018581525090508060086000836000015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060208201518160000160146101000a81548160ff02191690836002811115620003f657fe5b021790555060408201518160010155606082015181600201908051906020019062000423929190620006e5565b50905050600160028111156200043557fe5b816020015160028111156200044657fe5b1415620004bd576007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050620005b8565b600280811115620004ca57fe5b81602001516002811115620004db57fe5b1415620005b7576000816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550505b5b620005dd82600454620006406401000000000262002cad179091906401000000009004565b60048190555060008160600151511415156200063957600181606001519080600181540180825580915050906001820390600052602060002001600090919290919091509080519060200190620006369291906200076c565b50505b5050505050565b600080828401905083811015151562000690576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620006879062000c03565b60405180910390fd5b8091505092915050565b608060405190810160405280600073ffffffffffffffffffffffffffffffffffffffff16815260200160006002811115620006d157fe5b815260200160008152602001606081525090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106200072857805160ff191683800117855562000759565b8280016001018555821562000759579182015b82811115620007585782518255916020019190600101906200073b565b5b509050620007689190620007f3565b5090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f10620007af57805160ff1916838001178555620007e0565b82800160010185558215620007e0579182015b82811115620007df578251825591602001919060010190620007c2565b5b509050620007ef9190620007f3565b5090565b6200081891905b8082111562000814576000816000905550600101620007fa565b5090565b90565b600062000829825162000d3d565b905092915050565b600082601f83011215156200084557600080fd5b81516200085c620008568262000c75565b62000c47565b915081818352602084019350602081019050838560208402820111156200088257600080fd5b60005b83811015620008b657816200089b88826200081b565b84526020840193506020830192505060018101905062000885565b5050505092915050565b600082601f8301121515620008d457600080fd5b8151620008eb620008e58262000c9e565b62000c47565b9150818183526020840193506020810190508360005b838110156200093557815186016200091a8882620009ce565b84526020840193506020830192505060018101905062000901565b5050505092915050565b600082601f83011215156200095357600080fd5b81516200096a620009648262000cc7565b62000c47565b915081818352602084019350602081019050838560208402820111156200099057600080fd5b60005b83811015620009c45781620009a9888262000a2c565b84526020840193506020830192505060018101905062000993565b5050505092915050565b600082601f8301121515620009e257600080fd5b8151620009f9620009f38262000cf0565b62000c47565b9150808252602083016020830185838301111562000a1657600080fd5b62000a2383828462000d5b565b50505092915050565b600062000a3a825162000d51565b905092915050565b60008060008060008060c0878903121562000a5c57600080fd5b600087015167ffffffffffffffff81111562000a7757600080fd5b62000a8589828a0162000831565b965050602087015167ffffffffffffffff81111562000aa357600080fd5b62000ab189828a01620008c0565b955050604087015167ffffffffffffffff81111562000acf57600080fd5b62000add89828a016200093f565b945050606087015167ffffffffffffffff81111562000afb57600080fd5b62000b0989828a016200093f565b935050608062000b1c89828a016200081b565b92505060a062000b2f89828a0162000a2c565b9150509295509295509295565b6000601982527f416464726573736573206d75737420626520646566696e6564000000000000006020830152604082019050919050565b6000601b82527f536166654d6174683a206164646974696f6e206f766572666c6f7700000000006020830152604082019050919050565b6000601c82527f496e636f727265637420636f6e7374727563746f7220706172616d73000000006020830152604082019050919050565b6000602082019050818103600083015262000bfc8162000b3c565b9050919050565b6000602082019050818103600083015262000c1e8162000b73565b9050919050565b6000602082019050818103600083015262000c408162000baa565b9050919050565b6000604051905081810181811067ffffffffffffffff8211171562000c6b57600080fd5b8060405250919050565b600067ffffffffffffffff82111562000c8d57600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000cb657600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000cdf57600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000d0857600080fd5b601f19601f8301169050602081019050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600062000d4a8262000d1d565b9050919050565b6000819050919050565b60005b8381101562000d7b57808201518184015260208101905062000d5e565b8381111562000d8b576000848401525b50505050565b6142b48062000da16000396000f3fe608060405260043610610138576000357c01000000000000000000000000000000000000000000000000000000009004806301736c351461013a57806310ea5d881461016357806318160ddd1461018e57806319fac8fd146101b957806327e06247146101f65780632801643d1461021f57806335aa2e441461024a57806337cef791146102875780635e30913f146102c45780639857518814610301578063a7b05df51461032a578063aaf2e5d814610367578063b68feb84146103a4578063b6992247146103cd578063b7ab4db5146103f8578063ca43c38f14610423578063d01f63f51461044c578063d0679d3414610477578063d249b31c146104b4578063d5f39488146104dd578063dfa6bd4614610508578063e221094f14610531578063f918379a1461055a578063fc0e3d9014610585575b005b34801561014657600080fd5b50610161600480360361015c9190810190613955565b6105b0565b005b34801561016f57600080fd5b5061017861068f565b604051610185919061406d565b60405180910390f35b34801561019a57600080fd5b506101a3610695565b6040516101b0919061406d565b60405180910390f35b3480156101c557600080fd5b506101e060048036036101db91908101906139f8565b61069f565b6040516101ed9190613eee565b60405180910390f35b34801561020257600080fd5b5061021d600480360361021891908101906138ee565b610988565b005b34801561022b57600080fd5b50610234610a67565b6040516102419190613deb565b60405180910390f35b34801561025657600080fd5b50610271600480360361026c91908101906139f8565b610a8d565b60405161027e9190613deb565b60405180910390f35b34801561029357600080fd5b506102ae60048036036102a99190810190613871565b610acb565b6040516102bb919061406d565b60405180910390f35b3480156102d057600080fd5b506102eb60048036036102e69190810190613871565b610b14565b6040516102f8919061406d565b60405180910390f35b34801561030d57600080fd5b5061032860048036036103239190810190613871565b610dc1565b005b34801561033657600080fd5b50610351600480360361034c91908101906139f8565b6113e3565b60405161035e9190613f09565b60405180910390f35b34801561037357600080fd5b5061038e60048036036103899190810190613871565b61149e565b60405161039b9190613eee565b60405180910390f35b3480156103b057600080fd5b506103cb60048036036103c6919081019061389a565b611538565b005b3480156103d957600080fd5b506103e2611617565b6040516103ef9190613eaa565b60405180910390f35b34801561040457600080fd5b5061040d6116a5565b60405161041a9190613eaa565b60405180910390f35b34801561042f57600080fd5b5061044a600480360361044591908101906139bc565b611733565b005b34801561045857600080fd5b50610461611b1b565b60405161046e9190613ecc565b60405180910390f35b34801561048357600080fd5b5061049e600480360361049991908101906139bc565b611c04565b6040516104ab9190613eee565b60405180910390f35b3480156104c057600080fd5b506104db60048036036104d691908101906139f8565b611c1b565b005b3480156104e957600080fd5b506104f2611cf0565b6040516104ff9190613deb565b60405180910390f35b34801561051457600080fd5b5061052f600480360361052a91908101906139bc565b611d16565b005b34801561053d57600080fd5b50610558600480360361055391908101906139f8565b61215c565b005b34801561056657600080fd5b5061056f6123cc565b60405161057c919061406d565b60405180910390f35b34801561059157600080fd5b5061059a6123d6565b6040516105a7919061406d565b60405180910390f35b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610643576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161063a9061404d565b60405180910390fd5b6106508483600286612681565b7f228a1437a402e19b16880154e2c1f2edc5600a20524c05d21f880e2efefe54ae8484604051610681929190613e2f565b60405180910390a150505050565b60055481565b6000600454905090565b600033600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515610714576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161070b9061400d565b60405180910390fd5b6001600281111561072157fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561077c57fe5b14806107ec575060028081111561078f57fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156107ea57fe5b145b151561082d576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016108249061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610901576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016108f89061400d565b60405180910390fd5b82600660003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507ffb621a017bb038be49d13b22e821cbca1b2f153f0a4933795e7a363aa47fdf883384604051610976929190613e2f565b60405180910390a16001915050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610a1b576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610a129061404d565b60405180910390fd5b610a288484600185612681565b7fd08cf8a1921ddc51bc560b9f60369fe04e20c696b01c7cf4e8a49c692ee83ed48483604051610a59929190613e2f565b60405180910390a150505050565b600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600081815481101515610a9c57fe5b906000526020600020016000915054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000600660008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b600081600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515610b89576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610b809061400d565b60405180910390fd5b60016002811115610b9657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115610bf157fe5b1480610c615750600280811115610c0457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115610c5f57fe5b145b1515610ca2576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610c999061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610d76576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610d6d9061400d565b60405180910390fd5b600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154915050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610e54576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610e4b9061404d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614151515610ec6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610ebd9061400d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610f9a576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610f9190613fad565b60405180910390fd5b6000600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209050600280811115610fe957fe5b8160000160149054906101000a900460ff16600281111561100657fe5b148061103957506001600281111561101a57fe5b8160000160149054906101000a900460ff16600281111561103757fe5b145b1561106e5761106d8160000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166007612a1d565b5b60028081111561107a57fe5b8160000160149054906101000a900460ff16600281111561109757fe5b14156110cd576110cc8160000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166000612a1d565b5b6000816002018054600181600116156101000203166002900490501415156112e05760008090505b6001805490508110156112de5761125960018281548110151561111457fe5b906000526020600020018054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156111b25780601f10611187576101008083540402835291602001916111b2565b820191906000526020600020905b81548152906001019060200180831161119557829003601f168201915b5050505050836002018054600181600116156101000203166002900480601f01602080910402602001604051908101604052809291908181526020018280546001816001161561010002031660029004801561124f5780601f106112245761010080835404028352916020019161124f565b820191906000526020600020905b81548152906001019060200180831161123257829003601f168201915b5050505050612b70565b156112d15760018080805490500381548110151561127357fe5b9060005260206000200160018281548110151561128c57fe5b9060005260206000200190805460018160011615610100020316600290046112b592919061351d565b5060018054809190600190036112cb91906135a4565b506112de565b80806001019150506110f5565b505b6112f98160010154600454612c6390919063ffffffff16565b600481905550600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600080820160006101000a81549073ffffffffffffffffffffffffffffffffffffffff02191690556000820160146101000a81549060ff0219169055600182016000905560028201600061139291906135d0565b50507f0a9b5000d97f68a05b3d86a812e2d8e403fc40244cff1942ccc94fb4b96757d9838260000160149054906101000a900460ff166040516113d6929190613e58565b60405180910390a1505050565b6001818154811015156113f257fe5b906000526020600020016000915090508054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156114965780601f1061146b57610100808354040283529160200191611496565b820191906000526020600020905b81548152906001019060200180831161147957829003601f168201915b505050505081565b60008173ffffffffffffffffffffffffffffffffffffffff16600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16149050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156115cb576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016115c29061404d565b60405180910390fd5b6115d88383600080612681565b7f9a3241a61899aa3b76752287aeacbe5298c70570fac9796bbf4716964d1a014783600060405161160a929190613e06565b60405180910390a1505050565b6060600780548060200260200160405190810160405280929190818152602001828054801561169b57602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019060010190808311611651575b5050505050905090565b6060600080548060200260200160405190810160405280929190818152602001828054801561172957602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190600101908083116116df575b5050505050905090565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156117c6576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016117bd9061404d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515611839576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016118309061400d565b60405180910390fd5b6001600281111561184657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156118a157fe5b148061191157506002808111156118b457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561190f57fe5b145b1515611952576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016119499061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515611a26576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611a1d9061400d565b60405180910390fd5b611a7b83600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154612cad90919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010181905550611ad683600454612cad90919063ffffffff16565b6004819055507f96a9a8981a322aeae183999165c1fa2610a0c066a01fe86ae3194afade9b49688484604051611b0d929190613e81565b60405180910390a150505050565b60606001805480602002602001604051908101604052809291908181526020016000905b82821015611bfb578382906000526020600020018054600181600116156101000203166002900480601f016020809104026020016040519081016040528092919081815260200182805460018160011615610100020316600290048015611be75780601f10611bbc57610100808354040283529160200191611be7565b820191906000526020600020905b815481529060010190602001808311611bca57829003601f168201915b505050505081526020019060010190611b3f565b50505050905090565b6000611c11338484612d04565b6001905092915050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515611cae576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611ca59061404d565b60405180910390fd5b81600a819055507fb58ce08a43dbde3538e0851b84afb70f6ffe3ecfbc4d8383e9e92d552f9b41bb82604051611ce4919061406d565b60405180910390a15050565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515611da9576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611da09061404d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515611e1c576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611e139061400d565b60405180910390fd5b60016002811115611e2957fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115611e8457fe5b1480611ef45750600280811115611e9757fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115611ef257fe5b145b1515611f35576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611f2c9061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612009576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016120009061400d565b60405180910390fd5b6120bc83606060405190810160405280602381526020017f52656465656d207374616b6520616d6f756e7420657863656564732062616c6181526020017f6e63650000000000000000000000000000000000000000000000000000000000815250600860008873ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015461339d9092919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001018190555061211783600454612c6390919063ffffffff16565b6004819055507f4258db2358b464608335ef14dc2734bb42b15a6d03279d5cf12cb066af068f9c848460405161214e929190613e81565b60405180910390a150505050565b338073ffffffffffffffffffffffffffffffffffffffff16600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156121ef576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016121e69061404d565b60405180910390fd5b813073ffffffffffffffffffffffffffffffffffffffff16311015151561224b576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161224290613fed565b60405180910390fd5b6000600780549050111515612295576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161228c90613f4d565b60405180910390fd5b60008090505b6007805490508110156123c7576000600860006007848154811015156122bd57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002090508060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166108fc61238d60045461237f8886600101546133fa90919063ffffffff16565b61346e90919063ffffffff16565b9081150290604051600060405180830381858888f193505050501580156123b8573d6000803e3d6000fd5b5050808060010191505061229b565b505050565b6000600a54905090565b600033600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff161415151561244b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016124429061400d565b60405180910390fd5b6001600281111561245857fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156124b357fe5b148061252357506002808111156124c657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561252157fe5b145b1515612564576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161255b9061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612638576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161262f9061400d565b60405180910390fd5b600860003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015491505090565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16141515156126f3576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016126ea90613f6d565b60405180910390fd5b6126fb613618565b6080604051908101604052808673ffffffffffffffffffffffffffffffffffffffff16815260200184600281111561272f57fe5b81526020018381526020018581525090508060086000836000015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060208201518160000160146101000a81548160ff021916908360028111156127ef57fe5b021790555060408201518160010155606082015181600201908051906020019061281a929190613662565b509050506001600281111561282b57fe5b8160200151600281111561283b57fe5b14156128b0576007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506129a8565b6002808111156128bc57fe5b816020015160028111156128cc57fe5b14156129a7576000816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550505b5b6129bd82600454612cad90919063ffffffff16565b6004819055506000816060015151141515612a1657600181606001519080600181540180825580915050906001820390600052602060002001600090919290919091509080519060200190612a139291906136e2565b50505b5050505050565b60008180549050111515612a3057600080fd5b60008090505b8180549050811015612b6b578273ffffffffffffffffffffffffffffffffffffffff168282815481101515612a6757fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415612b5e57816001838054905003815481101515612ac357fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168282815481101515612afc57fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555081805480919060019003612b589190613762565b50612b6b565b8080600101915050612a36565b505050565b6000816040516020018082805190602001908083835b602083101515612bab5780518252602082019150602081019050602083039250612b86565b6001836020036101000a03801982511681845116808217855250505050505090500191505060405160208183030381529060405280519060200120836040516020018082805190602001908083835b602083101515612c1f5780518252602082019150602081019050602083039250612bfa565b6001836020036101000a0380198251168184511680821785525050505050509050019150506040516020818303038152906040528051906020012014905092915050565b6000612ca583836040805190810160405280601e81526020017f536166654d6174683a207375627472616374696f6e206f766572666c6f77000081525061339d565b905092915050565b6000808284019050838110151515612cfa576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612cf190613f8d565b60405180910390fd5b8091505092915050565b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515612d77576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612d6e9061400d565b60405180910390fd5b60016002811115612d8457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115612ddf57fe5b1480612e4f5750600280811115612df257fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115612e4d57fe5b145b1515612e90576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612e879061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612f64576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612f5b9061400d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515612fd7576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612fce9061400d565b60405180910390fd5b60016002811115612fe457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561303f57fe5b14806130af575060028081111561305257fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156130ad57fe5b145b15156130f0576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016130e79061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515156131c4576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016131bb9061400d565b60405180910390fd5b613250836040805190810160405280601f81526020017f5472616e7366657220616d6f756e7420657863656564732062616c616e636500815250600860008973ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015461339d9092919063ffffffff16565b600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055506132eb83600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154612cad90919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055508373ffffffffffffffffffffffffffffffffffffffff168573ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8560405161338e919061406d565b60405180910390a35050505050565b600083831115829015156133e7576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016133de9190613f2b565b60405180910390fd5b5060008385039050809150509392505050565b60008083141561340d5760009050613468565b6000828402905082848281151561342057fe5b04141515613463576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161345a90613fcd565b60405180910390fd5b809150505b92915050565b60006134b083836040805190810160405280601a81526020017f536166654d6174683a206469766973696f6e206279207a65726f0000000000008152506134b8565b905092915050565b600080831182901515613501576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016134f89190613f2b565b60405180910390fd5b506000838581151561350f57fe5b049050809150509392505050565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106135565780548555613593565b8280016001018555821561359357600052602060002091601f016020900482015b82811115613592578254825591600101919060010190613577565b5b5090506135a0919061378e565b5090565b8154818355818111156135cb578183600052602060002091820191016135ca91906137b3565b5b505050565b50805460018160011615610100020316600290046000825580601f106135f65750613615565b601f016020900490600052602060002090810190613614919061378e565b5b50565b608060405190810160405280600073ffffffffffffffffffffffffffffffffffffffff1681526020016000600281111561364e57fe5b815260200160008152602001606081525090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106136a357805160ff19168380011785556136d1565b828001600101855582156136d1579182015b828111156136d05782518255916020019190600101906136b5565b5b5090506136de919061378e565b5090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f1061372357805160ff1916838001178555613751565b82800160010185558215613751579182015b82811115613750578251825591602001919060010190613735565b5b50905061375e919061378e565b5090565b81548183558181111561378957818360005260206000209182019101613788919061378e565b5b505050565b6137b091905b808211156137ac576000816000905550600101613794565b5090565b90565b6137dc91905b808211156137d857600081816137cf91906135d0565b506001016137b9565b5090565b90565b60006137eb823561419f565b905092915050565b60006137ff82356141b1565b905092915050565b600082601f830112151561381a57600080fd5b813561382d613828826140b5565b614088565b9150808252602083016020830185838301111561384957600080fd5b613854838284614227565b50505092915050565b600061386982356141c3565b905092915050565b60006020828403121561388357600080fd5b6000613891848285016137df565b91505092915050565b600080604083850312156138ad57600080fd5b60006138bb858286016137f3565b925050602083013567ffffffffffffffff8111156138d857600080fd5b6138e485828601613807565b9150509250929050565b60008060006060848603121561390357600080fd5b6000613911868287016137f3565b935050602084013567ffffffffffffffff81111561392e57600080fd5b61393a86828701613807565b925050604061394b8682870161385d565b9150509250925092565b60008060006060848603121561396a57600080fd5b6000613978868287016137f3565b93505060206139898682870161385d565b925050604084013567ffffffffffffffff8111156139a657600080fd5b6139b286828701613807565b9150509250925092565b600080604083850312156139cf57600080fd5b60006139dd858286016137df565b92505060206139ee8582860161385d565b9150509250929050565b600060208284031215613a0a57600080fd5b6000613a188482850161385d565b91505092915050565b613a2a816141cd565b82525050565b613a3981614141565b82525050565b6000613a4a826140fb565b808452602084019350613a5c836140e1565b60005b82811015613a8e57613a72868351613a30565b613a7b82614127565b9150602086019550600181019050613a5f565b50849250505092915050565b6000613aa582614106565b80845260208401935083602082028501613abe856140ee565b60005b84811015613af7578383038852613ad9838351613b6b565b9250613ae482614134565b9150602088019750600181019050613ac1565b508196508694505050505092915050565b613b1181614153565b82525050565b613b20816141df565b82525050565b613b2f816141f1565b82525050565b6000613b408261411c565b808452613b54816020860160208601614236565b613b5d81614269565b602085010191505092915050565b6000613b7682614111565b808452613b8a816020860160208601614236565b613b9381614269565b602085010191505092915050565b6000601b82527f7468657265206d75737420626520737461636b20686f6c6465727300000000006020830152604082019050919050565b6000601982527f416464726573736573206d75737420626520646566696e6564000000000000006020830152604082019050919050565b6000601b82527f536166654d6174683a206164646974696f6e206f766572666c6f7700000000006020830152604082019050919050565b6000601082527f75736572206d75737420657869737473000000000000000000000000000000006020830152604082019050919050565b6000602182527f536166654d6174683a206d756c7469706c69636174696f6e206f766572666c6f60208301527f77000000000000000000000000000000000000000000000000000000000000006040830152606082019050919050565b6000602a82527f6e6f7420656e6f7567682066756e647320746f20706572666f726d207265646960208301527f73747269627574696f6e000000000000000000000000000000000000000000006040830152606082019050919050565b6000601782527f61646472657373206d75737420626520646566696e65640000000000000000006020830152604082019050919050565b6000602082527f61646472657373206e6f7420616c6c6f77656420746f20757365207374616b656020830152604082019050919050565b6000601882527f43616c6c6572206973206e6f742061206f70657261746f7200000000000000006020830152604082019050919050565b613de581614195565b82525050565b6000602082019050613e006000830184613a30565b92915050565b6000604082019050613e1b6000830185613a21565b613e286020830184613b26565b9392505050565b6000604082019050613e446000830185613a21565b613e516020830184613ddc565b9392505050565b6000604082019050613e6d6000830185613a30565b613e7a6020830184613b17565b9392505050565b6000604082019050613e966000830185613a30565b613ea36020830184613ddc565b9392505050565b60006020820190508181036000830152613ec48184613a3f565b905092915050565b60006020820190508181036000830152613ee68184613a9a565b905092915050565b6000602082019050613f036000830184613b08565b92915050565b60006020820190508181036000830152613f238184613b6b565b905092915050565b60006020820190508181036000830152613f458184613b35565b905092915050565b60006020820190508181036000830152613f6681613ba1565b9050919050565b60006020820190508181036000830152613f8681613bd8565b9050919050565b60006020820190508181036000830152613fa681613c0f565b9050919050565b60006020820190508181036000830152613fc681613c46565b9050919050565b60006020820190508181036000830152613fe681613c7d565b9050919050565b6000602082019050818103600083015261400681613cda565b9050919050565b6000602082019050818103600083015261402681613d37565b9050919050565b6000602082019050818103600083015261404681613d6e565b9050919050565b6000602082019050818103600083015261406681613da5565b9050919050565b60006020820190506140826000830184613ddc565b92915050565b6000604051905081810181811067ffffffffffffffff821117156140ab57600080fd5b8060405250919050565b600067ffffffffffffffff8211156140cc57600080fd5b601f19601f8301169050602081019050919050565b6000602082019050919050565b6000602082019050919050565b600081519050919050565b600081519050919050565b600081519050919050565b600081519050919050565b6000602082019050919050565b6000602082019050919050565b600061414c82614175565b9050919050565b60008115159050919050565b600060038210151561416d57fe5b819050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b60006141aa82614175565b9050919050565b60006141bc82614175565b9050919050565b6000819050919050565b60006141d882614203565b9050919050565b60006141ea8261415f565b9050919050565b60006141fc82614195565b9050919050565b600061420e82614215565b9050919050565b600061422082614175565b9050919050565b82818337600083830152505050565b60005b83811015614254578082015181840152602081019050614239565b83811115614263576000848401525b50505050565b6000601f19601f830116905091905056fea265627a7a72305820874d5824df42264c7642afd70d92b1acbfb4856208efe85e03dbdf94b6585b4c6c6578706572696d656e74616cf50037"
+	DefaultBytecode   = "60806040526064600555615a86565b6000600a553480156200002057600080fd5b5060405162005aa638038062005aa6833981018060405262000046919081019062000a42565b8451865114801562000059575083518651145b801562000067575082518651145b1515620000ab576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620000a29062000c25565b60405180910390fd5b60008090505b8651811015620001eb57600073ffffffffffffffffffffffffffffffffffffffff168782815181101515620000e257fe5b9060200190602002015173ffffffffffffffffffffffffffffffffffffffff161415151562000148576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016200013f9062000be1565b60405180910390fd5b600085828151811015156200015957fe5b9060200190602002015160028111156200016f57fe5b9050600088838151811015156200018257fe5b906020019060200201519050620001db818985815181101515620001a257fe5b90602001906020020151848987815181101515620001bc57fe5b9060200190602002015162000281640100000000026401000000009004565b50508080600101915050620000b1565b5033600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555081600360006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555080600a8190555050505050505062000d91565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff1614151515620002f6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620002ed9062000be1565b60405180910390fd5b620003006200069a565b6080604051908101604052808673ffffffffffffffffffffffffffffffffffffffff1681526020018460028111156200033557fe5b81526020018381526020018581525090508060086000836000015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060208201518160000160146101000a81548160ff02191690836002811115620003f657fe5b021790555060408201518160010155606082015181600201908051906020019062000423929190620006e5565b50905050600160028111156200043557fe5b816020015160028111156200044657fe5b1415620004bd576007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050620005b8565b600280811115620004ca57fe5b81602001516002811115620004db57fe5b1415620005b7576000816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550505b5b620005dd82600454620006406401000000000262002cad179091906401000000009004565b60048190555060008160600151511415156200063957600181606001519080600181540180825580915050906001820390600052602060002001600090919290919091509080519060200190620006369291906200076c565b50505b5050505050565b600080828401905083811015151562000690576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401620006879062000c03565b60405180910390fd5b8091505092915050565b608060405190810160405280600073ffffffffffffffffffffffffffffffffffffffff16815260200160006002811115620006d157fe5b815260200160008152602001606081525090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106200072857805160ff191683800117855562000759565b8280016001018555821562000759579182015b82811115620007585782518255916020019190600101906200073b565b5b509050620007689190620007f3565b5090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f10620007af57805160ff1916838001178555620007e0565b82800160010185558215620007e0579182015b82811115620007df578251825591602001919060010190620007c2565b5b509050620007ef9190620007f3565b5090565b6200081891905b8082111562000814576000816000905550600101620007fa565b5090565b90565b600062000829825162000d3d565b905092915050565b600082601f83011215156200084557600080fd5b81516200085c620008568262000c75565b62000c47565b915081818352602084019350602081019050838560208402820111156200088257600080fd5b60005b83811015620008b657816200089b88826200081b565b84526020840193506020830192505060018101905062000885565b5050505092915050565b600082601f8301121515620008d457600080fd5b8151620008eb620008e58262000c9e565b62000c47565b9150818183526020840193506020810190508360005b838110156200093557815186016200091a8882620009ce565b84526020840193506020830192505060018101905062000901565b5050505092915050565b600082601f83011215156200095357600080fd5b81516200096a620009648262000cc7565b62000c47565b915081818352602084019350602081019050838560208402820111156200099057600080fd5b60005b83811015620009c45781620009a9888262000a2c565b84526020840193506020830192505060018101905062000993565b5050505092915050565b600082601f8301121515620009e257600080fd5b8151620009f9620009f38262000cf0565b62000c47565b9150808252602083016020830185838301111562000a1657600080fd5b62000a2383828462000d5b565b50505092915050565b600062000a3a825162000d51565b905092915050565b60008060008060008060c0878903121562000a5c57600080fd5b600087015167ffffffffffffffff81111562000a7757600080fd5b62000a8589828a0162000831565b965050602087015167ffffffffffffffff81111562000aa357600080fd5b62000ab189828a01620008c0565b955050604087015167ffffffffffffffff81111562000acf57600080fd5b62000add89828a016200093f565b945050606087015167ffffffffffffffff81111562000afb57600080fd5b62000b0989828a016200093f565b935050608062000b1c89828a016200081b565b92505060a062000b2f89828a0162000a2c565b9150509295509295509295565b6000601982527f416464726573736573206d75737420626520646566696e6564000000000000006020830152604082019050919050565b6000601b82527f536166654d6174683a206164646974696f6e206f766572666c6f7700000000006020830152604082019050919050565b6000601c82527f496e636f727265637420636f6e7374727563746f7220706172616d73000000006020830152604082019050919050565b6000602082019050818103600083015262000bfc8162000b3c565b9050919050565b6000602082019050818103600083015262000c1e8162000b73565b9050919050565b6000602082019050818103600083015262000c408162000baa565b9050919050565b6000604051905081810181811067ffffffffffffffff8211171562000c6b57600080fd5b8060405250919050565b600067ffffffffffffffff82111562000c8d57600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000cb657600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000cdf57600080fd5b602082029050602081019050919050565b600067ffffffffffffffff82111562000d0857600080fd5b601f19601f8301169050602081019050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600062000d4a8262000d1d565b9050919050565b6000819050919050565b60005b8381101562000d7b57808201518184015260208101905062000d5e565b8381111562000d8b576000848401525b50505050565b614ce58062000da16000396000f3fe60806040526142b456000000006000357c01000000000000000000000000000000000000000000000000000000009004806301736c351461013a57806310ea5d881461016357806318160ddd1461018e57806319fac8fd146101b957806327e06247146101f65780632801643d1461021f57806335aa2e441461024a57806337cef791146102875780635e30913f146102c45780639857518814610301578063a7b05df51461032a578063aaf2e5d814610367578063b68feb84146103a4578063b6992247146103cd578063b7ab4db5146103f8578063ca43c38f14610423578063d01f63f51461044c578063d0679d3414610477578063d249b31c146104b4578063d5f39488146104dd578063dfa6bd4614610508578063e221094f14610531578063f918379a1461055a578063fc0e3d9014610585575b005b34801561014657600080fd5b50610161600480360361015c9190810190613955565b6105b0565b005b34801561016f57600080fd5b5061017861068f565b604051610185919061406d565b60405180910390f35b34801561019a57600080fd5b506101a3610695565b6040516101b0919061406d565b60405180910390f35b3480156101c557600080fd5b506101e060048036036101db91908101906139f8565b61069f565b6040516101ed9190613eee565b60405180910390f35b34801561020257600080fd5b5061021d600480360361021891908101906138ee565b610988565b005b34801561022b57600080fd5b50610234610a67565b6040516102419190613deb565b60405180910390f35b34801561025657600080fd5b50610271600480360361026c91908101906139f8565b610a8d565b60405161027e9190613deb565b60405180910390f35b34801561029357600080fd5b506102ae60048036036102a99190810190613871565b610acb565b6040516102bb919061406d565b60405180910390f35b3480156102d057600080fd5b506102eb60048036036102e69190810190613871565b610b14565b6040516102f8919061406d565b60405180910390f35b34801561030d57600080fd5b5061032860048036036103239190810190613871565b610dc1565b005b34801561033657600080fd5b50610351600480360361034c91908101906139f8565b6113e3565b60405161035e9190613f09565b60405180910390f35b34801561037357600080fd5b5061038e60048036036103899190810190613871565b61149e565b60405161039b9190613eee565b60405180910390f35b3480156103b057600080fd5b506103cb60048036036103c6919081019061389a565b611538565b005b3480156103d957600080fd5b506103e2611617565b6040516103ef9190613eaa565b60405180910390f35b34801561040457600080fd5b5061040d6116a5565b60405161041a9190613eaa565b60405180910390f35b34801561042f57600080fd5b5061044a600480360361044591908101906139bc565b611733565b005b34801561045857600080fd5b50610461611b1b565b60405161046e9190613ecc565b60405180910390f35b34801561048357600080fd5b5061049e600480360361049991908101906139bc565b611c04565b6040516104ab9190613eee565b60405180910390f35b3480156104c057600080fd5b506104db60048036036104d691908101906139f8565b611c1b565b005b3480156104e957600080fd5b506104f2611cf0565b6040516104ff9190613deb565b60405180910390f35b34801561051457600080fd5b5061052f600480360361052a91908101906139bc565b611d16565b005b34801561053d57600080fd5b50610558600480360361055391908101906139f8565b61215c565b005b34801561056657600080fd5b5061056f6123cc565b60405161057c919061406d565b60405180910390f35b34801561059157600080fd5b5061059a6123d6565b6040516105a7919061406d565b60405180910390f35b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610643576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161063a9061404d565b60405180910390fd5b6106508483600286612681565b7f228a1437a402e19b16880154e2c1f2edc5600a20524c05d21f880e2efefe54ae8484604051610681929190613e2f565b60405180910390a150505050565b60055481565b6000600454905090565b600033600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515610714576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161070b9061400d565b60405180910390fd5b6001600281111561072157fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561077c57fe5b14806107ec575060028081111561078f57fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156107ea57fe5b145b151561082d576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016108249061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610901576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016108f89061400d565b60405180910390fd5b82600660003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507ffb621a017bb038be49d13b22e821cbca1b2f153f0a4933795e7a363aa47fdf883384604051610976929190613e2f565b60405180910390a16001915050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610a1b576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610a129061404d565b60405180910390fd5b610a288484600185612681565b7fd08cf8a1921ddc51bc560b9f60369fe04e20c696b01c7cf4e8a49c692ee83ed48483604051610a59929190613e2f565b60405180910390a150505050565b600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600081815481101515610a9c57fe5b906000526020600020016000915054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000600660008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b600081600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515610b89576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610b809061400d565b60405180910390fd5b60016002811115610b9657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115610bf157fe5b1480610c615750600280811115610c0457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115610c5f57fe5b145b1515610ca2576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610c999061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610d76576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610d6d9061400d565b60405180910390fd5b600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154915050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515610e54576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610e4b9061404d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614151515610ec6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610ebd9061400d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515610f9a576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610f9190613fad565b60405180910390fd5b6000600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209050600280811115610fe957fe5b8160000160149054906101000a900460ff16600281111561100657fe5b148061103957506001600281111561101a57fe5b8160000160149054906101000a900460ff16600281111561103757fe5b145b1561106e5761106d8160000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166007612a1d565b5b60028081111561107a57fe5b8160000160149054906101000a900460ff16600281111561109757fe5b14156110cd576110cc8160000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166000612a1d565b5b6000816002018054600181600116156101000203166002900490501415156112e05760008090505b6001805490508110156112de5761125960018281548110151561111457fe5b906000526020600020018054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156111b25780601f10611187576101008083540402835291602001916111b2565b820191906000526020600020905b81548152906001019060200180831161119557829003601f168201915b5050505050836002018054600181600116156101000203166002900480601f01602080910402602001604051908101604052809291908181526020018280546001816001161561010002031660029004801561124f5780601f106112245761010080835404028352916020019161124f565b820191906000526020600020905b81548152906001019060200180831161123257829003601f168201915b5050505050612b70565b156112d15760018080805490500381548110151561127357fe5b9060005260206000200160018281548110151561128c57fe5b9060005260206000200190805460018160011615610100020316600290046112b592919061351d565b5060018054809190600190036112cb91906135a4565b506112de565b80806001019150506110f5565b505b6112f98160010154600454612c6390919063ffffffff16565b600481905550600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600080820160006101000a81549073ffffffffffffffffffffffffffffffffffffffff02191690556000820160146101000a81549060ff0219169055600182016000905560028201600061139291906135d0565b50507f0a9b5000d97f68a05b3d86a812e2d8e403fc40244cff1942ccc94fb4b96757d9838260000160149054906101000a900460ff166040516113d6929190613e58565b60405180910390a1505050565b6001818154811015156113f257fe5b906000526020600020016000915090508054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156114965780601f1061146b57610100808354040283529160200191611496565b820191906000526020600020905b81548152906001019060200180831161147957829003601f168201915b505050505081565b60008173ffffffffffffffffffffffffffffffffffffffff16600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16149050919050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156115cb576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016115c29061404d565b60405180910390fd5b6115d88383600080612681565b7f9a3241a61899aa3b76752287aeacbe5298c70570fac9796bbf4716964d1a014783600060405161160a929190613e06565b60405180910390a1505050565b6060600780548060200260200160405190810160405280929190818152602001828054801561169b57602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019060010190808311611651575b5050505050905090565b6060600080548060200260200160405190810160405280929190818152602001828054801561172957602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190600101908083116116df575b5050505050905090565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156117c6576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016117bd9061404d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515611839576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016118309061400d565b60405180910390fd5b6001600281111561184657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156118a157fe5b148061191157506002808111156118b457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561190f57fe5b145b1515611952576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016119499061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515611a26576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611a1d9061400d565b60405180910390fd5b611a7b83600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154612cad90919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010181905550611ad683600454612cad90919063ffffffff16565b6004819055507f96a9a8981a322aeae183999165c1fa2610a0c066a01fe86ae3194afade9b49688484604051611b0d929190613e81565b60405180910390a150505050565b60606001805480602002602001604051908101604052809291908181526020016000905b82821015611bfb578382906000526020600020018054600181600116156101000203166002900480601f016020809104026020016040519081016040528092919081815260200182805460018160011615610100020316600290048015611be75780601f10611bbc57610100808354040283529160200191611be7565b820191906000526020600020905b815481529060010190602001808311611bca57829003601f168201915b505050505081526020019060010190611b3f565b50505050905090565b6000611c11338484612d04565b6001905092915050565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515611cae576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611ca59061404d565b60405180910390fd5b81600a819055507fb58ce08a43dbde3538e0851b84afb70f6ffe3ecfbc4d8383e9e92d552f9b41bb82604051611ce4919061406d565b60405180910390a15050565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b338073ffffffffffffffffffffffffffffffffffffffff16600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515611da9576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611da09061404d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515611e1c576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611e139061400d565b60405180910390fd5b60016002811115611e2957fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115611e8457fe5b1480611ef45750600280811115611e9757fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115611ef257fe5b145b1515611f35576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611f2c9061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612009576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016120009061400d565b60405180910390fd5b6120bc83606060405190810160405280602381526020017f52656465656d207374616b6520616d6f756e7420657863656564732062616c6181526020017f6e63650000000000000000000000000000000000000000000000000000000000815250600860008873ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015461339d9092919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001018190555061211783600454612c6390919063ffffffff16565b6004819055507f4258db2358b464608335ef14dc2734bb42b15a6d03279d5cf12cb066af068f9c848460405161214e929190613e81565b60405180910390a150505050565b338073ffffffffffffffffffffffffffffffffffffffff16600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415156121ef576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016121e69061404d565b60405180910390fd5b813073ffffffffffffffffffffffffffffffffffffffff16311015151561224b576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161224290613fed565b60405180910390fd5b6000600780549050111515612295576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161228c90613f4d565b60405180910390fd5b60008090505b6007805490508110156123c7576000600860006007848154811015156122bd57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002090508060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166108fc61238d60045461237f8886600101546133fa90919063ffffffff16565b61346e90919063ffffffff16565b9081150290604051600060405180830381858888f193505050501580156123b8573d6000803e3d6000fd5b5050808060010191505061229b565b505050565b6000600a54905090565b600033600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff161415151561244b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016124429061400d565b60405180910390fd5b6001600281111561245857fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156124b357fe5b148061252357506002808111156124c657fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561252157fe5b145b1515612564576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161255b9061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612638576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161262f9061400d565b60405180910390fd5b600860003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015491505090565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16141515156126f3576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016126ea90613f6d565b60405180910390fd5b6126fb613618565b6080604051908101604052808673ffffffffffffffffffffffffffffffffffffffff16815260200184600281111561272f57fe5b81526020018381526020018581525090508060086000836000015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060208201518160000160146101000a81548160ff021916908360028111156127ef57fe5b021790555060408201518160010155606082015181600201908051906020019061281a929190613662565b509050506001600281111561282b57fe5b8160200151600281111561283b57fe5b14156128b0576007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506129a8565b6002808111156128bc57fe5b816020015160028111156128cc57fe5b14156129a7576000816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506007816000015190806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550505b5b6129bd82600454612cad90919063ffffffff16565b6004819055506000816060015151141515612a1657600181606001519080600181540180825580915050906001820390600052602060002001600090919290919091509080519060200190612a139291906136e2565b50505b5050505050565b60008180549050111515612a3057600080fd5b60008090505b8180549050811015612b6b578273ffffffffffffffffffffffffffffffffffffffff168282815481101515612a6757fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff161415612b5e57816001838054905003815481101515612ac357fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168282815481101515612afc57fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555081805480919060019003612b589190613762565b50612b6b565b8080600101915050612a36565b505050565b6000816040516020018082805190602001908083835b602083101515612bab5780518252602082019150602081019050602083039250612b86565b6001836020036101000a03801982511681845116808217855250505050505090500191505060405160208183030381529060405280519060200120836040516020018082805190602001908083835b602083101515612c1f5780518252602082019150602081019050602083039250612bfa565b6001836020036101000a0380198251168184511680821785525050505050509050019150506040516020818303038152906040528051906020012014905092915050565b6000612ca583836040805190810160405280601e81526020017f536166654d6174683a207375627472616374696f6e206f766572666c6f77000081525061339d565b905092915050565b6000808284019050838110151515612cfa576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612cf190613f8d565b60405180910390fd5b8091505092915050565b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515612d77576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612d6e9061400d565b60405180910390fd5b60016002811115612d8457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115612ddf57fe5b1480612e4f5750600280811115612df257fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff166002811115612e4d57fe5b145b1515612e90576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612e879061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614151515612f64576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612f5b9061400d565b60405180910390fd5b82600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614151515612fd7576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401612fce9061400d565b60405180910390fd5b60016002811115612fe457fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff16600281111561303f57fe5b14806130af575060028081111561305257fe5b600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160149054906101000a900460ff1660028111156130ad57fe5b145b15156130f0576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016130e79061402d565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff16600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16141515156131c4576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016131bb9061400d565b60405180910390fd5b613250836040805190810160405280601f81526020017f5472616e7366657220616d6f756e7420657863656564732062616c616e636500815250600860008973ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015461339d9092919063ffffffff16565b600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055506132eb83600860008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154612cad90919063ffffffff16565b600860008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055508373ffffffffffffffffffffffffffffffffffffffff168573ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8560405161338e919061406d565b60405180910390a35050505050565b600083831115829015156133e7576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016133de9190613f2b565b60405180910390fd5b5060008385039050809150509392505050565b60008083141561340d5760009050613468565b6000828402905082848281151561342057fe5b04141515613463576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161345a90613fcd565b60405180910390fd5b809150505b92915050565b60006134b083836040805190810160405280601a81526020017f536166654d6174683a206469766973696f6e206279207a65726f0000000000008152506134b8565b905092915050565b600080831182901515613501576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016134f89190613f2b565b60405180910390fd5b506000838581151561350f57fe5b049050809150509392505050565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106135565780548555613593565b8280016001018555821561359357600052602060002091601f016020900482015b82811115613592578254825591600101919060010190613577565b5b5090506135a0919061378e565b5090565b8154818355818111156135cb578183600052602060002091820191016135ca91906137b3565b5b505050565b50805460018160011615610100020316600290046000825580601f106135f65750613615565b601f016020900490600052602060002090810190613614919061378e565b5b50565b608060405190810160405280600073ffffffffffffffffffffffffffffffffffffffff1681526020016000600281111561364e57fe5b815260200160008152602001606081525090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f106136a357805160ff19168380011785556136d1565b828001600101855582156136d1579182015b828111156136d05782518255916020019190600101906136b5565b5b5090506136de919061378e565b5090565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f1061372357805160ff1916838001178555613751565b82800160010185558215613751579182015b82811115613750578251825591602001919060010190613735565b5b50905061375e919061378e565b5090565b81548183558181111561378957818360005260206000209182019101613788919061378e565b5b505050565b6137b091905b808211156137ac576000816000905550600101613794565b5090565b90565b6137dc91905b808211156137d857600081816137cf91906135d0565b506001016137b9565b5090565b90565b60006137eb823561419f565b905092915050565b60006137ff82356141b1565b905092915050565b600082601f830112151561381a57600080fd5b813561382d613828826140b5565b614088565b9150808252602083016020830185838301111561384957600080fd5b613854838284614227565b50505092915050565b600061386982356141c3565b905092915050565b60006020828403121561388357600080fd5b6000613891848285016137df565b91505092915050565b600080604083850312156138ad57600080fd5b60006138bb858286016137f3565b925050602083013567ffffffffffffffff8111156138d857600080fd5b6138e485828601613807565b9150509250929050565b60008060006060848603121561390357600080fd5b6000613911868287016137f3565b935050602084013567ffffffffffffffff81111561392e57600080fd5b61393a86828701613807565b925050604061394b8682870161385d565b9150509250925092565b60008060006060848603121561396a57600080fd5b6000613978868287016137f3565b93505060206139898682870161385d565b925050604084013567ffffffffffffffff8111156139a657600080fd5b6139b286828701613807565b9150509250925092565b600080604083850312156139cf57600080fd5b60006139dd858286016137df565b92505060206139ee8582860161385d565b9150509250929050565b600060208284031215613a0a57600080fd5b6000613a188482850161385d565b91505092915050565b613a2a816141cd565b82525050565b613a3981614141565b82525050565b6000613a4a826140fb565b808452602084019350613a5c836140e1565b60005b82811015613a8e57613a72868351613a30565b613a7b82614127565b9150602086019550600181019050613a5f565b50849250505092915050565b6000613aa582614106565b80845260208401935083602082028501613abe856140ee565b60005b84811015613af7578383038852613ad9838351613b6b565b9250613ae482614134565b9150602088019750600181019050613ac1565b508196508694505050505092915050565b613b1181614153565b82525050565b613b20816141df565b82525050565b613b2f816141f1565b82525050565b6000613b408261411c565b808452613b54816020860160208601614236565b613b5d81614269565b602085010191505092915050565b6000613b7682614111565b808452613b8a816020860160208601614236565b613b9381614269565b602085010191505092915050565b6000601b82527f7468657265206d75737420626520737461636b20686f6c6465727300000000006020830152604082019050919050565b6000601982527f416464726573736573206d75737420626520646566696e6564000000000000006020830152604082019050919050565b6000601b82527f536166654d6174683a206164646974696f6e206f766572666c6f7700000000006020830152604082019050919050565b6000601082527f75736572206d75737420657869737473000000000000000000000000000000006020830152604082019050919050565b6000602182527f536166654d6174683a206d756c7469706c69636174696f6e206f766572666c6f60208301527f77000000000000000000000000000000000000000000000000000000000000006040830152606082019050919050565b6000602a82527f6e6f7420656e6f7567682066756e647320746f20706572666f726d207265646960208301527f73747269627574696f6e000000000000000000000000000000000000000000006040830152606082019050919050565b6000601782527f61646472657373206d75737420626520646566696e65640000000000000000006020830152604082019050919050565b6000602082527f61646472657373206e6f7420616c6c6f77656420746f20757365207374616b656020830152604082019050919050565b6000601882527f43616c6c6572206973206e6f742061206f70657261746f7200000000000000006020830152604082019050919050565b613de581614195565b82525050565b6000602082019050613e006000830184613a30565b92915050565b6000604082019050613e1b6000830185613a21565b613e286020830184613b26565b9392505050565b6000604082019050613e446000830185613a21565b613e516020830184613ddc565b9392505050565b6000604082019050613e6d6000830185613a30565b613e7a6020830184613b17565b9392505050565b6000604082019050613e966000830185613a30565b613ea36020830184613ddc565b9392505050565b60006020820190508181036000830152613ec48184613a3f565b905092915050565b60006020820190508181036000830152613ee68184613a9a565b905092915050565b6000602082019050613f036000830184613b08565b92915050565b60006020820190508181036000830152613f238184613b6b565b905092915050565b60006020820190508181036000830152613f458184613b35565b905092915050565b60006020820190508181036000830152613f6681613ba1565b9050919050565b60006020820190508181036000830152613f8681613bd8565b9050919050565b60006020820190508181036000830152613fa681613c0f565b9050919050565b60006020820190508181036000830152613fc681613c46565b9050919050565b60006020820190508181036000830152613fe681613c7d565b9050919050565b6000602082019050818103600083015261400681613cda565b9050919050565b6000602082019050818103600083015261402681613d37565b9050919050565b6000602082019050818103600083015261404681613d6e565b9050919050565b6000602082019050818103600083015261406681613da5565b9050919050565b60006020820190506140826000830184613ddc565b92915050565b6000604051905081810181811067ffffffffffffffff821117156140ab57600080fd5b8060405250919050565b600067ffffffffffffffff8211156140cc57600080fd5b601f19601f8301169050602081019050919050565b6000602082019050919050565b6000602082019050919050565b600081519050919050565b600081519050919050565b600081519050919050565b600081519050919050565b6000602082019050919050565b6000602082019050919050565b600061414c82614175565b9050919050565b60008115159050919050565b600060038210151561416d57fe5b819050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b60006141aa82614175565b9050919050565b60006141bc82614175565b9050919050565b6000819050919050565b60006141d882614203565b9050919050565b60006141ea8261415f565b9050919050565b60006141fc82614195565b9050919050565b600061420e82614215565b9050919050565b600061422082614175565b9050919050565b82818337600083830152505050565b60005b83811015614254578082015181840152602081019050614239565b83811115614263576000848401525b50505050565b6000601f19601f830116905091905056fea265627a7a72305820874d5824df42264c7642afd70d92b1acbfb4856208efe85e03dbdf94b6585b4c6c6578706572696d656e74616cf500375b60043610610138576000357c01000000000000000000000000000000000000000000000000000000009004806313baa0691461447d57806315c978871461448a578063de833b50146144975780637043e5dd146144a4578063eb5304a2146144dd578063c2c38c79146145405780635887f763146146c0578063f679d305146147f75780631af6fd8a146148ad57806301736c351461013a57806310ea5d881461016357806318160ddd1461018e57806319fac8fd146101b957806327e06247146101f65780632801643d1461021f57806335aa2e441461024a57806337cef791146102875780635e30913f146102c4578063985751881461444f578063a7b05df51461032a578063aaf2e5d814610367578063b68feb84146103a4578063b6992247146103cd578063b7ab4db5146103f8578063ca43c38f14610423578063d01f63f51461044c578063d0679d3414610477578063d249b31c146104b4578063d5f39488146104dd578063dfa6bd4614610508578063e221094f14610531578063f918379a1461055a578063fc0e3d901461058557610138565b600060043573ffffffffffffffffffffffffffffffffffffffff166000526012602052604060002055610301565b346149fc57600b546149f1565b346149fc57600d546149f1565b346149fc57600e546149f1565b346149fc57602436106149fc5760043573ffffffffffffffffffffffffffffffffffffffff1660005260126020526040600020546149f1565b346149fc57602436106149fc5760043573ffffffffffffffffffffffffffffffffffffffff168060005260106020526040600020548160005260116020526040600020548260005260126020526040600020546040526020526000525060606000f35b346149fc57604436106149fc5760025473ffffffffffffffffffffffffffffffffffffffff16331415614a015760043573ffffffffffffffffffffffffffffffffffffffff1660243580600052600c602052604060002080546149fa578260005260086020526040600020805474010000000000000000000000000000000000000000900460ff16600214156149fa576001825580600101546145e581600b54614913565b61271090048015156145f45750805b6145fe8282614944565b80846001015561461060045483614944565b6004558660005281602052856040527fb32dcdc95482c3f3fe0874475e1b6295d84bcbe68c239a62ef614e3392e1dd8660606000a18015156149fa57614657876000614950565b83547fffffffffffffffffffffff00ffffffffffffffffffffffffffffffffffffffff1674010000000000000000000000000000000000000000178455866000527faab9a5274cbd52414fab1e87c642323300d336fb37ed694c5a6988b2d8142b2d60206000a1005b346149fc57604436106149fc5760025473ffffffffffffffffffffffffffffffffffffffff16331415614a01576146fd60043560040160106149a3565b61470d60243560040160116149a3565b61471943600f54614944565b600d5490106149fa5743600f556000545b80156149fa5760019003806000600052602060002001548060005260116020526040600020805460008255905081600052601060205260406000208054600082559050600160005411156147ef5761478482612710614913565b61478e8383614934565b61479a90600e54614913565b10156147ef576147ab836000614950565b60018360005260126020526040600020558260005281602052806040527f4c6b901264edf15717b11dca79f7976091f58685915aa60b6c9e705b6ba1e9fa60606000a15b50505061472a565b346149fc573360005260126020526040600020805415614a595733600052600860205260406000205474010000000000000000000000000000000000000000900460ff1660021415614ab1576000905560003360005260116020526040600020556000336000526010602052604060002055600054806001016000553390600060005260206000200155336000527fc3ef55ddda4bc9300706e15ab3aed03c762d8afd43a7d358a7b9503cb39f281b60206000a1005b346149fc57604436106149fc5760035473ffffffffffffffffffffffffffffffffffffffff16331460025473ffffffffffffffffffffffffffffffffffffffff1633141715614a01576004358015614b09576024358061271010614b6157600e55600d55005b811561492d5781810280839004821415614bb95791505090565b5050600090565b818101828110614c355791505090565b818111614c8d57900390565b805480156149fc5781600052602060002060005b8281101561499c57808201805486146149805750600101614964565b6001840383018054825560009055600184038555505050505050565b5050505050565b813560005b818110156149eb578060200284016020013573ffffffffffffffffffffffffffffffffffffffff16600052826020526040600020805460010190556001016149a8565b50505050565b60005260206000f35b005b600080fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260186024527f43616c6c6572206973206e6f742061206f70657261746f72000000000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260176024527f76616c696461746f72206973206e6f74206a61696c656400000000000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601a6024527f61646472657373206973206e6f7420612076616c696461746f7200000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260176024527f77696e646f77206d75737420626520706f73697469766500000000000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601c6024527f7468726573686f6c6420697320696e20626173697320706f696e74730000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260216024527f536166654d6174683a206d756c7469706c69636174696f6e206f766572666c6f6044527f770000000000000000000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601b6024527f536166654d6174683a206164646974696f6e206f766572666c6f77000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601e6024527f536166654d6174683a207375627472616374696f6e206f766572666c6f77000060445260646000fd5b60006009556103e8600b556103e8600d55611388600e5543600f5561000e56"
 	DefaultABI        = `[
     {
       "constant": true,
@@ -598,6 +598,225 @@ var (
       "payable": false,
       "stateMutability": "view",
       "type": "function"
+    },
+    {
+      "constant": true,
+      "inputs": [],
+      "name": "slashingRate",
+      "outputs": [
+        {
+          "name": "",
+          "type": "uint256"
+        }
+      ],
+      "payable": false,
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "constant": true,
+      "inputs": [],
+      "name": "livenessWindow",
+      "outputs": [
+        {
+          "name": "",
+          "type": "uint256"
+        }
+      ],
+      "payable": false,
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "constant": true,
+      "inputs": [],
+      "name": "jailThreshold",
+      "outputs": [
+        {
+          "name": "",
+          "type": "uint256"
+        }
+      ],
+      "payable": false,
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "constant": true,
+      "inputs": [
+        {
+          "name": "",
+          "type": "address"
+        }
+      ],
+      "name": "jailed",
+      "outputs": [
+        {
+          "name": "",
+          "type": "bool"
+        }
+      ],
+      "payable": false,
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "anonymous": false,
+      "inputs": [
+        {
+          "indexed": false,
+          "name": "_address",
+          "type": "address"
+        },
+        {
+          "indexed": false,
+          "name": "_amount",
+          "type": "uint256"
+        },
+        {
+          "indexed": false,
+          "name": "_evidence",
+          "type": "bytes32"
+        }
+      ],
+      "name": "Slash",
+      "type": "event"
+    },
+    {
+      "anonymous": false,
+      "inputs": [
+        {
+          "indexed": false,
+          "name": "_address",
+          "type": "address"
+        }
+      ],
+      "name": "EjectValidator",
+      "type": "event"
+    },
+    {
+      "anonymous": false,
+      "inputs": [
+        {
+          "indexed": false,
+          "name": "_address",
+          "type": "address"
+        },
+        {
+          "indexed": false,
+          "name": "_missed",
+          "type": "uint256"
+        },
+        {
+          "indexed": false,
+          "name": "_signed",
+          "type": "uint256"
+        }
+      ],
+      "name": "Jail",
+      "type": "event"
+    },
+    {
+      "anonymous": false,
+      "inputs": [
+        {
+          "indexed": false,
+          "name": "_address",
+          "type": "address"
+        }
+      ],
+      "name": "Unjail",
+      "type": "event"
+    },
+    {
+      "constant": false,
+      "inputs": [
+        {
+          "name": "_offender",
+          "type": "address"
+        },
+        {
+          "name": "_evidence",
+          "type": "bytes32"
+        }
+      ],
+      "name": "slash",
+      "outputs": [],
+      "payable": false,
+      "stateMutability": "nonpayable",
+      "type": "function"
+    },
+    {
+      "constant": false,
+      "inputs": [
+        {
+          "name": "_signers",
+          "type": "address[]"
+        },
+        {
+          "name": "_missed",
+          "type": "address[]"
+        }
+      ],
+      "name": "recordSignatures",
+      "outputs": [],
+      "payable": false,
+      "stateMutability": "nonpayable",
+      "type": "function"
+    },
+    {
+      "constant": false,
+      "inputs": [],
+      "name": "unjail",
+      "outputs": [],
+      "payable": false,
+      "stateMutability": "nonpayable",
+      "type": "function"
+    },
+    {
+      "constant": false,
+      "inputs": [
+        {
+          "name": "_window",
+          "type": "uint256"
+        },
+        {
+          "name": "_threshold",
+          "type": "uint256"
+        }
+      ],
+      "name": "setLivenessParameters",
+      "outputs": [],
+      "payable": false,
+      "stateMutability": "nonpayable",
+      "type": "function"
+    },
+    {
+      "constant": true,
+      "inputs": [
+        {
+          "name": "_account",
+          "type": "address"
+        }
+      ],
+      "name": "getLiveness",
+      "outputs": [
+        {
+          "name": "signed",
+          "type": "uint256"
+        },
+        {
+          "name": "missed",
+          "type": "uint256"
+        },
+        {
+          "name": "isJailed",
+          "type": "bool"
+        }
+      ],
+      "payable": false,
+      "stateMutability": "view",
+      "type": "function"
     }
   ]`
 )
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"math/big"
 	"net"
 	"reflect"
 	"testing"
@@ -158,3 +159,51 @@ func TestUsePartOfEnodeAsAddress(t *testing.T) {
 	}
 
 }
+
+func TestValidateAutonityContract_Liveness(t *testing.T) {
+	contractConfig := AutonityContractGenesis{
+		Deployer:       common.HexToAddress("0xff"),
+		Operator:       common.HexToAddress("0xff"),
+		Bytecode:       "some code",
+		ABI:            `[{"type":"function","name":"getValidators","inputs":[],"outputs":[{"name":"","type":"address[]"}]}]`,
+		LivenessWindow: 1000,
+		JailThreshold:  5000,
+	}
+	if err := contractConfig.Validate(); err == nil {
+		t.Fatal("contract without liveness tracking accepted")
+	}
+
+	contractConfig.ABI = DefaultABI
+	if err := contractConfig.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	contractConfig.JailThreshold = 10001
+	if err := contractConfig.Validate(); err == nil {
+		t.Fatal("jail threshold above 10000 basis points accepted")
+	}
+}
+
+func TestCheckLiveness(t *testing.T) {
+	config := &ChainConfig{}
+	if err := config.CheckLiveness(); err != nil {
+		t.Fatalf("chain without liveness fork rejected: %v", err)
+	}
+
+	config.LivenessBlock = big.NewInt(10)
+	if err := config.CheckLiveness(); err == nil {
+		t.Fatal("liveness fork without autonity contract accepted")
+	}
+
+	config.AutonityContractConfig = &AutonityContractGenesis{
+		ABI: `[{"type":"function","name":"getValidators","inputs":[],"outputs":[{"name":"","type":"address[]"}]}]`,
+	}
+	if err := config.CheckLiveness(); err == nil {
+		t.Fatal("liveness fork with a contract lacking the liveness methods accepted")
+	}
+
+	config.AutonityContractConfig.ABI = DefaultABI
+	if err := config.CheckLiveness(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -270,19 +270,19 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(20080914), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, false, true, false, 0, 32, nil, nil, nil, nil, nil, big.NewInt(0)}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(20080914), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, false, true, false, 0, 32, nil, nil, nil, nil, nil, nil, big.NewInt(0), nil, nil, nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil, false, false, false, 0, 32, nil, nil, nil, nil, nil, big.NewInt(0)}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil, false, false, false, 0, 32, nil, nil, nil, nil, nil, nil, big.NewInt(0), nil, nil, nil}
 
-	TestChainConfig = &ChainConfig{big.NewInt(10), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, false, true, false, 0, 32, nil, nil, nil, nil, nil, big.NewInt(0)}
+	TestChainConfig = &ChainConfig{big.NewInt(10), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, false, true, false, 0, 32, nil, nil, nil, nil, nil, nil, big.NewInt(0), nil, nil, nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 
-	SmiloTestChainConfig = &ChainConfig{big.NewInt(10), big.NewInt(0), nil, false, nil, common.Hash{}, nil, nil, big.NewInt(300000), nil, nil, big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, true, true, false, 0, 32, nil, nil, nil, nil, nil, big.NewInt(0)}
+	SmiloTestChainConfig = &ChainConfig{big.NewInt(10), big.NewInt(0), nil, false, nil, common.Hash{}, nil, nil, big.NewInt(300000), nil, nil, big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, true, true, false, 0, 32, nil, nil, nil, nil, nil, nil, big.NewInt(0), nil, nil, nil}
 )
 
 // TrustedCheckpoint represents a set of post-processed trie roots (CHT and
@@ -373,11 +373,24 @@ type ChainConfig struct {
 	Istanbul               *IstanbulConfig          `json:"istanbul,omitempty"`
 	SportDAO               *SportDAOConfig          `json:"sportdao,omitempty"`
 
+	// ConsensusTransitions switch the consensus engine at the given blocks
+	ConsensusTransitions []ConsensusTransition `json:"consensusTransitions,omitempty"`
+
 	// Quorum
 	//
 	// QIP714Block implements the permissions related changes
 	QIP714Block            *big.Int `json:"qip714Block,omitempty"`
 	MaxCodeSizeChangeBlock *big.Int `json:"maxCodeSizeChangeBlock,omitempty"`
+	// PermissionContracts are the permission contracts the account permissions
+	// are read from when processing the blocks from QIP714Block on
+	PermissionContracts *PermissionContractsConfig `json:"permissionContracts,omitempty"`
+
+	SmiloPayFixedPointBlock *big.Int `json:"smiloPayFixedPointBlock,omitempty"` // Integer SmiloPay calculation switch block (nil = no fork)
+
+	// LivenessBlock starts recording the committed seals of the SportDAO blocks
+	// in the Autonity contract, which jails the validators that stop signing
+	// (nil = no fork)
+	LivenessBlock *big.Int `json:"livenessBlock,omitempty"`
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -401,9 +414,12 @@ func (c *CliqueConfig) String() string {
 
 // SportConfig is the consensus engine configs for Sport based sealing.
 type SportConfig struct {
-	Epoch         uint64 `json:"epoch"`    // Epoch length to reset votes and checkpoint
-	SpeakerPolicy uint64 `json:"policy"`   // The policy for speaker selection
-	MinFunds      int64  `json:"minfunds"` // The policy for speaker selection
+	Epoch         uint64          `json:"epoch"`                // Epoch length to reset votes and checkpoint
+	SpeakerPolicy uint64          `json:"policy"`               // The policy for speaker selection, 0 for round robin and 1 for stake weighted
+	MinFunds      int64           `json:"minfunds"`             // The policy for speaker selection
+	Governance    bool            `json:"governance,omitempty"` // Vote on fullnodes through the governance contract instead of header fields
+	Threshold     uint64          `json:"threshold,omitempty"`  // Percentage of fullnodes that must vote for a governance proposal
+	Rewards       *RewardSchedule `json:"rewards,omitempty"`    // Block reward schedule, the built-in Smilo schedule if nil
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -413,9 +429,10 @@ func (c *SportConfig) String() string {
 
 // IstanbulConfig is the consensus engine configs for Istanbul based sealing.
 type SportDAOConfig struct {
-	Epoch         uint64 `json:"epoch"`    // Epoch length to reset votes and checkpoint
-	SpeakerPolicy uint64 `json:"policy"`   // The policy for speaker selection
-	MinFunds      int64  `json:"minfunds"` // The policy for speaker selection
+	Epoch         uint64          `json:"epoch"`             // Epoch length to reset votes and checkpoint
+	SpeakerPolicy uint64          `json:"policy"`            // The policy for speaker selection, 0 for round robin and 1 for stake weighted
+	MinFunds      int64           `json:"minfunds"`          // The policy for speaker selection
+	Rewards       *RewardSchedule `json:"rewards,omitempty"` // Block reward schedule, the built-in Smilo schedule if nil
 }
 
 //String implements the stringer interface, returning the consensus engine details.
@@ -450,6 +467,18 @@ func (c *TendermintConfig) String() string {
 	return "tendermint"
 }
 
+// PermissionContractsConfig holds the addresses of the permission contracts
+// enforced in block processing. Every node must read the permissions from the
+// same contracts, so they are part of the chain config and not of the
+// permission-config.json of the node.
+type PermissionContractsConfig struct {
+	InterfAddress  common.Address `json:"interfaceAddress"` // the network boot status is read from it, no permissions apply before the network is booted
+	OrgAddress     common.Address `json:"orgMgrAddress"`
+	RoleAddress    common.Address `json:"roleMgrAddress"`
+	AccountAddress common.Address `json:"accountMgrAddress"`
+	RuleAddress    common.Address `json:"ruleMgrAddress,omitempty"` // optional, no function rules are enforced without it
+}
+
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
 	var engine interface{}
@@ -556,6 +585,18 @@ func (c *ChainConfig) IsMaxCodeSizeChangeBlock(num *big.Int) bool {
 	return isForked(c.MaxCodeSizeChangeBlock, num)
 }
 
+// IsSmiloPayFixedPoint returns whether num represents a block number where
+// SmiloPay is calculated with integer arithmetic
+func (c *ChainConfig) IsSmiloPayFixedPoint(num *big.Int) bool {
+	return isForked(c.SmiloPayFixedPointBlock, num)
+}
+
+// IsLiveness returns whether num represents a block number where the signers
+// of the parent block are recorded by the Autonity contract
+func (c *ChainConfig) IsLiveness(num *big.Int) bool {
+	return isForked(c.LivenessBlock, num)
+}
+
 // GasTable returns the gas table corresponding to the current phase (homestead or homestead reprice).
 //
 // The returned GasTable's fields shouldn't, under any circumstances, be changed.
@@ -639,9 +680,21 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int, isSmil
 	if isForkIncompatible(c.QIP714Block, newcfg.QIP714Block, head) {
 		return newCompatError("permissions fork block", c.QIP714Block, newcfg.QIP714Block)
 	}
+	if c.IsQIP714(head) && !permissionContractsEqual(c.PermissionContracts, newcfg.PermissionContracts) {
+		return newCompatError("permission contracts", c.QIP714Block, newcfg.QIP714Block)
+	}
 	if isForkIncompatible(c.MaxCodeSizeChangeBlock, newcfg.MaxCodeSizeChangeBlock, head) {
 		return newCompatError("max code size change fork block", c.MaxCodeSizeChangeBlock, newcfg.MaxCodeSizeChangeBlock)
 	}
+	if isForkIncompatible(c.SmiloPayFixedPointBlock, newcfg.SmiloPayFixedPointBlock, head) {
+		return newCompatError("SmiloPay fixed point fork block", c.SmiloPayFixedPointBlock, newcfg.SmiloPayFixedPointBlock)
+	}
+	if isForkIncompatible(c.LivenessBlock, newcfg.LivenessBlock, head) {
+		return newCompatError("liveness fork block", c.LivenessBlock, newcfg.LivenessBlock)
+	}
+	if err := c.checkConsensusTransitions(newcfg, head); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -747,6 +800,19 @@ func (c *ChainConfig) Copy() *ChainConfig {
 	if c.EWASMBlock != nil {
 		cfg.EWASMBlock = big.NewInt(0).Set(c.EWASMBlock)
 	}
+	if c.SmiloPayFixedPointBlock != nil {
+		cfg.SmiloPayFixedPointBlock = big.NewInt(0).Set(c.SmiloPayFixedPointBlock)
+	}
+	if c.LivenessBlock != nil {
+		cfg.LivenessBlock = big.NewInt(0).Set(c.LivenessBlock)
+	}
+	for _, t := range c.ConsensusTransitions {
+		transition := ConsensusTransition{Engine: t.Engine}
+		if t.Block != nil {
+			transition.Block = big.NewInt(0).Set(t.Block)
+		}
+		cfg.ConsensusTransitions = append(cfg.ConsensusTransitions, transition)
+	}
 
 	return cfg
 }
@@ -775,6 +841,13 @@ func configNumEqual(x, y *big.Int) bool {
 	return x.Cmp(y) == 0
 }
 
+func permissionContractsEqual(x, y *PermissionContractsConfig) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
 // ConfigCompatError is raised if the locally-stored blockchain is initialised with a
 // ChainConfig that would alter the past.
 type ConfigCompatError struct {
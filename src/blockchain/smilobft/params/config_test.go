@@ -20,6 +20,8 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func TestCheckCompatible(t *testing.T) {
@@ -121,6 +123,23 @@ func TestCheckCompatible(t *testing.T) {
 			head:    4,
 			wantErr: nil,
 		},
+		{
+			stored: &ChainConfig{QIP714Block: big.NewInt(10), PermissionContracts: &PermissionContractsConfig{AccountAddress: common.HexToAddress("0x01")}},
+			new:    &ChainConfig{QIP714Block: big.NewInt(10), PermissionContracts: &PermissionContractsConfig{AccountAddress: common.HexToAddress("0x02")}},
+			head:   30,
+			wantErr: &ConfigCompatError{
+				What:         "permission contracts",
+				StoredConfig: big.NewInt(10),
+				NewConfig:    big.NewInt(10),
+				RewindTo:     9,
+			},
+		},
+		{
+			stored:  &ChainConfig{QIP714Block: big.NewInt(10)},
+			new:     &ChainConfig{QIP714Block: big.NewInt(10), PermissionContracts: &PermissionContractsConfig{AccountAddress: common.HexToAddress("0x02")}},
+			head:    4,
+			wantErr: nil,
+		},
 
 	}
 
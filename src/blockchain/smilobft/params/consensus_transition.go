@@ -0,0 +1,200 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Names of the consensus engines, as used by ConsensusTransition.
+const (
+	CliqueEngine     = "clique"
+	SportEngine      = "sport"
+	SportDAOEngine   = "sportdao"
+	IstanbulEngine   = "istanbul"
+	TendermintEngine = "tendermint"
+	EthashEngine     = "ethash"
+)
+
+var (
+	// errTransitionBlock is returned when a transition is not scheduled after the
+	// previous one.
+	errTransitionBlock = errors.New("consensus transitions must be scheduled in ascending order after the genesis block")
+	// errTransitionGenesisEngine is returned when the chain does not start with a
+	// BFT engine.
+	errTransitionGenesisEngine = errors.New("consensus transitions require a BFT genesis engine")
+)
+
+// ConsensusTransition switches the consensus engine of the chain from Block
+// onwards. The configuration of the target engine must be present in the chain
+// configuration as well.
+type ConsensusTransition struct {
+	Block  *big.Int `json:"block"`
+	Engine string   `json:"engine"`
+}
+
+// GenesisConsensusEngine returns the name of the engine sealing the chain until
+// the first consensus transition: the first configured engine, in the order
+// clique, sport, sportdao, istanbul, tendermint, that is not the target of a
+// transition.
+func (c *ChainConfig) GenesisConsensusEngine() string {
+	targets := make(map[string]bool)
+	for _, t := range c.ConsensusTransitions {
+		targets[t.Engine] = true
+	}
+	engines := []struct {
+		name       string
+		configured bool
+	}{
+		{CliqueEngine, c.Clique != nil},
+		{SportEngine, c.Sport != nil},
+		{SportDAOEngine, c.SportDAO != nil},
+		{IstanbulEngine, c.Istanbul != nil},
+		{TendermintEngine, c.Tendermint != nil},
+	}
+	for _, engine := range engines {
+		if engine.configured && !targets[engine.name] {
+			return engine.name
+		}
+	}
+	return EthashEngine
+}
+
+// ConsensusEngine returns the name of the engine sealing the block num.
+func (c *ChainConfig) ConsensusEngine(num *big.Int) string {
+	engine := c.GenesisConsensusEngine()
+	for _, t := range c.ConsensusTransitions {
+		if !isForked(t.Block, num) {
+			break
+		}
+		engine = t.Engine
+	}
+	return engine
+}
+
+// IsConsensusTransition returns whether num is the first block sealed by a new
+// consensus engine.
+func (c *ChainConfig) IsConsensusTransition(num *big.Int) bool {
+	for _, t := range c.ConsensusTransitions {
+		if configNumEqual(t.Block, num) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAutonity returns whether the block num is sealed by an engine governed by
+// the Autonity contract, that is Istanbul, SportDAO or Tendermint.
+func (c *ChainConfig) IsAutonity(num *big.Int) bool {
+	if len(c.ConsensusTransitions) == 0 {
+		return c.Istanbul != nil || c.SportDAO != nil || c.Tendermint != nil
+	}
+	switch c.ConsensusEngine(num) {
+	case IstanbulEngine, SportDAOEngine, TendermintEngine:
+		return true
+	}
+	return false
+}
+
+// CheckConsensusTransitions checks that the consensus transitions are scheduled
+// in order and move between configured BFT engines. Only engines reading their
+// validators from the Autonity contract can be transitioned to, the contract is
+// deployed on the transition block with the validators of the previous engine.
+// Their enodes are not on chain, so the validators must be configured as users
+// of the contract, the deployment fails on any validator missing.
+func (c *ChainConfig) CheckConsensusTransitions() error {
+	if len(c.ConsensusTransitions) == 0 {
+		return nil
+	}
+	switch c.GenesisConsensusEngine() {
+	case SportEngine, SportDAOEngine, IstanbulEngine, TendermintEngine:
+	default:
+		return errTransitionGenesisEngine
+	}
+
+	last := big.NewInt(0)
+	engine := c.GenesisConsensusEngine()
+	for _, t := range c.ConsensusTransitions {
+		if t.Block == nil || t.Block.Cmp(last) <= 0 {
+			return errTransitionBlock
+		}
+		var configured bool
+		switch t.Engine {
+		case SportDAOEngine:
+			configured = c.SportDAO != nil
+		case IstanbulEngine:
+			configured = c.Istanbul != nil
+		case TendermintEngine:
+			configured = c.Tendermint != nil
+		default:
+			return fmt.Errorf("unsupported consensus transition to %q at block %v", t.Engine, t.Block)
+		}
+		if !configured {
+			return fmt.Errorf("missing %s configuration for consensus transition at block %v", t.Engine, t.Block)
+		}
+		if c.AutonityContractConfig == nil {
+			return fmt.Errorf("missing autonity contract configuration for consensus transition at block %v", t.Block)
+		}
+		if !c.AutonityContractConfig.hasValidator() {
+			return fmt.Errorf("missing autonity contract validator users for consensus transition at block %v", t.Block)
+		}
+		if t.Engine == engine {
+			return fmt.Errorf("consensus transition at block %v does not change the engine %s", t.Block, engine)
+		}
+		last, engine = t.Block, t.Engine
+	}
+	return nil
+}
+
+// checkConsensusTransitions returns an error if a consensus transition that
+// already happened at head is rescheduled or removed in newcfg.
+func (c *ChainConfig) checkConsensusTransitions(newcfg *ChainConfig, head *big.Int) *ConfigCompatError {
+	for _, t := range c.ConsensusTransitions {
+		if isForked(t.Block, head) && c.ConsensusEngine(t.Block) != newcfg.ConsensusEngine(t.Block) {
+			return newCompatError("consensus transition block", t.Block, newcfg.consensusTransitionBlock(t.Engine))
+		}
+	}
+	for _, t := range newcfg.ConsensusTransitions {
+		if isForked(t.Block, head) && c.ConsensusEngine(t.Block) != newcfg.ConsensusEngine(t.Block) {
+			return newCompatError("consensus transition block", c.consensusTransitionBlock(t.Engine), t.Block)
+		}
+	}
+	return nil
+}
+
+// consensusTransitionBlock returns the block of the transition to engine, if any.
+func (c *ChainConfig) consensusTransitionBlock(engine string) *big.Int {
+	for _, t := range c.ConsensusTransitions {
+		if t.Engine == engine {
+			return t.Block
+		}
+	}
+	return nil
+}
+
+// hasValidator returns whether a validator is among the users of the contract.
+func (ac *AutonityContractGenesis) hasValidator() bool {
+	for _, user := range ac.Users {
+		if user.Type == UserValidator {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// transitionContract is an Autonity contract configuration with a validator,
+// which a consensus transition requires.
+func transitionContract() *AutonityContractGenesis {
+	return &AutonityContractGenesis{
+		Users: []User{{Address: common.HexToAddress("0x01"), Type: UserValidator}},
+	}
+}
+
+func TestConsensusEngine(t *testing.T) {
+	config := &ChainConfig{
+		Sport:                  &SportConfig{},
+		Tendermint:             &TendermintConfig{},
+		AutonityContractConfig: transitionContract(),
+		ConsensusTransitions:   []ConsensusTransition{{Block: big.NewInt(10), Engine: TendermintEngine}},
+	}
+	if err := config.CheckConsensusTransitions(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		number     int64
+		engine     string
+		autonity   bool
+		transition bool
+	}{
+		{0, SportEngine, false, false},
+		{9, SportEngine, false, false},
+		{10, TendermintEngine, true, true},
+		{11, TendermintEngine, true, false},
+	}
+	for _, test := range tests {
+		number := big.NewInt(test.number)
+		if engine := config.ConsensusEngine(number); engine != test.engine {
+			t.Errorf("block %d: engine mismatch: have %s, want %s", test.number, engine, test.engine)
+		}
+		if autonity := config.IsAutonity(number); autonity != test.autonity {
+			t.Errorf("block %d: autonity mismatch: have %v, want %v", test.number, autonity, test.autonity)
+		}
+		if transition := config.IsConsensusTransition(number); transition != test.transition {
+			t.Errorf("block %d: transition mismatch: have %v, want %v", test.number, transition, test.transition)
+		}
+	}
+
+	// Without transitions the configured engines keep their precedence
+	legacy := &ChainConfig{Sport: &SportConfig{}, Tendermint: &TendermintConfig{}}
+	if engine := legacy.ConsensusEngine(big.NewInt(100)); engine != SportEngine {
+		t.Errorf("legacy engine mismatch: have %s, want %s", engine, SportEngine)
+	}
+	if !legacy.IsAutonity(big.NewInt(100)) {
+		t.Errorf("legacy autonity mismatch: have false, want true")
+	}
+}
+
+func TestCheckConsensusTransitions(t *testing.T) {
+	transitions := func(blocks ...int64) []ConsensusTransition {
+		var ts []ConsensusTransition
+		engines := []string{IstanbulEngine, TendermintEngine}
+		for i, block := range blocks {
+			ts = append(ts, ConsensusTransition{Block: big.NewInt(block), Engine: engines[i%len(engines)]})
+		}
+		return ts
+	}
+	tests := []struct {
+		config *ChainConfig
+		ok     bool
+	}{
+		{&ChainConfig{Ethash: &EthashConfig{}}, true},
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, Tendermint: &TendermintConfig{}, AutonityContractConfig: transitionContract(), ConsensusTransitions: transitions(5, 10)}, true},
+		// Transitions out of order or at genesis
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, Tendermint: &TendermintConfig{}, AutonityContractConfig: transitionContract(), ConsensusTransitions: transitions(10, 5)}, false},
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, AutonityContractConfig: transitionContract(), ConsensusTransitions: transitions(0)}, false},
+		// Missing configuration of the target engine or of the contract
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, AutonityContractConfig: transitionContract(), ConsensusTransitions: transitions(5, 10)}, false},
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, ConsensusTransitions: transitions(5)}, false},
+		// No validator among the contract users
+		{&ChainConfig{Sport: &SportConfig{}, Istanbul: &IstanbulConfig{}, AutonityContractConfig: &AutonityContractGenesis{}, ConsensusTransitions: transitions(5)}, false},
+		// Unsupported engines
+		{&ChainConfig{Ethash: &EthashConfig{}, Istanbul: &IstanbulConfig{}, AutonityContractConfig: transitionContract(), ConsensusTransitions: transitions(5)}, false},
+		{&ChainConfig{Sport: &SportConfig{}, ConsensusTransitions: []ConsensusTransition{{Block: big.NewInt(5), Engine: CliqueEngine}}}, false},
+	}
+	for i, test := range tests {
+		if err := test.config.CheckConsensusTransitions(); (err == nil) != test.ok {
+			t.Errorf("test %d: error mismatch: have %v, want ok %v", i, err, test.ok)
+		}
+	}
+}
+
+func TestCheckCompatibleConsensusTransitions(t *testing.T) {
+	stored := &ChainConfig{
+		Sport:                &SportConfig{},
+		Tendermint:           &TendermintConfig{},
+		ConsensusTransitions: []ConsensusTransition{{Block: big.NewInt(10), Engine: TendermintEngine}},
+	}
+	moved := &ChainConfig{
+		Sport:                &SportConfig{},
+		Tendermint:           &TendermintConfig{},
+		ConsensusTransitions: []ConsensusTransition{{Block: big.NewInt(20), Engine: TendermintEngine}},
+	}
+	if err := stored.CheckCompatible(moved, 5, true); err != nil {
+		t.Errorf("transition ahead of head: unexpected error %v", err)
+	}
+	err := stored.CheckCompatible(moved, 15, true)
+	if err == nil {
+		t.Fatalf("transition behind head: expected error")
+	}
+	if err.RewindTo != 9 {
+		t.Errorf("rewind mismatch: have %d, want 9", err.RewindTo)
+	}
+}
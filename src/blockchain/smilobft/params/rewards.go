@@ -0,0 +1,222 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errRewardTranche  = errors.New("reward tranches must end at increasing blocks")
+	errRewardHalving  = errors.New("reward halving interval requires an initial reward")
+	errRewardNegative = errors.New("rewards and supply cap can't be negative")
+)
+
+// RewardTranche pays a fixed reward for every block up to, but excluding, Until.
+type RewardTranche struct {
+	Until  uint64   `json:"until"`  // First block no longer paid by the tranche
+	Reward *big.Int `json:"reward"` // Reward in wei credited to the coinbase of each block
+}
+
+// RewardShare mints a share of every block reward to a beneficiary, on top of the
+// reward of the coinbase.
+type RewardShare struct {
+	Address common.Address `json:"address"` // Beneficiary of the share
+	Percent uint64         `json:"percent"` // Share in percent of the block reward
+}
+
+// RewardSchedule declares the block rewards minted by the BFT engines. Blocks are
+// paid by the first tranche covering them and, once every tranche is over, by an
+// initial reward halved every HalvingInterval blocks. The supply cap bounds the
+// scheduled issuance of all blocks, coinbase rewards and shares together.
+type RewardSchedule struct {
+	Tranches        []RewardTranche `json:"tranches,omitempty"`        // Fixed rewards by block range, in ascending order
+	InitialReward   *big.Int        `json:"initialReward,omitempty"`   // Reward of the first block after the tranches
+	HalvingInterval uint64          `json:"halvingInterval,omitempty"` // Blocks between two halvings, zero to never halve
+	Shares          []RewardShare   `json:"shares,omitempty"`          // Beneficiaries minted along with every block reward
+	SupplyCap       *big.Int        `json:"supplyCap,omitempty"`       // Maximum amount ever minted by the schedule, nil for none
+}
+
+// BlockRewards is what the schedule mints for a block.
+type BlockRewards struct {
+	Coinbase *big.Int                    `json:"coinbase"` // Reward of the block coinbase
+	Shares   map[common.Address]*big.Int `json:"shares"`   // Shares minted to the beneficiaries
+}
+
+// Total returns the amount minted for the block.
+func (r *BlockRewards) Total() *big.Int {
+	total := new(big.Int).Set(r.Coinbase)
+	for _, share := range r.Shares {
+		total.Add(total, share)
+	}
+	return total
+}
+
+// Check verifies that the schedule is well formed.
+func (s *RewardSchedule) Check() error {
+	var last uint64
+	for _, t := range s.Tranches {
+		if t.Until <= last {
+			return errRewardTranche
+		}
+		if t.Reward == nil || t.Reward.Sign() < 0 {
+			return errRewardNegative
+		}
+		last = t.Until
+	}
+	if s.HalvingInterval != 0 && s.InitialReward == nil {
+		return errRewardHalving
+	}
+	if (s.InitialReward != nil && s.InitialReward.Sign() < 0) || (s.SupplyCap != nil && s.SupplyCap.Sign() < 0) {
+		return errRewardNegative
+	}
+	return nil
+}
+
+// Rewards returns what the schedule mints for the given block. The genesis block
+// is never rewarded.
+func (s *RewardSchedule) Rewards(number uint64) *BlockRewards {
+	if number == 0 {
+		return s.uncapped(new(big.Int))
+	}
+	rewards := s.uncapped(s.reward(number))
+	if s.SupplyCap == nil {
+		return rewards
+	}
+	// Scale the shares down to what the cap still allows, the coinbase gets the rest
+	allowed := new(big.Int).Sub(s.Issuance(number), s.Issuance(number-1))
+	total := rewards.Total()
+	if allowed.Cmp(total) >= 0 {
+		return rewards
+	}
+	rewards.Coinbase.Set(allowed)
+	for _, share := range rewards.Shares {
+		share.Mul(share, allowed).Div(share, total)
+		rewards.Coinbase.Sub(rewards.Coinbase, share)
+	}
+	return rewards
+}
+
+// Issuance returns the amount scheduled to be minted from genesis up to and
+// including the given block, assuming every block is rewarded.
+func (s *RewardSchedule) Issuance(number uint64) *big.Int {
+	issued := new(big.Int)
+	s.segments(func(from, to uint64, reward *big.Int) bool {
+		if from < 1 {
+			from = 1
+		}
+		if to > number {
+			to = number
+		}
+		if from <= to {
+			blocks := new(big.Int).SetUint64(to - from + 1)
+			issued.Add(issued, blocks.Mul(blocks, s.uncapped(reward).Total()))
+		}
+		return to < number
+	})
+	if s.SupplyCap != nil && issued.Cmp(s.SupplyCap) > 0 {
+		issued.Set(s.SupplyCap)
+	}
+	return issued
+}
+
+// reward returns the coinbase reward of a block before applying the supply cap.
+func (s *RewardSchedule) reward(number uint64) *big.Int {
+	reward := new(big.Int)
+	s.segments(func(from, to uint64, r *big.Int) bool {
+		if number < from {
+			return false
+		}
+		if number <= to {
+			reward.Set(r)
+			return false
+		}
+		return true
+	})
+	return reward
+}
+
+// uncapped computes the rewards of a block with the given coinbase reward.
+func (s *RewardSchedule) uncapped(reward *big.Int) *BlockRewards {
+	rewards := &BlockRewards{
+		Coinbase: new(big.Int).Set(reward),
+		Shares:   make(map[common.Address]*big.Int, len(s.Shares)),
+	}
+	for _, share := range s.Shares {
+		amount := new(big.Int).Mul(reward, new(big.Int).SetUint64(share.Percent))
+		amount.Div(amount, big.NewInt(100))
+		if prev, ok := rewards.Shares[share.Address]; ok {
+			amount.Add(amount, prev)
+		}
+		rewards.Shares[share.Address] = amount
+	}
+	return rewards
+}
+
+// segments calls fn with the inclusive block ranges paying a constant reward, in
+// ascending order, until fn returns false or no reward is left.
+func (s *RewardSchedule) segments(fn func(from, to uint64, reward *big.Int) bool) {
+	var start uint64
+	for _, t := range s.Tranches {
+		if !fn(start, t.Until-1, t.Reward) {
+			return
+		}
+		start = t.Until
+	}
+	if s.InitialReward == nil || s.InitialReward.Sign() == 0 {
+		return
+	}
+	if s.HalvingInterval == 0 {
+		fn(start, math.MaxUint64, s.InitialReward)
+		return
+	}
+	for era := uint(0); ; era++ {
+		reward := new(big.Int).Rsh(s.InitialReward, era)
+		if reward.Sign() == 0 {
+			return
+		}
+		end := start + s.HalvingInterval - 1
+		if end < start {
+			end = math.MaxUint64
+		}
+		if !fn(start, end, reward) || end == math.MaxUint64 {
+			return
+		}
+		start = end + 1
+	}
+}
+
+// CheckRewardSchedules verifies the reward schedules of the BFT engines.
+func (c *ChainConfig) CheckRewardSchedules() error {
+	if c.Sport != nil && c.Sport.Rewards != nil {
+		if err := c.Sport.Rewards.Check(); err != nil {
+			return fmt.Errorf("invalid sport reward schedule: %v", err)
+		}
+	}
+	if c.SportDAO != nil && c.SportDAO.Rewards != nil {
+		if err := c.SportDAO.Rewards.Check(); err != nil {
+			return fmt.Errorf("invalid sportdao reward schedule: %v", err)
+		}
+	}
+	return nil
+}
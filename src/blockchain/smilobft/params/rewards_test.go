@@ -0,0 +1,112 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRewardSchedule(t *testing.T) {
+	community := common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	schedule := &RewardSchedule{
+		Tranches:        []RewardTranche{{Until: 10, Reward: big.NewInt(100)}, {Until: 20, Reward: big.NewInt(60)}},
+		InitialReward:   big.NewInt(40),
+		HalvingInterval: 5,
+		Shares:          []RewardShare{{Address: community, Percent: 25}},
+	}
+	if err := schedule.Check(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		number uint64
+		reward int64
+	}{
+		{0, 0}, {1, 100}, {9, 100}, {10, 60}, {19, 60},
+		{20, 40}, {24, 40}, {25, 20}, {30, 10}, {35, 5}, {40, 2}, {45, 1}, {50, 0},
+	}
+	issued := new(big.Int)
+	for number := uint64(0); number <= 60; number++ {
+		rewards := schedule.Rewards(number)
+		issued.Add(issued, rewards.Total())
+		if have := schedule.Issuance(number); have.Cmp(issued) != 0 {
+			t.Errorf("block %d: issuance mismatch: have %v, want %v", number, have, issued)
+		}
+		if share := rewards.Shares[community]; share.Cmp(new(big.Int).Div(rewards.Coinbase, big.NewInt(4))) != 0 {
+			t.Errorf("block %d: community share mismatch: have %v, reward %v", number, share, rewards.Coinbase)
+		}
+	}
+	for _, test := range tests {
+		if reward := schedule.Rewards(test.number).Coinbase; reward.Int64() != test.reward {
+			t.Errorf("block %d: reward mismatch: have %v, want %d", test.number, reward, test.reward)
+		}
+	}
+}
+
+func TestRewardScheduleSupplyCap(t *testing.T) {
+	schedule := &RewardSchedule{
+		InitialReward: big.NewInt(100),
+		Shares:        []RewardShare{{Address: common.Address{1}, Percent: 50}},
+		SupplyCap:     big.NewInt(1000),
+	}
+	// Every block mints 150 until the cap is reached on the seventh one
+	for number, want := range []int64{0, 150, 150, 150, 150, 150, 150, 100, 0} {
+		if total := schedule.Rewards(uint64(number)).Total(); total.Int64() != want {
+			t.Errorf("block %d: minted mismatch: have %v, want %d", number, total, want)
+		}
+	}
+	rewards := schedule.Rewards(7)
+	if rewards.Coinbase.Int64() != 67 || rewards.Shares[common.Address{1}].Int64() != 33 {
+		t.Errorf("capped rewards not scaled: have %v and %v", rewards.Coinbase, rewards.Shares)
+	}
+	if issued := schedule.Issuance(1 << 62); issued.Cmp(schedule.SupplyCap) != 0 {
+		t.Errorf("issuance over the cap: have %v", issued)
+	}
+}
+
+func TestRewardScheduleCheck(t *testing.T) {
+	tests := []struct {
+		schedule RewardSchedule
+		err      error
+	}{
+		{RewardSchedule{}, nil},
+		{RewardSchedule{Tranches: []RewardTranche{{Until: 10, Reward: big.NewInt(1)}, {Until: 10, Reward: big.NewInt(1)}}}, errRewardTranche},
+		{RewardSchedule{Tranches: []RewardTranche{{Until: 10}}}, errRewardNegative},
+		{RewardSchedule{HalvingInterval: 10}, errRewardHalving},
+		{RewardSchedule{InitialReward: big.NewInt(-1)}, errRewardNegative},
+	}
+	for i, test := range tests {
+		if err := test.schedule.Check(); err != test.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+}
+
+func TestRewardScheduleJSON(t *testing.T) {
+	blob := []byte(`{"tranches":[{"until":100,"reward":4000000000000000000}],"initialReward":2000000000000000000,"halvingInterval":1000,"shares":[{"address":"0x00000000000000000000000000000000000c0de1","percent":25}]}`)
+	var config SportConfig
+	if err := json.Unmarshal(append(append([]byte(`{"epoch":30000,"rewards":`), blob...), '}'), &config); err != nil {
+		t.Fatal(err)
+	}
+	if reward := config.Rewards.Rewards(100).Coinbase; reward.Cmp(big.NewInt(2e18)) != 0 {
+		t.Errorf("reward mismatch: have %v", reward)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"go-smilo/src/blockchain/smilobft/core/types"
 	"go-smilo/src/blockchain/smilobft/internal/ethapi"
 	"go-smilo/src/blockchain/smilobft/p2p/enode"
+	"go-smilo/src/blockchain/smilobft/permission/audit"
 	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -47,6 +48,8 @@ const (
 	InitiateAccountRecovery
 	ApproveNodeRecovery
 	ApproveAccountRecovery
+	AddFunctionRule
+	RemoveFunctionRule
 )
 
 type AccountUpdateAction int
@@ -95,6 +98,8 @@ type txArgs struct {
 	voter      common.Address
 	morgId     string
 	tmKey      string
+	contract   common.Address
+	selector   types.FunctionSelector
 	txa        ethapi.SendTxArgs
 }
 
@@ -146,6 +151,10 @@ var (
 	ErrInvalidRole        = ExecStatus{false, "Invalid role"}
 	ErrInvalidInput       = ExecStatus{false, "Invalid input"}
 	ErrNotMasterOrg       = ExecStatus{false, "Org is not a master org"}
+	ErrRulesDisabled      = ExecStatus{false, "Function rules not enabled. Function rule manager address not configured"}
+	ErrAdminRoleRules     = ExecStatus{false, "Function rules cannot be set on admin roles"}
+	ErrRuleExists         = ExecStatus{false, "Function rule exists for the role"}
+	ErrRuleDoesNotExist   = ExecStatus{false, "Function rule does not exist for the role"}
 
 	ExecSuccess = ExecStatus{true, "Action completed successfully"}
 )
@@ -156,23 +165,35 @@ func NewQuorumControlsAPI(p *PermissionCtrl) *QuorumControlsAPI {
 }
 
 func (q *QuorumControlsAPI) OrgList() []types.OrgInfo {
-	return types.OrgInfoMap.GetOrgList()
+	return q.permCtrl.cache.OrgInfoMap.GetOrgList()
 }
 
 func (q *QuorumControlsAPI) NodeList() []types.NodeInfo {
-	return types.NodeInfoMap.GetNodeList()
+	return q.permCtrl.cache.NodeInfoMap.GetNodeList()
 }
 
 func (q *QuorumControlsAPI) RoleList() []types.RoleInfo {
-	return types.RoleInfoMap.GetRoleList()
+	return q.permCtrl.cache.RoleInfoMap.GetRoleList()
 }
 
 func (q *QuorumControlsAPI) AcctList() []types.AccountInfo {
-	return types.AcctInfoMap.GetAcctList()
+	return q.permCtrl.cache.AcctInfoMap.GetAcctList()
+}
+
+// AuditLog returns the permission changes matching the query, oldest first.
+// The changes can be selected by org, by account, matching the changes made by
+// the account or to it, by node and by block range.
+func (q *QuorumControlsAPI) AuditLog(query audit.Query) []audit.Entry {
+	return q.permCtrl.audit.Query(query)
+}
+
+// FunctionRuleList returns the function rules of the roles, active or not
+func (q *QuorumControlsAPI) FunctionRuleList() []types.FunctionRule {
+	return q.permCtrl.cache.RuleInfoMap.GetRuleList()
 }
 
 func (q *QuorumControlsAPI) GetOrgDetails(orgId string) (types.OrgDetailInfo, error) {
-	if o := types.OrgInfoMap.GetOrg(orgId); o == nil {
+	if o := q.permCtrl.cache.OrgInfoMap.GetOrg(orgId); o == nil {
 		return types.OrgDetailInfo{}, errors.New("org does not exist")
 	}
 	var acctList []types.AccountInfo
@@ -193,7 +214,7 @@ func (q *QuorumControlsAPI) GetOrgDetails(orgId string) (types.OrgDetailInfo, er
 			nodeList = append(nodeList, a)
 		}
 	}
-	return types.OrgDetailInfo{NodeList: nodeList, RoleList: roleList, AcctList: acctList, SubOrgList: types.OrgInfoMap.GetOrg(orgId).SubOrgList}, nil
+	return types.OrgDetailInfo{NodeList: nodeList, RoleList: roleList, AcctList: acctList, SubOrgList: q.permCtrl.cache.OrgInfoMap.GetOrg(orgId).SubOrgList}, nil
 }
 
 func (q *QuorumControlsAPI) initOp(txa ethapi.SendTxArgs) (*pbind.PermInterfaceSession, ExecStatus) {
@@ -537,18 +558,58 @@ func (q *QuorumControlsAPI) ApproveBlackListedAccountRecovery(orgId string, acct
 	return ExecSuccess.OpStatus()
 }
 
+// AddFunctionRule lets the accounts of a role call a function of a contract.
+// Once a role has an active rule its accounts may only call the functions its
+// rules allow. The selector 0x00000000 allows every function of the contract.
+func (q *QuorumControlsAPI) AddFunctionRule(orgId string, roleId string, contract common.Address, selector types.FunctionSelector, txa ethapi.SendTxArgs) (string, error) {
+	prule, execStatus := q.initRuleOp(txa)
+	if execStatus != ExecSuccess {
+		return execStatus.OpStatus()
+	}
+	args := txArgs{orgId: orgId, roleId: roleId, contract: contract, selector: selector, txa: txa}
+
+	if execStatus := q.valFunctionRule(args, true); execStatus != ExecSuccess {
+		return execStatus.OpStatus()
+	}
+	tx, err := prule.AddFunctionRule(args.orgId, args.roleId, args.contract, args.selector)
+	if err != nil {
+		return reportExecError(AddFunctionRule, err)
+	}
+	log.Debug("executed permission action", "action", AddFunctionRule, "tx", tx)
+	return ExecSuccess.OpStatus()
+}
+
+// RemoveFunctionRule deactivates a function rule of a role
+func (q *QuorumControlsAPI) RemoveFunctionRule(orgId string, roleId string, contract common.Address, selector types.FunctionSelector, txa ethapi.SendTxArgs) (string, error) {
+	prule, execStatus := q.initRuleOp(txa)
+	if execStatus != ExecSuccess {
+		return execStatus.OpStatus()
+	}
+	args := txArgs{orgId: orgId, roleId: roleId, contract: contract, selector: selector, txa: txa}
+
+	if execStatus := q.valFunctionRule(args, false); execStatus != ExecSuccess {
+		return execStatus.OpStatus()
+	}
+	tx, err := prule.RemoveFunctionRule(args.orgId, args.roleId, args.contract, args.selector)
+	if err != nil {
+		return reportExecError(RemoveFunctionRule, err)
+	}
+	log.Debug("executed permission action", "action", RemoveFunctionRule, "tx", tx)
+	return ExecSuccess.OpStatus()
+}
+
 // check if the account is network admin
 func (q *QuorumControlsAPI) isNetworkAdmin(account common.Address) bool {
-	ac := types.AcctInfoMap.GetAccount(account)
+	ac := q.permCtrl.cache.AcctInfoMap.GetAccount(account)
 	return ac != nil && ac.RoleId == q.permCtrl.permConfig.NwAdminRole
 }
 
 func (q *QuorumControlsAPI) isOrgAdmin(account common.Address, orgId string) (ExecStatus, error) {
-	org := types.OrgInfoMap.GetOrg(orgId)
+	org := q.permCtrl.cache.OrgInfoMap.GetOrg(orgId)
 	if org == nil {
 		return ErrOrgDoesNotExists, errors.New("invalid org")
 	}
-	ac := types.AcctInfoMap.GetAccount(account)
+	ac := q.permCtrl.cache.AcctInfoMap.GetAccount(account)
 	if ac == nil {
 		return ErrNotOrgAdmin, errors.New("not org admin")
 	}
@@ -562,14 +623,14 @@ func (q *QuorumControlsAPI) isOrgAdmin(account common.Address, orgId string) (Ex
 func (q *QuorumControlsAPI) validateOrg(orgId, pOrgId string) (ExecStatus, error) {
 	// validate Parent org id
 	if pOrgId != "" {
-		if types.OrgInfoMap.GetOrg(pOrgId) == nil {
+		if q.permCtrl.cache.OrgInfoMap.GetOrg(pOrgId) == nil {
 			return ErrInvalidParentOrg, errors.New("invalid parent org")
 		}
 		locOrgId := pOrgId + "." + orgId
-		if types.OrgInfoMap.GetOrg(locOrgId) != nil {
+		if q.permCtrl.cache.OrgInfoMap.GetOrg(locOrgId) != nil {
 			return ErrOrgExists, errors.New("org exists")
 		}
-	} else if types.OrgInfoMap.GetOrg(orgId) != nil {
+	} else if q.permCtrl.cache.OrgInfoMap.GetOrg(orgId) != nil {
 		return ErrOrgExists, errors.New("org exists")
 	}
 	return ExecSuccess, nil
@@ -586,7 +647,7 @@ func (q *QuorumControlsAPI) checkPendingOp(orgId string, pinterf *pbind.PermInte
 }
 
 func (q *QuorumControlsAPI) checkOrgStatus(orgId string, op uint8) (ExecStatus, error) {
-	org := types.OrgInfoMap.GetOrg(orgId)
+	org := q.permCtrl.cache.OrgInfoMap.GetOrg(orgId)
 
 	if org == nil {
 		return ErrOrgDoesNotExists, errors.New("org does not exist")
@@ -612,7 +673,7 @@ func (q *QuorumControlsAPI) valNodeStatusChange(orgId, url string, op NodeUpdate
 		return execStatus, errors.New("node not found")
 	}
 
-	node := types.NodeInfoMap.GetNodeByUrl(url)
+	node := q.permCtrl.cache.NodeInfoMap.GetNodeByUrl(url)
 	if node != nil {
 		if node.OrgId != orgId {
 			return ErrNodeOrgMismatch, errors.New("node does not belong to the organization passed")
@@ -645,9 +706,9 @@ func (q *QuorumControlsAPI) valNodeStatusChange(orgId, url string, op NodeUpdate
 
 func (q *QuorumControlsAPI) validateRole(orgId, roleId string) bool {
 	var r *types.RoleInfo
-	r = types.RoleInfoMap.GetRole(orgId, roleId)
+	r = q.permCtrl.cache.RoleInfoMap.GetRole(orgId, roleId)
 	if r == nil {
-		r = types.RoleInfoMap.GetRole(types.OrgInfoMap.GetOrg(orgId).UltimateParent, roleId)
+		r = q.permCtrl.cache.RoleInfoMap.GetRole(q.permCtrl.cache.OrgInfoMap.GetOrg(orgId).UltimateParent, roleId)
 	}
 
 	return r != nil && r.Active
@@ -655,7 +716,7 @@ func (q *QuorumControlsAPI) validateRole(orgId, roleId string) bool {
 
 func (q *QuorumControlsAPI) valAccountStatusChange(orgId string, account common.Address, permAction PermAction, op AccountUpdateAction) (ExecStatus, error) {
 	// validates if the enode is linked the passed organization
-	ac := types.AcctInfoMap.GetAccount(account)
+	ac := q.permCtrl.cache.AcctInfoMap.GetAccount(account)
 
 	if ac == nil {
 		return ErrAccountNotThere, errors.New("account not there")
@@ -689,7 +750,7 @@ func (q *QuorumControlsAPI) valAccountStatusChange(orgId string, account common.
 }
 
 func (q *QuorumControlsAPI) checkOrgAdminExists(orgId, roleId string, account common.Address) (ExecStatus, error) {
-	ac := types.AcctInfoMap.GetAccount(account)
+	ac := q.permCtrl.cache.AcctInfoMap.GetAccount(account)
 
 	if ac != nil {
 		if ac.OrgId != orgId {
@@ -703,7 +764,7 @@ func (q *QuorumControlsAPI) checkOrgAdminExists(orgId, roleId string, account co
 }
 
 func (q *QuorumControlsAPI) valSubOrgBreadthDepth(porgId string) (ExecStatus, error) {
-	org := types.OrgInfoMap.GetOrg(porgId)
+	org := q.permCtrl.cache.OrgInfoMap.GetOrg(porgId)
 
 	if q.permCtrl.permConfig.SubOrgDepth.Cmp(org.Level) == 0 {
 		return ErrMaxDepth, errors.New("max depth for sub orgs reached")
@@ -717,12 +778,12 @@ func (q *QuorumControlsAPI) valSubOrgBreadthDepth(porgId string) (ExecStatus, er
 }
 
 func (q *QuorumControlsAPI) checkNodeExists(url, enodeId string) bool {
-	node := types.NodeInfoMap.GetNodeByUrl(url)
+	node := q.permCtrl.cache.NodeInfoMap.GetNodeByUrl(url)
 	if node != nil {
 		return true
 	}
 	// check if the same nodeid is in use with different port numbers
-	nodeList := types.NodeInfoMap.GetNodeList()
+	nodeList := q.permCtrl.cache.NodeInfoMap.GetNodeList()
 	for _, n := range nodeList {
 		if enodeDet, er := enode.ParseV4(n.Url); er == nil {
 			if enodeDet.URLv4() == enodeId {
@@ -927,7 +988,7 @@ func (q *QuorumControlsAPI) valApproveAdminRole(args txArgs, pinterf *pbind.Perm
 	// check if the org exists
 
 	// check if account is valid
-	ac := types.AcctInfoMap.GetAccount(args.acctId)
+	ac := q.permCtrl.cache.AcctInfoMap.GetAccount(args.acctId)
 	if ac == nil {
 		return ErrInvalidAccount
 	}
@@ -947,7 +1008,7 @@ func (q *QuorumControlsAPI) valAddNewRole(args txArgs, pinterf *pbind.PermInterf
 		return execStatus
 	}
 	// validate if role is already present
-	if types.RoleInfoMap.GetRole(args.orgId, args.roleId) != nil {
+	if q.permCtrl.cache.RoleInfoMap.GetRole(args.orgId, args.roleId) != nil {
 		return ErrRoleExists
 	}
 	return ExecSuccess
@@ -965,7 +1026,7 @@ func (q *QuorumControlsAPI) valRemoveRole(args txArgs, pinterf *pbind.PermInterf
 	}
 
 	// check if role is alraedy inactive
-	r := types.RoleInfoMap.GetRole(args.orgId, args.roleId)
+	r := q.permCtrl.cache.RoleInfoMap.GetRole(args.orgId, args.roleId)
 	if r == nil {
 		return ErrInvalidRole
 	} else if !r.Active {
@@ -973,7 +1034,7 @@ func (q *QuorumControlsAPI) valRemoveRole(args txArgs, pinterf *pbind.PermInterf
 	}
 
 	// check if the role has active accounts. if yes operations should not be allowed
-	if len(types.AcctInfoMap.GetAcctListRole(args.orgId, args.roleId)) != 0 {
+	if len(q.permCtrl.cache.GetAcctListRole(args.orgId, args.roleId)) != 0 {
 		return ErrRoleActive
 	}
 	return ExecSuccess
@@ -997,7 +1058,7 @@ func (q *QuorumControlsAPI) valAssignRole(args txArgs, pinterf *pbind.PermInterf
 	}
 
 	// check if the account is part of another org
-	if ac := types.AcctInfoMap.GetAccount(args.acctId); ac != nil {
+	if ac := q.permCtrl.cache.AcctInfoMap.GetAccount(args.acctId); ac != nil {
 		if ac.OrgId != args.orgId {
 			return ErrAccountInUse
 		}
@@ -1079,6 +1140,38 @@ func (q *QuorumControlsAPI) valRecoverAccount(args txArgs, pinterf *pbind.PermIn
 	return ExecSuccess
 }
 
+// valFunctionRule validates the addition or the removal of a function rule
+func (q *QuorumControlsAPI) valFunctionRule(args txArgs, add bool) ExecStatus {
+	if args.contract == (common.Address{}) {
+		return ErrInvalidInput
+	}
+	// check if caller is network admin
+	if !q.isNetworkAdmin(args.txa.From) {
+		return ErrNotNetworkAdmin
+	}
+	// admin roles are never restricted by function rules
+	if args.roleId == q.permCtrl.permConfig.OrgAdminRole || args.roleId == q.permCtrl.permConfig.NwAdminRole {
+		return ErrAdminRoleRules
+	}
+	if r := q.permCtrl.cache.RoleInfoMap.GetRole(args.orgId, args.roleId); r == nil || !r.Active {
+		return ErrInvalidRole
+	}
+
+	active := false
+	for _, rule := range q.permCtrl.cache.RuleInfoMap.GetRoleRules(args.orgId, args.roleId) {
+		if rule.Contract == args.contract && rule.Selector == args.selector {
+			active = rule.Active
+		}
+	}
+	if add && active {
+		return ErrRuleExists
+	}
+	if !add && !active {
+		return ErrRuleDoesNotExist
+	}
+	return ExecSuccess
+}
+
 // validateAccount validates the account and returns the wallet associated with that for signing the transaction
 func (q *QuorumControlsAPI) validateAccount(from common.Address) (accounts.Wallet, error) {
 	acct := accounts.Account{Address: from}
@@ -1106,6 +1199,31 @@ func (q *QuorumControlsAPI) newPermInterfaceSession(w accounts.Wallet, txa ethap
 	return ps
 }
 
+// initRuleOp creates a session of the function rule manager signing with the
+// account of txa
+func (q *QuorumControlsAPI) initRuleOp(txa ethapi.SendTxArgs) (*pbind.FunctionRuleManagerSession, ExecStatus) {
+	if q.permCtrl.permRule == nil {
+		return nil, ErrRulesDisabled
+	}
+	w, err := q.validateAccount(txa.From)
+	if err != nil {
+		return nil, ErrInvalidAccount
+	}
+	frmAcct, transactOpts, gasLimit, gasPrice := q.getTxParams(txa, w)
+	return &pbind.FunctionRuleManagerSession{
+		Contract: q.permCtrl.permRule,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+		TransactOpts: bind.TransactOpts{
+			From:     frmAcct.Address,
+			GasLimit: gasLimit,
+			GasPrice: gasPrice,
+			Signer:   transactOpts.Signer,
+		},
+	}, ExecSuccess
+}
+
 // getTxParams extracts the transaction related parameters
 func (q *QuorumControlsAPI) getTxParams(txa ethapi.SendTxArgs, w accounts.Wallet) (accounts.Account, *bind.TransactOpts, uint64, *big.Int) {
 	fromAcct := accounts.Account{Address: txa.From}
@@ -0,0 +1,473 @@
+// Package audit indexes the events of the permission contracts into a history
+// of the permission changes, who made them and when.
+package audit
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/ethdb"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
+	"go-smilo/src/blockchain/smilobft/params"
+	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
+)
+
+var (
+	entryPrefix = []byte("permission-audit-e")    // entryPrefix + num (uint64 big endian) + log index (uint32 big endian) -> entry
+	headKey     = []byte("permission-audit-head") // number and hash of the last indexed block
+)
+
+// Entry is a permission change, decoded from an event of a permission contract
+type Entry struct {
+	BlockNumber uint64            `json:"blockNumber"`
+	BlockHash   common.Hash       `json:"blockHash"`
+	Time        uint64            `json:"time"`
+	TxHash      common.Hash       `json:"txHash"`
+	LogIndex    uint              `json:"logIndex"`
+	Sender      common.Address    `json:"sender"` // account that sent the transaction making the change
+	Contract    string            `json:"contract"`
+	Event       string            `json:"event"`
+	OrgId       string            `json:"orgId,omitempty"`
+	EnodeId     string            `json:"enodeId,omitempty"`
+	Account     *common.Address   `json:"account,omitempty"`
+	RoleId      string            `json:"roleId,omitempty"`
+	Args        map[string]string `json:"args"` // every argument of the event
+}
+
+// Query selects entries of the log, the criteria set are all matched
+type Query struct {
+	OrgId     string          `json:"orgId"`
+	Account   *common.Address `json:"account"` // matches the changes made by the account or to it
+	Node      string          `json:"node"`    // enode url or id
+	FromBlock uint64          `json:"fromBlock"`
+	ToBlock   uint64          `json:"toBlock"` // 0 for the last indexed block
+}
+
+// Chain is the part of the blockchain the log is built from
+type Chain interface {
+	Config() *params.ChainConfig
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+type contract struct {
+	name string
+	abi  abi.ABI
+}
+
+// lastBlock is the last indexed block
+type lastBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Log is the history of the permission changes. It is built block by block
+// from the logs of the permission contracts and indexed by org, account and
+// node. The blocks that leave the canonical chain are dropped from the log.
+type Log struct {
+	contracts map[common.Address]*contract
+	indexMu   sync.Mutex          // serializes the indexing of the blocks
+	db        ethdb.KeyValueStore // database the log is persisted to, nil to keep it in memory
+
+	mu        sync.RWMutex
+	entries   []Entry
+	byOrg     map[string][]int
+	byAccount map[common.Address][]int
+	byNode    map[string][]int
+	next      uint64      // next block to index
+	head      common.Hash // hash of the last indexed block
+}
+
+// New creates an empty log of the permission contracts deployed at the
+// addresses of config
+func New(config *types.PermissionConfig) (*Log, error) {
+	l := &Log{
+		contracts: make(map[common.Address]*contract),
+		byOrg:     make(map[string][]int),
+		byAccount: make(map[common.Address][]int),
+		byNode:    make(map[string][]int),
+	}
+	for _, c := range []struct {
+		name    string
+		address common.Address
+		abi     string
+	}{
+		{"PermissionsImplementation", config.ImplAddress, pbind.PermImplABI},
+		{"OrgManager", config.OrgAddress, pbind.OrgManagerABI},
+		{"NodeManager", config.NodeAddress, pbind.NodeManagerABI},
+		{"RoleManager", config.RoleAddress, pbind.RoleManagerABI},
+		{"AccountManager", config.AccountAddress, pbind.AcctManagerABI},
+		{"VoterManager", config.VoterAddress, pbind.VoterManagerABI},
+		{"FunctionRuleManager", config.RuleAddress, pbind.FunctionRuleManagerABI},
+	} {
+		if c.address == (common.Address{}) {
+			continue
+		}
+		parsed, err := abi.JSON(strings.NewReader(c.abi))
+		if err != nil {
+			return nil, err
+		}
+		l.contracts[c.address] = &contract{name: c.name, abi: parsed}
+	}
+	return l, nil
+}
+
+// Open loads the log persisted in db and persists the blocks indexed from now
+// on. It must be called before the first block is indexed.
+func (l *Log) Open(db ethdb.KeyValueStore) error {
+	l.indexMu.Lock()
+	defer l.indexMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next != 0 {
+		return errors.New("audit log already indexed blocks")
+	}
+	l.db = db
+	if ok, err := db.Has(headKey); err != nil || !ok {
+		return err
+	}
+	blob, err := db.Get(headKey)
+	if err != nil {
+		return err
+	}
+	var last lastBlock
+	if err := json.Unmarshal(blob, &last); err != nil {
+		return err
+	}
+	it := db.NewIteratorWithPrefix(entryPrefix)
+	defer it.Release()
+	for it.Next() {
+		var e Entry
+		if err := json.Unmarshal(it.Value(), &e); err != nil {
+			return err
+		}
+		l.insert(e)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	l.next, l.head = last.Number+1, last.Hash
+	return nil
+}
+
+// IndexChain indexes the blocks of chain following the last indexed one up to
+// head. The blocks whose bloom doesn't match a permission contract are skipped
+// without reading their receipts. If the last indexed block is not canonical
+// anymore, the blocks following the common ancestor are indexed again.
+func (l *Log) IndexChain(chain Chain, head uint64) error {
+	l.indexMu.Lock()
+	defer l.indexMu.Unlock()
+
+	if err := l.rewind(chain); err != nil {
+		return err
+	}
+	for number := l.next; number <= head; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("block #%d not found", number)
+		}
+		var (
+			block    *types.Block
+			receipts types.Receipts
+		)
+		if l.bloomMatch(header.Bloom) {
+			if block = chain.GetBlock(header.Hash(), number); block == nil {
+				return fmt.Errorf("block #%d [%x…] not found", number, header.Hash().Bytes()[:4])
+			}
+			receipts = chain.GetReceiptsByHash(block.Hash())
+		}
+
+		// the log is only locked while a block is added, not during the reads
+		l.mu.Lock()
+		first := len(l.entries)
+		if block != nil {
+			signer := types.MakeSigner(chain.Config(), block.Number())
+			for _, receipt := range receipts {
+				for _, log := range receipt.Logs {
+					l.add(block, signer, log)
+				}
+			}
+		}
+		err := l.persist(l.entries[first:], nil, number, header.Hash())
+		if err != nil {
+			l.truncate(first)
+		} else {
+			l.next, l.head = number+1, header.Hash()
+		}
+		l.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewind drops the entries of the indexed blocks that left the canonical
+// chain, walking back from the last indexed block to the common ancestor
+func (l *Log) rewind(chain Chain) error {
+	if l.next == 0 {
+		return nil
+	}
+	number, hash := l.next-1, l.head
+	for {
+		if header := chain.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+			break
+		}
+		if number == 0 {
+			return errors.New("audit log indexed another genesis block")
+		}
+		old := chain.GetHeader(hash, number)
+		if old == nil {
+			return fmt.Errorf("indexed block #%d [%x…] not found", number, hash.Bytes()[:4])
+		}
+		number, hash = number-1, old.ParentHash
+	}
+	if number == l.next-1 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cut := len(l.entries)
+	for cut > 0 && l.entries[cut-1].BlockNumber > number {
+		cut--
+	}
+	if err := l.persist(nil, l.entries[cut:], number, hash); err != nil {
+		return err
+	}
+	l.truncate(cut)
+	l.next, l.head = number+1, hash
+	return nil
+}
+
+// persist writes the added entries, deletes the dropped ones and moves the
+// last indexed block in a single batch
+func (l *Log) persist(added, dropped []Entry, number uint64, hash common.Hash) error {
+	if l.db == nil {
+		return nil
+	}
+	batch := l.db.NewBatch()
+	for _, e := range dropped {
+		if err := batch.Delete(entryKey(e)); err != nil {
+			return err
+		}
+	}
+	for _, e := range added {
+		blob, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(entryKey(e), blob); err != nil {
+			return err
+		}
+	}
+	blob, err := json.Marshal(lastBlock{Number: number, Hash: hash})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(headKey, blob); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// truncate drops the entries from the cut on, with their indexes
+func (l *Log) truncate(cut int) {
+	l.entries = l.entries[:cut]
+	for key, ids := range l.byOrg {
+		if l.byOrg[key] = trimIds(ids, cut); len(l.byOrg[key]) == 0 {
+			delete(l.byOrg, key)
+		}
+	}
+	for key, ids := range l.byAccount {
+		if l.byAccount[key] = trimIds(ids, cut); len(l.byAccount[key]) == 0 {
+			delete(l.byAccount, key)
+		}
+	}
+	for key, ids := range l.byNode {
+		if l.byNode[key] = trimIds(ids, cut); len(l.byNode[key]) == 0 {
+			delete(l.byNode, key)
+		}
+	}
+}
+
+// trimIds drops the entry ids from the cut on, the ids are in increasing order
+func trimIds(ids []int, cut int) []int {
+	n := len(ids)
+	for n > 0 && ids[n-1] >= cut {
+		n--
+	}
+	return ids[:n]
+}
+
+func entryKey(e Entry) []byte {
+	key := make([]byte, len(entryPrefix)+12)
+	copy(key, entryPrefix)
+	binary.BigEndian.PutUint64(key[len(entryPrefix):], e.BlockNumber)
+	binary.BigEndian.PutUint32(key[len(entryPrefix)+8:], uint32(e.LogIndex))
+	return key
+}
+
+// Indexed returns the number of the last indexed block, false if none is
+func (l *Log) Indexed() (uint64, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.next - 1, l.next > 0
+}
+
+// Query returns the entries matching q, oldest first
+func (l *Log) Query(q Query) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var candidates []int
+	switch {
+	case q.OrgId != "":
+		candidates = l.byOrg[q.OrgId]
+	case q.Account != nil:
+		candidates = l.byAccount[*q.Account]
+	case q.Node != "":
+		candidates = l.byNode[nodeKey(q.Node)]
+	default:
+		candidates = make([]int, len(l.entries))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	entries := []Entry{}
+	for _, i := range candidates {
+		e := l.entries[i]
+		if e.BlockNumber < q.FromBlock || (q.ToBlock != 0 && e.BlockNumber > q.ToBlock) {
+			continue
+		}
+		if q.OrgId != "" && e.OrgId != q.OrgId {
+			continue
+		}
+		if q.Account != nil && !e.involves(*q.Account) {
+			continue
+		}
+		if q.Node != "" && (e.EnodeId == "" || nodeKey(e.EnodeId) != nodeKey(q.Node)) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func (e *Entry) involves(account common.Address) bool {
+	return e.Sender == account || (e.Account != nil && *e.Account == account)
+}
+
+func (l *Log) bloomMatch(bloom types.Bloom) bool {
+	for address := range l.contracts {
+		if types.BloomLookup(bloom, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// add decodes a log of a permission contract into an entry and indexes it
+func (l *Log) add(block *types.Block, signer types.Signer, log *types.Log) {
+	c, ok := l.contracts[log.Address]
+	if !ok || len(log.Topics) == 0 {
+		return
+	}
+	var event *abi.Event
+	for _, ev := range c.abi.Events {
+		if ev.ID() == log.Topics[0] {
+			ev := ev
+			event = &ev
+			break
+		}
+	}
+	if event == nil {
+		return
+	}
+	values := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return
+	}
+
+	e := Entry{
+		BlockNumber: block.NumberU64(),
+		BlockHash:   block.Hash(),
+		Time:        block.Time(),
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		Contract:    c.name,
+		Event:       event.Name,
+		Args:        make(map[string]string, len(values)),
+	}
+	if tx := block.Transaction(log.TxHash); tx != nil {
+		e.Sender, _ = types.Sender(signer, tx)
+	}
+	for name, value := range values {
+		e.Args[strings.TrimPrefix(name, "_")] = formatValue(value)
+		switch name {
+		case "_orgId":
+			e.OrgId = value.(string)
+		case "_enodeId":
+			e.EnodeId = value.(string)
+		case "_roleId":
+			e.RoleId = value.(string)
+		case "_account", "_vAccount":
+			account := value.(common.Address)
+			e.Account = &account
+		}
+	}
+	l.insert(e)
+}
+
+// insert appends an entry to the log and indexes it
+func (l *Log) insert(e Entry) {
+	i := len(l.entries)
+	l.entries = append(l.entries, e)
+	if e.OrgId != "" {
+		l.byOrg[e.OrgId] = append(l.byOrg[e.OrgId], i)
+	}
+	if e.EnodeId != "" {
+		key := nodeKey(e.EnodeId)
+		l.byNode[key] = append(l.byNode[key], i)
+	}
+	l.byAccount[e.Sender] = append(l.byAccount[e.Sender], i)
+	if e.Account != nil && *e.Account != e.Sender {
+		l.byAccount[*e.Account] = append(l.byAccount[*e.Account], i)
+	}
+}
+
+// nodeKey identifies a node by its id when the url parses, so that a node can
+// be looked up by its full url as well as by its id
+func nodeKey(url string) string {
+	if node, err := enode.ParseV4(url); err == nil {
+		return node.ID().String()
+	}
+	return url
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case [4]byte:
+		return hexutil.Encode(v[:])
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
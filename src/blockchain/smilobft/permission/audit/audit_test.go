@@ -0,0 +1,340 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/core/rawdb"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/params"
+	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
+)
+
+const testNode = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@127.0.0.1:21000?discport=0"
+
+var (
+	testConfig = &types.PermissionConfig{
+		OrgAddress:     common.HexToAddress("0x01"),
+		NodeAddress:    common.HexToAddress("0x02"),
+		AccountAddress: common.HexToAddress("0x03"),
+	}
+	adminKey, _ = crypto.GenerateKey()
+	admin       = crypto.PubkeyToAddress(adminKey.PublicKey)
+	member      = common.HexToAddress("0x1234")
+)
+
+// testChain is a chain of blocks whose transactions each emit one event
+type testChain struct {
+	blocks   []*types.Block
+	headers  map[common.Hash]*types.Header // every known header, canonical or not
+	receipts map[common.Hash]types.Receipts
+}
+
+type testEvent struct {
+	contract common.Address
+	abi      string
+	name     string
+	args     []interface{}
+}
+
+func newTestChain(t *testing.T, blocks ...[]testEvent) *testChain {
+	chain := &testChain{headers: make(map[common.Hash]*types.Header), receipts: make(map[common.Hash]types.Receipts)}
+	signer := types.MakeSigner(params.TestChainConfig, big.NewInt(0))
+	nonce := uint64(0)
+	for i, events := range append([][]testEvent{nil}, blocks...) {
+		var (
+			txs      []*types.Transaction
+			receipts types.Receipts
+		)
+		for _, ev := range events {
+			parsed, err := abi.JSON(strings.NewReader(ev.abi))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := parsed.Events[ev.name].Inputs.Pack(ev.args...)
+			if err != nil {
+				t.Fatalf("could not pack %s: %v", ev.name, err)
+			}
+			tx, _ := types.SignTx(types.NewTransaction(nonce, ev.contract, new(big.Int), 100000, new(big.Int), nil), signer, adminKey)
+			nonce++
+			receipt := &types.Receipt{Logs: []*types.Log{{
+				Address: ev.contract,
+				Topics:  []common.Hash{parsed.Events[ev.name].ID()},
+				Data:    data,
+				TxHash:  tx.Hash(),
+				Index:   uint(len(receipts)),
+			}}}
+			receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+			txs, receipts = append(txs, tx), append(receipts, receipt)
+		}
+		header := &types.Header{Number: big.NewInt(int64(i)), Time: uint64(1000 + i)}
+		if i > 0 {
+			header.ParentHash = chain.blocks[i-1].Hash()
+		}
+		block := types.NewBlock(header, txs, nil, receipts)
+		for _, r := range receipts {
+			for _, l := range r.Logs {
+				l.BlockNumber, l.BlockHash = block.NumberU64(), block.Hash()
+			}
+		}
+		chain.blocks = append(chain.blocks, block)
+		chain.headers[block.Hash()] = block.Header()
+		chain.receipts[block.Hash()] = receipts
+	}
+	return chain
+}
+
+func (c *testChain) Config() *params.ChainConfig { return params.TestChainConfig }
+
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *testChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number].Header()
+}
+
+func (c *testChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if number >= uint64(len(c.blocks)) || c.blocks[number].Hash() != hash {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return c.receipts[hash]
+}
+
+func orgApproved(orgId string) testEvent {
+	return testEvent{testConfig.OrgAddress, pbind.OrgManagerABI, "OrgApproved", []interface{}{orgId, "", orgId, big.NewInt(1), big.NewInt(2)}}
+}
+
+func nodeBlacklisted(url, orgId string) testEvent {
+	return testEvent{testConfig.NodeAddress, pbind.NodeManagerABI, "NodeBlacklisted", []interface{}{url, orgId}}
+}
+
+func accountStatusChanged(account common.Address, orgId string, status int64) testEvent {
+	return testEvent{testConfig.AccountAddress, pbind.AcctManagerABI, "AccountStatusChanged", []interface{}{account, orgId, big.NewInt(status)}}
+}
+
+func newTestLog(t *testing.T, chain *testChain) *Log {
+	l, err := New(testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.IndexChain(chain, uint64(len(chain.blocks)-1)); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestIndexChain(t *testing.T) {
+	chain := newTestChain(t,
+		[]testEvent{orgApproved("ORG1")},
+		nil,
+		[]testEvent{nodeBlacklisted(testNode, "ORG1"), accountStatusChanged(member, "ORG1", 4)},
+	)
+	l := newTestLog(t, chain)
+	if last, ok := l.Indexed(); !ok || last != 3 {
+		t.Fatalf("last indexed block mismatch: got %d (%v), want 3", last, ok)
+	}
+
+	entries := l.Query(Query{})
+	if len(entries) != 3 {
+		t.Fatalf("entry count mismatch: got %d, want 3", len(entries))
+	}
+	org := entries[0]
+	if org.Event != "OrgApproved" || org.Contract != "OrgManager" || org.OrgId != "ORG1" {
+		t.Errorf("org entry mismatch: %+v", org)
+	}
+	if org.BlockNumber != 1 || org.Time != 1001 || org.BlockHash != chain.blocks[1].Hash() {
+		t.Errorf("org entry block mismatch: %+v", org)
+	}
+	if org.Sender != admin {
+		t.Errorf("sender mismatch: got %x, want %x", org.Sender, admin)
+	}
+	if org.Args["status"] != "2" || org.Args["ultParent"] != "ORG1" {
+		t.Errorf("org entry args mismatch: %v", org.Args)
+	}
+	if acct := entries[2]; acct.Account == nil || *acct.Account != member || acct.LogIndex != 1 {
+		t.Errorf("account entry mismatch: %+v", acct)
+	}
+
+	// blocks already indexed aren't indexed twice
+	if err := l.IndexChain(chain, 3); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(l.Query(Query{})); n != 3 {
+		t.Errorf("entry count mismatch after a second pass: got %d, want 3", n)
+	}
+	// blocks beyond the chain are reported
+	if err := l.IndexChain(chain, 4); err == nil {
+		t.Error("missing block not reported")
+	}
+}
+
+func TestIndexChainReorg(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	open := func() *Log {
+		l, err := New(testConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Open(db); err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+	events := func(l *Log) string {
+		var events []string
+		for _, e := range l.Query(Query{}) {
+			events = append(events, e.Event+":"+e.OrgId)
+		}
+		return strings.Join(events, ",")
+	}
+
+	chain := newTestChain(t,
+		[]testEvent{orgApproved("ORG1")},
+		nil,
+		[]testEvent{nodeBlacklisted(testNode, "ORG1"), accountStatusChanged(member, "ORG1", 4)},
+	)
+	l := open()
+	if err := l.IndexChain(chain, 3); err != nil {
+		t.Fatal(err)
+	}
+	want := "OrgApproved:ORG1,NodeBlacklisted:ORG1,AccountStatusChanged:ORG1"
+	if have := events(l); have != want {
+		t.Fatalf("events mismatch: have %s, want %s", have, want)
+	}
+
+	// the log is loaded back from the database
+	l = open()
+	if last, ok := l.Indexed(); !ok || last != 3 {
+		t.Fatalf("last indexed block mismatch: got %d (%v), want 3", last, ok)
+	}
+	if have := events(l); have != want {
+		t.Fatalf("events mismatch after reopening: have %s, want %s", have, want)
+	}
+
+	// a shorter chain forking after block 1 replaces blocks 2 and 3
+	fork := newTestChain(t,
+		[]testEvent{orgApproved("ORG1")},
+		[]testEvent{orgApproved("ORG2")},
+	)
+	if fork.blocks[1].Hash() != chain.blocks[1].Hash() || fork.blocks[2].Hash() == chain.blocks[2].Hash() {
+		t.Fatal("fork doesn't share block 1 only")
+	}
+	for hash, header := range chain.headers {
+		fork.headers[hash] = header
+	}
+	if err := l.IndexChain(fork, 2); err != nil {
+		t.Fatal(err)
+	}
+	want = "OrgApproved:ORG1,OrgApproved:ORG2"
+	if have := events(l); have != want {
+		t.Fatalf("events mismatch after the reorg: have %s, want %s", have, want)
+	}
+	if n := len(l.Query(Query{Node: testNode})); n != 0 {
+		t.Errorf("dropped node entry still indexed")
+	}
+	if n := len(l.Query(Query{Account: &member})); n != 0 {
+		t.Errorf("dropped account entry still indexed")
+	}
+
+	// the reorg is persisted too
+	l = open()
+	if last, ok := l.Indexed(); !ok || last != 2 {
+		t.Fatalf("last indexed block mismatch: got %d (%v), want 2", last, ok)
+	}
+	if have := events(l); have != want {
+		t.Fatalf("events mismatch after reopening: have %s, want %s", have, want)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	chain := newTestChain(t,
+		[]testEvent{orgApproved("ORG1"), orgApproved("ORG2")},
+		[]testEvent{nodeBlacklisted(testNode, "ORG1")},
+		[]testEvent{accountStatusChanged(member, "ORG2", 4)},
+	)
+	l := newTestLog(t, chain)
+	nodeID := testNode[len("enode://"):strings.Index(testNode, "@")]
+	other := common.HexToAddress("0x5678")
+
+	tests := []struct {
+		query  Query
+		events []string
+	}{
+		{Query{OrgId: "ORG1"}, []string{"OrgApproved", "NodeBlacklisted"}},
+		{Query{OrgId: "ORG2", FromBlock: 2}, []string{"AccountStatusChanged"}},
+		{Query{Node: testNode}, []string{"NodeBlacklisted"}},
+		{Query{Node: nodeID}, []string{"NodeBlacklisted"}},
+		{Query{Account: &member}, []string{"AccountStatusChanged"}},
+		{Query{Account: &admin, ToBlock: 2}, []string{"OrgApproved", "OrgApproved", "NodeBlacklisted"}},
+		{Query{Account: &other}, nil},
+		{Query{OrgId: "ORG1", Account: &member}, nil},
+		{Query{FromBlock: 2, ToBlock: 2}, []string{"NodeBlacklisted"}},
+	}
+	for i, test := range tests {
+		var events []string
+		for _, e := range l.Query(test.query) {
+			events = append(events, e.Event)
+		}
+		if strings.Join(events, ",") != strings.Join(test.events, ",") {
+			t.Errorf("test %d: events mismatch: got %v, want %v", i, events, test.events)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	l := newTestLog(t, newTestChain(t, []testEvent{accountStatusChanged(member, "ORG1", 4)}))
+	entries := l.Query(Query{})
+
+	var out bytes.Buffer
+	if err := Export(&out, entries, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || len(records[1]) != len(csvHeader) {
+		t.Fatalf("csv export mismatch: %v", records)
+	}
+	if records[1][5] != admin.Hex() || records[1][10] != member.Hex() {
+		t.Errorf("csv record mismatch: %v", records[1])
+	}
+	if want := "account=" + member.Hex() + ";orgId=ORG1;status=4"; records[1][12] != want {
+		t.Errorf("csv args mismatch: got %s, want %s", records[1][12], want)
+	}
+
+	out.Reset()
+	if err := Export(&out, entries, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Entry
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].Sender != admin || decoded[0].OrgId != "ORG1" {
+		t.Errorf("json export mismatch: %+v", decoded)
+	}
+
+	if err := Export(&out, entries, "xml"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formats the entries can be exported in
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+var csvHeader = []string{
+	"blockNumber", "blockHash", "time", "txHash", "logIndex", "sender",
+	"contract", "event", "orgId", "enodeId", "account", "roleId", "args",
+}
+
+// Export writes entries to w in the given format. The CSV export has one row
+// per entry, the arguments of the event are joined in its last column.
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			account := ""
+			if e.Account != nil {
+				account = e.Account.Hex()
+			}
+			record := []string{
+				strconv.FormatUint(e.BlockNumber, 10),
+				e.BlockHash.Hex(),
+				strconv.FormatUint(e.Time, 10),
+				e.TxHash.Hex(),
+				strconv.FormatUint(uint64(e.LogIndex), 10),
+				e.Sender.Hex(),
+				e.Contract,
+				e.Event,
+				e.OrgId,
+				e.EnodeId,
+				account,
+				e.RoleId,
+				strings.Join(sortedArgs(e.Args), ";"),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown export format %q, want %s or %s", format, FormatCSV, FormatJSON)
+	}
+}
+
+// sortedArgs returns the arguments of an entry as name=value pairs sorted by name
+func sortedArgs(args map[string]string) []string {
+	pairs := make([]string, 0, len(args))
+	for name, value := range args {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
@@ -0,0 +1,650 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package permission
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "go-smilo/src/blockchain/smilobft"
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	"go-smilo/src/blockchain/smilobft/accounts/abi/bind"
+	"go-smilo/src/blockchain/smilobft/core/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FunctionRuleManagerABI is the input ABI used to generate the binding from.
+const FunctionRuleManagerABI = "[{\"constant\":true,\"inputs\":[{\"name\":\"_ruleIndex\",\"type\":\"uint256\"}],\"name\":\"getRuleFromIndex\",\"outputs\":[{\"name\":\"\",\"type\":\"string\"},{\"name\":\"\",\"type\":\"string\"},{\"name\":\"\",\"type\":\"address\"},{\"name\":\"\",\"type\":\"bytes4\"},{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"removeFunctionRule\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_index\",\"type\":\"uint256\"}],\"name\":\"getRoleRuleFromIndex\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"},{\"name\":\"\",\"type\":\"bytes4\"},{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_roleId\",\"type\":\"string\"}],\"name\":\"getNumberOfRoleRules\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"isFunctionAllowed\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"addFunctionRule\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getNumberOfRules\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"_permUpgradable\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"_orgId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_roleId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_contract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"FunctionRuleAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"_orgId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_roleId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_contract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"FunctionRuleRemoved\",\"type\":\"event\"}]"
+
+// FunctionRuleManagerBin is the compiled bytecode used for deploying new contracts.
+const FunctionRuleManagerBin = `346100375760206020380360003960005173ffffffffffffffffffffffffffffffffffffffff1660005561131d8061003c6000396000f35b600080fd60043610611188576000357c0100000000000000000000000000000000000000000000000000000000900480635fc535011461007c57806321b133ae1461069857806317d8d87b1461096957806385716c001461097a57806302f4eb0314610bf5578063b5a574db14610d125780636f051aa914610ef457611188565b3461118857608436106111885760043563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100a0526020016100805260243563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100e0526020016100c05260443573ffffffffffffffffffffffffffffffffffffffff16610100526064357fffffffff0000000000000000000000000000000000000000000000000000000016610120527fe572515c0000000000000000000000000000000000000000000000000000000060005260005473ffffffffffffffffffffffffffffffffffffffff16803b15611188576020600060046000845afa1561118d575060203d106111885760005173ffffffffffffffffffffffffffffffffffffffff167fd1aa0c200000000000000000000000000000000000000000000000000000000060005233600452803b15611188576020600060246000845afa1561118d575060203d106111885760005115611199576080610400526100a051601f016020900460200260a00161042052610100516104405261012051610460526100a051610480526100a051610080516104a0376104a06101a0526100a051601f01602090046020026104a0016100e051815280602001806101c0526100e0516100c0518237506100e051601f016020900460200201602001610400900361018052610180516104002060005260026020526040600020806101e05254801561032357600190036003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6016002018054807801000000000000000000000000000000000000000000000000900460ff166112155778010000000000000000000000000000000000000000000000001790556105e0565b5060035460010180600355806101e05155600154806001016001556003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf60161020052610200516102c0526100a051602011156103a3576100a0516020036101000a60019003196101a05151166100a051600202176102c05155610414565b6100a0516002026001016102c051556102c0516000526020600020610340526100a051601f016020900460200260209004610360526000610320525b6103605161032051101561041457610320516020026101a05101516103205161034051015561032051600101610320526103df565b610200516001016102c0526100e05160201115610453576100e0516020036101000a60019003196101c05151166100e051600202176102c051556104c4565b6100e0516002026001016102c051556102c0516000526020600020610340526100e051601f016020900460200260209004610360526000610320525b610360516103205110156104c457610320516020026101c051015161032051610340510155610320516001016103205261048f565b610120517c010000000000000000000000000000000000000000000000000000000090047401000000000000000000000000000000000000000002610100511778010000000000000000000000000000000000000000000000001761020051600201556040610400526100a051601f0160209004602002606001610420526100a051610440526100a05161008051610460376104606101a0526100a051601f0160209004602002610460016100e051815280602001806101c0526100e0516100c0518237506100e051601f01602090046020020160200161040090036101805261018051610400206000526004602052604060002061022052610220515480600101610220515590600190039061022051600052602060002001555b6080610400526100a051601f016020900460200260a00161042052610100516104405261012051610460526100a051610480526100a051610080516104a0376104a06101a0526100a051601f01602090046020026104a0016100e051815280602001806101c0526100e0516100c0518237506100e051601f0160209004602002016020016104009003610180527f6e0393a5b7d4eab24ec1e04ea91847506f7c7570a1fb116f9235d5ae2bed835361018051610400a1005b3461118857608436106111885760043563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100a0526020016100805260243563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100e0526020016100c05260443573ffffffffffffffffffffffffffffffffffffffff16610100526064357fffffffff0000000000000000000000000000000000000000000000000000000016610120527fe572515c0000000000000000000000000000000000000000000000000000000060005260005473ffffffffffffffffffffffffffffffffffffffff16803b15611188576020600060046000845afa1561118d575060203d106111885760005173ffffffffffffffffffffffffffffffffffffffff167fd1aa0c200000000000000000000000000000000000000000000000000000000060005233600452803b15611188576020600060246000845afa1561118d575060203d106111885760005115611199576080610400526100a051601f016020900460200260a00161042052610100516104405261012051610460526100a051610480526100a051610080516104a0376104a06101a0526100a051601f01602090046020026104a0016100e051815280602001806101c0526100e0516100c0518237506100e051601f01602090046020020160200161040090036101805261018051610400206000526002602052604060002054801561126d57600190036003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6016002018054807801000000000000000000000000000000000000000000000000900460ff16156112c5577f00000000000000ff000000000000000000000000000000000000000000000000191690557f3eb989dd9288e71bba20529f6df6648b9166df122de31cc05bfb3335e233ef7161018051610400a1005b346111885760035460005260206000f35b34611188576024361061118857600435600154811015611197576003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6016102005260a061040052610200516102c0526104a0610380526102c0515480600116610a0f578060ff1660029004806103805152601f01602090046020026020016103a05260ff19166103805160200152610a86565b6001900360029004806103805152601f0160209004602002806020016103a05260209004610360526102c0516000526020600020610340526000610320525b61036051610320511015610a865761032051610340510154610320516020026103805101602001526103205160010161032052610a4e565b6103a05160a00161042052610200516001016102c0526103a0516104a001610380526102c0515480600116610ae5578060ff1660029004806103805152601f01602090046020026020016103c05260ff19166103805160200152610b5c565b6001900360029004806103805152601f0160209004602002806020016103c05260209004610360526102c0516000526020600020610340526000610320525b61036051610320511015610b5c5761032051610340510154610320516020026103805101602001526103205160010161032052610b24565b61020051600201548073ffffffffffffffffffffffffffffffffffffffff16610440528074010000000000000000000000000000000000000000900463ffffffff167c010000000000000000000000000000000000000000000000000000000002610460527801000000000000000000000000000000000000000000000000900460ff16610480526103c0516103a0510160a001610400f35b3461118857604436106111885760043563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100a0526020016100805260243563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100e0526020016100c0526040610400526100a051601f0160209004602002606001610420526100a051610440526100a05161008051610460376104606101a0526100a051601f0160209004602002610460016100e051815280602001806101c0526100e0516100c0518237506100e051601f01602090046020020160200161040090036101805261018051610400206000526004602052604060002061022052610220515460005260206000f35b3461118857606436106111885760043563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100a0526020016100805260243563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100e0526020016100c0526040610400526100a051601f0160209004602002606001610420526100a051610440526100a05161008051610460376104606101a0526100a051601f0160209004602002610460016100e051815280602001806101c0526100e0516100c0518237506100e051601f016020900460200201602001610400900361018052610180516104002060005260046020526040600020610220526044356102205154811015611197576102205160005260206000200154600154811015611197576003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf601600201548073ffffffffffffffffffffffffffffffffffffffff166000528074010000000000000000000000000000000000000000900463ffffffff167c0100000000000000000000000000000000000000000000000000000000026020527801000000000000000000000000000000000000000000000000900460ff1660405260606000f35b3461118857608436106111885760043563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100a0526020016100805260243563ffffffff811161118857600401803563ffffffff811161118857808201602001369011611188576100e0526020016100c05260443573ffffffffffffffffffffffffffffffffffffffff16610100526064357fffffffff0000000000000000000000000000000000000000000000000000000016610120526040610400526100a051601f0160209004602002606001610420526100a051610440526100a05161008051610460376104606101a0526100a051601f0160209004602002610460016100e051815280602001806101c0526100e0516100c0518237506100e051601f01602090046020020160200161040090036101805261018051610400206000526004602052604060002061022052610220515461024052610220516000526020600020610200526000610280526000610260525b6102405161026051101561117a5761026051610200510154600154811015611197576003027fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf601600201546102a0526102a0517801000000000000000000000000000000000000000000000000900460ff161561116a576001610280526102a05173ffffffffffffffffffffffffffffffffffffffff1661010051141561116a576102a05174010000000000000000000000000000000000000000900463ffffffff167c0100000000000000000000000000000000000000000000000000000000028061012051149015171561116a57600160005260206000f35b610260516001016102605261106f565b610280511560005260206000f35b600080fd5b3d6000803e3d6000fd5bfe5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260266024527f6163636f756e74206973206e6f742061206e6574776f726b2061646d696e20616044527f63636f756e74000000000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260186024527f72756c652065786973747320666f722074686520726f6c65000000000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260136024527f72756c6520646f6573206e6f742065786973740000000000000000000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260126024527f72756c65206973206e6f7420616374697665000000000000000000000000000060445260646000fd`
+
+// DeployFunctionRuleManager deploys a new Ethereum contract, binding an instance of FunctionRuleManager to it.
+func DeployFunctionRuleManager(auth *bind.TransactOpts, backend bind.ContractBackend, _permUpgradable common.Address) (common.Address, *types.Transaction, *FunctionRuleManager, error) {
+	parsed, err := abi.JSON(strings.NewReader(FunctionRuleManagerABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(FunctionRuleManagerBin), backend, _permUpgradable)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &FunctionRuleManager{FunctionRuleManagerCaller: FunctionRuleManagerCaller{contract: contract}, FunctionRuleManagerTransactor: FunctionRuleManagerTransactor{contract: contract}, FunctionRuleManagerFilterer: FunctionRuleManagerFilterer{contract: contract}}, nil
+}
+
+// FunctionRuleManager is an auto generated Go binding around an Ethereum contract.
+type FunctionRuleManager struct {
+	FunctionRuleManagerCaller     // Read-only binding to the contract
+	FunctionRuleManagerTransactor // Write-only binding to the contract
+	FunctionRuleManagerFilterer   // Log filterer for contract events
+}
+
+// FunctionRuleManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FunctionRuleManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FunctionRuleManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FunctionRuleManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FunctionRuleManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FunctionRuleManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FunctionRuleManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FunctionRuleManagerSession struct {
+	Contract     *FunctionRuleManager // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts        // Call options to use throughout this session
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// FunctionRuleManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FunctionRuleManagerCallerSession struct {
+	Contract *FunctionRuleManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts              // Call options to use throughout this session
+}
+
+// FunctionRuleManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FunctionRuleManagerTransactorSession struct {
+	Contract     *FunctionRuleManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts              // Transaction auth options to use throughout this session
+}
+
+// FunctionRuleManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FunctionRuleManagerRaw struct {
+	Contract *FunctionRuleManager // Generic contract binding to access the raw methods on
+}
+
+// FunctionRuleManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FunctionRuleManagerCallerRaw struct {
+	Contract *FunctionRuleManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FunctionRuleManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FunctionRuleManagerTransactorRaw struct {
+	Contract *FunctionRuleManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFunctionRuleManager creates a new instance of FunctionRuleManager, bound to a specific deployed contract.
+func NewFunctionRuleManager(address common.Address, backend bind.ContractBackend) (*FunctionRuleManager, error) {
+	contract, err := bindFunctionRuleManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManager{FunctionRuleManagerCaller: FunctionRuleManagerCaller{contract: contract}, FunctionRuleManagerTransactor: FunctionRuleManagerTransactor{contract: contract}, FunctionRuleManagerFilterer: FunctionRuleManagerFilterer{contract: contract}}, nil
+}
+
+// NewFunctionRuleManagerCaller creates a new read-only instance of FunctionRuleManager, bound to a specific deployed contract.
+func NewFunctionRuleManagerCaller(address common.Address, caller bind.ContractCaller) (*FunctionRuleManagerCaller, error) {
+	contract, err := bindFunctionRuleManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManagerCaller{contract: contract}, nil
+}
+
+// NewFunctionRuleManagerTransactor creates a new write-only instance of FunctionRuleManager, bound to a specific deployed contract.
+func NewFunctionRuleManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*FunctionRuleManagerTransactor, error) {
+	contract, err := bindFunctionRuleManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManagerTransactor{contract: contract}, nil
+}
+
+// NewFunctionRuleManagerFilterer creates a new log filterer instance of FunctionRuleManager, bound to a specific deployed contract.
+func NewFunctionRuleManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*FunctionRuleManagerFilterer, error) {
+	contract, err := bindFunctionRuleManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManagerFilterer{contract: contract}, nil
+}
+
+// bindFunctionRuleManager binds a generic wrapper to an already deployed contract.
+func bindFunctionRuleManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FunctionRuleManagerABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FunctionRuleManager *FunctionRuleManagerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _FunctionRuleManager.Contract.FunctionRuleManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FunctionRuleManager *FunctionRuleManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.FunctionRuleManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FunctionRuleManager *FunctionRuleManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.FunctionRuleManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FunctionRuleManager *FunctionRuleManagerCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _FunctionRuleManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FunctionRuleManager *FunctionRuleManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FunctionRuleManager *FunctionRuleManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetNumberOfRoleRules is a free data retrieval call binding the contract method 0x02f4eb03.
+//
+// Solidity: function getNumberOfRoleRules(string _orgId, string _roleId) constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerCaller) GetNumberOfRoleRules(opts *bind.CallOpts, _orgId string, _roleId string) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _FunctionRuleManager.contract.Call(opts, out, "getNumberOfRoleRules", _orgId, _roleId)
+	return *ret0, err
+}
+
+// GetNumberOfRoleRules is a free data retrieval call binding the contract method 0x02f4eb03.
+//
+// Solidity: function getNumberOfRoleRules(string _orgId, string _roleId) constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerSession) GetNumberOfRoleRules(_orgId string, _roleId string) (*big.Int, error) {
+	return _FunctionRuleManager.Contract.GetNumberOfRoleRules(&_FunctionRuleManager.CallOpts, _orgId, _roleId)
+}
+
+// GetNumberOfRoleRules is a free data retrieval call binding the contract method 0x02f4eb03.
+//
+// Solidity: function getNumberOfRoleRules(string _orgId, string _roleId) constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerCallerSession) GetNumberOfRoleRules(_orgId string, _roleId string) (*big.Int, error) {
+	return _FunctionRuleManager.Contract.GetNumberOfRoleRules(&_FunctionRuleManager.CallOpts, _orgId, _roleId)
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerCaller) GetNumberOfRules(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _FunctionRuleManager.contract.Call(opts, out, "getNumberOfRules")
+	return *ret0, err
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerSession) GetNumberOfRules() (*big.Int, error) {
+	return _FunctionRuleManager.Contract.GetNumberOfRules(&_FunctionRuleManager.CallOpts)
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_FunctionRuleManager *FunctionRuleManagerCallerSession) GetNumberOfRules() (*big.Int, error) {
+	return _FunctionRuleManager.Contract.GetNumberOfRules(&_FunctionRuleManager.CallOpts)
+}
+
+// GetRoleRuleFromIndex is a free data retrieval call binding the contract method 0xb5a574db.
+//
+// Solidity: function getRoleRuleFromIndex(string _orgId, string _roleId, uint256 _index) constant returns(address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerCaller) GetRoleRuleFromIndex(opts *bind.CallOpts, _orgId string, _roleId string, _index *big.Int) (common.Address, [4]byte, bool, error) {
+	var (
+		ret0 = new(common.Address)
+		ret1 = new([4]byte)
+		ret2 = new(bool)
+	)
+	out := &[]interface{}{
+		ret0,
+		ret1,
+		ret2,
+	}
+	err := _FunctionRuleManager.contract.Call(opts, out, "getRoleRuleFromIndex", _orgId, _roleId, _index)
+	return *ret0, *ret1, *ret2, err
+}
+
+// GetRoleRuleFromIndex is a free data retrieval call binding the contract method 0xb5a574db.
+//
+// Solidity: function getRoleRuleFromIndex(string _orgId, string _roleId, uint256 _index) constant returns(address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerSession) GetRoleRuleFromIndex(_orgId string, _roleId string, _index *big.Int) (common.Address, [4]byte, bool, error) {
+	return _FunctionRuleManager.Contract.GetRoleRuleFromIndex(&_FunctionRuleManager.CallOpts, _orgId, _roleId, _index)
+}
+
+// GetRoleRuleFromIndex is a free data retrieval call binding the contract method 0xb5a574db.
+//
+// Solidity: function getRoleRuleFromIndex(string _orgId, string _roleId, uint256 _index) constant returns(address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerCallerSession) GetRoleRuleFromIndex(_orgId string, _roleId string, _index *big.Int) (common.Address, [4]byte, bool, error) {
+	return _FunctionRuleManager.Contract.GetRoleRuleFromIndex(&_FunctionRuleManager.CallOpts, _orgId, _roleId, _index)
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string, string, address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerCaller) GetRuleFromIndex(opts *bind.CallOpts, _ruleIndex *big.Int) (string, string, common.Address, [4]byte, bool, error) {
+	var (
+		ret0 = new(string)
+		ret1 = new(string)
+		ret2 = new(common.Address)
+		ret3 = new([4]byte)
+		ret4 = new(bool)
+	)
+	out := &[]interface{}{
+		ret0,
+		ret1,
+		ret2,
+		ret3,
+		ret4,
+	}
+	err := _FunctionRuleManager.contract.Call(opts, out, "getRuleFromIndex", _ruleIndex)
+	return *ret0, *ret1, *ret2, *ret3, *ret4, err
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string, string, address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerSession) GetRuleFromIndex(_ruleIndex *big.Int) (string, string, common.Address, [4]byte, bool, error) {
+	return _FunctionRuleManager.Contract.GetRuleFromIndex(&_FunctionRuleManager.CallOpts, _ruleIndex)
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string, string, address, bytes4, bool)
+func (_FunctionRuleManager *FunctionRuleManagerCallerSession) GetRuleFromIndex(_ruleIndex *big.Int) (string, string, common.Address, [4]byte, bool, error) {
+	return _FunctionRuleManager.Contract.GetRuleFromIndex(&_FunctionRuleManager.CallOpts, _ruleIndex)
+}
+
+// IsFunctionAllowed is a free data retrieval call binding the contract method 0x6f051aa9.
+//
+// Solidity: function isFunctionAllowed(string _orgId, string _roleId, address _contract, bytes4 _functionSig) constant returns(bool)
+func (_FunctionRuleManager *FunctionRuleManagerCaller) IsFunctionAllowed(opts *bind.CallOpts, _orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _FunctionRuleManager.contract.Call(opts, out, "isFunctionAllowed", _orgId, _roleId, _contract, _functionSig)
+	return *ret0, err
+}
+
+// IsFunctionAllowed is a free data retrieval call binding the contract method 0x6f051aa9.
+//
+// Solidity: function isFunctionAllowed(string _orgId, string _roleId, address _contract, bytes4 _functionSig) constant returns(bool)
+func (_FunctionRuleManager *FunctionRuleManagerSession) IsFunctionAllowed(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (bool, error) {
+	return _FunctionRuleManager.Contract.IsFunctionAllowed(&_FunctionRuleManager.CallOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// IsFunctionAllowed is a free data retrieval call binding the contract method 0x6f051aa9.
+//
+// Solidity: function isFunctionAllowed(string _orgId, string _roleId, address _contract, bytes4 _functionSig) constant returns(bool)
+func (_FunctionRuleManager *FunctionRuleManagerCallerSession) IsFunctionAllowed(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (bool, error) {
+	return _FunctionRuleManager.Contract.IsFunctionAllowed(&_FunctionRuleManager.CallOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// AddFunctionRule is a paid mutator transaction binding the contract method 0x5fc53501.
+//
+// Solidity: function addFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerTransactor) AddFunctionRule(opts *bind.TransactOpts, _orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.contract.Transact(opts, "addFunctionRule", _orgId, _roleId, _contract, _functionSig)
+}
+
+// AddFunctionRule is a paid mutator transaction binding the contract method 0x5fc53501.
+//
+// Solidity: function addFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerSession) AddFunctionRule(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.AddFunctionRule(&_FunctionRuleManager.TransactOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// AddFunctionRule is a paid mutator transaction binding the contract method 0x5fc53501.
+//
+// Solidity: function addFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerTransactorSession) AddFunctionRule(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.AddFunctionRule(&_FunctionRuleManager.TransactOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// RemoveFunctionRule is a paid mutator transaction binding the contract method 0x21b133ae.
+//
+// Solidity: function removeFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerTransactor) RemoveFunctionRule(opts *bind.TransactOpts, _orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.contract.Transact(opts, "removeFunctionRule", _orgId, _roleId, _contract, _functionSig)
+}
+
+// RemoveFunctionRule is a paid mutator transaction binding the contract method 0x21b133ae.
+//
+// Solidity: function removeFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerSession) RemoveFunctionRule(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.RemoveFunctionRule(&_FunctionRuleManager.TransactOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// RemoveFunctionRule is a paid mutator transaction binding the contract method 0x21b133ae.
+//
+// Solidity: function removeFunctionRule(string _orgId, string _roleId, address _contract, bytes4 _functionSig) returns()
+func (_FunctionRuleManager *FunctionRuleManagerTransactorSession) RemoveFunctionRule(_orgId string, _roleId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _FunctionRuleManager.Contract.RemoveFunctionRule(&_FunctionRuleManager.TransactOpts, _orgId, _roleId, _contract, _functionSig)
+}
+
+// FunctionRuleManagerFunctionRuleAddedIterator is returned from FilterFunctionRuleAdded and is used to iterate over the raw logs and unpacked data for FunctionRuleAdded events raised by the FunctionRuleManager contract.
+type FunctionRuleManagerFunctionRuleAddedIterator struct {
+	Event *FunctionRuleManagerFunctionRuleAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *FunctionRuleManagerFunctionRuleAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(FunctionRuleManagerFunctionRuleAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(FunctionRuleManagerFunctionRuleAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *FunctionRuleManagerFunctionRuleAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *FunctionRuleManagerFunctionRuleAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FunctionRuleManagerFunctionRuleAdded represents a FunctionRuleAdded event raised by the FunctionRuleManager contract.
+type FunctionRuleManagerFunctionRuleAdded struct {
+	OrgId       string
+	RoleId      string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterFunctionRuleAdded is a free log retrieval operation binding the contract event 0x6e0393a5b7d4eab24ec1e04ea91847506f7c7570a1fb116f9235d5ae2bed8353.
+//
+// Solidity: event FunctionRuleAdded(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) FilterFunctionRuleAdded(opts *bind.FilterOpts) (*FunctionRuleManagerFunctionRuleAddedIterator, error) {
+
+	logs, sub, err := _FunctionRuleManager.contract.FilterLogs(opts, "FunctionRuleAdded")
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManagerFunctionRuleAddedIterator{contract: _FunctionRuleManager.contract, event: "FunctionRuleAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchFunctionRuleAdded is a free log subscription operation binding the contract event 0x6e0393a5b7d4eab24ec1e04ea91847506f7c7570a1fb116f9235d5ae2bed8353.
+//
+// Solidity: event FunctionRuleAdded(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) WatchFunctionRuleAdded(opts *bind.WatchOpts, sink chan<- *FunctionRuleManagerFunctionRuleAdded) (event.Subscription, error) {
+
+	logs, sub, err := _FunctionRuleManager.contract.WatchLogs(opts, "FunctionRuleAdded")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(FunctionRuleManagerFunctionRuleAdded)
+				if err := _FunctionRuleManager.contract.UnpackLog(event, "FunctionRuleAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFunctionRuleAdded is a log parse operation binding the contract event 0x6e0393a5b7d4eab24ec1e04ea91847506f7c7570a1fb116f9235d5ae2bed8353.
+//
+// Solidity: event FunctionRuleAdded(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) ParseFunctionRuleAdded(log types.Log) (*FunctionRuleManagerFunctionRuleAdded, error) {
+	event := new(FunctionRuleManagerFunctionRuleAdded)
+	if err := _FunctionRuleManager.contract.UnpackLog(event, "FunctionRuleAdded", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// FunctionRuleManagerFunctionRuleRemovedIterator is returned from FilterFunctionRuleRemoved and is used to iterate over the raw logs and unpacked data for FunctionRuleRemoved events raised by the FunctionRuleManager contract.
+type FunctionRuleManagerFunctionRuleRemovedIterator struct {
+	Event *FunctionRuleManagerFunctionRuleRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *FunctionRuleManagerFunctionRuleRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(FunctionRuleManagerFunctionRuleRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(FunctionRuleManagerFunctionRuleRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *FunctionRuleManagerFunctionRuleRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *FunctionRuleManagerFunctionRuleRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FunctionRuleManagerFunctionRuleRemoved represents a FunctionRuleRemoved event raised by the FunctionRuleManager contract.
+type FunctionRuleManagerFunctionRuleRemoved struct {
+	OrgId       string
+	RoleId      string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterFunctionRuleRemoved is a free log retrieval operation binding the contract event 0x3eb989dd9288e71bba20529f6df6648b9166df122de31cc05bfb3335e233ef71.
+//
+// Solidity: event FunctionRuleRemoved(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) FilterFunctionRuleRemoved(opts *bind.FilterOpts) (*FunctionRuleManagerFunctionRuleRemovedIterator, error) {
+
+	logs, sub, err := _FunctionRuleManager.contract.FilterLogs(opts, "FunctionRuleRemoved")
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionRuleManagerFunctionRuleRemovedIterator{contract: _FunctionRuleManager.contract, event: "FunctionRuleRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchFunctionRuleRemoved is a free log subscription operation binding the contract event 0x3eb989dd9288e71bba20529f6df6648b9166df122de31cc05bfb3335e233ef71.
+//
+// Solidity: event FunctionRuleRemoved(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) WatchFunctionRuleRemoved(opts *bind.WatchOpts, sink chan<- *FunctionRuleManagerFunctionRuleRemoved) (event.Subscription, error) {
+
+	logs, sub, err := _FunctionRuleManager.contract.WatchLogs(opts, "FunctionRuleRemoved")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(FunctionRuleManagerFunctionRuleRemoved)
+				if err := _FunctionRuleManager.contract.UnpackLog(event, "FunctionRuleRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFunctionRuleRemoved is a log parse operation binding the contract event 0x3eb989dd9288e71bba20529f6df6648b9166df122de31cc05bfb3335e233ef71.
+//
+// Solidity: event FunctionRuleRemoved(string _orgId, string _roleId, address _contract, bytes4 _functionSig)
+func (_FunctionRuleManager *FunctionRuleManagerFilterer) ParseFunctionRuleRemoved(log types.Log) (*FunctionRuleManagerFunctionRuleRemoved, error) {
+	event := new(FunctionRuleManagerFunctionRuleRemoved)
+	if err := _FunctionRuleManager.contract.UnpackLog(event, "FunctionRuleRemoved", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,162 @@
+pragma solidity ^0.5.3;
+
+import "./PermissionsUpgradable.sol";
+import "./PermissionsInterface.sol";
+/** @title Function rule manager contract
+  * @notice This contract holds the function level rules of the roles. A
+    role with active rules may only call the functions of the contracts
+    listed by its rules, on top of the restrictions of its base access.
+    Roles without active rules are not restricted. The rules are managed
+    by the network admin accounts. There are few view functions exposed
+    as public and can be called directly. These are invoked by quorum for
+    populating permissions data in cache and when validating transactions
+  * @dev a rule with the function signature 0x00000000 allows every function
+    of the contract, including the plain transfers to it
+  */
+contract FunctionRuleManager {
+    PermissionsUpgradable private permUpgradable;
+
+    struct FunctionRule {
+        string orgId;
+        string roleId;
+        address contractAddress;
+        bytes4 functionSig;
+        bool active;
+    }
+
+    FunctionRule[] private ruleList;
+    // mapping of rule key to array index, a rule is identified by the role
+    // and org it belongs to, the contract address and function signature
+    mapping(bytes32 => uint256) private ruleIndex;
+    uint256 private numberOfRules;
+    // indexes of the rules of a role
+    mapping(bytes32 => uint256[]) private roleRules;
+
+    event FunctionRuleAdded(string _orgId, string _roleId, address _contract, bytes4 _functionSig);
+    event FunctionRuleRemoved(string _orgId, string _roleId, address _contract, bytes4 _functionSig);
+
+    /** @notice confirms that the caller is a network admin account
+      */
+    modifier onlyNetworkAdmin {
+        require(PermissionsInterface(permUpgradable.getPermInterface()).isNetworkAdmin(msg.sender) == true,
+            "account is not a network admin account");
+        _;
+    }
+
+    /** @notice constructor. sets the permissions upgradable address
+      */
+    constructor (address _permUpgradable) public {
+        permUpgradable = PermissionsUpgradable(_permUpgradable);
+    }
+
+    /** @notice function to add a rule to a role or activate it again
+      * @param _orgId - org id to which the role belongs
+      * @param _roleId - role the rule applies to
+      * @param _contract - contract address the role may call
+      * @param _functionSig - function signature the role may call, 0x00000000
+                for every function of the contract
+      */
+    function addFunctionRule(string calldata _orgId, string calldata _roleId,
+        address _contract, bytes4 _functionSig) external onlyNetworkAdmin {
+        bytes32 key = keccak256(abi.encode(_orgId, _roleId, _contract, _functionSig));
+        if (ruleIndex[key] == 0) {
+            numberOfRules ++;
+            ruleIndex[key] = numberOfRules;
+            ruleList.push(FunctionRule(_orgId, _roleId, _contract, _functionSig, true));
+            roleRules[keccak256(abi.encode(_orgId, _roleId))].push(numberOfRules - 1);
+        } else {
+            FunctionRule storage rule = ruleList[ruleIndex[key] - 1];
+            require(rule.active == false, "rule exists for the role");
+            rule.active = true;
+        }
+        emit FunctionRuleAdded(_orgId, _roleId, _contract, _functionSig);
+    }
+
+    /** @notice function to deactivate a rule of a role
+      * @param _orgId - org id to which the role belongs
+      * @param _roleId - role the rule applies to
+      * @param _contract - contract address of the rule
+      * @param _functionSig - function signature of the rule
+      */
+    function removeFunctionRule(string calldata _orgId, string calldata _roleId,
+        address _contract, bytes4 _functionSig) external onlyNetworkAdmin {
+        bytes32 key = keccak256(abi.encode(_orgId, _roleId, _contract, _functionSig));
+        require(ruleIndex[key] != 0, "rule does not exist");
+        FunctionRule storage rule = ruleList[ruleIndex[key] - 1];
+        require(rule.active == true, "rule is not active");
+        rule.active = false;
+        emit FunctionRuleRemoved(_orgId, _roleId, _contract, _functionSig);
+    }
+
+    /** @notice returns the total number of rules
+      * @return total number of rules
+      */
+    function getNumberOfRules() external view returns (uint256) {
+        return numberOfRules;
+    }
+
+    /** @notice returns the rule details given the index
+      * @param _ruleIndex rule index
+      * @return org id
+      * @return role id
+      * @return contract address
+      * @return function signature
+      * @return bool to indicate if the rule is active
+      */
+    function getRuleFromIndex(uint256 _ruleIndex) external view returns (string memory,
+        string memory, address, bytes4, bool) {
+        FunctionRule storage rule = ruleList[_ruleIndex];
+        return (rule.orgId, rule.roleId, rule.contractAddress, rule.functionSig, rule.active);
+    }
+
+    /** @notice returns the number of rules of a role, active or not
+      * @param _orgId - org id to which the role belongs
+      * @param _roleId - role id
+      * @return number of rules of the role
+      */
+    function getNumberOfRoleRules(string calldata _orgId, string calldata _roleId)
+    external view returns (uint256) {
+        return roleRules[keccak256(abi.encode(_orgId, _roleId))].length;
+    }
+
+    /** @notice returns a rule of a role given its index among the rules of
+        the role
+      * @param _orgId - org id to which the role belongs
+      * @param _roleId - role id
+      * @param _index - index of the rule among the rules of the role
+      * @return contract address
+      * @return function signature
+      * @return bool to indicate if the rule is active
+      */
+    function getRoleRuleFromIndex(string calldata _orgId, string calldata _roleId,
+        uint256 _index) external view returns (address, bytes4, bool) {
+        FunctionRule storage rule = ruleList[roleRules[keccak256(abi.encode(_orgId, _roleId))][_index]];
+        return (rule.contractAddress, rule.functionSig, rule.active);
+    }
+
+    /** @notice checks if a role may call a function of a contract
+      * @param _orgId - org id to which the role belongs
+      * @param _roleId - role id
+      * @param _contract - contract address
+      * @param _functionSig - function signature
+      * @return true if the role has no active rule or one of its active
+                rules allows the function
+      */
+    function isFunctionAllowed(string calldata _orgId, string calldata _roleId,
+        address _contract, bytes4 _functionSig) external view returns (bool) {
+        uint256[] storage rules = roleRules[keccak256(abi.encode(_orgId, _roleId))];
+        bool restricted = false;
+        for (uint256 i = 0; i < rules.length; i++) {
+            FunctionRule storage rule = ruleList[rules[i]];
+            if (!rule.active) {
+                continue;
+            }
+            restricted = true;
+            if (rule.contractAddress == _contract &&
+                (rule.functionSig == _functionSig || rule.functionSig == bytes4(0))) {
+                return true;
+            }
+        }
+        return !restricted;
+    }
+}
@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
+	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
+)
+
+var (
+	solComments     = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	solDeclarations = regexp.MustCompile(`(function|event|constructor)\s*(\w*)\s*\(([^)]*)\)([^{;]*)`)
+	solReturns      = regexp.MustCompile(`returns\s*\(([^)]*)\)`)
+)
+
+// solDeclaration is a function, event or constructor declared in a Solidity source
+type solDeclaration struct {
+	kind    string
+	name    string
+	inputs  []string
+	outputs []string
+	view    bool
+}
+
+// parseSol returns the public and external functions, the events and the
+// constructor declared in a Solidity source
+func parseSol(t *testing.T, path string) map[string]solDeclaration {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decls := make(map[string]solDeclaration)
+	for _, m := range solDeclarations.FindAllStringSubmatch(solComments.ReplaceAllString(string(src), ""), -1) {
+		decl := solDeclaration{kind: m[1], name: m[2], inputs: solTypes(m[3])}
+		modifiers := strings.Fields(m[4])
+		if decl.kind == "function" && !contains(modifiers, "public") && !contains(modifiers, "external") {
+			continue
+		}
+		if returns := solReturns.FindStringSubmatch(m[4]); returns != nil {
+			decl.outputs = solTypes(returns[1])
+		}
+		decl.view = contains(modifiers, "view") || contains(modifiers, "pure")
+		decls[decl.kind+" "+decl.name] = decl
+	}
+	return decls
+}
+
+// solTypes returns the types of a Solidity parameter list
+func solTypes(params string) []string {
+	var types []string
+	for _, param := range strings.Split(params, ",") {
+		if fields := strings.Fields(param); len(fields) > 0 {
+			types = append(types, fields[0])
+		}
+	}
+	return types
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func abiTypes(args abi.Arguments) []string {
+	var types []string
+	for _, arg := range args {
+		types = append(types, arg.Type.String())
+	}
+	return types
+}
+
+// TestFunctionRuleManagerABI checks the hand-written ABI of the function rule
+// manager binding against the Solidity source of the contract, as long as no
+// solc output is checked in for it.
+func TestFunctionRuleManagerABI(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(pbind.FunctionRuleManagerABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decls := parseSol(t, "../FunctionRuleManager.sol")
+
+	declared := 0
+	for _, decl := range decls {
+		switch decl.kind {
+		case "function":
+			declared++
+			method, ok := parsed.Methods[decl.name]
+			if !ok {
+				t.Errorf("function %s missing from the ABI", decl.name)
+				continue
+			}
+			if have := abiTypes(method.Inputs); !reflect.DeepEqual(have, decl.inputs) {
+				t.Errorf("function %s inputs mismatch: have %v, want %v", decl.name, have, decl.inputs)
+			}
+			if have := abiTypes(method.Outputs); !reflect.DeepEqual(have, decl.outputs) {
+				t.Errorf("function %s outputs mismatch: have %v, want %v", decl.name, have, decl.outputs)
+			}
+			if method.Const != decl.view {
+				t.Errorf("function %s constant mismatch: have %v, want %v", decl.name, method.Const, decl.view)
+			}
+		case "event":
+			declared++
+			event, ok := parsed.Events[decl.name]
+			if !ok {
+				t.Errorf("event %s missing from the ABI", decl.name)
+				continue
+			}
+			if have := abiTypes(event.Inputs); !reflect.DeepEqual(have, decl.inputs) {
+				t.Errorf("event %s inputs mismatch: have %v, want %v", decl.name, have, decl.inputs)
+			}
+		case "constructor":
+			if have := abiTypes(parsed.Constructor.Inputs); !reflect.DeepEqual(have, decl.inputs) {
+				t.Errorf("constructor inputs mismatch: have %v, want %v", have, decl.inputs)
+			}
+		}
+	}
+	if have := len(parsed.Methods) + len(parsed.Events); have != declared {
+		t.Errorf("ABI entry count mismatch: have %d, want %d", have, declared)
+	}
+
+	// the binding is generated from the ABI file generated by solc
+	blob, err := ioutil.ReadFile("FunctionRuleManager.abi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file, binding interface{}
+	if err := json.Unmarshal(blob, &file); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(pbind.FunctionRuleManagerABI), &binding); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(file, binding) {
+		t.Error("the binding ABI differs from FunctionRuleManager.abi")
+	}
+}
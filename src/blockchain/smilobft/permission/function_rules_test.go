@@ -0,0 +1,179 @@
+package permission
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go-smilo/src/blockchain/smilobft/accounts/abi/bind"
+	"go-smilo/src/blockchain/smilobft/accounts/abi/bind/backends"
+	"go-smilo/src/blockchain/smilobft/core"
+	"go-smilo/src/blockchain/smilobft/core/types"
+	"go-smilo/src/blockchain/smilobft/core/vm"
+	"go-smilo/src/blockchain/smilobft/params"
+	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
+)
+
+func TestFunctionRuleManager_Deploy(t *testing.T) {
+	adminKey, _ := crypto.GenerateKey()
+	admin := bind.NewKeyedTransactor(adminKey)
+	userKey, _ := crypto.GenerateKey()
+	user := bind.NewKeyedTransactor(userKey)
+
+	// the permission contracts are too large for the simulated chain, a stub
+	// answers the rule manager: it is its own permission interface and the
+	// admin is its only network admin
+	code := []byte{
+		byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0xe0, byte(vm.SHR),
+		byte(vm.PUSH4), 0xe5, 0x72, 0x51, 0x5c, byte(vm.EQ), // getPermInterface()
+		byte(vm.PUSH1), 48, byte(vm.JUMPI),
+		byte(vm.PUSH1), 4, byte(vm.CALLDATALOAD), byte(vm.PUSH20),
+	}
+	code = append(code, admin.From.Bytes()...)
+	code = append(code,
+		byte(vm.EQ), byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN),
+		byte(vm.JUMPDEST), byte(vm.ADDRESS), byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	)
+	permUpgrAddress := common.HexToAddress("0x0000000000000000000000000000000000000fff")
+
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		admin.From:      {Balance: big.NewInt(1000000000000000000)},
+		user.From:       {Balance: big.NewInt(1000000000000000000)},
+		permUpgrAddress: {Balance: big.NewInt(0), Code: code},
+	}, params.MinGasLimit)
+	// receipt returns whether a transaction succeeded once it is mined
+	receipt := func(step string, tx *types.Transaction, err error) bool {
+		if err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+		sim.Commit()
+		r, err := sim.TransactionReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+		return r.Status == types.ReceiptStatusSuccessful
+	}
+
+	ruleManagerAddress, tx, ruleManager, err := pbind.DeployFunctionRuleManager(admin, sim, permUpgrAddress)
+	if !receipt("deploy", tx, err) {
+		t.Fatal("rule manager deployment failed")
+	}
+	if code, _ := sim.CodeAt(context.Background(), ruleManagerAddress, nil); len(code) == 0 {
+		t.Fatal("rule manager has no code")
+	}
+
+	contract := common.HexToAddress("0x1234")
+	transfer := [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	approve := [4]byte{0x09, 0x5e, 0xa7, 0xb3}
+	gas := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		opts.GasLimit = 1000000
+		return opts
+	}
+
+	// only the network admins manage the rules
+	tx, err = ruleManager.AddFunctionRule(gas(user), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if receipt("add rule as user", tx, err) {
+		t.Fatal("rule added by an account which is not a network admin")
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, approve); !ok {
+		t.Error("role without rules restricted")
+	}
+
+	tx, err = ruleManager.AddFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if !receipt("add rule", tx, err) {
+		t.Fatal("rule not added by the network admin")
+	}
+	tx, err = ruleManager.AddFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if receipt("add rule again", tx, err) {
+		t.Error("active rule added twice")
+	}
+	events, err := ruleManager.FilterFunctionRuleAdded(&bind.FilterOpts{Context: context.Background()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !events.Next() || events.Event.OrgId != arbitraryOrgToAdd || events.Event.RoleId != arbitrartNewRole1 ||
+		events.Event.Contract != contract || events.Event.FunctionSig != transfer {
+		t.Error("rule added event mismatch")
+	}
+	events.Close()
+	if n, _ := ruleManager.GetNumberOfRules(nil); n.Uint64() != 1 {
+		t.Errorf("number of rules mismatch: have %v, want 1", n)
+	}
+	orgId, roleId, ruleContract, selector, active, err := ruleManager.GetRuleFromIndex(nil, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orgId != arbitraryOrgToAdd || roleId != arbitrartNewRole1 || ruleContract != contract || selector != transfer || !active {
+		t.Errorf("rule mismatch: have %s %s %x %x %v", orgId, roleId, ruleContract, selector, active)
+	}
+	if n, _ := ruleManager.GetNumberOfRoleRules(nil, arbitraryOrgToAdd, arbitrartNewRole1); n.Uint64() != 1 {
+		t.Errorf("number of role rules mismatch: have %v, want 1", n)
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer); !ok {
+		t.Error("function of the rule denied")
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, approve); ok {
+		t.Error("function outside the rules allowed")
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole2, contract, approve); !ok {
+		t.Error("rule applied to another role")
+	}
+
+	// the zero selector allows every function of the contract
+	tx, err = ruleManager.AddFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, [4]byte{})
+	receipt("add contract rule", tx, err)
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, approve); !ok {
+		t.Error("function of the contract rule denied")
+	}
+
+	// a role with only inactive rules is not restricted
+	tx, err = ruleManager.RemoveFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, [4]byte{})
+	receipt("remove contract rule", tx, err)
+	tx, err = ruleManager.RemoveFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if !receipt("remove rule", tx, err) {
+		t.Fatal("rule not removed by the network admin")
+	}
+	tx, err = ruleManager.RemoveFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if receipt("remove rule again", tx, err) {
+		t.Error("inactive rule removed")
+	}
+	ruleContract, selector, active, err = ruleManager.GetRoleRuleFromIndex(nil, arbitraryOrgToAdd, arbitrartNewRole1, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ruleContract != contract || selector != transfer || active {
+		t.Errorf("role rule mismatch: have %x %x %v", ruleContract, selector, active)
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, approve); !ok {
+		t.Error("role with inactive rules restricted")
+	}
+
+	// a removed rule is activated again
+	tx, err = ruleManager.AddFunctionRule(gas(admin), arbitraryOrgToAdd, arbitrartNewRole1, contract, transfer)
+	if !receipt("add rule back", tx, err) {
+		t.Fatal("inactive rule not activated")
+	}
+	if n, _ := ruleManager.GetNumberOfRules(nil); n.Uint64() != 2 {
+		t.Errorf("number of rules mismatch: have %v, want 2", n)
+	}
+	if ok, _ := ruleManager.IsFunctionAllowed(nil, arbitraryOrgToAdd, arbitrartNewRole1, contract, approve); ok {
+		t.Error("function outside the rules allowed")
+	}
+
+	// ids longer than a storage word are kept whole
+	longRole := strings.Repeat(arbitrartNewRole2, 5)
+	tx, err = ruleManager.AddFunctionRule(gas(admin), arbitraryOrgToAdd, longRole, contract, approve)
+	receipt("add long rule", tx, err)
+	orgId, roleId, _, selector, _, err = ruleManager.GetRuleFromIndex(nil, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orgId != arbitraryOrgToAdd || roleId != longRole || selector != approve {
+		t.Errorf("long rule mismatch: have %s %s %x", orgId, roleId, selector)
+	}
+}
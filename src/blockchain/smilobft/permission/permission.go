@@ -15,6 +15,7 @@ import (
 
 	"go-smilo/src/blockchain/smilobft/ethclient"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 
 	"go-smilo/src/blockchain/smilobft/rpc"
@@ -24,8 +25,8 @@ import (
 	"go-smilo/src/blockchain/smilobft/eth"
 	"go-smilo/src/blockchain/smilobft/node"
 	"go-smilo/src/blockchain/smilobft/p2p"
-	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	"go-smilo/src/blockchain/smilobft/params"
+	"go-smilo/src/blockchain/smilobft/permission/audit"
 	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -43,20 +44,20 @@ type PermissionCtrl struct {
 	ethClnt    bind.ContractBackend
 	eth        *eth.Smilo
 	key        *ecdsa.PrivateKey
-	dataDir    string
 	permUpgr   *pbind.PermUpgr
 	permInterf *pbind.PermInterface
 	permNode   *pbind.NodeManager
 	permAcct   *pbind.AcctManager
 	permRole   *pbind.RoleManager
 	permOrg    *pbind.OrgManager
+	permRule   *pbind.FunctionRuleManager // nil if no function rule manager is configured
 	permConfig *types.PermissionConfig
+	cache      *types.PermissionCache
+	audit      *audit.Log // history of the permission changes
 
 	startWaitGroup *sync.WaitGroup // waitgroup to make sure all dependencies are ready before we start the service
 	stopFeed       event.Feed      // broadcasting stopEvent when service is being stopped
 	errorChan      chan error      // channel to capture error when starting aysnc
-
-	mux sync.Mutex
 }
 
 // to signal all watches when service is stopped
@@ -100,6 +101,23 @@ func ParsePermissionConfig(dir string) (types.PermissionConfig, error) {
 	return permConfig, nil
 }
 
+// checkChainConfig returns an error if the permission contracts of the node
+// config aren't the ones block processing reads the account permissions from
+func checkChainConfig(permConfig *types.PermissionConfig, chainConfig *params.ChainConfig) error {
+	contracts := chainConfig.PermissionContracts
+	if contracts == nil {
+		if chainConfig.QIP714Block != nil {
+			log.Warn("No permission contracts in the chain config, the account permissions are not enforced in block processing")
+		}
+		return nil
+	}
+	if contracts.InterfAddress != permConfig.InterfAddress || contracts.OrgAddress != permConfig.OrgAddress || contracts.RoleAddress != permConfig.RoleAddress ||
+		contracts.AccountAddress != permConfig.AccountAddress || contracts.RuleAddress != permConfig.RuleAddress {
+		return fmt.Errorf("the permission contracts of %s don't match the permission contracts of the chain config", params.PERMISSION_MODEL_CONFIG)
+	}
+	return nil
+}
+
 // Create a service instance for permissioning
 //
 // Permission Service depends on the following:
@@ -107,13 +125,18 @@ func ParsePermissionConfig(dir string) (types.PermissionConfig, error) {
 // 2. Downloader to sync up blocks
 // 3. InProc RPC server to be ready
 func NewQuorumPermissionCtrl(stack *node.Node, pconfig *types.PermissionConfig) (*PermissionCtrl, error) {
+	auditLog, err := audit.New(pconfig)
+	if err != nil {
+		return nil, err
+	}
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	p := &PermissionCtrl{
 		node:           stack,
 		key:            stack.GetNodeKey(),
-		dataDir:        stack.DataDir(),
 		permConfig:     pconfig,
+		cache:          types.NewPermissionCache(),
+		audit:          auditLog,
 		startWaitGroup: wg,
 		errorChan:      make(chan error),
 	}
@@ -174,14 +197,33 @@ func (p *PermissionCtrl) AfterStart() error {
 	if err := p.bindContract(&p.permOrg, func() (interface{}, error) { return pbind.NewOrgManager(p.permConfig.OrgAddress, p.ethClnt) }); err != nil {
 		return err
 	}
+	if p.permConfig.RuleAddress != (common.Address{}) {
+		if err := p.bindContract(&p.permRule, func() (interface{}, error) { return pbind.NewFunctionRuleManager(p.permConfig.RuleAddress, p.ethClnt) }); err != nil {
+			return err
+		}
+	}
 
 	// populate the initial list of permissioned nodes and account accesses
 	if err := p.populateInitPermissions(); err != nil {
 		return fmt.Errorf("populateInitPermissions failed: %v", err)
 	}
+	// entries evicted from the caches are read back from the contracts
+	p.cache.OrgInfoMap.PopulateCacheFunc(p.readOrgFromContract)
+	p.cache.NodeInfoMap.PopulateCacheFunc(p.readNodeFromContract)
+	p.cache.RoleInfoMap.PopulateCacheFunc(p.readRoleFromContract)
+	p.cache.AcctInfoMap.PopulateCacheFunc(p.readAccountFromContract)
+	p.cache.RuleInfoMap.PopulateCacheFunc(p.readRoleRulesFromContract)
+	p.cache.OrgInfoMap.PopulateListFunc(p.readOrgsFromContract)
+	p.cache.NodeInfoMap.PopulateListFunc(p.readNodesFromContract)
+	p.cache.RoleInfoMap.PopulateListFunc(p.readRolesFromContract)
+	p.cache.AcctInfoMap.PopulateListFunc(p.readAccountsFromContract)
+	p.cache.RuleInfoMap.PopulateListFunc(p.readRulesFromContract)
 
 	// set the default access to ReadOnly
-	types.SetDefaults(p.permConfig.NwAdminRole, p.permConfig.OrgAdminRole)
+	p.cache.SetDefaults(p.permConfig.NwAdminRole, p.permConfig.OrgAdminRole)
+
+	// the pool checks the transactions against the caches from now on
+	p.eth.TxPool().SetPermissions(p.cache)
 
 	for _, f := range []func() error{
 		p.monitorQIP714Block,       // monitor block number to activate new permissions controls
@@ -189,6 +231,8 @@ func (p *PermissionCtrl) AfterStart() error {
 		p.manageNodePermissions,    // monitor org  level node management events
 		p.manageRolePermissions,    // monitor org level role management events
 		p.manageAccountPermissions, // monitor org level account management events
+		p.manageFunctionRules,      // monitor function rule management events
+		p.manageAuditLog,           // index the permission changes of the new blocks
 	} {
 		if err := f(); err != nil {
 			return err
@@ -208,6 +252,12 @@ func (p *PermissionCtrl) asyncStart() {
 		p.errorChan <- fmt.Errorf("dependent ethereum service not started")
 		return
 	}
+	// block processing reads the account permissions from the contracts of
+	// the chain config, the caches must follow the same contracts
+	if err := checkChainConfig(p.permConfig, ethereum.BlockChain().Config()); err != nil {
+		p.errorChan <- err
+		return
+	}
 	defer func() {
 		p.errorChan <- nil
 	}()
@@ -228,7 +278,7 @@ func (p *PermissionCtrl) asyncStart() {
 		for {
 			select {
 			case <-pollingTicker.C:
-				if types.GetSyncStatus() && !ethereum.Downloader().Synchronising() {
+				if p.cache.GetSyncStatus() && !ethereum.Downloader().Synchronising() {
 					return
 				}
 			case <-stopChan:
@@ -257,6 +307,11 @@ func (p *PermissionCtrl) Start(srvr *p2p.Server) error {
 	return nil
 }
 
+// Permissions returns the permission caches of the node
+func (p *PermissionCtrl) Permissions() *types.PermissionCache {
+	return p.cache
+}
+
 func (p *PermissionCtrl) APIs() []rpc.API {
 	return []rpc.API{
 		{
@@ -279,12 +334,48 @@ func (p *PermissionCtrl) Stop() error {
 	return nil
 }
 
+// indexes the permission changes of the chain into the audit log, the blocks
+// already in the chain first and then every new head. The log is kept in the
+// chain database so that only the blocks added since the last run are indexed.
+func (p *PermissionCtrl) manageAuditLog() error {
+	if err := p.audit.Open(p.eth.ChainDb()); err != nil {
+		return fmt.Errorf("failed to open the permission audit log: %v", err)
+	}
+	chain := p.eth.BlockChain()
+	chainHeadCh := make(chan core.ChainHeadEvent, 10)
+	headSub := chain.SubscribeChainHeadEvent(chainHeadCh)
+
+	go func() {
+		defer headSub.Unsubscribe()
+		stopChan, stopSubscription := p.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+
+		start := time.Now()
+		if err := p.audit.IndexChain(chain, chain.CurrentBlock().NumberU64()); err != nil {
+			log.Error("Failed to index the permission audit log", "err", err)
+		} else {
+			log.Info("Indexed the permission audit log", "took", time.Since(start))
+		}
+		for {
+			select {
+			case head := <-chainHeadCh:
+				if err := p.audit.IndexChain(chain, head.Block.NumberU64()); err != nil {
+					log.Error("Failed to index the permission audit log", "number", head.Block.Number(), "err", err)
+				}
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
 // monitors QIP714Block and set default access
 func (p *PermissionCtrl) monitorQIP714Block() error {
 	// if QIP714block is not given, set the default access
 	// to readonly
 	if p.eth.ChainConfig().QIP714Block == nil {
-		types.SetDefaultAccess()
+		p.cache.SetDefaultAccess()
 		return nil
 	}
 	//QIP714block is given, monitor block count
@@ -298,7 +389,7 @@ func (p *PermissionCtrl) monitorQIP714Block() error {
 			select {
 			case head := <-chainHeadCh:
 				if p.eth.ChainConfig().IsQIP714(head.Block.Number()) {
-					types.SetDefaultAccess()
+					p.cache.SetDefaultAccess()
 					return
 				}
 			case <-stopChan:
@@ -343,16 +434,16 @@ func (p *PermissionCtrl) manageOrgPermissions() error {
 		for {
 			select {
 			case evtPendingApproval := <-chPendingApproval:
-				types.OrgInfoMap.UpsertOrg(evtPendingApproval.OrgId, evtPendingApproval.PorgId, evtPendingApproval.UltParent, evtPendingApproval.Level, types.OrgStatus(evtPendingApproval.Status.Uint64()))
+				p.cache.OrgInfoMap.UpsertOrg(evtPendingApproval.OrgId, evtPendingApproval.PorgId, evtPendingApproval.UltParent, evtPendingApproval.Level, types.OrgStatus(evtPendingApproval.Status.Uint64()))
 
 			case evtOrgApproved := <-chOrgApproved:
-				types.OrgInfoMap.UpsertOrg(evtOrgApproved.OrgId, evtOrgApproved.PorgId, evtOrgApproved.UltParent, evtOrgApproved.Level, types.OrgApproved)
+				p.cache.OrgInfoMap.UpsertOrg(evtOrgApproved.OrgId, evtOrgApproved.PorgId, evtOrgApproved.UltParent, evtOrgApproved.Level, types.OrgApproved)
 
 			case evtOrgSuspended := <-chOrgSuspended:
-				types.OrgInfoMap.UpsertOrg(evtOrgSuspended.OrgId, evtOrgSuspended.PorgId, evtOrgSuspended.UltParent, evtOrgSuspended.Level, types.OrgSuspended)
+				p.cache.OrgInfoMap.UpsertOrg(evtOrgSuspended.OrgId, evtOrgSuspended.PorgId, evtOrgSuspended.UltParent, evtOrgSuspended.Level, types.OrgSuspended)
 
 			case evtOrgReactivated := <-chOrgReactivated:
-				types.OrgInfoMap.UpsertOrg(evtOrgReactivated.OrgId, evtOrgReactivated.PorgId, evtOrgReactivated.UltParent, evtOrgReactivated.Level, types.OrgApproved)
+				p.cache.OrgInfoMap.UpsertOrg(evtOrgReactivated.OrgId, evtOrgReactivated.PorgId, evtOrgReactivated.UltParent, evtOrgReactivated.Level, types.OrgApproved)
 			case <-stopChan:
 				log.Info("quit org contract watch")
 				return
@@ -416,29 +507,29 @@ func (p *PermissionCtrl) manageNodePermissions() error {
 			select {
 			case evtNodeApproved := <-chNodeApproved:
 				p.updatePermissionedNodes(evtNodeApproved.EnodeId, NodeAdd)
-				types.NodeInfoMap.UpsertNode(evtNodeApproved.OrgId, evtNodeApproved.EnodeId, types.NodeApproved)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeApproved.OrgId, evtNodeApproved.EnodeId, types.NodeApproved)
 
 			case evtNodeProposed := <-chNodeProposed:
-				types.NodeInfoMap.UpsertNode(evtNodeProposed.OrgId, evtNodeProposed.EnodeId, types.NodePendingApproval)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeProposed.OrgId, evtNodeProposed.EnodeId, types.NodePendingApproval)
 
 			case evtNodeDeactivated := <-chNodeDeactivated:
 				p.updatePermissionedNodes(evtNodeDeactivated.EnodeId, NodeDelete)
-				types.NodeInfoMap.UpsertNode(evtNodeDeactivated.OrgId, evtNodeDeactivated.EnodeId, types.NodeDeactivated)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeDeactivated.OrgId, evtNodeDeactivated.EnodeId, types.NodeDeactivated)
 
 			case evtNodeActivated := <-chNodeActivated:
 				p.updatePermissionedNodes(evtNodeActivated.EnodeId, NodeAdd)
-				types.NodeInfoMap.UpsertNode(evtNodeActivated.OrgId, evtNodeActivated.EnodeId, types.NodeApproved)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeActivated.OrgId, evtNodeActivated.EnodeId, types.NodeApproved)
 
 			case evtNodeBlacklisted := <-chNodeBlacklisted:
-				types.NodeInfoMap.UpsertNode(evtNodeBlacklisted.OrgId, evtNodeBlacklisted.EnodeId, types.NodeBlackListed)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeBlacklisted.OrgId, evtNodeBlacklisted.EnodeId, types.NodeBlackListed)
 				p.updateDisallowedNodes(evtNodeBlacklisted.EnodeId, NodeAdd)
 				p.updatePermissionedNodes(evtNodeBlacklisted.EnodeId, NodeDelete)
 
 			case evtNodeRecoveryInit := <-chNodeRecoveryInit:
-				types.NodeInfoMap.UpsertNode(evtNodeRecoveryInit.OrgId, evtNodeRecoveryInit.EnodeId, types.NodeRecoveryInitiated)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeRecoveryInit.OrgId, evtNodeRecoveryInit.EnodeId, types.NodeRecoveryInitiated)
 
 			case evtNodeRecoveryDone := <-chNodeRecoveryDone:
-				types.NodeInfoMap.UpsertNode(evtNodeRecoveryDone.OrgId, evtNodeRecoveryDone.EnodeId, types.NodeApproved)
+				p.cache.NodeInfoMap.UpsertNode(evtNodeRecoveryDone.OrgId, evtNodeRecoveryDone.EnodeId, types.NodeApproved)
 				p.updateDisallowedNodes(evtNodeRecoveryDone.EnodeId, NodeDelete)
 				p.updatePermissionedNodes(evtNodeRecoveryDone.EnodeId, NodeAdd)
 
@@ -451,90 +542,40 @@ func (p *PermissionCtrl) manageNodePermissions() error {
 	return nil
 }
 
-// adds or deletes and entry from a given file
-func (p *PermissionCtrl) updateFile(fileName, enodeId string, operation NodeOperation, createFile bool) {
-	// Load the nodes from the config file
-	var nodeList []string
-	index := 0
-	// if createFile is false means the file is already existing. read the file
-	if !createFile {
-		blob, err := ioutil.ReadFile(fileName)
-		if err != nil && !createFile {
-			log.Error("Failed to access the file", "fileName", fileName, "err", err)
-			return
-		}
-
-		if err := json.Unmarshal(blob, &nodeList); err != nil {
-			log.Error("Failed to load nodes list from file", "fileName", fileName, "err", err)
-			return
-		}
-
-		// logic to update the permissioned-nodes.json file based on action
-
-		recExists := false
-		for i, eid := range nodeList {
-			if eid == enodeId {
-				index = i
-				recExists = true
-				break
-			}
-		}
-		if (operation == NodeAdd && recExists) || (operation == NodeDelete && !recExists) {
-			return
-		}
+// updates node information in the permissioned-nodes.json list of the p2p
+// server based on node management activities in smart contract. The server
+// disconnects the nodes removed from the list.
+func (p *PermissionCtrl) updatePermissionedNodes(enodeId string, operation NodeOperation) {
+	nodeList := p.node.Server().NodeList()
+	if nodeList == nil {
+		return
 	}
+	var err error
 	if operation == NodeAdd {
-		nodeList = append(nodeList, enodeId)
+		err = nodeList.Allow(enodeId)
 	} else {
-		nodeList = append(nodeList[:index], nodeList[index+1:]...)
-	}
-	blob, _ := json.Marshal(nodeList)
-
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
-	if err := ioutil.WriteFile(fileName, blob, 0644); err != nil {
-		log.Error("Error writing new node info to file", "fileName", fileName, "err", err)
+		err = nodeList.RemoveAllowed(enodeId)
 	}
-}
-
-// updates node information in the permissioned-nodes.json file based on node
-// management activities in smart contract
-func (p *PermissionCtrl) updatePermissionedNodes(enodeId string, operation NodeOperation) {
-	log.Debug("updatePermissionedNodes", "DataDir", p.dataDir, "file", params.PERMISSIONED_CONFIG)
-
-	path := filepath.Join(p.dataDir, params.PERMISSIONED_CONFIG)
-	if _, err := os.Stat(path); err != nil {
-		log.Error("Read Error for permissioned-nodes.json file. This is because 'permissioned' flag is specified but no permissioned-nodes.json file is present", "err", err)
-		return
-	}
-
-	p.updateFile(path, enodeId, operation, false)
-	if operation == NodeDelete {
-		p.disconnectNode(enodeId)
+	if err != nil {
+		log.Error("Failed to update the permissioned nodes", "enodeId", enodeId, "err", err)
 	}
 }
 
-//this function populates the black listed node information into the disallowed-nodes.json file
+// updates the black listed node information in the disallowed-nodes.json list
+// of the p2p server
 func (p *PermissionCtrl) updateDisallowedNodes(url string, operation NodeOperation) {
-	log.Debug("updateDisallowedNodes", "DataDir", p.dataDir, "file", params.BLACKLIST_CONFIG)
-
-	fileExists := true
-	path := filepath.Join(p.dataDir, params.BLACKLIST_CONFIG)
-	// Check if the file is existing. If the file is not existing create the file
-	if _, err := os.Stat(path); err != nil {
-		log.Error("Read Error for disallowed-nodes.json file", "err", err)
-		if _, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644); err != nil {
-			log.Error("Failed to create disallowed-nodes.json file", "err", err)
-			return
-		}
-		fileExists = false
+	nodeList := p.node.Server().NodeList()
+	if nodeList == nil {
+		return
 	}
-
-	if fileExists {
-		p.updateFile(path, url, operation, false)
+	var err error
+	if operation == NodeAdd {
+		err = nodeList.Disallow(url)
 	} else {
-		p.updateFile(path, url, operation, true)
+		err = nodeList.RemoveDisallowed(url)
+	}
+	if err != nil {
+		log.Error("Failed to update the disallowed nodes", "url", url, "err", err)
 	}
 }
 
@@ -566,14 +607,14 @@ func (p *PermissionCtrl) manageAccountPermissions() error {
 		for {
 			select {
 			case evtAccessModified := <-chAccessModified:
-				types.AcctInfoMap.UpsertAccount(evtAccessModified.OrgId, evtAccessModified.RoleId, evtAccessModified.Account, evtAccessModified.OrgAdmin, types.AcctStatus(int(evtAccessModified.Status.Uint64())))
+				p.cache.AcctInfoMap.UpsertAccount(evtAccessModified.OrgId, evtAccessModified.RoleId, evtAccessModified.Account, evtAccessModified.OrgAdmin, types.AcctStatus(int(evtAccessModified.Status.Uint64())))
 
 			case evtAccessRevoked := <-chAccessRevoked:
-				types.AcctInfoMap.UpsertAccount(evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Account, evtAccessRevoked.OrgAdmin, types.AcctActive)
+				p.cache.AcctInfoMap.UpsertAccount(evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Account, evtAccessRevoked.OrgAdmin, types.AcctActive)
 
 			case evtStatusChanged := <-chStatusChanged:
-				ac := types.AcctInfoMap.GetAccount(evtStatusChanged.Account)
-				types.AcctInfoMap.UpsertAccount(evtStatusChanged.OrgId, ac.RoleId, evtStatusChanged.Account, ac.IsOrgAdmin, types.AcctStatus(int(evtStatusChanged.Status.Uint64())))
+				ac := p.cache.AcctInfoMap.GetAccount(evtStatusChanged.Account)
+				p.cache.AcctInfoMap.UpsertAccount(evtStatusChanged.OrgId, ac.RoleId, evtStatusChanged.Account, ac.IsOrgAdmin, types.AcctStatus(int(evtStatusChanged.Status.Uint64())))
 			case <-stopChan:
 				log.Info("quit account contract watch")
 				return
@@ -583,37 +624,6 @@ func (p *PermissionCtrl) manageAccountPermissions() error {
 	return nil
 }
 
-// Disconnect the node from the network
-func (p *PermissionCtrl) disconnectNode(enodeId string) {
-	if p.eth.ChainConfig().Istanbul == nil && p.eth.ChainConfig().Clique == nil {
-		// Quorum raft is not supported by Smilo
-		//var raftService *raft.RaftService
-		//if err := p.node.Service(&raftService); err == nil {
-		//	raftApi := raft.NewPublicRaftAPI(raftService)
-		//
-		//	//get the raftId for the given enodeId
-		//	raftId, err := raftApi.GetRaftId(enodeId)
-		//	if err == nil {
-		//		raftApi.RemovePeer(raftId)
-		//	} else {
-		//		log.Error("failed to get raft id", "err", err, "enodeId", enodeId)
-		//	}
-		//}
-	} else {
-		// Istanbul  or clique - disconnect the peer
-		server := p.node.Server()
-		if server != nil {
-			node, err := enode.ParseV4(enodeId)
-			if err == nil {
-				server.RemovePeer(node)
-			} else {
-				log.Error("failed parse node id", "err", err, "enodeId", enodeId)
-			}
-		}
-	}
-
-}
-
 // Thus function checks if the initial network boot up status and if no
 // populates permissions model with details from permission-config.json
 func (p *PermissionCtrl) populateInitPermissions() error {
@@ -649,6 +659,7 @@ func (p *PermissionCtrl) populateInitPermissions() error {
 			p.populateNodesFromContract,
 			p.populateRolesFromContract,
 			p.populateAccountsFromContract,
+			p.populateRulesFromContract,
 		} {
 			if err := f(auth); err != nil {
 				return err
@@ -670,8 +681,8 @@ func (p *PermissionCtrl) bootupNetwork(permInterfSession *pbind.PermInterfaceSes
 		return err
 	}
 
-	types.OrgInfoMap.UpsertOrg(p.permConfig.NwAdminOrg, "", p.permConfig.NwAdminOrg, big.NewInt(1), types.OrgApproved)
-	types.RoleInfoMap.UpsertRole(p.permConfig.NwAdminOrg, p.permConfig.NwAdminRole, true, true, types.FullAccess, true)
+	p.cache.OrgInfoMap.UpsertOrg(p.permConfig.NwAdminOrg, "", p.permConfig.NwAdminOrg, big.NewInt(1), types.OrgApproved)
+	p.cache.RoleInfoMap.UpsertRole(p.permConfig.NwAdminOrg, p.permConfig.NwAdminRole, true, true, types.FullAccess, true)
 	// populate the initial node list from static-nodes.json
 	if err := p.populateStaticNodesToContract(permInterfSession); err != nil {
 		return err
@@ -691,92 +702,321 @@ func (p *PermissionCtrl) bootupNetwork(permInterfSession *pbind.PermInterfaceSes
 
 // populates the account access details from contract into cache
 func (p *PermissionCtrl) populateAccountsFromContract(auth *bind.TransactOpts) error {
-	//populate accounts
+	accounts, err := p.readAccountsFromContract()
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		p.cache.AcctInfoMap.UpsertAccount(a.OrgId, a.RoleId, a.AcctId, a.IsOrgAdmin, a.Status)
+	}
+	return nil
+}
+
+// populates the role details from contract into cache
+func (p *PermissionCtrl) populateRolesFromContract(auth *bind.TransactOpts) error {
+	roles, err := p.readRolesFromContract()
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		p.cache.RoleInfoMap.UpsertRole(r.OrgId, r.RoleId, r.IsVoter, r.IsAdmin, r.Access, r.Active)
+	}
+	return nil
+}
+
+// populates the function rules from contract into cache
+func (p *PermissionCtrl) populateRulesFromContract(auth *bind.TransactOpts) error {
+	rules, err := p.readRulesFromContract()
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		p.cache.RuleInfoMap.UpsertRule(r)
+	}
+	return nil
+}
+
+// populates the node details from contract into cache
+func (p *PermissionCtrl) populateNodesFromContract(auth *bind.TransactOpts) error {
+	nodes, err := p.readNodesFromContract()
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		p.cache.NodeInfoMap.UpsertNode(n.OrgId, n.Url, n.Status)
+	}
+	return nil
+}
+
+// populates the org details from contract into cache
+func (p *PermissionCtrl) populateOrgsFromContract(auth *bind.TransactOpts) error {
+	orgs, err := p.readOrgsFromContract()
+	if err != nil {
+		return err
+	}
+	for _, o := range orgs {
+		p.cache.OrgInfoMap.UpsertOrg(o.OrgId, o.ParentOrgId, o.UltimateParent, o.Level, o.Status)
+	}
+	return nil
+}
+
+// reads all the accounts from the contract
+func (p *PermissionCtrl) readAccountsFromContract() ([]types.AccountInfo, error) {
 	permAcctSession := &pbind.AcctManagerSession{
 		Contract: p.permAcct,
 		CallOpts: bind.CallOpts{
 			Pending: true,
 		},
 	}
-
-	if numberOfRoles, err := permAcctSession.GetNumberOfAccounts(); err == nil {
-		iOrgNum := numberOfRoles.Uint64()
-		for k := uint64(0); k < iOrgNum; k++ {
-			if addr, org, role, status, orgAdmin, err := permAcctSession.GetAccountDetailsFromIndex(big.NewInt(int64(k))); err == nil {
-				types.AcctInfoMap.UpsertAccount(org, role, addr, orgAdmin, types.AcctStatus(int(status.Int64())))
-			}
+	numberOfAccounts, err := permAcctSession.GetNumberOfAccounts()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]types.AccountInfo, 0, numberOfAccounts.Uint64())
+	for k := uint64(0); k < numberOfAccounts.Uint64(); k++ {
+		addr, org, role, status, orgAdmin, err := permAcctSession.GetAccountDetailsFromIndex(big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		return err
+		accounts = append(accounts, types.AccountInfo{OrgId: org, RoleId: role, AcctId: addr, IsOrgAdmin: orgAdmin, Status: types.AcctStatus(int(status.Int64()))})
 	}
-	return nil
+	return accounts, nil
 }
 
-// populates the role details from contract into cache
-func (p *PermissionCtrl) populateRolesFromContract(auth *bind.TransactOpts) error {
-	//populate roles
+// reads all the roles from the contract
+func (p *PermissionCtrl) readRolesFromContract() ([]types.RoleInfo, error) {
 	permRoleSession := &pbind.RoleManagerSession{
 		Contract: p.permRole,
 		CallOpts: bind.CallOpts{
 			Pending: true,
 		},
 	}
-	if numberOfRoles, err := permRoleSession.GetNumberOfRoles(); err == nil {
-		iOrgNum := numberOfRoles.Uint64()
-		for k := uint64(0); k < iOrgNum; k++ {
-			if roleStruct, err := permRoleSession.GetRoleDetailsFromIndex(big.NewInt(int64(k))); err == nil {
-				types.RoleInfoMap.UpsertRole(roleStruct.OrgId, roleStruct.RoleId, roleStruct.Voter, roleStruct.Admin, types.AccessType(int(roleStruct.AccessType.Int64())), roleStruct.Active)
-			}
+	numberOfRoles, err := permRoleSession.GetNumberOfRoles()
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]types.RoleInfo, 0, numberOfRoles.Uint64())
+	for k := uint64(0); k < numberOfRoles.Uint64(); k++ {
+		roleStruct, err := permRoleSession.GetRoleDetailsFromIndex(big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
 		}
+		roles = append(roles, types.RoleInfo{
+			OrgId:   roleStruct.OrgId,
+			RoleId:  roleStruct.RoleId,
+			IsVoter: roleStruct.Voter,
+			IsAdmin: roleStruct.Admin,
+			Access:  types.AccessType(int(roleStruct.AccessType.Int64())),
+			Active:  roleStruct.Active,
+		})
+	}
+	return roles, nil
+}
 
-	} else {
-		return err
+// reads all the function rules from the contract
+func (p *PermissionCtrl) readRulesFromContract() ([]types.FunctionRule, error) {
+	if p.permRule == nil {
+		return nil, nil
 	}
-	return nil
+	permRuleSession := &pbind.FunctionRuleManagerSession{
+		Contract: p.permRule,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	numberOfRules, err := permRuleSession.GetNumberOfRules()
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]types.FunctionRule, 0, numberOfRules.Uint64())
+	for k := uint64(0); k < numberOfRules.Uint64(); k++ {
+		org, role, contract, sig, active, err := permRuleSession.GetRuleFromIndex(big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, types.FunctionRule{OrgId: org, RoleId: role, Contract: contract, Selector: sig, Active: active})
+	}
+	return rules, nil
 }
 
-// populates the node details from contract into cache
-func (p *PermissionCtrl) populateNodesFromContract(auth *bind.TransactOpts) error {
-	//populate nodes
+// reads all the nodes from the contract
+func (p *PermissionCtrl) readNodesFromContract() ([]types.NodeInfo, error) {
 	permNodeSession := &pbind.NodeManagerSession{
 		Contract: p.permNode,
 		CallOpts: bind.CallOpts{
 			Pending: true,
 		},
 	}
-	if numberOfNodes, err := permNodeSession.GetNumberOfNodes(); err == nil {
-		iOrgNum := numberOfNodes.Uint64()
-		for k := uint64(0); k < iOrgNum; k++ {
-			if nodeStruct, err := permNodeSession.GetNodeDetailsFromIndex(big.NewInt(int64(k))); err == nil {
-				types.NodeInfoMap.UpsertNode(nodeStruct.OrgId, nodeStruct.EnodeId, types.NodeStatus(int(nodeStruct.NodeStatus.Int64())))
-			}
+	numberOfNodes, err := permNodeSession.GetNumberOfNodes()
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]types.NodeInfo, 0, numberOfNodes.Uint64())
+	for k := uint64(0); k < numberOfNodes.Uint64(); k++ {
+		nodeStruct, err := permNodeSession.GetNodeDetailsFromIndex(big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		return err
+		nodes = append(nodes, types.NodeInfo{OrgId: nodeStruct.OrgId, Url: nodeStruct.EnodeId, Status: types.NodeStatus(int(nodeStruct.NodeStatus.Int64()))})
 	}
-	return nil
+	return nodes, nil
 }
 
-// populates the org details from contract into cache
-func (p *PermissionCtrl) populateOrgsFromContract(auth *bind.TransactOpts) error {
-	//populate orgs
+// reads all the orgs from the contract, with the sub orgs of each org
+func (p *PermissionCtrl) readOrgsFromContract() ([]types.OrgInfo, error) {
 	permOrgSession := &pbind.OrgManagerSession{
 		Contract: p.permOrg,
 		CallOpts: bind.CallOpts{
 			Pending: true,
 		},
 	}
-	if numberOfOrgs, err := permOrgSession.GetNumberOfOrgs(); err == nil {
-		iOrgNum := numberOfOrgs.Uint64()
-		for k := uint64(0); k < iOrgNum; k++ {
-			if orgId, porgId, ultParent, level, status, err := permOrgSession.GetOrgInfo(big.NewInt(int64(k))); err == nil {
-				types.OrgInfoMap.UpsertOrg(orgId, porgId, ultParent, level, types.OrgStatus(int(status.Int64())))
-			}
+	numberOfOrgs, err := permOrgSession.GetNumberOfOrgs()
+	if err != nil {
+		return nil, err
+	}
+	orgs := make([]types.OrgInfo, 0, numberOfOrgs.Uint64())
+	index := make(map[string]int, numberOfOrgs.Uint64())
+	for k := uint64(0); k < numberOfOrgs.Uint64(); k++ {
+		orgId, porgId, ultParent, level, status, err := permOrgSession.GetOrgInfo(big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
+		}
+		fullOrgId := orgId
+		if porgId != "" {
+			fullOrgId = porgId + "." + orgId
+		}
+		index[fullOrgId] = len(orgs)
+		orgs = append(orgs, types.OrgInfo{
+			OrgId:          orgId,
+			FullOrgId:      fullOrgId,
+			ParentOrgId:    porgId,
+			UltimateParent: ultParent,
+			Level:          level,
+			Status:         types.OrgStatus(int(status.Int64())),
+		})
+	}
+	for _, org := range orgs {
+		if i, ok := index[org.ParentOrgId]; ok && org.ParentOrgId != "" {
+			orgs[i].SubOrgList = append(orgs[i].SubOrgList, org.FullOrgId)
 		}
-	} else {
-		return err
 	}
-	return nil
+	return orgs, nil
+}
+
+// reads an org missing from the cache from the contract, nil if it doesn't exist
+func (p *PermissionCtrl) readOrgFromContract(orgId string) (*types.OrgInfo, error) {
+	permOrgSession := &pbind.OrgManagerSession{
+		Contract: p.permOrg,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	if exists, err := permOrgSession.CheckOrgExists(orgId); err != nil || !exists {
+		return nil, err
+	}
+	orgIndex, err := permOrgSession.GetOrgIndex(orgId)
+	if err != nil {
+		return nil, err
+	}
+	org, porgId, ultParent, level, status, err := permOrgSession.GetOrgInfo(orgIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &types.OrgInfo{
+		OrgId:          org,
+		FullOrgId:      orgId,
+		ParentOrgId:    porgId,
+		UltimateParent: ultParent,
+		Level:          level,
+		Status:         types.OrgStatus(int(status.Int64())),
+	}, nil
+}
+
+// reads a node missing from the cache from the contract, nil if it doesn't exist
+func (p *PermissionCtrl) readNodeFromContract(url string) (*types.NodeInfo, error) {
+	permNodeSession := &pbind.NodeManagerSession{
+		Contract: p.permNode,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	nodeStruct, err := permNodeSession.GetNodeDetails(url)
+	if err != nil {
+		return nil, err
+	}
+	if nodeStruct.OrgId == "" || nodeStruct.EnodeId != url {
+		return nil, nil
+	}
+	return &types.NodeInfo{OrgId: nodeStruct.OrgId, Url: nodeStruct.EnodeId, Status: types.NodeStatus(int(nodeStruct.NodeStatus.Int64()))}, nil
+}
+
+// reads a role missing from the cache from the contract, nil if it doesn't exist
+func (p *PermissionCtrl) readRoleFromContract(orgId, roleId string) (*types.RoleInfo, error) {
+	permRoleSession := &pbind.RoleManagerSession{
+		Contract: p.permRole,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	roleStruct, err := permRoleSession.GetRoleDetails(roleId, orgId)
+	if err != nil {
+		return nil, err
+	}
+	if roleStruct.OrgId != orgId {
+		return nil, nil
+	}
+	return &types.RoleInfo{
+		OrgId:   roleStruct.OrgId,
+		RoleId:  roleStruct.RoleId,
+		IsVoter: roleStruct.Voter,
+		IsAdmin: roleStruct.Admin,
+		Access:  types.AccessType(int(roleStruct.AccessType.Int64())),
+		Active:  roleStruct.Active,
+	}, nil
+}
+
+// reads an account missing from the cache from the contract, nil if it doesn't exist
+func (p *PermissionCtrl) readAccountFromContract(acct common.Address) (*types.AccountInfo, error) {
+	permAcctSession := &pbind.AcctManagerSession{
+		Contract: p.permAcct,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	addr, org, role, status, orgAdmin, err := permAcctSession.GetAccountDetails(acct)
+	if err != nil {
+		return nil, err
+	}
+	if org == "NONE" {
+		return nil, nil
+	}
+	return &types.AccountInfo{OrgId: org, RoleId: role, AcctId: addr, IsOrgAdmin: orgAdmin, Status: types.AcctStatus(int(status.Int64()))}, nil
+}
+
+// reads the function rules of a role missing from the cache from the contract
+func (p *PermissionCtrl) readRoleRulesFromContract(orgId, roleId string) ([]types.FunctionRule, error) {
+	if p.permRule == nil {
+		return nil, nil
+	}
+	permRuleSession := &pbind.FunctionRuleManagerSession{
+		Contract: p.permRule,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	numberOfRules, err := permRuleSession.GetNumberOfRoleRules(orgId, roleId)
+	if err != nil {
+		return nil, err
+	}
+	var rules []types.FunctionRule
+	for k := uint64(0); k < numberOfRules.Uint64(); k++ {
+		contract, sig, active, err := permRuleSession.GetRoleRuleFromIndex(orgId, roleId, big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, types.FunctionRule{OrgId: orgId, RoleId: roleId, Contract: contract, Selector: sig, Active: active})
+	}
+	return rules, nil
 }
 
 // Reads the node list from static-nodes.json and populates into the contract
@@ -788,7 +1028,7 @@ func (p *PermissionCtrl) populateStaticNodesToContract(permissionsSession *pbind
 			log.Warn("Failed to propose node", "err", err, "enode", node.URLv4())
 			return err
 		}
-		types.NodeInfoMap.UpsertNode(p.permConfig.NwAdminOrg, node.String(), 2)
+		p.cache.NodeInfoMap.UpsertNode(p.permConfig.NwAdminOrg, node.String(), 2)
 	}
 	return nil
 }
@@ -802,7 +1042,7 @@ func (p *PermissionCtrl) populateInitAccountAccess(permissionsSession *pbind.Per
 			log.Warn("Error adding permission initial account list", "err", er, "account", a)
 			return er
 		}
-		types.AcctInfoMap.UpsertAccount(p.permConfig.NwAdminOrg, p.permConfig.NwAdminRole, a, true, 2)
+		p.cache.AcctInfoMap.UpsertAccount(p.permConfig.NwAdminOrg, p.permConfig.NwAdminRole, a, true, 2)
 	}
 	return nil
 }
@@ -840,11 +1080,11 @@ func (p *PermissionCtrl) manageRolePermissions() error {
 		for {
 			select {
 			case evtRoleCreated := <-chRoleCreated:
-				types.RoleInfoMap.UpsertRole(evtRoleCreated.OrgId, evtRoleCreated.RoleId, evtRoleCreated.IsVoter, evtRoleCreated.IsAdmin, types.AccessType(int(evtRoleCreated.BaseAccess.Uint64())), true)
+				p.cache.RoleInfoMap.UpsertRole(evtRoleCreated.OrgId, evtRoleCreated.RoleId, evtRoleCreated.IsVoter, evtRoleCreated.IsAdmin, types.AccessType(int(evtRoleCreated.BaseAccess.Uint64())), true)
 
 			case evtRoleRevoked := <-chRoleRevoked:
-				if r := types.RoleInfoMap.GetRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId); r != nil {
-					types.RoleInfoMap.UpsertRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId, r.IsVoter, r.IsAdmin, r.Access, false)
+				if r := p.cache.RoleInfoMap.GetRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId); r != nil {
+					p.cache.RoleInfoMap.UpsertRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId, r.IsVoter, r.IsAdmin, r.Access, false)
 				} else {
 					log.Error("Revoke role - cache is missing role", "org", evtRoleRevoked.OrgId, "role", evtRoleRevoked.RoleId)
 				}
@@ -856,3 +1096,43 @@ func (p *PermissionCtrl) manageRolePermissions() error {
 	}()
 	return nil
 }
+
+// monitors function rule management events and updates cache
+func (p *PermissionCtrl) manageFunctionRules() error {
+	if p.permRule == nil {
+		return nil
+	}
+	chRuleAdded := make(chan *pbind.FunctionRuleManagerFunctionRuleAdded, 1)
+	chRuleRemoved := make(chan *pbind.FunctionRuleManagerFunctionRuleRemoved, 1)
+
+	opts := &bind.WatchOpts{}
+	var blockNumber uint64 = 1
+	opts.Start = &blockNumber
+
+	if _, err := p.permRule.FunctionRuleManagerFilterer.WatchFunctionRuleAdded(opts, chRuleAdded); err != nil {
+		return fmt.Errorf("failed WatchFunctionRuleAdded: %v", err)
+	}
+
+	if _, err := p.permRule.FunctionRuleManagerFilterer.WatchFunctionRuleRemoved(opts, chRuleRemoved); err != nil {
+		return fmt.Errorf("failed WatchFunctionRuleRemoved: %v", err)
+	}
+
+	go func() {
+		stopChan, stopSubscription := p.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		for {
+			select {
+			case evtRuleAdded := <-chRuleAdded:
+				p.cache.RuleInfoMap.UpsertRule(types.FunctionRule{OrgId: evtRuleAdded.OrgId, RoleId: evtRuleAdded.RoleId, Contract: evtRuleAdded.Contract, Selector: evtRuleAdded.FunctionSig, Active: true})
+
+			case evtRuleRemoved := <-chRuleRemoved:
+				p.cache.RuleInfoMap.UpsertRule(types.FunctionRule{OrgId: evtRuleRemoved.OrgId, RoleId: evtRuleRemoved.RoleId, Contract: evtRuleRemoved.Contract, Selector: evtRuleRemoved.FunctionSig, Active: false})
+
+			case <-stopChan:
+				log.Info("quit function rule contract watch")
+				return
+			}
+		}
+	}()
+	return nil
+}
@@ -1,6 +1,7 @@
 package permission
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,8 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,10 +34,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	smilobft "go-smilo/src/blockchain/smilobft"
+	"go-smilo/src/blockchain/smilobft/accounts/abi"
 	"go-smilo/src/blockchain/smilobft/accounts/abi/bind"
 	"go-smilo/src/blockchain/smilobft/accounts/abi/bind/backends"
 	"go-smilo/src/blockchain/smilobft/core"
 	"go-smilo/src/blockchain/smilobft/node"
+	"go-smilo/src/blockchain/smilobft/p2p/enode"
 	pbind "go-smilo/src/blockchain/smilobft/permission/bind"
 )
 
@@ -67,10 +74,9 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	//TODO: fix me
-	return
-
-	setup()
+	//TODO: fix me, the permission contracts fail to deploy on the simulated
+	// backend, the tests using them are skipped
+	// setup()
 	ret := m.Run()
 	teardown()
 	os.Exit(ret)
@@ -254,8 +260,8 @@ func TestPermissionCtrl_PopulateInitPermissions_AfterNetworkIsInitialized(t *tes
 	assert.NoError(t, err)
 
 	// assert cache
-	assert.Equal(t, 1, len(types.OrgInfoMap.GetOrgList()))
-	cachedOrg := types.OrgInfoMap.GetOrgList()[0]
+	assert.Equal(t, 1, len(testObject.cache.OrgInfoMap.GetOrgList()))
+	cachedOrg := testObject.cache.OrgInfoMap.GetOrgList()[0]
 	assert.Equal(t, arbitraryNetworkAdminOrg, cachedOrg.OrgId)
 	assert.Equal(t, arbitraryNetworkAdminOrg, cachedOrg.FullOrgId)
 	assert.Equal(t, arbitraryNetworkAdminOrg, cachedOrg.UltimateParent)
@@ -264,8 +270,8 @@ func TestPermissionCtrl_PopulateInitPermissions_AfterNetworkIsInitialized(t *tes
 	assert.Equal(t, 0, len(cachedOrg.SubOrgList))
 	assert.Equal(t, big.NewInt(1), cachedOrg.Level)
 
-	assert.Equal(t, 1, len(types.RoleInfoMap.GetRoleList()))
-	cachedRole := types.RoleInfoMap.GetRoleList()[0]
+	assert.Equal(t, 1, len(testObject.cache.RoleInfoMap.GetRoleList()))
+	cachedRole := testObject.cache.RoleInfoMap.GetRoleList()[0]
 	assert.Equal(t, arbitraryNetworkAdminOrg, cachedRole.OrgId)
 	assert.Equal(t, arbitraryNetworkAdminRole, cachedRole.RoleId)
 	assert.True(t, cachedRole.Active)
@@ -273,10 +279,10 @@ func TestPermissionCtrl_PopulateInitPermissions_AfterNetworkIsInitialized(t *tes
 	assert.True(t, cachedRole.IsVoter)
 	assert.Equal(t, types.FullAccess, cachedRole.Access)
 
-	assert.Equal(t, 0, len(types.NodeInfoMap.GetNodeList()))
+	assert.Equal(t, 0, len(testObject.cache.NodeInfoMap.GetNodeList()))
 
-	assert.Equal(t, 1, len(types.AcctInfoMap.GetAcctList()))
-	cachedAccount := types.AcctInfoMap.GetAcctList()[0]
+	assert.Equal(t, 1, len(testObject.cache.AcctInfoMap.GetAcctList()))
+	cachedAccount := testObject.cache.AcctInfoMap.GetAcctList()[0]
 	assert.Equal(t, arbitraryNetworkAdminOrg, cachedAccount.OrgId)
 	assert.Equal(t, arbitraryNetworkAdminRole, cachedAccount.RoleId)
 	assert.Equal(t, types.AcctActive, cachedAccount.Status)
@@ -343,14 +349,14 @@ func TestQuorumControlsAPI_OrgAPIs(t *testing.T) {
 	_, err = testObject.ApproveOrg(arbitraryOrgToAdd, arbitraryNode1, orgAdminAddress, txa)
 	assert.NoError(t, err)
 
-	types.OrgInfoMap.UpsertOrg(arbitraryOrgToAdd, "", arbitraryOrgToAdd, big.NewInt(1), types.OrgApproved)
+	testObject.permCtrl.cache.OrgInfoMap.UpsertOrg(arbitraryOrgToAdd, "", arbitraryOrgToAdd, big.NewInt(1), types.OrgApproved)
 	_, err = testObject.UpdateOrgStatus(arbitraryOrgToAdd, uint8(SuspendOrg), invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
 	_, err = testObject.UpdateOrgStatus(arbitraryOrgToAdd, uint8(SuspendOrg), txa)
 	assert.NoError(t, err)
 
-	types.OrgInfoMap.UpsertOrg(arbitraryOrgToAdd, "", arbitraryOrgToAdd, big.NewInt(1), types.OrgSuspended)
+	testObject.permCtrl.cache.OrgInfoMap.UpsertOrg(arbitraryOrgToAdd, "", arbitraryOrgToAdd, big.NewInt(1), types.OrgSuspended)
 	_, err = testObject.ApproveOrgStatus(arbitraryOrgToAdd, uint8(SuspendOrg), invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
@@ -362,7 +368,7 @@ func TestQuorumControlsAPI_OrgAPIs(t *testing.T) {
 
 	_, err = testObject.AddSubOrg(arbitraryNetworkAdminOrg, arbitrarySubOrg, "", txa)
 	assert.NoError(t, err)
-	types.OrgInfoMap.UpsertOrg(arbitrarySubOrg, arbitraryNetworkAdminOrg, arbitraryNetworkAdminOrg, big.NewInt(2), types.OrgApproved)
+	testObject.permCtrl.cache.OrgInfoMap.UpsertOrg(arbitrarySubOrg, arbitraryNetworkAdminOrg, arbitraryNetworkAdminOrg, big.NewInt(2), types.OrgApproved)
 
 	suborg := "ABC.12345"
 	_, err = testObject.AddSubOrg(arbitraryNetworkAdminOrg, suborg, "", txa)
@@ -386,22 +392,22 @@ func TestQuorumControlsAPI_NodeAPIs(t *testing.T) {
 
 	_, err = testObject.AddNode(arbitraryNetworkAdminOrg, arbitraryNode2, txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
 
 	_, err = testObject.UpdateNodeStatus(arbitraryNetworkAdminOrg, arbitraryNode2, uint8(SuspendNode), invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
 	_, err = testObject.UpdateNodeStatus(arbitraryNetworkAdminOrg, arbitraryNode2, uint8(SuspendNode), txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeDeactivated)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeDeactivated)
 
 	_, err = testObject.UpdateNodeStatus(arbitraryNetworkAdminOrg, arbitraryNode2, uint8(ActivateSuspendedNode), txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
 
 	_, err = testObject.UpdateNodeStatus(arbitraryNetworkAdminOrg, arbitraryNode2, uint8(BlacklistNode), txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeBlackListed)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeBlackListed)
 
 	_, err = testObject.UpdateNodeStatus(arbitraryNetworkAdminOrg, arbitraryNode2, uint8(ActivateSuspendedNode), txa)
 	assert.Equal(t, err, ErrNodeBlacklisted)
@@ -411,14 +417,14 @@ func TestQuorumControlsAPI_NodeAPIs(t *testing.T) {
 
 	_, err = testObject.RecoverBlackListedNode(arbitraryNetworkAdminOrg, arbitraryNode2, txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeRecoveryInitiated)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeRecoveryInitiated)
 
 	_, err = testObject.ApproveBlackListedNodeRecovery(arbitraryNetworkAdminOrg, arbitraryNode2, invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
 	_, err = testObject.ApproveBlackListedNodeRecovery(arbitraryNetworkAdminOrg, arbitraryNode2, txa)
 	assert.NoError(t, err)
-	types.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
+	testObject.permCtrl.cache.NodeInfoMap.UpsertNode(arbitraryNetworkAdminOrg, arbitraryNode2, types.NodeApproved)
 }
 
 func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
@@ -431,7 +437,7 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
 	_, err = testObject.AssignAdminRole(arbitraryNetworkAdminOrg, acct, arbitraryNetworkAdminRole, txa)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitraryNetworkAdminRole, acct, true, types.AcctPendingApproval)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitraryNetworkAdminRole, acct, true, types.AcctPendingApproval)
 
 	_, err = testObject.ApproveAdminRole(arbitraryNetworkAdminOrg, acct, invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
@@ -441,14 +447,14 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 
 	_, err = testObject.ApproveAdminRole(arbitraryNetworkAdminOrg, acct, txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitraryNetworkAdminRole, acct, true, types.AcctActive)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitraryNetworkAdminRole, acct, true, types.AcctActive)
 
 	_, err = testObject.AddNewRole(arbitraryNetworkAdminOrg, arbitrartNewRole1, uint8(types.FullAccess), false, false, invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
 
 	_, err = testObject.AddNewRole(arbitraryNetworkAdminOrg, arbitrartNewRole1, uint8(types.FullAccess), false, false, txa)
 	assert.NoError(t, err)
-	types.RoleInfoMap.UpsertRole(arbitraryNetworkAdminOrg, arbitrartNewRole1, false, false, types.FullAccess, true)
+	testObject.permCtrl.cache.RoleInfoMap.UpsertRole(arbitraryNetworkAdminOrg, arbitrartNewRole1, false, false, types.FullAccess, true)
 
 	acct = getArbitraryAccount()
 	_, err = testObject.AddAccountToOrg(acct, arbitraryNetworkAdminOrg, arbitrartNewRole1, invalidTxa)
@@ -456,7 +462,7 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 
 	_, err = testObject.AddAccountToOrg(acct, arbitraryNetworkAdminOrg, arbitrartNewRole1, txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole1, acct, true, types.AcctActive)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole1, acct, true, types.AcctActive)
 
 	_, err = testObject.RemoveRole(arbitraryNetworkAdminOrg, arbitrartNewRole1, invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
@@ -466,7 +472,7 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 
 	_, err = testObject.AddNewRole(arbitraryNetworkAdminOrg, arbitrartNewRole2, uint8(types.FullAccess), false, false, txa)
 	assert.NoError(t, err)
-	types.RoleInfoMap.UpsertRole(arbitraryNetworkAdminOrg, arbitrartNewRole2, false, false, types.FullAccess, true)
+	testObject.permCtrl.cache.RoleInfoMap.UpsertRole(arbitraryNetworkAdminOrg, arbitrartNewRole2, false, false, types.FullAccess, true)
 
 	_, err = testObject.ChangeAccountRole(acct, arbitraryNetworkAdminOrg, arbitrartNewRole2, invalidTxa)
 	assert.Equal(t, err, errors.New("Invalid account id"))
@@ -482,15 +488,15 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 
 	_, err = testObject.UpdateAccountStatus(arbitraryNetworkAdminOrg, acct, uint8(SuspendAccount), txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctSuspended)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctSuspended)
 
 	_, err = testObject.UpdateAccountStatus(arbitraryNetworkAdminOrg, acct, uint8(ActivateSuspendedAccount), txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctActive)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctActive)
 
 	_, err = testObject.UpdateAccountStatus(arbitraryNetworkAdminOrg, acct, uint8(BlacklistAccount), txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctBlacklisted)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctBlacklisted)
 
 	_, err = testObject.UpdateAccountStatus(arbitraryNetworkAdminOrg, acct, uint8(ActivateSuspendedAccount), txa)
 	assert.Equal(t, err, ErrAcctBlacklisted)
@@ -500,10 +506,10 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 
 	_, err = testObject.RecoverBlackListedAccount(arbitraryNetworkAdminOrg, acct, txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctRecoveryInitiated)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctRecoveryInitiated)
 	_, err = testObject.ApproveBlackListedAccountRecovery(arbitraryNetworkAdminOrg, acct, txa)
 	assert.NoError(t, err)
-	types.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctActive)
+	testObject.permCtrl.cache.AcctInfoMap.UpsertAccount(arbitraryNetworkAdminOrg, arbitrartNewRole2, acct, true, types.AcctActive)
 
 }
 
@@ -513,6 +519,9 @@ func getArbitraryAccount() common.Address {
 }
 
 func typicalPermissionCtrl(t *testing.T) *PermissionCtrl {
+	if backend == nil {
+		t.Skip("permission contracts not deployed")
+	}
 	testObject, err := NewQuorumPermissionCtrl(stack, &types.PermissionConfig{
 		UpgrdAddress:   permUpgrAddress,
 		InterfAddress:  permInterfaceAddress,
@@ -556,57 +565,6 @@ func tmpKeyStore(encrypted bool) (string, *keystore.KeyStore, error) {
 	return d, new(d), err
 }
 
-func TestPermissionCtrl_whenUpdateFile(t *testing.T) {
-	testObject := typicalPermissionCtrl(t)
-	assert.NoError(t, testObject.AfterStart())
-
-	err := testObject.populateInitPermissions()
-	assert.NoError(t, err)
-
-	d, _ := ioutil.TempDir("", "qdata")
-	defer os.RemoveAll(d)
-
-	testObject.dataDir = d
-	testObject.updatePermissionedNodes(arbitraryNode1, NodeAdd)
-
-	permFile, _ := os.Create(d + "/" + "permissioned-nodes.json")
-
-	testObject.updateFile("testFile", arbitraryNode2, NodeAdd, false)
-	testObject.updateFile(permFile.Name(), arbitraryNode2, NodeAdd, false)
-	testObject.updateFile(permFile.Name(), arbitraryNode2, NodeAdd, true)
-	testObject.updateFile(permFile.Name(), arbitraryNode2, NodeAdd, true)
-	testObject.updateFile(permFile.Name(), arbitraryNode1, NodeAdd, false)
-	testObject.updateFile(permFile.Name(), arbitraryNode1, NodeDelete, false)
-	testObject.updateFile(permFile.Name(), arbitraryNode1, NodeDelete, false)
-
-	blob, err := ioutil.ReadFile(permFile.Name())
-	var nodeList []string
-	if err := json.Unmarshal(blob, &nodeList); err != nil {
-		t.Fatal("Failed to load nodes list from file", "fileName", permFile, "err", err)
-		return
-	}
-	assert.Equal(t, len(nodeList), 1)
-	testObject.updatePermissionedNodes(arbitraryNode1, NodeAdd)
-	testObject.updatePermissionedNodes(arbitraryNode1, NodeDelete)
-
-	blob, err = ioutil.ReadFile(permFile.Name())
-	if err := json.Unmarshal(blob, &nodeList); err != nil {
-		t.Fatal("Failed to load nodes list from file", "fileName", permFile, "err", err)
-		return
-	}
-	assert.Equal(t, len(nodeList), 1)
-
-	testObject.updateDisallowedNodes(arbitraryNode2, NodeAdd)
-	testObject.updateDisallowedNodes(arbitraryNode2, NodeDelete)
-	blob, err = ioutil.ReadFile(d + "/" + "disallowed-nodes.json")
-	if err := json.Unmarshal(blob, &nodeList); err != nil {
-		t.Fatal("Failed to load nodes list from file", "fileName", permFile, "err", err)
-		return
-	}
-	assert.Equal(t, len(nodeList), 0)
-
-}
-
 func TestParsePermissionConfig(t *testing.T) {
 	d, _ := ioutil.TempDir("", "qdata")
 	defer os.RemoveAll(d)
@@ -669,3 +627,188 @@ func TestParsePermissionConfig(t *testing.T) {
 	permConfig, err := ParsePermissionConfig(d)
 	assert.False(t, permConfig.IsEmpty(), "expected non empty object")
 }
+
+// testFilterer delivers the events of a contract to the watches of its
+// binding without a chain
+type testFilterer struct {
+	abi  abi.ABI
+	subs map[common.Hash]chan<- types.Log
+}
+
+func newTestFilterer(t *testing.T, contractABI string) *testFilterer {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testFilterer{abi: parsed, subs: make(map[common.Hash]chan<- types.Log)}
+}
+
+func (f *testFilterer) FilterLogs(ctx context.Context, query smilobft.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (f *testFilterer) SubscribeFilterLogs(ctx context.Context, query smilobft.FilterQuery, ch chan<- types.Log) (smilobft.Subscription, error) {
+	f.subs[query.Topics[0][0]] = ch
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+func (f *testFilterer) emit(t *testing.T, name string, args ...interface{}) {
+	ev := f.abi.Events[name]
+	data, err := ev.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.subs[ev.ID()] <- types.Log{Topics: []common.Hash{ev.ID()}, Data: data}
+}
+
+// startPermissionedNode starts a node with node permissioning whose node
+// manager events are sent by the returned filterer
+func startPermissionedNode(t *testing.T, datadir string) (*PermissionCtrl, *testFilterer) {
+	key, _ := crypto.GenerateKey()
+	stack, err := node.New(&node.Config{
+		DataDir:                  datadir,
+		EnableNodePermissionFlag: true,
+		P2P: p2p.Config{
+			PrivateKey:  key,
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+			MaxPeers:    10,
+		},
+		NoUSB: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatal(err)
+	}
+	filterer := newTestFilterer(t, pbind.NodeManagerABI)
+	nodeManager, err := pbind.NewNodeManagerFilterer(common.Address{}, filterer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &PermissionCtrl{
+		node:     stack,
+		permNode: &pbind.NodeManager{NodeManagerFilterer: *nodeManager},
+		cache:    types.NewPermissionCache(),
+	}
+	return p, filterer
+}
+
+func readNodeFile(t *testing.T, path string) []string {
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", path, err)
+	}
+	var urls []string
+	if err := json.Unmarshal(blob, &urls); err != nil {
+		t.Fatalf("could not decode %s: %v", path, err)
+	}
+	return urls
+}
+
+func TestPermissionCtrl_whenUpdateFile(t *testing.T) {
+	d, _ := ioutil.TempDir("", "qdata")
+	defer os.RemoveAll(d)
+
+	testObject, _ := startPermissionedNode(t, d)
+	defer testObject.node.Stop()
+
+	testObject.updatePermissionedNodes(arbitraryNode1, NodeAdd)
+	testObject.updatePermissionedNodes(arbitraryNode2, NodeAdd)
+	testObject.updatePermissionedNodes(arbitraryNode2, NodeAdd)
+	testObject.updatePermissionedNodes(arbitraryNode1, NodeDelete)
+	testObject.updatePermissionedNodes(arbitraryNode1, NodeDelete)
+
+	nodeList := readNodeFile(t, filepath.Join(d, params.PERMISSIONED_CONFIG))
+	assert.Equal(t, 1, len(nodeList))
+	assert.Equal(t, 1, len(testObject.node.Server().NodeList().Info().Allowed))
+
+	testObject.updateDisallowedNodes(arbitraryNode2, NodeAdd)
+	nodeList = readNodeFile(t, filepath.Join(d, params.BLACKLIST_CONFIG))
+	assert.Equal(t, 1, len(nodeList))
+	testObject.updateDisallowedNodes(arbitraryNode2, NodeDelete)
+	nodeList = readNodeFile(t, filepath.Join(d, params.BLACKLIST_CONFIG))
+	assert.Equal(t, 0, len(nodeList))
+}
+
+func TestPermissionCtrl_manageNodePermissions(t *testing.T) {
+	d, _ := ioutil.TempDir("", "qdata")
+	defer os.RemoveAll(d)
+
+	testObject, filterer := startPermissionedNode(t, d)
+	defer testObject.node.Stop()
+	assert.NoError(t, testObject.manageNodePermissions())
+	defer testObject.Stop()
+	srv := testObject.node.Server()
+
+	key, _ := crypto.GenerateKey()
+	peer := &p2p.Server{Config: p2p.Config{
+		PrivateKey:  key,
+		ListenAddr:  "127.0.0.1:0",
+		NoDiscovery: true,
+		MaxPeers:    10,
+	}}
+	if err := peer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer peer.Stop()
+	peerURL := peer.Self().URLv4()
+	peerID := peer.Self().ID()
+
+	events := make(chan *p2p.PeerEvent, 10)
+	sub := srv.SubscribeEvents(events)
+	defer sub.Unsubscribe()
+	waitEvent := func(typ p2p.PeerEventType) {
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case ev := <-events:
+				if ev.Type == typ && ev.Peer == peerID {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timeout waiting for peer event %s", typ)
+			}
+		}
+	}
+	isAllowed := func() bool {
+		for _, url := range srv.NodeList().Info().Allowed {
+			if enode.MustParseV4(url).ID() == peerID {
+				return true
+			}
+		}
+		return false
+	}
+	waitAllowed := func(allowed bool) {
+		for i := 0; isAllowed() != allowed; i++ {
+			if i == 500 {
+				t.Fatalf("timeout waiting for the peer to be allowed=%v", allowed)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// approved nodes may connect
+	filterer.emit(t, "NodeApproved", peerURL, arbitraryOrgToAdd)
+	waitAllowed(true)
+	srv.AddPeer(peer.Self())
+	waitEvent(p2p.PeerEventTypeAdd)
+
+	// blacklisted nodes are dropped and denied
+	filterer.emit(t, "NodeBlacklisted", peerURL, arbitraryOrgToAdd)
+	waitEvent(p2p.PeerEventTypeDrop)
+	waitAllowed(false)
+	assert.Equal(t, []string{peerURL}, readNodeFile(t, filepath.Join(d, params.BLACKLIST_CONFIG)))
+	assert.Equal(t, types.NodeBlackListed, testObject.cache.NodeInfoMap.GetNodeByUrl(peerURL).Status)
+
+	// recovered nodes are allowed again, deactivated nodes are removed
+	filterer.emit(t, "NodeRecoveryCompleted", peerURL, arbitraryOrgToAdd)
+	waitAllowed(true)
+	assert.Equal(t, 0, len(readNodeFile(t, filepath.Join(d, params.BLACKLIST_CONFIG))))
+	filterer.emit(t, "NodeDeactivated", peerURL, arbitraryOrgToAdd)
+	waitAllowed(false)
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package private
+
+import (
+	"time"
+
+	"go-smilo/src/blockchain/smilobft/private/privatetransactionmanager"
+)
+
+// VaultConfig selects and configures the Blackbox vault backend of a node.
+type VaultConfig struct {
+	Backend string `toml:",omitempty"` // Registered backend name, empty to use PRIVATE_CONFIG
+	Path    string `toml:",omitempty"` // Socket or Blackbox config file of the ipc backend
+	URL     string `toml:",omitempty"` // Base url of the https backend
+	TLSCert string `toml:",omitempty"` // Client certificate presented to the https backend
+	TLSKey  string `toml:",omitempty"` // Private key of the client certificate
+	TLSCA   string `toml:",omitempty"` // CA used to verify the https backend certificate
+
+	Retries        int           `toml:",omitempty"` // Attempts made after a failed Blackbox request
+	RequestTimeout time.Duration `toml:",omitempty"` // Timeout for a single Blackbox request
+	HealthInterval time.Duration `toml:",omitempty"` // Interval between two Blackbox upchecks
+	PauseTimeout   time.Duration `toml:",omitempty"` // Time block processing waits for an unreachable Blackbox
+}
+
+// options returns the Blackbox client options, overriding the defaults with
+// the configured values.
+func (c *VaultConfig) options() privatetransactionmanager.Options {
+	opts := privatetransactionmanager.DefaultOptions
+	if c.Retries > 0 {
+		opts.Retries = c.Retries
+	}
+	if c.RequestTimeout > 0 {
+		opts.RequestTimeout = c.RequestTimeout
+	}
+	if c.HealthInterval > 0 {
+		opts.HealthInterval = c.HealthInterval
+	}
+	if c.PauseTimeout > 0 {
+		opts.PauseTimeout = c.PauseTimeout
+	}
+	return opts
+}
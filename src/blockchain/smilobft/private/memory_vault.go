@@ -0,0 +1,149 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package private
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+var errEmptyPayload = errors.New("empty payload")
+
+// memoryStore holds the payloads shared between in-memory vaults.
+type memoryStore struct {
+	lock     sync.RWMutex
+	payloads map[string][]byte
+	parties  map[string]map[string]bool
+}
+
+// MemoryVault is an in-process BlackboxVault keeping payloads in memory. It
+// lets tests run private transactions without a Blackbox node. Vaults created
+// through Peer share their payloads and only return the ones they are a party
+// of, which mimics several Blackbox nodes talking to each other.
+type MemoryVault struct {
+	store *memoryStore
+	keys  []string // Public keys of this vault, empty means party to every payload
+}
+
+// NewMemoryVault creates an in-memory vault owning the given public keys.
+func NewMemoryVault(keys ...string) *MemoryVault {
+	return &MemoryVault{
+		store: &memoryStore{
+			payloads: make(map[string][]byte),
+			parties:  make(map[string]map[string]bool),
+		},
+		keys: keys,
+	}
+}
+
+// Peer returns a vault sharing the payloads of v but owning the given keys.
+func (v *MemoryVault) Peer(keys ...string) *MemoryVault {
+	return &MemoryVault{store: v.store, keys: keys}
+}
+
+func (v *MemoryVault) Post(data []byte, from string, to []string) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errEmptyPayload
+	}
+	parties := append([]string{from}, to...)
+	if from == "" && len(v.keys) > 0 {
+		parties = append(parties, v.keys[0])
+	}
+	return v.store.put(data, parties), nil
+}
+
+func (v *MemoryVault) PostRawTransaction(data []byte, to []string) ([]byte, error) {
+	return v.Post(data, "", to)
+}
+
+func (v *MemoryVault) Get(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	v.store.lock.RLock()
+	defer v.store.lock.RUnlock()
+
+	key := string(data)
+	payload, ok := v.store.payloads[key]
+	if !ok || !v.isParty(v.store.parties[key]) {
+		return nil, ErrPayloadNotFound
+	}
+	return common.CopyBytes(payload), nil
+}
+
+// Parties returns the keys of the sender and the recipients of the payload
+// stored under key, sorted.
+func (v *MemoryVault) Parties(key []byte) ([]string, error) {
+	v.store.lock.RLock()
+	defer v.store.lock.RUnlock()
+
+	parties, ok := v.store.parties[string(key)]
+	if !ok || !v.isParty(parties) {
+		return nil, ErrPayloadNotFound
+	}
+	keys := make([]string, 0, len(parties))
+	for party := range parties {
+		keys = append(keys, party)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Health reports the in-memory vault as always up.
+func (v *MemoryVault) Health() HealthStatus {
+	now := time.Now()
+	return HealthStatus{Up: true, LastCheck: now, LastSuccess: now}
+}
+
+func (v *MemoryVault) isParty(parties map[string]bool) bool {
+	if len(v.keys) == 0 {
+		return true
+	}
+	for _, key := range v.keys {
+		if parties[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// put stores the payload and returns its 64 byte digest, matching the
+// length of the keys handed out by Blackbox.
+func (s *memoryStore) put(data []byte, parties []string) []byte {
+	digest := sha3.Sum512(data)
+	key := string(digest[:])
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.payloads[key] = common.CopyBytes(data)
+	if s.parties[key] == nil {
+		s.parties[key] = make(map[string]bool)
+	}
+	for _, party := range parties {
+		if party != "" {
+			s.parties[key][party] = true
+		}
+	}
+	return digest[:]
+}
@@ -0,0 +1,149 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package private
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// PrivacyFlag selects the protection a private transaction asks for.
+type PrivacyFlag uint64
+
+const (
+	// PrivacyFlagStandard is the default mode: any party may call any private
+	// contract it knows of.
+	PrivacyFlagStandard PrivacyFlag = iota
+
+	// PrivacyFlagPartyProtection binds a contract to the participants of its
+	// creation. Every later call must be sent to exactly the same participants
+	// and contracts created in this mode can only be reached by transactions
+	// in this mode.
+	PrivacyFlagPartyProtection
+)
+
+// ErrInvalidPrivacyFlag is returned for privacy flags the node doesn't know.
+var ErrInvalidPrivacyFlag = errors.New("invalid privacy flag")
+
+// payloadMagic prefixes private payloads carrying privacy metadata. Standard
+// payloads are stored unwrapped, so nodes and vault tools unaware of the
+// metadata keep working with them.
+var payloadMagic = []byte("\xffsmilo-privacy\x01")
+
+// ErrParticipantsUnverifiable is returned for party protection payloads when
+// the vault can't vouch for the parties of the payload.
+var ErrParticipantsUnverifiable = errors.New("vault can't verify the participants of the payload")
+
+// PartiesReader is implemented by vaults which can tell the parties of a
+// payload, its sender and recipients, as recorded when it was posted.
+//
+// Only the in-memory vault implements it. The Blackbox API hands payloads out
+// by key and never tells their recipients, so nodes on the ipc and https
+// backends refuse to send party protection transactions and can't resolve the
+// participants of the ones they receive.
+type PartiesReader interface {
+	Parties(key []byte) ([]string, error)
+}
+
+// PrivacyMetadata is the privacy mode of a private transaction. Only the flag
+// is sent along with the payload through the vault, the participants are
+// filled in by the receiving node with ResolveParticipants.
+type PrivacyMetadata struct {
+	Flag         PrivacyFlag
+	Participants common.Hash // ParticipantsHash of the sender and the recipients
+}
+
+// ResolveParticipants sets the participants of the metadata of the payload
+// stored under key from the parties vouched for by the vault. The sender fills
+// the payload as it likes, so the participants are never read from it.
+func ResolveParticipants(vault BlackboxVault, key []byte, meta *PrivacyMetadata) error {
+	reader, ok := vault.(PartiesReader)
+	if !ok {
+		return ErrParticipantsUnverifiable
+	}
+	parties, err := reader.Parties(key)
+	if err != nil {
+		return err
+	}
+	meta.Participants = ParticipantsHash(parties...)
+	return nil
+}
+
+// Protected reports whether the metadata asks for party protection.
+func (m *PrivacyMetadata) Protected() bool {
+	return m != nil && m.Flag == PrivacyFlagPartyProtection
+}
+
+// ParticipantsHash returns an order independent digest of the vault public
+// keys of the parties to a transaction.
+func ParticipantsHash(keys ...string) common.Hash {
+	sorted := make([]string, 0, len(keys))
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	blob, _ := rlp.EncodeToBytes(sorted)
+	return crypto.Keccak256Hash(blob)
+}
+
+// WrapPayload prepends the privacy flag of meta to a private payload before it
+// is posted to the vault. Payloads of standard transactions are left untouched.
+func WrapPayload(meta *PrivacyMetadata, payload []byte) ([]byte, error) {
+	switch {
+	case meta == nil || meta.Flag == PrivacyFlagStandard:
+		return payload, nil
+	case meta.Flag != PrivacyFlagPartyProtection:
+		return nil, ErrInvalidPrivacyFlag
+	}
+	blob, err := rlp.EncodeToBytes([]interface{}{meta.Flag, payload})
+	if err != nil {
+		return nil, err
+	}
+	return append(common.CopyBytes(payloadMagic), blob...), nil
+}
+
+// UnwrapPayload splits a payload read from the vault into its privacy metadata
+// and the transaction data. The metadata is nil for standard payloads, its
+// participants are left unset.
+func UnwrapPayload(data []byte) (*PrivacyMetadata, []byte, error) {
+	if !bytes.HasPrefix(data, payloadMagic) {
+		return nil, data, nil
+	}
+	var envelope struct {
+		Flag    PrivacyFlag
+		Payload []byte
+	}
+	if err := rlp.DecodeBytes(data[len(payloadMagic):], &envelope); err != nil {
+		return nil, nil, fmt.Errorf("malformed privacy metadata: %v", err)
+	}
+	if envelope.Flag != PrivacyFlagPartyProtection {
+		return nil, nil, ErrInvalidPrivacyFlag
+	}
+	return &PrivacyMetadata{Flag: envelope.Flag}, envelope.Payload, nil
+}
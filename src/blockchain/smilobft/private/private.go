@@ -18,19 +18,134 @@
 package private
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"go-smilo/src/blockchain/smilobft/private/privatetransactionmanager"
 )
 
+// Names of the vault backends shipped with the node.
+const (
+	IPCBackend   = "ipc"   // Blackbox reached over a unix socket
+	HTTPSBackend = "https" // Blackbox reached over TCP, optionally with TLS client certificates
+	MockBackend  = "mock"  // In-memory vault, for tests and developer nodes only
+)
+
+// Errors returned by BlackboxVault.Get. Only ErrPayloadNotFound, and
+// ErrVaultNotStarted for nodes ignoring private transactions, mean the node is
+// not a party. Private payloads must not be assumed empty on any other error.
+var (
+	ErrPayloadNotFound  = privatetransactionmanager.ErrPayloadNotFound
+	ErrVaultNotStarted  = privatetransactionmanager.ErrBlackboxIsNotStarted
+	ErrUnauthorized     = privatetransactionmanager.ErrUnauthorized
+	ErrCorruptPayload   = privatetransactionmanager.ErrCorruptPayload
+	ErrVaultUnavailable = privatetransactionmanager.ErrBlackboxUnavailable
+)
+
 type BlackboxVault interface {
 	Post(data []byte, from string, to []string) ([]byte, error)
 	PostRawTransaction(data []byte, to []string) ([]byte, error)
 	Get(data []byte) ([]byte, error)
 }
 
+// HealthStatus is a snapshot of the health of the Blackbox node behind a vault.
+type HealthStatus = privatetransactionmanager.HealthStatus
+
+// HealthReporter is implemented by vaults tracking the health of their
+// Blackbox node.
+type HealthReporter interface {
+	Health() HealthStatus
+}
+
+// BackendFactory creates a BlackboxVault from the node vault configuration.
+type BackendFactory func(cfg *VaultConfig) (BlackboxVault, error)
+
+var (
+	backendsLock sync.RWMutex
+	backends     = make(map[string]BackendFactory)
+)
+
+func init() {
+	RegisterBackend(IPCBackend, newIPCVault)
+	RegisterBackend(HTTPSBackend, newHTTPSVault)
+	RegisterBackend(MockBackend, func(*VaultConfig) (BlackboxVault, error) {
+		log.Warn("Using the in-memory vault, private payloads are lost on restart")
+		return NewMemoryVault(), nil
+	})
+}
+
+// RegisterBackend makes a vault backend available under the given name. It
+// panics if a backend with the same name is already registered.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	name = strings.ToLower(name)
+	if _, ok := backends[name]; ok {
+		panic(fmt.Sprintf("vault backend %q registered twice", name))
+	}
+	backends[name] = factory
+}
+
+// Backends returns the sorted names of all registered vault backends.
+func Backends() []string {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewVault creates the vault selected by the given configuration. If no
+// backend is configured the process wide VaultInstance, built from the
+// PRIVATE_CONFIG environment variable, is returned.
+func NewVault(cfg *VaultConfig) (BlackboxVault, error) {
+	if cfg == nil || cfg.Backend == "" {
+		return VaultInstance, nil
+	}
+	backendsLock.RLock()
+	factory, ok := backends[strings.ToLower(cfg.Backend)]
+	backendsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown vault backend %q, available: %s", cfg.Backend, strings.Join(Backends(), ", "))
+	}
+	return factory(cfg)
+}
+
+func newIPCVault(cfg *VaultConfig) (BlackboxVault, error) {
+	path := cfg.Path
+	if path == "" {
+		path = os.Getenv("PRIVATE_CONFIG")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("vault backend %q requires a socket or config path", IPCBackend)
+	}
+	if strings.EqualFold(path, "ignore") {
+		return privatetransactionmanager.CreateNew(path), nil
+	}
+	return privatetransactionmanager.New(path, cfg.options())
+}
+
+func newHTTPSVault(cfg *VaultConfig) (BlackboxVault, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("vault backend %q requires an url", HTTPSBackend)
+	}
+	tlsConfig, err := privatetransactionmanager.LoadTLSConfig(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA)
+	if err != nil {
+		return nil, err
+	}
+	return privatetransactionmanager.NewRemote(cfg.URL, tlsConfig, cfg.options())
+}
+
 func GetBlackboxVault(targetIPC string) BlackboxVault {
 	log.Debug("################ GetBlackboxVault, ", "targetIPC", targetIPC)
 	config := os.Getenv(targetIPC)
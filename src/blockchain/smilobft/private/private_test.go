@@ -0,0 +1,149 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package private
+
+import (
+	"bytes"
+	"testing"
+
+	"go-smilo/src/blockchain/smilobft/private/privatetransactionmanager"
+)
+
+func TestNewVaultBackends(t *testing.T) {
+	vault, err := NewVault(&VaultConfig{Backend: "Mock"})
+	if err != nil {
+		t.Fatalf("failed to create mock vault: %v", err)
+	}
+	if _, ok := vault.(*MemoryVault); !ok {
+		t.Fatalf("mock backend returned %T, want *MemoryVault", vault)
+	}
+	if _, err := NewVault(&VaultConfig{Backend: "unknown"}); err == nil {
+		t.Fatal("expected an error for an unknown backend")
+	}
+	if _, err := NewVault(&VaultConfig{Backend: HTTPSBackend}); err == nil {
+		t.Fatal("expected an error for an https backend without url")
+	}
+	if vault, err := NewVault(&VaultConfig{}); err != nil || vault != VaultInstance {
+		t.Fatalf("unconfigured vault should fall back to VaultInstance, got %v, %v", vault, err)
+	}
+}
+
+func TestRegisterBackendTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic when registering a backend twice")
+		}
+	}()
+	RegisterBackend(MockBackend, nil)
+}
+
+func TestMemoryVault(t *testing.T) {
+	var (
+		payload = []byte{0x60, 0x0a, 0x60, 0x00}
+		alice   = NewMemoryVault("alice")
+		bob     = alice.Peer("bob")
+		carol   = alice.Peer("carol")
+	)
+	key, err := alice.Post(payload, "alice", []string{"bob"})
+	if err != nil {
+		t.Fatalf("failed to post payload: %v", err)
+	}
+	if len(key) != 64 {
+		t.Fatalf("key length mismatch: have %d, want 64", len(key))
+	}
+	for _, party := range []*MemoryVault{alice, bob} {
+		data, err := party.Get(key)
+		if err != nil || !bytes.Equal(data, payload) {
+			t.Errorf("party %v: payload mismatch: have %x, %v, want %x", party.keys, data, err, payload)
+		}
+	}
+	if data, err := carol.Get(key); err != ErrPayloadNotFound {
+		t.Errorf("non party should get ErrPayloadNotFound, have %x, %v", data, err)
+	}
+	if data, err := alice.Get(nil); err != nil || len(data) != 0 {
+		t.Errorf("empty key should give an empty payload, have %x, %v", data, err)
+	}
+	if _, err := alice.Post(nil, "alice", nil); err == nil {
+		t.Error("expected an error posting an empty payload")
+	}
+}
+
+func TestWrapPayload(t *testing.T) {
+	payload := []byte("payload")
+	if data, err := WrapPayload(nil, payload); err != nil || !bytes.Equal(data, payload) {
+		t.Fatalf("standard payload wrapped: %x, %v", data, err)
+	}
+	meta := &PrivacyMetadata{Flag: PrivacyFlagPartyProtection, Participants: ParticipantsHash("B", "A", "B")}
+	if meta.Participants != ParticipantsHash("A", "B") {
+		t.Fatal("participants hash depends on the order of the keys")
+	}
+	data, err := WrapPayload(meta, payload)
+	if err != nil {
+		t.Fatalf("failed to wrap payload: %v", err)
+	}
+	have, unwrapped, err := UnwrapPayload(data)
+	if err != nil {
+		t.Fatalf("failed to unwrap payload: %v", err)
+	}
+	// The participants are not sent, the receiving node asks its vault
+	want := PrivacyMetadata{Flag: PrivacyFlagPartyProtection}
+	if *have != want || !bytes.Equal(unwrapped, payload) {
+		t.Fatalf("unwrapped %+v %x, want %+v %x", have, unwrapped, want, payload)
+	}
+	if have, unwrapped, err := UnwrapPayload(payload); have != nil || err != nil || !bytes.Equal(unwrapped, payload) {
+		t.Fatalf("standard payload unwrapped to %+v %x, %v", have, unwrapped, err)
+	}
+	if _, _, err := UnwrapPayload(data[:len(data)-1]); err == nil {
+		t.Fatal("truncated metadata accepted")
+	}
+	if _, err := WrapPayload(&PrivacyMetadata{Flag: 7}, payload); err != ErrInvalidPrivacyFlag {
+		t.Fatalf("unknown flag: have %v, want %v", err, ErrInvalidPrivacyFlag)
+	}
+}
+
+func TestResolveParticipants(t *testing.T) {
+	alice := NewMemoryVault("alice")
+	bob := alice.Peer("bob")
+	carol := alice.Peer("carol")
+
+	key, err := alice.Post([]byte("payload"), "alice", []string{"bob"})
+	if err != nil {
+		t.Fatalf("failed to post payload: %v", err)
+	}
+	for _, party := range []*MemoryVault{alice, bob} {
+		meta := &PrivacyMetadata{Flag: PrivacyFlagPartyProtection, Participants: ParticipantsHash("mallory")}
+		if err := ResolveParticipants(party, key, meta); err != nil {
+			t.Fatalf("party %v: failed to resolve participants: %v", party.keys, err)
+		}
+		if meta.Participants != ParticipantsHash("alice", "bob") {
+			t.Errorf("party %v: participants mismatch: have %x, want %x", party.keys, meta.Participants, ParticipantsHash("alice", "bob"))
+		}
+	}
+	if err := ResolveParticipants(carol, key, &PrivacyMetadata{}); err != ErrPayloadNotFound {
+		t.Errorf("non party: have %v, want %v", err, ErrPayloadNotFound)
+	}
+
+	var vault struct{ BlackboxVault }
+	if err := ResolveParticipants(vault, key, &PrivacyMetadata{}); err != ErrParticipantsUnverifiable {
+		t.Errorf("vault without parties: have %v, want %v", err, ErrParticipantsUnverifiable)
+	}
+	// Blackbox doesn't tell the recipients of its payloads
+	if err := ResolveParticipants(new(privatetransactionmanager.BlackboxVault), key, &PrivacyMetadata{}); err != ErrParticipantsUnverifiable {
+		t.Errorf("blackbox vault: have %v, want %v", err, ErrParticipantsUnverifiable)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetransactionmanager
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is a circuit breaker guarding the Blackbox node. It opens after a
+// number of consecutive failures and rejects requests until the cooldown has
+// elapsed, after which a single trial request is let through (half-open).
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	lock     sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a request may be sent to the Blackbox node.
+func (b *breaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	// Half-open, let one request through and re-arm the cooldown
+	b.openedAt = time.Now()
+	return true
+}
+
+func (b *breaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures = 0
+	if b.open {
+		b.open = false
+		vaultBreakerOpenGauge.Update(0)
+	}
+}
+
+func (b *breaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold && !b.open {
+		b.open = true
+		b.openedAt = time.Now()
+		vaultBreakerOpenGauge.Update(1)
+	}
+}
+
+func (b *breaker) isOpen() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.open
+}
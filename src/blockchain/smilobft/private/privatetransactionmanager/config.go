@@ -18,6 +18,12 @@
 package privatetransactionmanager
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"time"
+
 	"github.com/BurntSushi/toml"
 )
 
@@ -29,6 +35,33 @@ type Config struct {
 	SocketPath string `toml:"socketPath"`
 }
 
+// Options tune the timeouts, retries and health tracking of a Blackbox client.
+type Options struct {
+	DialTimeout    time.Duration // Timeout for connecting to the Blackbox node
+	RequestTimeout time.Duration // Timeout for a single request to the Blackbox node
+
+	Retries      int           // Attempts made after a failed request
+	RetryBackoff time.Duration // Delay before the first retry, doubled for each further one
+
+	BreakerThreshold int           // Consecutive failures opening the circuit breaker
+	BreakerCooldown  time.Duration // Time the open breaker rejects requests before trying again
+
+	HealthInterval time.Duration // Interval between two upchecks of the health monitor
+	PauseTimeout   time.Duration // Time a payload lookup waits for an unreachable Blackbox node
+}
+
+// DefaultOptions are the client options used when none are configured.
+var DefaultOptions = Options{
+	DialTimeout:      1 * time.Second,
+	RequestTimeout:   5 * time.Second,
+	Retries:          3,
+	RetryBackoff:     200 * time.Millisecond,
+	BreakerThreshold: 5,
+	BreakerCooldown:  10 * time.Second,
+	HealthInterval:   5 * time.Second,
+	PauseTimeout:     1 * time.Minute,
+}
+
 func LoadConfig(configPath string) (*Config, error) {
 	cfg := new(Config)
 	if _, err := toml.DecodeFile(configPath, cfg); err != nil {
@@ -40,3 +73,29 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 	return cfg, nil
 }
+
+// LoadTLSConfig builds the client TLS configuration used to reach a remote
+// Blackbox node. The client certificate is optional, and when no CA file is
+// given the system roots are used to verify the server.
+func LoadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in blackbox CA file")
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetransactionmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HealthStatus is a snapshot of the health of a Blackbox node.
+type HealthStatus struct {
+	Up                  bool      `json:"up"`
+	CircuitOpen         bool      `json:"circuitOpen"`
+	LastCheck           time.Time `json:"lastCheck"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastError           string    `json:"lastError,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+}
+
+// healthMonitor periodically upchecks the Blackbox node in the background and
+// lets payload lookups wait for an unreachable node to come back.
+type healthMonitor struct {
+	client   *Client
+	interval time.Duration
+
+	lock   sync.RWMutex
+	status HealthStatus
+	upCh   chan struct{} // Closed while the Blackbox node is up
+
+	quit     chan struct{}
+	quitOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newHealthMonitor(client *Client, interval time.Duration) *healthMonitor {
+	m := &healthMonitor{
+		client:   client,
+		interval: interval,
+		upCh:     make(chan struct{}),
+		quit:     make(chan struct{}),
+	}
+	// The node passed its upcheck before the client was created
+	now := time.Now()
+	m.status = HealthStatus{Up: true, LastCheck: now, LastSuccess: now}
+	close(m.upCh)
+	vaultUpGauge.Update(1)
+
+	m.wg.Add(1)
+	go m.loop()
+	return m
+}
+
+func (m *healthMonitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check()
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// check upchecks the Blackbox node and records the outcome.
+func (m *healthMonitor) check() {
+	err := m.client.Upcheck()
+	if err != nil {
+		m.reportFailure(err)
+		return
+	}
+	// A reachable node closes the breaker without waiting for a trial request
+	m.client.breaker.success()
+	m.reportSuccess()
+}
+
+func (m *healthMonitor) reportSuccess() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	if !m.status.Up {
+		log.Info("Blackbox node is reachable again", "downtime", now.Sub(m.status.LastSuccess))
+		close(m.upCh)
+	}
+	m.status.Up = true
+	m.status.LastCheck = now
+	m.status.LastSuccess = now
+	m.status.LastError = ""
+	m.status.ConsecutiveFailures = 0
+	vaultUpGauge.Update(1)
+}
+
+func (m *healthMonitor) reportFailure(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.status.Up {
+		log.Error("Blackbox node is unreachable", "err", err)
+		m.upCh = make(chan struct{})
+	}
+	m.status.Up = false
+	m.status.LastCheck = time.Now()
+	m.status.LastError = err.Error()
+	m.status.ConsecutiveFailures++
+	vaultUpGauge.Update(0)
+	vaultUpcheckFailureMeter.Mark(1)
+}
+
+// Status returns the current health of the Blackbox node.
+func (m *healthMonitor) Status() HealthStatus {
+	m.lock.RLock()
+	status := m.status
+	m.lock.RUnlock()
+
+	status.CircuitOpen = m.client.breaker.isOpen()
+	return status
+}
+
+// waitUp blocks until the Blackbox node is reported up, the timeout expires
+// or the monitor is stopped. It reports whether the node is up.
+func (m *healthMonitor) waitUp(timeout time.Duration) bool {
+	m.lock.RLock()
+	upCh := m.upCh
+	m.lock.RUnlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-upCh:
+		return true
+	case <-timer.C:
+		return false
+	case <-m.quit:
+		return false
+	}
+}
+
+// stop stops the background upchecks, it may be called more than once.
+func (m *healthMonitor) stop() {
+	m.quitOnce.Do(func() { close(m.quit) })
+	m.wg.Wait()
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetransactionmanager
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	vaultUpGauge              = metrics.NewRegisteredGauge("vault/up", nil)
+	vaultBreakerOpenGauge     = metrics.NewRegisteredGauge("vault/breaker/open", nil)
+	vaultUpcheckTimer         = metrics.NewRegisteredTimer("vault/upcheck", nil)
+	vaultUpcheckFailureMeter  = metrics.NewRegisteredMeter("vault/upcheck/failure", nil)
+	vaultRequestTimer         = metrics.NewRegisteredTimer("vault/request", nil)
+	vaultRequestRetryMeter    = metrics.NewRegisteredMeter("vault/request/retry", nil)
+	vaultRequestFailureMeter  = metrics.NewRegisteredMeter("vault/request/failure", nil)
+	vaultRequestRejectedMeter = metrics.NewRegisteredMeter("vault/request/rejected", nil)
+
+	vaultPayloadNotFoundMeter     = metrics.NewRegisteredMeter("vault/payload/notfound", nil)
+	vaultPayloadUnauthorizedMeter = metrics.NewRegisteredMeter("vault/payload/unauthorized", nil)
+	vaultPayloadCorruptMeter      = metrics.NewRegisteredMeter("vault/payload/corrupt", nil)
+)
@@ -19,122 +19,256 @@ package privatetransactionmanager
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/tv42/httpunix"
 )
 
-func unixTransport(socketPath string) *httpunix.Transport {
+// unixBaseURL is the base url used for every request sent over the unix socket
+const unixBaseURL = "http+unix://c"
+
+var (
+	// ErrBlackboxUnavailable is returned when the Blackbox node could not be
+	// reached, or kept failing, after every retry.
+	ErrBlackboxUnavailable = errors.New("private transaction manager is unavailable")
+
+	// ErrPayloadNotFound is returned by /receiveraw when this node is not a
+	// party to the payload.
+	ErrPayloadNotFound = errors.New("payload not found, this node is not a party")
+
+	// ErrUnauthorized is returned when the Blackbox node refuses to hand out
+	// the payload to this node.
+	ErrUnauthorized = errors.New("not authorized to retrieve the payload")
+
+	// ErrCorruptPayload is returned when the payload could not be decoded.
+	ErrCorruptPayload = errors.New("corrupt payload received from the private transaction manager")
+
+	errUpcheckFailed = errors.New("blackbox Node API did not respond to upcheck request")
+)
+
+func unixTransport(socketPath string, opts Options) *httpunix.Transport {
 	t := &httpunix.Transport{
-		DialTimeout:           1 * time.Second,
-		RequestTimeout:        5 * time.Second,
-		ResponseHeaderTimeout: 5 * time.Second,
+		DialTimeout:           opts.DialTimeout,
+		RequestTimeout:        opts.RequestTimeout,
+		ResponseHeaderTimeout: opts.RequestTimeout,
 	}
 	t.RegisterLocation("c", socketPath)
 	return t
 }
 
-func unixClient(socketPath string) *http.Client {
+func unixClient(socketPath string, opts Options) *http.Client {
 	return &http.Client{
-		Transport: unixTransport(socketPath),
+		Transport: unixTransport(socketPath, opts),
+	}
+}
+
+func httpsClient(tlsConfig *tls.Config, opts Options) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout: opts.DialTimeout,
+			}).DialContext,
+			TLSClientConfig:       tlsConfig,
+			TLSHandshakeTimeout:   opts.RequestTimeout,
+			ResponseHeaderTimeout: opts.RequestTimeout,
+		},
+		Timeout: opts.RequestTimeout,
 	}
 }
 
 func RunNode(socketPath string) error {
-	c := unixClient(socketPath)
-	res, err := c.Get("http+unix://c/upcheck")
+	return upcheck(unixClient(socketPath, DefaultOptions), unixBaseURL)
+}
+
+func upcheck(c *http.Client, baseURL string) error {
+	res, err := c.Get(baseURL + "/upcheck")
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode == 200 {
 		return nil
 	}
-	return errors.New("blackbox Node API did not respond to upcheck request")
+	return errUpcheckFailed
 }
 
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
+	opts       Options
+	breaker    *breaker
 }
 
-func (c *Client) PostData(pl []byte, b64From string, b64To []string) ([]byte, error) {
-	buf := bytes.NewBuffer([]byte(base64.StdEncoding.EncodeToString(pl)))
-	req, err := http.NewRequest("POST", "http+unix://c/sendraw", buf)
-	if err != nil {
-		return nil, err
+// Upcheck asks the Blackbox node whether it is up, bypassing retries and the
+// circuit breaker.
+func (c *Client) Upcheck() error {
+	start := time.Now()
+	err := upcheck(c.httpClient, c.baseURL)
+	vaultUpcheckTimer.UpdateSince(start)
+	return err
+}
+
+// response is a fully read Blackbox reply
+type response struct {
+	status int
+	body   []byte
+}
+
+// send performs the request, retrying with an exponential backoff while the
+// Blackbox node is unreachable or answers with a server error. Any reply
+// below 500 is returned as is and left to the caller to interpret.
+func (c *Client) send(method, path string, body []byte, header http.Header) (*response, error) {
+	if !c.breaker.allow() {
+		vaultRequestRejectedMeter.Mark(1)
+		return nil, ErrBlackboxUnavailable
 	}
-	if b64From != "" {
-		req.Header.Set("c11n-from", b64From)
+	backoff := c.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		res, err := c.sendOnce(method, path, body, header)
+		if err == nil && res.status < 500 {
+			c.breaker.success()
+			return res, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("status code %d: %s", res.status, strings.TrimSpace(string(res.body)))
+		}
+		c.breaker.failure()
+		if attempt >= c.opts.Retries || !c.breaker.allow() {
+			vaultRequestFailureMeter.Mark(1)
+			log.Error("Blackbox request failed", "method", method, "path", path, "attempts", attempt+1, "err", err)
+			return nil, ErrBlackboxUnavailable
+		}
+		vaultRequestRetryMeter.Mark(1)
+		log.Warn("Blackbox request failed, retrying", "method", method, "path", path, "attempt", attempt+1, "backoff", backoff, "err", err)
+		time.Sleep(backoff)
+		backoff *= 2
 	}
-	req.Header.Set("c11n-to", strings.Join(b64To, ","))
-	req.Header.Set("Content-Type", "application/octet-stream")
-	res, err := c.httpClient.Do(req)
+}
 
-	if res != nil {
-		defer res.Body.Close()
+func (c *Client) sendOnce(method, path string, body []byte, header http.Header) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("non-200 status code: %+v", res)
+	for key, values := range header {
+		req.Header[key] = values
 	}
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	vaultRequestTimer.UpdateSince(start)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
 
-	return ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, res.Body))
-}
-
-func (c *Client) PostDataRawTransaction(signedPayload []byte, b64To []string) ([]byte, error) {
-	buf := bytes.NewBuffer(signedPayload)
-	req, err := http.NewRequest("POST", "http+unix://c/sendsignedtx", buf)
+	data, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
+	return &response{status: res.StatusCode, body: data}, nil
+}
 
-	req.Header.Set("c11n-to", strings.Join(b64To, ","))
-	req.Header.Set("Content-Type", "application/octet-stream")
-	res, err := c.httpClient.Do(req)
+func decodeBody(res *response) ([]byte, error) {
+	if res.status != 200 {
+		return nil, fmt.Errorf("non-200 status code: %d", res.status)
+	}
+	return ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(res.body)))
+}
 
-	if res != nil {
-		defer res.Body.Close()
+func (c *Client) PostData(pl []byte, b64From string, b64To []string) ([]byte, error) {
+	header := make(http.Header)
+	if b64From != "" {
+		header.Set("c11n-from", b64From)
 	}
+	header.Set("c11n-to", strings.Join(b64To, ","))
+	header.Set("Content-Type", "application/octet-stream")
+
+	res, err := c.send("POST", "/sendraw", []byte(base64.StdEncoding.EncodeToString(pl)), header)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("non-200 status code: %+v", res)
-	}
-
-	return ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, res.Body))
+	return decodeBody(res)
 }
 
-func (c *Client) GetData(key []byte) ([]byte, error) {
-	req, err := http.NewRequest("GET", "http+unix://c/receiveraw", nil)
+func (c *Client) PostDataRawTransaction(signedPayload []byte, b64To []string) ([]byte, error) {
+	header := make(http.Header)
+	header.Set("c11n-to", strings.Join(b64To, ","))
+	header.Set("Content-Type", "application/octet-stream")
+
+	res, err := c.send("POST", "/sendsignedtx", signedPayload, header)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("c11n-key", base64.StdEncoding.EncodeToString(key))
-	res, err := c.httpClient.Do(req)
+	return decodeBody(res)
+}
 
-	if res != nil {
-		defer res.Body.Close()
-	}
+// GetData retrieves the payload stored under the given key. Failures are
+// classified as ErrPayloadNotFound, ErrUnauthorized, ErrCorruptPayload or
+// ErrBlackboxUnavailable.
+func (c *Client) GetData(key []byte) ([]byte, error) {
+	header := make(http.Header)
+	header.Set("c11n-key", base64.StdEncoding.EncodeToString(key))
+
+	res, err := c.send("GET", "/receiveraw", nil, header)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("non-200 status code: %+v", res)
+	switch res.status {
+	case http.StatusOK:
+		pl, err := decodeBody(res)
+		if err != nil {
+			vaultPayloadCorruptMeter.Mark(1)
+			log.Error("Failed to decode Blackbox payload", "err", err)
+			return nil, ErrCorruptPayload
+		}
+		return pl, nil
+	case http.StatusNotFound:
+		vaultPayloadNotFoundMeter.Mark(1)
+		return nil, ErrPayloadNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		vaultPayloadUnauthorizedMeter.Mark(1)
+		log.Error("Blackbox refused to hand out the payload", "status", res.status)
+		return nil, ErrUnauthorized
+	default:
+		// Anything else means the node does not behave as expected, its
+		// answer cannot be trusted to decide whether we are a party.
+		log.Error("Unexpected Blackbox response", "status", res.status, "body", strings.TrimSpace(string(res.body)))
+		return nil, ErrBlackboxUnavailable
 	}
-
-	return ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, res.Body))
 }
 
 func CreateClient(socketPath string) (*Client, error) {
+	return createClient(unixClient(socketPath, DefaultOptions), unixBaseURL, DefaultOptions), nil
+}
+
+// CreateRemoteClient creates a client talking to a Blackbox node over TCP,
+// using HTTPS when a TLS configuration is given.
+func CreateRemoteClient(url string, tlsConfig *tls.Config, opts Options) (*Client, error) {
+	if url == "" {
+		return nil, errors.New("blackbox url is empty")
+	}
+	return createClient(httpsClient(tlsConfig, opts), strings.TrimSuffix(url, "/"), opts), nil
+}
+
+func createClient(httpClient *http.Client, baseURL string, opts Options) *Client {
 	return &Client{
-		httpClient: unixClient(socketPath),
-	}, nil
+		httpClient: httpClient,
+		baseURL:    baseURL,
+		opts:       opts,
+		breaker:    newBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+	}
 }
@@ -18,6 +18,7 @@
 package privatetransactionmanager
 
 import (
+	"crypto/tls"
 	"errors"
 	"os"
 	"path/filepath"
@@ -28,20 +29,27 @@ import (
 	"github.com/patrickmn/go-cache"
 )
 
+// BlackboxVault is the vault of a Blackbox node. It doesn't implement
+// private.PartiesReader, Blackbox doesn't tell the recipients of a payload.
 type BlackboxVault struct {
 	node               *Client
+	health             *healthMonitor
 	cache              *cache.Cache
 	isBlackboxNotInUse bool
+	pauseTimeout       time.Duration
 }
 
 var (
 	ErrBlackboxIsNotStarted = errors.New("private transaction manager is not started")
 )
 
+// notParty is cached for payloads this node is not a party to
+type notParty struct{}
+
 func (b *BlackboxVault) Post(data []byte, from string, to []string) (out []byte, err error) {
-	if b == nil || b.isBlackboxNotInUse {
-		log.Error("Could not start Blackbox, Post, PostData, ", "b", b, "error", ErrBlackboxIsNotStarted)
-		return nil, ErrBlackboxIsNotStarted
+	if err := b.checkStarted(); err != nil {
+		log.Error("Could not start Blackbox, Post, PostData, ", "error", err)
+		return nil, err
 	}
 	out, err = b.node.PostData(data, from, to)
 	if err != nil {
@@ -53,9 +61,9 @@ func (b *BlackboxVault) Post(data []byte, from string, to []string) (out []byte,
 }
 
 func (b *BlackboxVault) PostRawTransaction(data []byte, to []string) (out []byte, err error) {
-	if b == nil || b.isBlackboxNotInUse {
-		log.Error("Could not start Blackbox, Post, PostData, ", "b", b, "error", ErrBlackboxIsNotStarted)
-		return nil, ErrBlackboxIsNotStarted
+	if err := b.checkStarted(); err != nil {
+		log.Error("Could not start Blackbox, Post, PostData, ", "error", err)
+		return nil, err
 	}
 	out, err = b.node.PostDataRawTransaction(data, to)
 	if err != nil {
@@ -64,29 +72,77 @@ func (b *BlackboxVault) PostRawTransaction(data []byte, to []string) (out []byte
 	return out, nil
 }
 
+// Get returns the payload stored under the given key. A node which is not a
+// party to the payload gets ErrPayloadNotFound, any other error means the
+// Blackbox node could not tell.
 func (b *BlackboxVault) Get(data []byte) ([]byte, error) {
-	if b == nil || b.isBlackboxNotInUse {
-		log.Error("Could not start Blackbox, Get ", "error", ErrBlackboxIsNotStarted)
-		return nil, ErrBlackboxIsNotStarted
+	if err := b.checkStarted(); err != nil {
+		log.Error("Could not start Blackbox, Get ", "error", err)
+		return nil, err
 	}
 	if len(data) == 0 {
 		return data, nil
 	}
-	// Ignore this error since not being a recipient of
-	// a payload isn't an error.
-	// TODO: Return an error if it's anything OTHER than
-	// 'you are not a recipient.'
 	dataStr := string(data)
 	x, found := b.cache.Get(dataStr)
 	if found {
+		if _, ok := x.(notParty); ok {
+			return nil, ErrPayloadNotFound
+		}
 		return x.([]byte), nil
 	}
-	pl, _ := b.node.GetData(data)
-	b.cache.Set(dataStr, pl, cache.DefaultExpiration)
-	return pl, nil
+	pl, err := b.node.GetData(data)
+	if err == ErrBlackboxUnavailable {
+		// Never cache the payload of an unreachable node, it would make this
+		// node diverge from the other parties. Wait for the node to come back.
+		log.Error("Blackbox is unreachable, pausing payload lookup", "timeout", b.pauseTimeout)
+		b.health.check()
+		if !b.health.waitUp(b.pauseTimeout) {
+			return nil, ErrBlackboxUnavailable
+		}
+		pl, err = b.node.GetData(data)
+	}
+	// Only definitive answers are cached, errors may be transient
+	switch err {
+	case nil:
+		b.cache.Set(dataStr, pl, cache.DefaultExpiration)
+	case ErrPayloadNotFound:
+		b.cache.Set(dataStr, notParty{}, cache.DefaultExpiration)
+	}
+	return pl, err
+}
+
+// checkStarted returns ErrBlackboxIsNotStarted for vaults ignoring private
+// transactions on purpose. A nil vault, left by a failed connection, is
+// unavailable: the node can't tell whether it is a party.
+func (b *BlackboxVault) checkStarted() error {
+	switch {
+	case b == nil:
+		return ErrBlackboxUnavailable
+	case b.isBlackboxNotInUse:
+		return ErrBlackboxIsNotStarted
+	}
+	return nil
 }
 
-func New(path string) (*BlackboxVault, error) {
+// Health returns the health of the Blackbox node as seen by the background
+// monitor.
+func (b *BlackboxVault) Health() HealthStatus {
+	if err := b.checkStarted(); err != nil {
+		return HealthStatus{LastError: err.Error()}
+	}
+	return b.health.Status()
+}
+
+// Close stops the background health monitor.
+func (b *BlackboxVault) Close() error {
+	if b != nil && b.health != nil {
+		b.health.stop()
+	}
+	return nil
+}
+
+func New(path string, opts Options) (*BlackboxVault, error) {
 	info, err := os.Lstat(path)
 	if err != nil {
 		log.Error("Could not start Blackbox, New, os.Lstat ", "path", path, "error", err)
@@ -103,21 +159,36 @@ func New(path string) (*BlackboxVault, error) {
 		}
 		path = filepath.Join(cfg.WorkDir, cfg.Socket)
 	}
-	err = RunNode(path)
-	if err != nil {
-		log.Error("Could not start Blackbox, New, RunNode, ", "path", path, "error", err)
+	n := createClient(unixClient(path, opts), unixBaseURL, opts)
+	if err = n.Upcheck(); err != nil {
+		log.Error("Could not start Blackbox, New, Upcheck, ", "path", path, "error", err)
 		return nil, err
 	}
-	n, err := CreateClient(path)
+	return newBlackboxVault(n, opts), nil
+}
+
+// NewRemote connects to a Blackbox node listening on TCP at the given url.
+func NewRemote(url string, tlsConfig *tls.Config, opts Options) (*BlackboxVault, error) {
+	n, err := CreateRemoteClient(url, tlsConfig, opts)
 	if err != nil {
-		log.Error("Could not start Blackbox, New, CreateClient, ", "path", path, "error", err)
+		log.Error("Could not start Blackbox, NewRemote, CreateRemoteClient, ", "url", url, "error", err)
+		return nil, err
+	}
+	if err = n.Upcheck(); err != nil {
+		log.Error("Could not start Blackbox, NewRemote, Upcheck, ", "url", url, "error", err)
 		return nil, err
 	}
+	return newBlackboxVault(n, opts), nil
+}
+
+func newBlackboxVault(n *Client, opts Options) *BlackboxVault {
 	return &BlackboxVault{
 		node:               n,
+		health:             newHealthMonitor(n, opts.HealthInterval),
 		cache:              cache.New(5*time.Minute, 5*time.Minute),
 		isBlackboxNotInUse: false,
-	}, nil
+		pauseTimeout:       opts.PauseTimeout,
+	}
 }
 
 func CreateNew(path string) *BlackboxVault {
@@ -129,7 +200,7 @@ func CreateNew(path string) *BlackboxVault {
 			isBlackboxNotInUse: true,
 		}
 	}
-	b, err := New(path)
+	b, err := New(path, DefaultOptions)
 	if err != nil || b == nil {
 		log.Error("############################## ERROR: Failed to connect to BlackBox, CreateNew, ", "path", path, "error", err)
 	}
@@ -0,0 +1,221 @@
+// Copyright 2019 The go-smilo Authors
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetransactionmanager
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testOptions = Options{
+	DialTimeout:      100 * time.Millisecond,
+	RequestTimeout:   100 * time.Millisecond,
+	Retries:          2,
+	RetryBackoff:     time.Millisecond,
+	BreakerThreshold: 5,
+	BreakerCooldown:  time.Hour,
+	HealthInterval:   10 * time.Millisecond,
+	PauseTimeout:     200 * time.Millisecond,
+}
+
+// testBlackbox serves a single payload, failing with a 503 while down is set.
+// A non zero status overrides the /receiveraw response.
+type testBlackbox struct {
+	payload  []byte
+	status   int
+	down     int32
+	requests int32
+}
+
+func (b *testBlackbox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/upcheck" {
+		atomic.AddInt32(&b.requests, 1)
+	}
+	if atomic.LoadInt32(&b.down) != 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.URL.Path {
+	case "/upcheck":
+		w.Write([]byte("I'm up!"))
+	case "/receiveraw":
+		if b.status != 0 {
+			w.WriteHeader(b.status)
+			return
+		}
+		w.Write([]byte(base64.StdEncoding.EncodeToString(b.payload)))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestVault(t *testing.T, blackbox *testBlackbox) (*BlackboxVault, func()) {
+	server := httptest.NewServer(blackbox)
+	vault, err := NewRemote(server.URL, nil, testOptions)
+	if err != nil {
+		server.Close()
+		t.Fatalf("failed to create vault: %v", err)
+	}
+	return vault, func() {
+		vault.Close()
+		server.Close()
+	}
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	blackbox := &testBlackbox{payload: []byte{0x01, 0x02}}
+	vault, closeFn := newTestVault(t, blackbox)
+	defer closeFn()
+
+	atomic.StoreInt32(&blackbox.down, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		atomic.StoreInt32(&blackbox.down, 0)
+	}()
+	data, err := vault.Get([]byte("key"))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if !bytes.Equal(data, blackbox.payload) {
+		t.Fatalf("payload mismatch: have %x, want %x", data, blackbox.payload)
+	}
+}
+
+func TestGetDoesNotCacheUnavailable(t *testing.T) {
+	blackbox := &testBlackbox{payload: []byte{0x01, 0x02}}
+	vault, closeFn := newTestVault(t, blackbox)
+	defer closeFn()
+
+	atomic.StoreInt32(&blackbox.down, 1)
+	if _, err := vault.Get([]byte("key")); err != ErrBlackboxUnavailable {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrBlackboxUnavailable)
+	}
+	if status := vault.Health(); status.Up {
+		t.Fatalf("health should report the node down: %+v", status)
+	}
+	atomic.StoreInt32(&blackbox.down, 0)
+
+	// The health monitor closes the breaker once the node answers again
+	time.Sleep(5 * testOptions.HealthInterval)
+	data, err := vault.Get([]byte("key"))
+	if err != nil || !bytes.Equal(data, blackbox.payload) {
+		t.Fatalf("payload mismatch: have %x, %v, want %x", data, err, blackbox.payload)
+	}
+	if status := vault.Health(); !status.Up || status.CircuitOpen {
+		t.Fatalf("health should report the node up: %+v", status)
+	}
+}
+
+func TestBreakerRejectsRequests(t *testing.T) {
+	blackbox := &testBlackbox{}
+	server := httptest.NewServer(blackbox)
+	defer server.Close()
+
+	client, err := CreateRemoteClient(server.URL, nil, testOptions)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	atomic.StoreInt32(&blackbox.down, 1)
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetData([]byte("key")); err != ErrBlackboxUnavailable {
+			t.Fatalf("request %d: error mismatch: have %v, want %v", i, err, ErrBlackboxUnavailable)
+		}
+	}
+	// Two requests of three attempts each tripped the breaker after five
+	// failures, the third one must not reach the server anymore.
+	if have := atomic.LoadInt32(&blackbox.requests); have != int32(testOptions.BreakerThreshold) {
+		t.Fatalf("requests mismatch: have %d, want %d", have, testOptions.BreakerThreshold)
+	}
+	if !client.breaker.isOpen() {
+		t.Fatal("breaker should be open")
+	}
+}
+
+func TestGetDataErrors(t *testing.T) {
+	for _, x := range []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrPayloadNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusBadRequest, ErrBlackboxUnavailable},
+		{http.StatusInternalServerError, ErrBlackboxUnavailable},
+	} {
+		blackbox := &testBlackbox{status: x.status}
+		server := httptest.NewServer(blackbox)
+		client, _ := CreateRemoteClient(server.URL, nil, testOptions)
+		if _, err := client.GetData([]byte("key")); err != x.want {
+			t.Errorf("status %d: error mismatch: have %v, want %v", x.status, err, x.want)
+		}
+		server.Close()
+	}
+}
+
+func TestGetDataCorruptPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not base64 !"))
+	}))
+	defer server.Close()
+
+	client, _ := CreateRemoteClient(server.URL, nil, testOptions)
+	if _, err := client.GetData([]byte("key")); err != ErrCorruptPayload {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrCorruptPayload)
+	}
+}
+
+func TestGetCachesNotFound(t *testing.T) {
+	blackbox := &testBlackbox{status: http.StatusNotFound}
+	vault, closeFn := newTestVault(t, blackbox)
+	defer closeFn()
+
+	for i := 0; i < 2; i++ {
+		if _, err := vault.Get([]byte("key")); err != ErrPayloadNotFound {
+			t.Fatalf("get %d: error mismatch: have %v, want %v", i, err, ErrPayloadNotFound)
+		}
+	}
+	if have := atomic.LoadInt32(&blackbox.requests); have != 1 {
+		t.Fatalf("requests mismatch: have %d, want 1", have)
+	}
+}
+
+func TestGetWithoutNode(t *testing.T) {
+	// A failed connection leaves a nil vault, which can't tell whether the
+	// node is a party
+	var missing *BlackboxVault
+	if _, err := missing.Get([]byte("key")); err != ErrBlackboxUnavailable {
+		t.Errorf("nil vault: have %v, want %v", err, ErrBlackboxUnavailable)
+	}
+	if _, err := CreateNew("ignore").Get([]byte("key")); err != ErrBlackboxIsNotStarted {
+		t.Errorf("ignoring vault: have %v, want %v", err, ErrBlackboxIsNotStarted)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	vault, closeFn := newTestVault(t, &testBlackbox{})
+	defer closeFn()
+
+	if err := vault.Close(); err != nil {
+		t.Fatalf("failed to close vault: %v", err)
+	}
+}